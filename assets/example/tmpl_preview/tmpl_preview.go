@@ -11,6 +11,7 @@ import (
 	"syscall"
 
 	"main/internal/infrastructure/configuration"
+	"main/internal/interfaces/http/html/views"
 
 	"github.com/gin-gonic/gin"
 )
@@ -25,7 +26,13 @@ func main() {
 	router := gin.Default()
 
 	router.Static("web/static", "./web/static")
-	router.LoadHTMLGlob("web/templates/*")
+	htmlRenderer, err := views.NewRenderer("web/templates/*")
+	if err != nil {
+		slog.Error("failed to load templates", slog.String("err", err.Error()))
+		os.Exit(1)
+	}
+
+	router.HTMLRender = htmlRenderer
 	api := router.Group("/")
 
 	api.GET("/", preview)
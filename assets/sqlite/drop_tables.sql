@@ -1,7 +0,0 @@
-DROP TABLE IF EXISTS classes;
-
-DROP TABLE IF EXISTS bookings;
-
-DROP TABLE IF EXISTS pending_bookings;
-
-
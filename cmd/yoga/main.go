@@ -8,51 +8,128 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"main/internal/application/attendance"
 	"main/internal/application/bookings"
+	"main/internal/application/calendar"
 	"main/internal/application/classes"
+	"main/internal/application/classseries"
+	"main/internal/application/classtypes"
+	"main/internal/application/instructors"
+	"main/internal/application/locations"
+	"main/internal/application/outbox"
 	"main/internal/application/passes"
+	paymentsApp "main/internal/application/payments"
 	"main/internal/application/pendingbookings"
+	"main/internal/application/products"
 	"main/internal/application/reminder"
+	"main/internal/application/scheduler"
+	"main/internal/application/studentbookings"
+	"main/internal/application/waitlist"
+	"main/internal/domain/models"
 	"main/internal/domain/repositories"
 	"main/internal/domain/services"
 	"main/internal/infrastructure/configuration"
 	"main/internal/infrastructure/generator/token"
-	dbModels "main/internal/infrastructure/models/db"
-	"main/internal/infrastructure/notifier/gmail"
+	"main/internal/infrastructure/migrations"
+	"main/internal/infrastructure/notifier"
+	"main/internal/infrastructure/payments"
+	"main/internal/infrastructure/repository"
+	postgresRepo "main/internal/infrastructure/repository/postgres"
 	sqliteRepo "main/internal/infrastructure/repository/sqlite"
 	apiErrs "main/internal/interfaces/http/api/errs"
 	apiErrHandler "main/internal/interfaces/http/api/errs/handler"
 	"main/internal/interfaces/http/api/errs/logging"
 	"main/internal/interfaces/http/api/handlers/activatepass"
+	apiCreateBooking "main/internal/interfaces/http/api/handlers/createbooking"
 	"main/internal/interfaces/http/api/handlers/createclasses"
+	"main/internal/interfaces/http/api/handlers/createclassseries"
+	"main/internal/interfaces/http/api/handlers/createclasstype"
 	"main/internal/interfaces/http/api/handlers/createcontacts"
+	"main/internal/interfaces/http/api/handlers/createinstructor"
+	"main/internal/interfaces/http/api/handlers/createlocation"
+	"main/internal/interfaces/http/api/handlers/createproduct"
+	"main/internal/interfaces/http/api/handlers/createwalkin"
 	"main/internal/interfaces/http/api/handlers/deletebooking"
 	"main/internal/interfaces/http/api/handlers/deleteclass"
+	"main/internal/interfaces/http/api/handlers/deleteclassseries"
+	"main/internal/interfaces/http/api/handlers/deleteclasstype"
+	"main/internal/interfaces/http/api/handlers/deleteinstructor"
+	"main/internal/interfaces/http/api/handlers/deletelocation"
+	"main/internal/interfaces/http/api/handlers/extendpass"
+	"main/internal/interfaces/http/api/handlers/freezepass"
+	"main/internal/interfaces/http/api/handlers/getclasstype"
+	"main/internal/interfaces/http/api/handlers/getcontactstatistics"
+	"main/internal/interfaces/http/api/handlers/getinstructor"
+	"main/internal/interfaces/http/api/handlers/getlocation"
+	"main/internal/interfaces/http/api/handlers/getpass"
 	"main/internal/interfaces/http/api/handlers/listbookings"
 	"main/internal/interfaces/http/api/handlers/listbookingsbyclass"
 	"main/internal/interfaces/http/api/handlers/listclasses"
+	"main/internal/interfaces/http/api/handlers/listclassseries"
+	"main/internal/interfaces/http/api/handlers/listclasstypes"
+	"main/internal/interfaces/http/api/handlers/listcontactpasses"
 	"main/internal/interfaces/http/api/handlers/listcontacts"
+	"main/internal/interfaces/http/api/handlers/listinstructors"
+	"main/internal/interfaces/http/api/handlers/listjobs"
+	"main/internal/interfaces/http/api/handlers/listlocations"
+	"main/internal/interfaces/http/api/handlers/listoutbox"
+	"main/internal/interfaces/http/api/handlers/listpasses"
+	"main/internal/interfaces/http/api/handlers/listpayments"
 	"main/internal/interfaces/http/api/handlers/listpendingbookings"
+	"main/internal/interfaces/http/api/handlers/listproducts"
+	"main/internal/interfaces/http/api/handlers/listpublicclasses"
+	"main/internal/interfaces/http/api/handlers/listwaitlist"
+	"main/internal/interfaces/http/api/handlers/markattendance"
+	"main/internal/interfaces/http/api/handlers/paymentwebhook"
+	apiRescheduleBooking "main/internal/interfaces/http/api/handlers/reschedulebooking"
+	"main/internal/interfaces/http/api/handlers/retryoutboxmessage"
+	"main/internal/interfaces/http/api/handlers/substituteinstructor"
 	"main/internal/interfaces/http/api/handlers/updateclass"
+	"main/internal/interfaces/http/api/handlers/updateclassseries"
+	"main/internal/interfaces/http/api/handlers/updateclasstype"
+	"main/internal/interfaces/http/api/handlers/updateinstructor"
+	"main/internal/interfaces/http/api/handlers/updatelocation"
+	"main/internal/interfaces/http/api/handlers/updateproduct"
 	viewErrs "main/internal/interfaces/http/html/errs"
 	viewErrHandler "main/internal/interfaces/http/html/errs/handler"
 	logWrapper "main/internal/interfaces/http/html/errs/wrapper"
+	"main/internal/interfaces/http/html/handlers/attendancecheckin"
+	"main/internal/interfaces/http/html/handlers/attendanceroster"
+	"main/internal/interfaces/http/html/handlers/attendancewalkin"
+	"main/internal/interfaces/http/html/handlers/buypassform"
+	"main/internal/interfaces/http/html/handlers/calendarfeed"
 	"main/internal/interfaces/http/html/handlers/cancelbooking"
 	"main/internal/interfaces/http/html/handlers/cancelbookingform"
+	"main/internal/interfaces/http/html/handlers/classtype"
 	"main/internal/interfaces/http/html/handlers/createbooking"
+	"main/internal/interfaces/http/html/handlers/createdropinpayment"
+	"main/internal/interfaces/http/html/handlers/createpasspayment"
 	"main/internal/interfaces/http/html/handlers/errorpage"
 	"main/internal/interfaces/http/html/handlers/home"
+	"main/internal/interfaces/http/html/handlers/joinwaitlist"
+	"main/internal/interfaces/http/html/handlers/payment"
 	creatependingbooking "main/internal/interfaces/http/html/handlers/pendingbooking"
 	"main/internal/interfaces/http/html/handlers/pendingbookingform"
+	"main/internal/interfaces/http/html/handlers/reschedulebooking"
+	"main/internal/interfaces/http/html/handlers/schedule"
+	studentBookingsHandler "main/internal/interfaces/http/html/handlers/studentbookings"
+	"main/internal/interfaces/http/html/handlers/studentcalendarfeed"
+	"main/internal/interfaces/http/html/handlers/studentlogin"
+	"main/internal/interfaces/http/html/handlers/studentloginlink"
+	"main/internal/interfaces/http/html/handlers/studentlogout"
+	"main/internal/interfaces/http/html/handlers/waitlistform"
+	"main/internal/interfaces/http/html/views"
 	"main/internal/interfaces/http/middleware"
+	"main/pkg/converter"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
 	_ "github.com/lib/pq"
 	"golang.org/x/time/rate"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
@@ -60,14 +137,31 @@ import (
 type Components struct {
 	unitOfWork             repositories.IUnitOfWork
 	classesService         services.IClassesService
+	classSeriesService     services.IClassSeriesService
 	bookingsService        services.IBookingsService
 	pendingBookingsService services.IPendingBookingsService
 	passesService          services.IPassesService
+	waitlistService        services.IWaitlistService
+	studentBookingsService services.IStudentBookingsService
+	calendarService        services.ICalendarService
+	attendanceService      services.IAttendanceService
 	bookingsRepo           repositories.IBookings
 	pendingBookingsRepo    repositories.IPendingBookings
 	contactsRepo           repositories.IContacts
-	reminder               reminder.IReminderService
-	database               *gorm.DB
+	waitlistRepo           repositories.IWaitlist
+	jobsService            services.IJobsService
+	outboxService          services.IOutboxService
+	paymentsService        services.IPaymentsService
+	productsService        services.IProductsService
+	locationsService       services.ILocationsService
+	instructorsService     services.IInstructorsService
+	classTypesService      services.IClassTypesService
+	scheduler              BackgroundWorker
+	outboxDispatcher       BackgroundWorker
+}
+
+type BackgroundWorker interface {
+	Start(ctx context.Context)
 }
 
 func main() {
@@ -77,41 +171,58 @@ func main() {
 		os.Exit(1)
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err = runMigrate(cfg, os.Args[2:])
+		if err != nil {
+			slog.Error("failed to migrate database", slog.String("err", err.Error()))
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	components, err := buildComponents(cfg)
 	if err != nil {
 		slog.Error("failed to build components", slog.String("err", err.Error()))
 		os.Exit(1)
 	}
 
+	htmlRenderer, err := views.NewRenderer("web/templates/*")
+	if err != nil {
+		slog.Error("failed to load templates", slog.String("err", err.Error()))
+		os.Exit(1)
+	}
+
 	router := setupRouter(
+		htmlRenderer,
 		components.bookingsService,
 		components.classesService,
+		components.classSeriesService,
 		components.pendingBookingsService,
 		components.passesService,
+		components.waitlistService,
+		components.studentBookingsService,
+		components.calendarService,
+		components.attendanceService,
 		components.bookingsRepo,
 		components.pendingBookingsRepo,
 		components.contactsRepo,
+		components.waitlistRepo,
+		components.jobsService,
+		components.outboxService,
+		components.paymentsService,
+		components.productsService,
+		components.locationsService,
+		components.instructorsService,
+		components.classTypesService,
 		cfg,
 	)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	go func(ctxTimeout time.Duration) {
-		cleanupCtx, cancel := context.WithTimeout(ctx, ctxTimeout)
-		defer cancel()
-
-		cleanUpPendingBookings(cleanupCtx, components.database)
-	}(cfg.ContextTimeout.Duration)
-
-	go func(ctxTimeout time.Duration) {
-		time.Sleep(2 * time.Second)
-
-		reminderCtx, cancel := context.WithTimeout(ctx, ctxTimeout)
-		defer cancel()
-
-		remindBookings(reminderCtx, components.reminder)
-	}(cfg.ContextTimeout.Duration)
+	go components.scheduler.Start(ctx)
+	go components.outboxDispatcher.Start(ctx)
 
 	srv := &http.Server{
 		Addr:              cfg.ListenAddress,
@@ -134,114 +245,416 @@ func loadConfig() (*configuration.Configuration, error) {
 		slog.Info(cfg.Pretty())
 	}
 
+	err = converter.SetTimeZone(cfg.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("error loading time zone: %w", err)
+	}
+
 	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
 
 	return cfg, nil
 }
 
-func buildComponents(cfg *configuration.Configuration) (Components, error) {
-	database, err := gorm.Open(sqlite.Open(cfg.DBPath), &gorm.Config{
+// runMigrate handles `yoga migrate up|down|status`.
+func runMigrate(cfg *configuration.Configuration, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: yoga migrate up|down|status")
+	}
+
+	database, _, err := openDatabase(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	migrator, err := migrations.NewMigrator(database, cfg.DBDriver)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Printf("applied %04d_%s\n", migration.Version, migration.Name)
+		}
+
+		if err != nil {
+			return fmt.Errorf("could not migrate up: %w", err)
+		}
+
+		if len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+	case "down":
+		migration, err := migrator.Down(ctx)
+		if err != nil {
+			return fmt.Errorf("could not migrate down: %w", err)
+		}
+
+		fmt.Printf("rolled back %04d_%s\n", migration.Version, migration.Name)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return fmt.Errorf("could not get migrations status: %w", err)
+		}
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q, use up, down or status", args[0])
+	}
+
+	return nil
+}
+
+func newDialect(cfg *configuration.Configuration) (repository.Dialect, string, error) {
+	switch cfg.DBDriver {
+	case configuration.DBDriverSQLite:
+		return sqliteRepo.NewDialect(), cfg.DBPath, nil
+	case configuration.DBDriverPostgres:
+		return postgresRepo.NewDialect(), cfg.Postgres.DSN(), nil
+	default:
+		return nil, "", fmt.Errorf("unknown database driver: %s", cfg.DBDriver)
+	}
+}
+
+func openDatabase(cfg *configuration.Configuration) (*gorm.DB, repository.Dialect, error) {
+	dialect, dsn, err := newDialect(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	database, err := gorm.Open(dialect.Open(dsn), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent),
 	})
 	if err != nil {
-		return Components{}, fmt.Errorf("failed to connect to database: %w", err)
+		return nil, nil, fmt.Errorf("could not open %s database: %w", cfg.DBDriver, err)
 	}
 
-	slog.Info("Successfully connected to database")
+	return database, dialect, nil
+}
+
+func newBookingPolicy(cfg configuration.BookingPolicy) *services.BookingPolicy {
+	levelRules := make(map[string]models.BookingRules, len(cfg.Levels))
+
+	for level, rules := range cfg.Levels {
+		levelRules[level] = toBookingRules(rules)
+	}
 
-	err = database.AutoMigrate(
-		&dbModels.SQLClass{},
-		&dbModels.SQLPendingBooking{},
-		&dbModels.SQLBooking{},
-		&dbModels.SQLPass{},
-		&dbModels.SQLContact{},
+	return services.NewBookingPolicy(
+		cfg.MaxPendingBookings, cfg.PassesToCheck, toBookingRules(cfg.Rules), levelRules,
 	)
+}
+
+func toBookingRules(rules configuration.BookingRules) models.BookingRules {
+	return models.BookingRules{
+		MaxPendingBookingsPerEmail: rules.MaxPendingBookingsPerEmail,
+		EmptyClassDeadline:         rules.EmptyClassDeadline.Duration,
+		CancellationCutoff:         rules.CancellationCutoff.Duration,
+		MaxActiveBookings:          rules.MaxActiveBookings,
+		BookingWindow:              rules.BookingWindow.Duration,
+	}
+}
+
+func buildComponents(cfg *configuration.Configuration) (Components, error) {
+	database, dialect, err := openDatabase(cfg)
+	if err != nil {
+		return Components{}, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	slog.Info("Successfully connected to database", "driver", cfg.DBDriver)
+
+	migrator, err := migrations.NewMigrator(database, cfg.DBDriver)
+	if err != nil {
+		return Components{}, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	err = migrator.EnsureUpToDate(context.Background())
 	if err != nil {
-		return Components{}, fmt.Errorf("failed to migrate database: %w", err)
+		return Components{}, fmt.Errorf("failed to check database schema: %w", err)
 	}
 
-	classesRepo := sqliteRepo.NewClassesRepo(database)
-	bookingsRepo := sqliteRepo.NewBookingsRepo(database)
-	pendingBookingsRepo := sqliteRepo.NewPendingBookingsRepo(database)
-	passesRepo := sqliteRepo.NewPassesRepo(database)
-	contactsRepo := sqliteRepo.NewContactsRepo(database)
+	repos := repository.NewRepositories(database, dialect)
+	unitOfWork := repository.NewUnitOfWork(database, dialect)
+
+	classesRepo := repos.Classes
+	bookingsRepo := repos.Bookings
+	pendingBookingsRepo := repos.PendingBookings
+	contactsRepo := repos.Contacts
+	waitlistRepo := repos.Waitlist
+	classSeriesRepo := repos.ClassSeries
+	jobRunsRepo := repos.JobRuns
+	outboxRepo := repos.Outbox
+	passesRepo := repos.Passes
+	studentSessionsRepo := repos.StudentSessions
 
 	tokenGenerator := token.NewGenerator()
-	emailNotifier := gmail.NewNotifier(
-		cfg.Notifier.Host,
-		cfg.Notifier.Port,
-		cfg.Notifier.Login,
-		cfg.Notifier.Password,
-		cfg.Notifier.Signature,
-		cfg.BaseNotifierTmplPath,
-	)
+	emailNotifier, err := notifier.NewNotifier(cfg.Notifier, cfg.BaseNotifierTmplPath)
+	if err != nil {
+		return Components{}, fmt.Errorf("could not create notifier: %w", err)
+	}
 
-	unitOfWork := sqliteRepo.NewUnitOfWork(database)
 	passManager := services.PassManager{}
+	bookingPolicy := newBookingPolicy(cfg.BookingPolicy)
+
+	waitlistService := waitlist.NewService(
+		unitOfWork,
+		tokenGenerator,
+		cfg.DomainAddr,
+	)
 
 	classesService := classes.NewService(
 		classesRepo,
 		bookingsRepo,
+		repos.Locations,
+		repos.Instructors,
+		repos.ClassTypes,
+		repos.Waitlist,
 		unitOfWork,
 		&passManager,
-		emailNotifier,
+		waitlistService,
+	)
+	classSeriesService := classseries.NewService(
+		classSeriesRepo,
+		classesRepo,
+		bookingsRepo,
+		repos.Locations,
+		repos.Instructors,
+		classesService,
+		unitOfWork,
+		func(repos repositories.Repositories) services.IClassesService {
+			unitOfWork := repositories.InTransaction(repos)
+
+			return classes.NewService(
+				repos.Classes,
+				repos.Bookings,
+				repos.Locations,
+				repos.Instructors,
+				repos.ClassTypes,
+				repos.Waitlist,
+				unitOfWork,
+				&passManager,
+				waitlist.NewService(unitOfWork, tokenGenerator, cfg.DomainAddr),
+			)
+		},
+		cfg.ClassSeriesHorizon.Duration,
 	)
 	bookingsService := bookings.NewService(
 		unitOfWork,
 		bookingsRepo,
+		tokenGenerator,
 		&passManager,
-		emailNotifier,
+		bookingPolicy,
+		waitlistService,
 		cfg.DomainAddr,
 	)
 
 	pendingBookingsService := pendingbookings.NewService(
 		unitOfWork,
 		tokenGenerator,
-		emailNotifier,
+		bookingPolicy,
 		cfg.DomainAddr,
 	)
 
-	passesService := passes.NewService(passesRepo, bookingsRepo, emailNotifier, &passManager)
+	productsService := products.NewService(repos.Products)
+	locationsService := locations.NewService(repos.Locations)
+	instructorsService := instructors.NewService(repos.Instructors)
+	classTypesService := classtypes.NewService(repos.ClassTypes, repos.Locations, classesService)
+
+	passesService := passes.NewService(
+		unitOfWork,
+		passesRepo,
+		bookingsRepo,
+		repos.Products,
+		&passManager,
+		cfg.PassValidity.Duration,
+	)
+
+	calendarService := calendar.NewService(
+		classesService,
+		bookingsRepo,
+		repos.CalendarFeeds,
+		tokenGenerator,
+		cfg.DomainAddr,
+	)
+
+	studentBookingsService := studentbookings.NewService(
+		unitOfWork,
+		studentSessionsRepo,
+		bookingsRepo,
+		passesRepo,
+		tokenGenerator,
+		&passManager,
+		bookingPolicy,
+		calendarService,
+		cfg.DomainAddr,
+	)
+
+	attendanceService := attendance.NewService(
+		unitOfWork,
+		classesRepo,
+		bookingsRepo,
+		bookingsService,
+	)
 
 	reminder := reminder.New(
 		unitOfWork,
 		classesRepo,
 		bookingsRepo,
-		emailNotifier,
 		&passManager,
 		cfg.DomainAddr,
 	)
 
+	outboxDispatcher := outbox.NewDispatcher(
+		outboxRepo,
+		contactsRepo,
+		emailNotifier,
+		cfg.Outbox.PollInterval.Duration,
+		cfg.Outbox.MaxAttempts,
+		cfg.Outbox.BaseBackoff.Duration,
+	)
+
+	jobScheduler := scheduler.New(
+		jobRunsRepo,
+		cfg.Scheduler.TickInterval.Duration,
+		cfg.ContextTimeout.Duration,
+		scheduler.Job{
+			Name:     "remind_bookings",
+			Interval: cfg.Scheduler.RemindBookingsInterval.Duration,
+			Run:      reminder.RemindBookings,
+		},
+		scheduler.Job{
+			Name:     "clean_up_pending_bookings",
+			Interval: cfg.Scheduler.CleanUpPendingBookingsInterval.Duration,
+			Run:      pendingBookingsService.CleanUpPendingBookings,
+		},
+		scheduler.Job{
+			Name:     "materialize_class_series",
+			Interval: cfg.Scheduler.MaterializeClassSeriesInterval.Duration,
+			Run:      classSeriesService.MaterializeClassSeries,
+		},
+		scheduler.Job{
+			Name:     "expire_waitlist_offers",
+			Interval: cfg.Scheduler.ExpireWaitlistOffersInterval.Duration,
+			Run:      waitlistService.ExpireOffers,
+		},
+		scheduler.Job{
+			Name:     "clean_up_student_sessions",
+			Interval: cfg.Scheduler.CleanUpStudentSessionsInterval.Duration,
+			Run:      studentBookingsService.CleanUpSessions,
+		},
+	)
+
+	// payments are optional, without them passes are activated by the admin
+	var paymentsService services.IPaymentsService
+
+	if cfg.Payments.Enabled() {
+		paymentProvider, err := payments.NewProvider(cfg.Payments)
+		if err != nil {
+			return Components{}, fmt.Errorf("could not create payment provider: %w", err)
+		}
+
+		paymentsService = paymentsApp.NewService(
+			unitOfWork,
+			repos.Payments,
+			repos.Products,
+			paymentProvider,
+			func(repos repositories.Repositories) services.IPassesService {
+				return passes.NewService(
+					repositories.InTransaction(repos),
+					repos.Passes,
+					repos.Bookings,
+					repos.Products,
+					&passManager,
+					cfg.PassValidity.Duration,
+				)
+			},
+			func(repos repositories.Repositories) services.IBookingsService {
+				unitOfWork := repositories.InTransaction(repos)
+
+				return bookings.NewService(
+					unitOfWork,
+					repos.Bookings,
+					tokenGenerator,
+					&passManager,
+					bookingPolicy,
+					waitlist.NewService(unitOfWork, tokenGenerator, cfg.DomainAddr),
+					cfg.DomainAddr,
+				)
+			},
+			bookingPolicy,
+			cfg.DomainAddr,
+		)
+	}
+
 	return Components{
 		unitOfWork:             unitOfWork,
 		classesService:         classesService,
+		classSeriesService:     classSeriesService,
 		bookingsService:        bookingsService,
 		pendingBookingsService: pendingBookingsService,
 		passesService:          passesService,
+		waitlistService:        waitlistService,
+		studentBookingsService: studentBookingsService,
+		calendarService:        calendarService,
+		attendanceService:      attendanceService,
 		bookingsRepo:           bookingsRepo,
 		pendingBookingsRepo:    pendingBookingsRepo,
 		contactsRepo:           contactsRepo,
-		reminder:               reminder,
-		database:               database,
+		waitlistRepo:           waitlistRepo,
+		jobsService:            jobScheduler,
+		scheduler:              jobScheduler,
+		outboxService:          outboxDispatcher,
+		paymentsService:        paymentsService,
+		productsService:        productsService,
+		locationsService:       locationsService,
+		instructorsService:     instructorsService,
+		classTypesService:      classTypesService,
+		outboxDispatcher:       outboxDispatcher,
 	}, nil
 }
 
 func setupRouter(
+	htmlRenderer render.HTMLRender,
 	bookingsService services.IBookingsService,
 	classesService services.IClassesService,
+	classSeriesService services.IClassSeriesService,
 	pendingBookingsService services.IPendingBookingsService,
 	passesService services.IPassesService,
+	waitlistService services.IWaitlistService,
+	studentBookingsService services.IStudentBookingsService,
+	calendarService services.ICalendarService,
+	attendanceService services.IAttendanceService,
 	bookingsRepo repositories.IBookings,
 	pendingBookingsRepo repositories.IPendingBookings,
 	contactsRepo repositories.IContacts,
+	waitlistRepo repositories.IWaitlist,
+	jobsService services.IJobsService,
+	outboxService services.IOutboxService,
+	paymentsService services.IPaymentsService,
+	productsService services.IProductsService,
+	locationsService services.ILocationsService,
+	instructorsService services.IInstructorsService,
+	classTypesService services.IClassTypesService,
 	cfg *configuration.Configuration,
 ) *gin.Engine {
 	router := gin.Default()
 
+	secureCookie := strings.HasPrefix(cfg.DomainAddr, "https://")
+
 	router.Static("web/static", "./web/static")
-	router.LoadHTMLGlob("web/templates/*")
+	router.HTMLRender = htmlRenderer
 	router.Use(middleware.RequestID())
 	api := router.Group("/")
+	pages := router.Group("/", middleware.Locale(secureCookie))
 
 	var viewErrorHandler viewErrs.IErrorHandler
 
@@ -251,32 +664,96 @@ func setupRouter(
 	// HTML
 	rateLimiterMiddleware := middleware.GlobalRateLimit
 
-	homeHandler := home.NewHandler(classesService, viewErrorHandler, cfg.IsVacation)
+	payOnline := paymentsService != nil
+
+	homeHandler := home.NewHandler(
+		classesService, classTypesService, productsService, viewErrorHandler, cfg.IsVacation, payOnline,
+	)
+	classTypeHandler := classtype.NewHandler(classTypesService, viewErrorHandler)
+	scheduleHandler := schedule.NewHandler(classesService, viewErrorHandler)
 	createBookingHandler := createbooking.NewHandler(bookingsService, viewErrorHandler)
 	cancelBookingHandler := cancelbooking.NewHandler(bookingsService, viewErrorHandler)
 	createPendingBookingHandler := creatependingbooking.NewHandler(pendingBookingsService, viewErrorHandler)
-	pendingBookingFormHandler := pendingbookingform.NewHandler()
-	cancelBookingFormHandler := cancelbookingform.NewHandler(bookingsService, viewErrorHandler)
+	pendingBookingFormHandler := pendingbookingform.NewHandler(productsService, viewErrorHandler, payOnline)
+	cancelBookingFormHandler := cancelbookingform.NewHandler(bookingsService, classesService, viewErrorHandler)
+	rescheduleBookingHandler := reschedulebooking.NewHandler(bookingsService, viewErrorHandler)
+	waitlistFormHandler := waitlistform.NewHandler()
+	joinWaitlistHandler := joinwaitlist.NewHandler(waitlistService, viewErrorHandler)
 	errorPageHandler := errorpage.NewHandler()
 
+	studentBookingsPageHandler := studentBookingsHandler.NewHandler(studentBookingsService, viewErrorHandler)
+	studentLoginLinkHandler := studentloginlink.NewHandler(studentBookingsService, viewErrorHandler)
+	studentLoginHandler := studentlogin.NewHandler(studentBookingsService, viewErrorHandler, secureCookie)
+	studentLogoutHandler := studentlogout.NewHandler(studentBookingsService, viewErrorHandler, secureCookie)
+	calendarFeedHandler := calendarfeed.NewHandler(calendarService, viewErrorHandler)
+	studentCalendarFeedHandler := studentcalendarfeed.NewHandler(calendarService, viewErrorHandler)
+	attendanceRosterHandler := attendanceroster.NewHandler(attendanceService)
+	attendanceCheckInHandler := attendancecheckin.NewHandler(attendanceService)
+	attendanceWalkInHandler := attendancewalkin.NewHandler(attendanceService)
+
 	{
 		// home
-		api.GET("/", homeHandler.Handle)
+		pages.GET("/", homeHandler.Handle)
+
+		// schedule
+		pages.GET("/schedule", scheduleHandler.Handle)
+
+		// class types
+		pages.GET("/class_types/:id", classTypeHandler.Handle)
 
 		// error page
-		api.GET("/error", errorPageHandler.Handle)
+		pages.GET("/error", errorPageHandler.Handle)
 
 		// bookings
 		// this endpoint should be POST according to REST, it is GET - confirmation link sent via email
-		api.GET("/bookings", createBookingHandler.Handle)
-		api.DELETE("/bookings/:id", cancelBookingHandler.Handle)
-		api.GET("/bookings/:id/cancel_form", cancelBookingFormHandler.Handle)
+		pages.GET("/bookings", createBookingHandler.Handle)
+		pages.DELETE("/bookings/:id", cancelBookingHandler.Handle)
+		pages.GET("/bookings/:id/cancel_form", cancelBookingFormHandler.Handle)
+		pages.POST("/bookings/:id/reschedule", rescheduleBookingHandler.Handle)
 
 		// pending_bookings
-		api.GET("/classes/:class_id/pending_bookings/form", pendingBookingFormHandler.Handle)
+		pages.GET("/classes/:class_id/pending_bookings/form", pendingBookingFormHandler.Handle)
 
 		requestLimiter := rate.NewLimiter(rate.Limit(1), 2)
-		api.POST("/pending_bookings", rateLimiterMiddleware(requestLimiter), createPendingBookingHandler.Handle)
+		pages.POST("/pending_bookings", rateLimiterMiddleware(requestLimiter), createPendingBookingHandler.Handle)
+
+		// waitlist
+		pages.GET("/classes/:class_id/waitlist/form", waitlistFormHandler.Handle)
+
+		waitlistLimiter := rate.NewLimiter(rate.Limit(1), 2)
+		pages.POST("/waitlist", rateLimiterMiddleware(waitlistLimiter), joinWaitlistHandler.Handle)
+
+		// my bookings
+		pages.GET("/my_bookings", studentBookingsPageHandler.Handle)
+		// GET because the login link is opened from an email
+		pages.GET("/my_bookings/auth", studentLoginHandler.Handle)
+		pages.POST("/my_bookings/logout", studentLogoutHandler.Handle)
+
+		loginLinkLimiter := rate.NewLimiter(rate.Limit(1), 2)
+		pages.POST("/my_bookings/login", rateLimiterMiddleware(loginLinkLimiter), studentLoginLinkHandler.Handle)
+
+		// calendar feeds
+		pages.GET("/calendar.ics", calendarFeedHandler.Handle)
+		pages.GET("/my_bookings/calendar.ics", studentCalendarFeedHandler.Handle)
+
+		// attendance, opened by the instructor on a phone
+		staffAuthMiddleware := middleware.BasicAuth(cfg.AuthSecret)
+		pages.GET("/classes/:class_id/attendance", staffAuthMiddleware, attendanceRosterHandler.Handle)
+		pages.POST("/classes/:class_id/attendance/:booking_id", staffAuthMiddleware, attendanceCheckInHandler.Handle)
+		pages.POST("/classes/:class_id/walk_ins", staffAuthMiddleware, attendanceWalkInHandler.Handle)
+	}
+
+	if paymentsService != nil {
+		buyPassFormHandler := buypassform.NewHandler(productsService, viewErrorHandler)
+		createPassPaymentHandler := createpasspayment.NewHandler(paymentsService, viewErrorHandler)
+		createDropInPaymentHandler := createdropinpayment.NewHandler(paymentsService, viewErrorHandler)
+		paymentHandler := payment.NewHandler(paymentsService, viewErrorHandler)
+
+		paymentsLimiter := rate.NewLimiter(rate.Limit(1), 2)
+		pages.GET("/passes/buy", buyPassFormHandler.Handle)
+		pages.POST("/payments/passes", rateLimiterMiddleware(paymentsLimiter), createPassPaymentHandler.Handle)
+		pages.POST("/payments/drop_ins", rateLimiterMiddleware(paymentsLimiter), createDropInPaymentHandler.Handle)
+		pages.GET("/payments/:id", paymentHandler.Handle)
 	}
 
 	var apiErrorHandler apiErrs.IErrorHandler
@@ -294,23 +771,120 @@ func setupRouter(
 	listBookingsHandler := listbookings.NewHandler(bookingsRepo, apiErrorHandler)
 	listBookingsByClassHandler := listbookingsbyclass.NewHandler(bookingsRepo, apiErrorHandler)
 	deleteBookingHandler := deletebooking.NewHandler(bookingsService, apiErrorHandler)
+	createBookingAPIHandler := apiCreateBooking.NewHandler(bookingsService, apiErrorHandler)
+	rescheduleBookingAPIHandler := apiRescheduleBooking.NewHandler(bookingsService, apiErrorHandler)
 	listPendingBookingsHandler := listpendingbookings.NewHandler(pendingBookingsRepo, apiErrorHandler)
 	activatePassHandler := activatepass.NewHandler(passesService, apiErrorHandler)
+	listPassesHandler := listpasses.NewHandler(passesService, apiErrorHandler)
+	listContactPassesHandler := listcontactpasses.NewHandler(passesService, apiErrorHandler)
+	getPassHandler := getpass.NewHandler(passesService, apiErrorHandler)
+	extendPassHandler := extendpass.NewHandler(passesService, apiErrorHandler)
+	freezePassHandler := freezepass.NewHandler(passesService, apiErrorHandler)
 	listContactsHandler := listcontacts.NewHandler(contactsRepo, apiErrorHandler)
 	createContactsHandler := createcontacts.NewHandler(contactsRepo, apiErrorHandler)
+	listWaitlistHandler := listwaitlist.NewHandler(waitlistRepo, apiErrorHandler)
+	createClassSeriesHandler := createclassseries.NewHandler(classSeriesService, apiErrorHandler)
+	listClassSeriesHandler := listclassseries.NewHandler(classSeriesService, apiErrorHandler)
+	updateClassSeriesHandler := updateclassseries.NewHandler(classSeriesService, apiErrorHandler)
+	deleteClassSeriesHandler := deleteclassseries.NewHandler(classSeriesService, apiErrorHandler)
+	listJobsHandler := listjobs.NewHandler(jobsService, apiErrorHandler)
+	listOutboxHandler := listoutbox.NewHandler(outboxService, apiErrorHandler)
+	retryOutboxMessageHandler := retryoutboxmessage.NewHandler(outboxService, apiErrorHandler)
+	markAttendanceHandler := markattendance.NewHandler(attendanceService, apiErrorHandler)
+	createWalkInHandler := createwalkin.NewHandler(attendanceService, apiErrorHandler)
+	getContactStatisticsHandler := getcontactstatistics.NewHandler(attendanceService, apiErrorHandler)
+	listProductsHandler := listproducts.NewHandler(productsService, apiErrorHandler)
+	createProductHandler := createproduct.NewHandler(productsService, apiErrorHandler)
+	updateProductHandler := updateproduct.NewHandler(productsService, apiErrorHandler)
+	listLocationsHandler := listlocations.NewHandler(locationsService, apiErrorHandler)
+	getLocationHandler := getlocation.NewHandler(locationsService, apiErrorHandler)
+	createLocationHandler := createlocation.NewHandler(locationsService, apiErrorHandler)
+	updateLocationHandler := updatelocation.NewHandler(locationsService, apiErrorHandler)
+	deleteLocationHandler := deletelocation.NewHandler(locationsService, apiErrorHandler)
+	listInstructorsHandler := listinstructors.NewHandler(instructorsService, apiErrorHandler)
+	getInstructorHandler := getinstructor.NewHandler(instructorsService, apiErrorHandler)
+	createInstructorHandler := createinstructor.NewHandler(instructorsService, apiErrorHandler)
+	updateInstructorHandler := updateinstructor.NewHandler(instructorsService, apiErrorHandler)
+	deleteInstructorHandler := deleteinstructor.NewHandler(instructorsService, apiErrorHandler)
+	substituteInstructorHandler := substituteinstructor.NewHandler(classesService, apiErrorHandler)
+	listClassTypesHandler := listclasstypes.NewHandler(classTypesService, apiErrorHandler)
+	getClassTypeHandler := getclasstype.NewHandler(classTypesService, apiErrorHandler)
+	createClassTypeHandler := createclasstype.NewHandler(classTypesService, apiErrorHandler)
+	updateClassTypeHandler := updateclasstype.NewHandler(classTypesService, apiErrorHandler)
+	deleteClassTypeHandler := deleteclasstype.NewHandler(classTypesService, apiErrorHandler)
 
 	{
 		api.GET("/api/v1/bookings", authMiddleware, listBookingsHandler.Handle)
 		api.DELETE("/api/v1/bookings/:booking_id", authMiddleware, deleteBookingHandler.Handle)
+		api.POST("/api/v1/bookings/:booking_id/reschedule", authMiddleware, rescheduleBookingAPIHandler.Handle)
 		api.GET("api/v1/pending_bookings", authMiddleware, listPendingBookingsHandler.Handle)
 		api.POST("/api/v1/classes", authMiddleware, createClassHandler.Handle)
 		api.GET("/api/v1/classes", authMiddleware, getClassesHandler.Handle)
 		api.PATCH("/api/v1/classes/:class_id", authMiddleware, updateClassHandler.Handle)
 		api.DELETE("/api/v1/classes/:class_id", authMiddleware, deleteClassHandler.Handle)
+		api.PUT("/api/v1/classes/:class_id/instructor", authMiddleware, substituteInstructorHandler.Handle)
 		api.GET("/api/v1/classes/:class_id/bookings", authMiddleware, listBookingsByClassHandler.Handle)
+		api.POST("/api/v1/classes/:class_id/bookings", authMiddleware, createBookingAPIHandler.Handle)
+		api.GET("/api/v1/classes/:class_id/waitlist", authMiddleware, listWaitlistHandler.Handle)
+		api.PUT("/api/v1/classes/:class_id/attendance/:booking_id", authMiddleware, markAttendanceHandler.Handle)
+		api.POST("/api/v1/classes/:class_id/walk_ins", authMiddleware, createWalkInHandler.Handle)
+		api.POST("/api/v1/class_series", authMiddleware, createClassSeriesHandler.Handle)
+		api.GET("/api/v1/class_series", authMiddleware, listClassSeriesHandler.Handle)
+		api.PATCH("/api/v1/class_series/:series_id", authMiddleware, updateClassSeriesHandler.Handle)
+		api.DELETE("/api/v1/class_series/:series_id", authMiddleware, deleteClassSeriesHandler.Handle)
 		api.PUT("/api/v1/passes", authMiddleware, activatePassHandler.Handle)
+		api.GET("/api/v1/passes", authMiddleware, listPassesHandler.Handle)
+		api.GET("/api/v1/passes/:pass_id", authMiddleware, getPassHandler.Handle)
+		api.POST("/api/v1/passes/:pass_id/extend", authMiddleware, extendPassHandler.Handle)
+		api.POST("/api/v1/passes/:pass_id/freezes", authMiddleware, freezePassHandler.Handle)
 		api.GET("/api/v1/contacts", authMiddleware, listContactsHandler.Handle)
 		api.POST("/api/v1/contacts", authMiddleware, createContactsHandler.Handle)
+		api.GET("/api/v1/contacts/:email/passes", authMiddleware, listContactPassesHandler.Handle)
+		api.GET("/api/v1/contacts/:email/statistics", authMiddleware, getContactStatisticsHandler.Handle)
+		api.GET("/api/v1/jobs", authMiddleware, listJobsHandler.Handle)
+		api.GET("/api/v1/outbox", authMiddleware, listOutboxHandler.Handle)
+		api.POST("/api/v1/outbox/:message_id/retry", authMiddleware, retryOutboxMessageHandler.Handle)
+		api.GET("/api/v1/products", authMiddleware, listProductsHandler.Handle)
+		api.POST("/api/v1/products", authMiddleware, createProductHandler.Handle)
+		api.PATCH("/api/v1/products/:product_id", authMiddleware, updateProductHandler.Handle)
+		api.GET("/api/v1/locations", authMiddleware, listLocationsHandler.Handle)
+		api.POST("/api/v1/locations", authMiddleware, createLocationHandler.Handle)
+		api.GET("/api/v1/locations/:location_id", authMiddleware, getLocationHandler.Handle)
+		api.PATCH("/api/v1/locations/:location_id", authMiddleware, updateLocationHandler.Handle)
+		api.DELETE("/api/v1/locations/:location_id", authMiddleware, deleteLocationHandler.Handle)
+		api.GET("/api/v1/instructors", authMiddleware, listInstructorsHandler.Handle)
+		api.POST("/api/v1/instructors", authMiddleware, createInstructorHandler.Handle)
+		api.GET("/api/v1/instructors/:instructor_id", authMiddleware, getInstructorHandler.Handle)
+		api.PATCH("/api/v1/instructors/:instructor_id", authMiddleware, updateInstructorHandler.Handle)
+		api.DELETE("/api/v1/instructors/:instructor_id", authMiddleware, deleteInstructorHandler.Handle)
+
+		api.GET("/api/v1/class_types", authMiddleware, listClassTypesHandler.Handle)
+		api.POST("/api/v1/class_types", authMiddleware, createClassTypeHandler.Handle)
+		api.GET("/api/v1/class_types/:class_type_id", authMiddleware, getClassTypeHandler.Handle)
+		api.PATCH("/api/v1/class_types/:class_type_id", authMiddleware, updateClassTypeHandler.Handle)
+		api.DELETE("/api/v1/class_types/:class_type_id", authMiddleware, deleteClassTypeHandler.Handle)
+	}
+
+	if paymentsService != nil {
+		paymentWebhookHandler := paymentwebhook.NewHandler(paymentsService, apiErrorHandler)
+		listPaymentsHandler := listpayments.NewHandler(paymentsService, apiErrorHandler)
+
+		// the webhook is signed by the payment provider instead
+		api.POST("/api/v1/payments/webhook", paymentWebhookHandler.Handle)
+		api.GET("/api/v1/payments", authMiddleware, listPaymentsHandler.Handle)
+	}
+
+	// public API, read by partner websites without the auth secret
+	listPublicClassesHandler := listpublicclasses.NewHandler(
+		classesService, apiErrorHandler, cfg.PublicAPI.CacheMaxAge.Duration,
+	)
+	publicLimiter := rate.NewLimiter(rate.Limit(5), 20)
+	public := router.Group("/public/v1", middleware.CORS(cfg.PublicAPI.AllowedOrigins))
+
+	{
+		public.GET("/classes", rateLimiterMiddleware(publicLimiter), listPublicClassesHandler.Handle)
+		// preflight requests are answered by the CORS middleware
+		public.OPTIONS("/classes")
 	}
 
 	return router
@@ -342,38 +916,3 @@ func runServer(srv *http.Server, cfg *configuration.Configuration) {
 
 	slog.Info("Server stopped")
 }
-
-func cleanUpPendingBookings(ctx context.Context, database *gorm.DB) {
-	oneHourAgo := time.Now().UTC().Add(-1 * time.Hour)
-
-	var pendingBooking dbModels.SQLPendingBooking
-
-	result := database.WithContext(ctx).
-		Where("created_at < ?", oneHourAgo).
-		Delete(&pendingBooking)
-
-	if result.Error != nil {
-		if errors.Is(result.Error, context.DeadlineExceeded) {
-			slog.Warn("cleanup timeout exceeded")
-		} else {
-			slog.Error("failed to cleanup pending bookings async",
-				slog.String("err", result.Error.Error()))
-		}
-
-		return
-	}
-
-	slog.Info("PendingBookingCleaner: cleaned up pending bookings", slog.Int64("deleted", result.RowsAffected))
-}
-
-func remindBookings(ctx context.Context, reminder reminder.IReminderService) {
-	err := reminder.RemindBookings(ctx)
-	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			slog.Warn("remind classes timeout exceeded")
-		} else {
-			slog.Error("failed to remind classes async",
-				slog.String("err", err.Error()))
-		}
-	}
-}
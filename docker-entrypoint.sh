@@ -0,0 +1,6 @@
+#!/bin/sh
+set -e
+
+/app/yoga migrate up
+
+exec /app/yoga "$@"
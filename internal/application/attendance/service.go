@@ -0,0 +1,170 @@
+package attendance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"main/internal/domain/errs/api"
+	"main/internal/domain/models"
+	"main/internal/domain/repositories"
+	"main/internal/domain/services"
+	"main/internal/infrastructure/errs"
+
+	"github.com/google/uuid"
+)
+
+type service struct {
+	unitOfWork      repositories.IUnitOfWork
+	classesRepo     repositories.IClasses
+	bookingsRepo    repositories.IBookings
+	bookingsService services.IBookingsService
+}
+
+func NewService(
+	unitOfWork repositories.IUnitOfWork,
+	classesRepo repositories.IClasses,
+	bookingsRepo repositories.IBookings,
+	bookingsService services.IBookingsService,
+) *service {
+	return &service{
+		unitOfWork:      unitOfWork,
+		classesRepo:     classesRepo,
+		bookingsRepo:    bookingsRepo,
+		bookingsService: bookingsService,
+	}
+}
+
+func (s *service) GetRoster(ctx context.Context, classID uuid.UUID) (models.Roster, error) {
+	class, err := s.classesRepo.Get(ctx, classID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return models.Roster{}, api.ErrNotFound(fmt.Errorf("class %s not found", classID))
+		}
+
+		return models.Roster{}, fmt.Errorf("could not get class %s: %w", classID, err)
+	}
+
+	bookings, err := s.bookingsRepo.ListByClassID(ctx, classID)
+	if err != nil {
+		return models.Roster{}, fmt.Errorf("could not list bookings for class %s: %w", classID, err)
+	}
+
+	emails := make([]string, len(bookings))
+
+	for i, booking := range bookings {
+		emails[i] = booking.Email
+	}
+
+	statistics, err := s.bookingsRepo.ListStatisticsByEmails(ctx, emails)
+	if err != nil {
+		return models.Roster{}, fmt.Errorf("could not get statistics for class %s: %w", classID, err)
+	}
+
+	statisticsByEmail := make(map[string]models.ContactStatistics, len(statistics))
+
+	for _, statistic := range statistics {
+		statisticsByEmail[statistic.Email] = statistic
+	}
+
+	// the instructor looks people up by name
+	sort.Slice(bookings, func(i, j int) bool {
+		a := strings.ToLower(bookings[i].LastName + " " + bookings[i].FirstName)
+		b := strings.ToLower(bookings[j].LastName + " " + bookings[j].FirstName)
+
+		return a < b
+	})
+
+	roster := models.Roster{
+		Class:   class,
+		Entries: make([]models.RosterEntry, len(bookings)),
+	}
+
+	for i, booking := range bookings {
+		roster.Entries[i] = models.RosterEntry{
+			Booking:    booking,
+			Statistics: statisticsByEmail[booking.Email],
+		}
+	}
+
+	return roster, nil
+}
+
+func (s *service) MarkAttendance(
+	ctx context.Context, classID, bookingID uuid.UUID, status models.BookingStatus,
+) (models.Booking, error) {
+	if !status.IsAttendanceStatus() {
+		return models.Booking{}, api.ErrValidation(
+			fmt.Errorf("status %q can not be set on attendance check, use %q or %q",
+				status, models.BookingAttended, models.BookingNoShow),
+		)
+	}
+
+	var booking models.Booking
+
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		var err error
+
+		booking, err = repos.Bookings.GetByID(ctx, bookingID)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return api.ErrNotFound(fmt.Errorf("booking %s not found", bookingID))
+			}
+
+			return fmt.Errorf("could not get booking %s: %w", bookingID, err)
+		}
+
+		if booking.ClassID != classID {
+			return api.ErrNotFound(fmt.Errorf("booking %s not found in class %s", bookingID, classID))
+		}
+
+		if booking.Class.StartTime.After(time.Now()) {
+			return api.ErrValidation(
+				fmt.Errorf("class %s has not started yet, it starts at %v", classID, booking.Class.StartTime),
+			)
+		}
+
+		err = repos.Bookings.UpdateStatus(ctx, bookingID, status)
+		if err != nil {
+			return fmt.Errorf("could not mark booking %s as %s: %w", bookingID, status, err)
+		}
+
+		booking.Status = status
+
+		return nil
+	})
+	if err != nil {
+		return models.Booking{}, fmt.Errorf("mark attendance transaction failed: %w", err)
+	}
+
+	return booking, nil
+}
+
+// AddWalkIn books the walk-in like any other booking, so it takes a pass slot and the
+// spot of the student on the waitlist.
+func (s *service) AddWalkIn(
+	ctx context.Context, classID uuid.UUID, params models.WalkInParams,
+) (models.Booking, error) {
+	booking, err := s.bookingsService.CreateWalkIn(ctx, classID, params)
+	if err != nil {
+		return models.Booking{}, fmt.Errorf("could not create walk-in booking: %w", err)
+	}
+
+	return booking, nil
+}
+
+func (s *service) GetContactStatistics(ctx context.Context, email string) (models.ContactStatistics, error) {
+	statistics, err := s.bookingsRepo.ListStatisticsByEmails(ctx, []string{email})
+	if err != nil {
+		return models.ContactStatistics{}, fmt.Errorf("could not get statistics for %s: %w", email, err)
+	}
+
+	if len(statistics) == 0 {
+		return models.ContactStatistics{Email: email}, nil
+	}
+
+	return statistics[0], nil
+}
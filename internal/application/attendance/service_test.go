@@ -0,0 +1,220 @@
+package attendance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"main/internal/application/bookings"
+	"main/internal/application/waitlist"
+	"main/internal/domain/errs/api"
+	"main/internal/domain/models"
+	"main/internal/domain/repositories"
+	"main/internal/domain/services"
+	"main/internal/infrastructure/generator/token"
+	"main/internal/infrastructure/repository/repositorytest"
+
+	"github.com/google/uuid"
+)
+
+const walkInEmail = "walk-in@example.com"
+
+func TestAddWalkIn(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxCapacity int
+		bookedBy    []string
+		offeredTo   string
+		withPass    bool
+		wantCode    *int
+		wantPass    bool
+	}{
+		{
+			name:        "walk-in is attended and takes a pass slot",
+			maxCapacity: 2,
+			withPass:    true,
+			wantPass:    true,
+		},
+		{
+			name:        "walk-in without pass",
+			maxCapacity: 2,
+		},
+		{
+			name:        "walk-in takes the spot offered to them from the waitlist",
+			maxCapacity: 1,
+			offeredTo:   walkInEmail,
+		},
+		{
+			name:        "spot offered to someone else is held",
+			maxCapacity: 2,
+			bookedBy:    []string{"anna@example.com"},
+			offeredTo:   "bob@example.com",
+			wantCode:    code(api.ConflictCode),
+		},
+		{
+			name:        "already booked",
+			maxCapacity: 2,
+			bookedBy:    []string{walkInEmail},
+			wantCode:    code(api.ConflictCode),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos, s := newTestService(t)
+			class := repositorytest.InsertClass(t, repos, time.Now().Add(-10*time.Minute), tt.maxCapacity)
+
+			for _, email := range tt.bookedBy {
+				repositorytest.InsertBooking(t, repos, class.ID, email)
+			}
+
+			if tt.offeredTo != "" {
+				offerSpot(t, repos, class.ID, tt.offeredTo)
+			}
+
+			if tt.withPass {
+				_, err := repos.Passes.Insert(ctx, models.Pass{
+					Email:      walkInEmail,
+					TotalSlots: 4,
+					ValidFrom:  time.Now().AddDate(0, -1, 0),
+					CreatedAt:  time.Now().UTC(),
+				})
+				if err != nil {
+					t.Fatalf("could not insert pass: %v", err)
+				}
+			}
+
+			booking, err := s.AddWalkIn(ctx, class.ID, models.WalkInParams{
+				FirstName: "Ewa",
+				LastName:  "Nowak",
+				Email:     walkInEmail,
+			})
+			if !hasCode(err, tt.wantCode) {
+				t.Fatalf("AddWalkIn() error = %v, want code %v", err, tt.wantCode)
+			}
+
+			if tt.wantCode != nil {
+				return
+			}
+
+			stored, err := repos.Bookings.GetByID(ctx, booking.ID)
+			if err != nil {
+				t.Fatalf("could not get walk-in booking: %v", err)
+			}
+
+			if stored.Status != models.BookingAttended || !stored.WalkIn {
+				t.Errorf("booking is %s, walk-in %v, want attended walk-in", stored.Status, stored.WalkIn)
+			}
+
+			if stored.PassID.Exists() != tt.wantPass {
+				t.Errorf("booking has pass %v, want %v", stored.PassID.Exists(), tt.wantPass)
+			}
+
+			_, err = repos.Waitlist.GetByClassIDAndEmail(ctx, class.ID, walkInEmail)
+			if err == nil {
+				t.Error("walk-in is still on the waitlist")
+			}
+		})
+	}
+}
+
+func TestMarkAttendance(t *testing.T) {
+	tests := []struct {
+		name      string
+		startTime time.Time
+		wantCode  *int
+	}{
+		{
+			name:      "class has started",
+			startTime: time.Now().Add(-10 * time.Minute),
+		},
+		{
+			name:      "class has not started yet",
+			startTime: time.Now().Add(time.Hour),
+			wantCode:  code(api.BadRequestCode),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos, s := newTestService(t)
+			class := repositorytest.InsertClass(t, repos, tt.startTime, 10)
+			bookingID := repositorytest.InsertBooking(t, repos, class.ID, "anna@example.com")
+
+			_, err := s.MarkAttendance(ctx, class.ID, bookingID, models.BookingNoShow)
+			if !hasCode(err, tt.wantCode) {
+				t.Fatalf("MarkAttendance() error = %v, want code %v", err, tt.wantCode)
+			}
+
+			booking, err := repos.Bookings.GetByID(ctx, bookingID)
+			if err != nil {
+				t.Fatalf("could not get booking: %v", err)
+			}
+
+			wantStatus := models.BookingNoShow
+			if tt.wantCode != nil {
+				wantStatus = models.BookingConfirmed
+			}
+
+			if booking.Status != wantStatus {
+				t.Errorf("booking is %s, want %s", booking.Status, wantStatus)
+			}
+		})
+	}
+}
+
+func newTestService(t *testing.T) (repositories.Repositories, *service) {
+	t.Helper()
+
+	repos, unitOfWork := repositorytest.OpenSQLite(t)
+	tokenGenerator := token.NewGenerator()
+	bookingPolicy := services.NewBookingPolicy(200, 3, models.BookingRules{}, nil)
+
+	bookingsService := bookings.NewService(
+		unitOfWork,
+		repos.Bookings,
+		tokenGenerator,
+		&services.PassManager{},
+		bookingPolicy,
+		waitlist.NewService(unitOfWork, tokenGenerator, ""),
+		"",
+	)
+
+	return repos, NewService(unitOfWork, repos.Classes, repos.Bookings, bookingsService)
+}
+
+func offerSpot(t *testing.T, repos repositories.Repositories, classID uuid.UUID, email string) {
+	t.Helper()
+
+	offeredAt := time.Now().Add(-time.Minute).UTC()
+
+	err := repos.Waitlist.Insert(context.Background(), models.WaitlistEntry{
+		ID:        uuid.New(),
+		ClassID:   classID,
+		Email:     email,
+		FirstName: "Ewa",
+		LastName:  "Nowak",
+		CreatedAt: offeredAt,
+		OfferedAt: &offeredAt,
+	})
+	if err != nil {
+		t.Fatalf("could not insert waitlist entry: %v", err)
+	}
+}
+
+func code(c int) *int {
+	return &c
+}
+
+func hasCode(err error, want *int) bool {
+	if want == nil {
+		return err == nil
+	}
+
+	var apiError *api.APIError
+
+	return errors.As(err, &apiError) && apiError.Code == *want
+}
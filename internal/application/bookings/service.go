@@ -4,53 +4,55 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
+	"main/internal/domain/errs/api"
 	viewErrors "main/internal/domain/errs/view"
 	"main/internal/domain/models"
-	"main/internal/domain/notifier"
 	"main/internal/domain/repositories"
 	"main/internal/domain/services"
 	"main/internal/infrastructure/errs"
+	"main/pkg/i18n"
 	"main/pkg/optional"
 
 	"github.com/google/uuid"
 )
 
-const (
-	threeLastPasses = 3
-)
+const tokenLength = 32
 
 type service struct {
-	unitOfWork   repositories.IUnitOfWork
-	bookingsRepo repositories.IBookings
-	passManager  services.IPassManager
-	notifier     notifier.INotifier
-	domainAddr   string
+	unitOfWork      repositories.IUnitOfWork
+	bookingsRepo    repositories.IBookings
+	tokenGenerator  services.ITokenGenerator
+	passManager     services.IPassManager
+	bookingPolicy   services.IBookingPolicy
+	waitlistService services.IWaitlistService
+	domainAddr      string
 }
 
 func NewService(
 	unitOfWork repositories.IUnitOfWork,
 	bookingsRepo repositories.IBookings,
+	tokenGenerator services.ITokenGenerator,
 	passManager services.IPassManager,
-	notifier notifier.INotifier,
+	bookingPolicy services.IBookingPolicy,
+	waitlistService services.IWaitlistService,
 	domainAddr string,
 ) *service {
 	return &service{
-		unitOfWork:   unitOfWork,
-		bookingsRepo: bookingsRepo,
-		passManager:  passManager,
-		notifier:     notifier,
-		domainAddr:   domainAddr,
+		unitOfWork:      unitOfWork,
+		bookingsRepo:    bookingsRepo,
+		tokenGenerator:  tokenGenerator,
+		passManager:     passManager,
+		bookingPolicy:   bookingPolicy,
+		waitlistService: waitlistService,
+		domainAddr:      domainAddr,
 	}
 }
 
 func (s *service) CreateBooking(ctx context.Context, token string) (models.Class, error) {
-	var (
-		pendingBooking models.PendingBooking
-		bookingID      uuid.UUID
-		passSlots      []models.PassSlot
-	)
+	var pendingBooking models.PendingBooking
 
 	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
 		var err error
@@ -66,155 +68,664 @@ func (s *service) CreateBooking(ctx context.Context, token string) (models.Class
 			return fmt.Errorf("could not get pending booking: %w", err)
 		}
 
-		_, err = repos.Bookings.GetByEmailAndClassID(ctx, pendingBooking.ClassID, pendingBooking.Email)
-		if err == nil {
-			return viewErrors.ErrBookingAlreadyExists(
-				pendingBooking.ClassID,
-				pendingBooking.Email,
-				fmt.Errorf("booking already exists for email %s and classID %s", pendingBooking.Email, pendingBooking.ClassID),
-			)
-		}
-
-		if !errors.Is(err, errs.ErrNotFound) {
-			return fmt.Errorf("could not get booking for email %s and classID %s: %w",
-				pendingBooking.Email,
-				pendingBooking.ClassID,
-				err,
-			)
+		err = s.ensureBookingAllowed(ctx, repos, pendingBooking.Class, pendingBooking.Email)
+		if err != nil {
+			return fmt.Errorf("booking not allowed: %w", err)
 		}
 
-		err = s.checkClassAvailability(ctx, repos, pendingBooking.Class)
+		// other bookings could have been confirmed since the link was sent
+		studentBookings, err := repos.Bookings.ListByEmail(ctx, pendingBooking.Email)
 		if err != nil {
-			return fmt.Errorf("class unavailable: %w", err)
+			return fmt.Errorf("could not list bookings for %s: %w", pendingBooking.Email, err)
 		}
 
-		// I need to make sure that I will check if previous pass will not have some empty slots. Three is enough.
-		passes, err := repos.Passes.ListByEmail(ctx, pendingBooking.Email, threeLastPasses)
+		err = s.bookingPolicy.CheckActiveBookings(
+			pendingBooking.Class, pendingBooking.Email, studentBookings, time.Now(),
+		)
 		if err != nil {
-			return fmt.Errorf("could not get pass: %w", err)
+			return fmt.Errorf("booking limit reached: %w", err)
 		}
 
-		booking := models.Booking{
+		booking, passSlots, err := s.insertConfirmedBooking(ctx, repos, models.Booking{
 			ID:                uuid.New(),
 			ClassID:           pendingBooking.ClassID,
+			Class:             pendingBooking.Class,
 			FirstName:         pendingBooking.FirstName,
 			LastName:          pendingBooking.LastName,
 			Email:             pendingBooking.Email,
 			CreatedAt:         time.Now().UTC(),
 			ConfirmationToken: pendingBooking.ConfirmationToken,
+		})
+		if err != nil {
+			return fmt.Errorf("could not confirm booking: %w", err)
 		}
 
-		_, err = repos.Contacts.Insert(ctx, booking.Email, booking.FirstName, booking.LastName)
+		// the link is used up, clicking it again finds no pending booking
+		err = repos.PendingBookings.Delete(ctx, pendingBooking.ID)
 		if err != nil {
-			if !errors.Is(err, errs.ErrAlreadyExist) {
-				return fmt.Errorf("could not insert contact: %w", err)
-			}
+			return fmt.Errorf("could not delete pending booking %v: %w", pendingBooking.ID, err)
+		}
+
+		err = s.enqueueConfirmation(ctx, repos, booking, passSlots)
+		if err != nil {
+			return fmt.Errorf("could not enqueue confirmation email %s: %w", pendingBooking.Email, err)
 		}
 
-		for _, pass := range passes {
-			bookingsWithPassCount, err := s.bookingsRepo.CountForPassID(ctx, pass.ID)
-			if err != nil {
-				return fmt.Errorf("could not count bookings for passID %d: %w", pass.ID, err)
+		return nil
+	})
+	if err != nil {
+		return models.Class{}, fmt.Errorf("create booking transaction failed: %w", err)
+	}
+
+	return pendingBooking.Class, nil
+}
+
+// CreateConfirmedBooking books a class for the admin, without the email confirmation
+// step and the per student limits of the booking policy.
+func (s *service) CreateConfirmedBooking(
+	ctx context.Context, params models.ConfirmedBookingParams,
+) (models.Booking, error) {
+	var booking models.Booking
+
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		class, err := repos.Classes.Get(ctx, params.ClassID)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return api.ErrNotFound(fmt.Errorf("class %s not found", params.ClassID))
 			}
 
-			if bookingsWithPassCount < pass.TotalSlots {
-				booking.PassID = optional.Of(pass.ID)
-				booking.Pass = optional.Of(pass)
+			return fmt.Errorf("could not get class %s: %w", params.ClassID, err)
+		}
+
+		err = s.ensureBookingAllowed(ctx, repos, class, params.Email)
+		if err != nil {
+			return fmt.Errorf("booking not allowed: %w", err)
+		}
+
+		// the token is what the cancellation link in the confirmation email is checked against
+		confirmationToken, err := s.tokenGenerator.Generate(tokenLength)
+		if err != nil {
+			return fmt.Errorf("could not generate confirmation token: %w", err)
+		}
+
+		var passSlots []models.PassSlot
+
+		booking, passSlots, err = s.insertConfirmedBooking(ctx, repos, models.Booking{
+			ID:                uuid.New(),
+			ClassID:           class.ID,
+			Class:             class,
+			FirstName:         params.FirstName,
+			LastName:          params.LastName,
+			Email:             params.Email,
+			CreatedAt:         time.Now().UTC(),
+			ConfirmationToken: confirmationToken,
+			PaymentID:         params.PaymentID,
+		})
+		if err != nil {
+			return fmt.Errorf("could not confirm booking: %w", err)
+		}
+
+		if !params.SendConfirmation {
+			return nil
+		}
+
+		err = s.enqueueConfirmation(ctx, repos, booking, passSlots)
+		if err != nil {
+			return fmt.Errorf("could not enqueue confirmation email %s: %w", params.Email, err)
+		}
 
-				bookingID, err = repos.Bookings.Insert(ctx, booking)
-				if err != nil {
-					return fmt.Errorf("could not insert booking: %w", err)
-				}
+		return nil
+	})
+	if err != nil {
+		return models.Booking{}, fmt.Errorf("create confirmed booking transaction failed: %w", err)
+	}
 
-				bookings, err := repos.Bookings.ListByPassID(ctx, pass.ID)
-				if err != nil {
-					return fmt.Errorf("could not list bookings by passID %d: %w", pass.ID, err)
-				}
+	return booking, nil
+}
 
-				passSlots = s.passManager.BuildPassSlots(bookings, pass.TotalSlots)
+// CreateWalkIn books a student who came to the class without a booking for the staff,
+// already marked as attended. The class may have started and no email is sent.
+func (s *service) CreateWalkIn(
+	ctx context.Context, classID uuid.UUID, params models.WalkInParams,
+) (models.Booking, error) {
+	var booking models.Booking
 
-				return nil
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		class, err := repos.Classes.Get(ctx, classID)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return api.ErrNotFound(fmt.Errorf("class %s not found", classID))
 			}
+
+			return fmt.Errorf("could not get class %s: %w", classID, err)
+		}
+
+		_, err = repos.Bookings.GetByEmailAndClassID(ctx, classID, params.Email)
+		if err == nil {
+			return api.ErrBookingAlreadyExists(
+				fmt.Errorf("booking already exists for email %s and classID %s", params.Email, classID),
+			)
 		}
 
-		bookingID, err = repos.Bookings.Insert(ctx, booking)
+		if !errors.Is(err, errs.ErrNotFound) {
+			return fmt.Errorf("could not get booking for email %s and classID %s: %w", params.Email, classID, err)
+		}
+
+		heldSpots, err := countHeldSpots(ctx, repos, classID, params.Email)
 		if err != nil {
-			return fmt.Errorf("could not insert booking: %w", err)
+			return fmt.Errorf("could not count held spots: %w", err)
+		}
+
+		if heldSpots >= class.MaxCapacity {
+			return api.ErrClassFull(fmt.Errorf("max capacity of class %d exceeded", class.MaxCapacity))
+		}
+
+		// there is no cancellation link for a walk-in, the token only has to be unique
+		confirmationToken, err := s.tokenGenerator.Generate(tokenLength)
+		if err != nil {
+			return fmt.Errorf("could not generate confirmation token: %w", err)
+		}
+
+		booking, _, err = s.insertConfirmedBooking(ctx, repos, models.Booking{
+			ID:                uuid.New(),
+			ClassID:           classID,
+			Class:             class,
+			FirstName:         params.FirstName,
+			LastName:          params.LastName,
+			Email:             params.Email,
+			CreatedAt:         time.Now().UTC(),
+			ConfirmationToken: confirmationToken,
+			Status:            models.BookingAttended,
+			WalkIn:            true,
+		})
+		if err != nil {
+			return fmt.Errorf("could not insert walk-in booking: %w", err)
 		}
 
 		return nil
 	})
 	if err != nil {
-		return models.Class{}, fmt.Errorf("create booking transaction failed: %w", err)
+		return models.Booking{}, fmt.Errorf("create walk-in transaction failed: %w", err)
 	}
 
-	err = s.sendConfirmation(
-		pendingBooking, passSlots, token, bookingID,
-	)
+	return booking, nil
+}
+
+// EnsureSpotAvailable checks the student could book the class now. The spot held for them
+// is theirs, so a student offered a spot from the waitlist can buy it.
+func (s *service) EnsureSpotAvailable(ctx context.Context, class models.Class, email string) error {
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		return s.ensureBookingAllowed(ctx, repos, class, email)
+	})
 	if err != nil {
-		return models.Class{},
-			fmt.Errorf("could not send confirmation email %s: %w", pendingBooking.Email, err)
+		return fmt.Errorf("ensure spot available transaction failed: %w", err)
 	}
 
-	return pendingBooking.Class, nil
+	return nil
+}
+
+func (s *service) ensureBookingAllowed(
+	ctx context.Context,
+	repos repositories.Repositories,
+	class models.Class,
+	email string,
+) error {
+	_, err := repos.Bookings.GetByEmailAndClassID(ctx, class.ID, email)
+	if err == nil {
+		return viewErrors.ErrBookingAlreadyExists(
+			class.ID,
+			email,
+			fmt.Errorf("booking already exists for email %s and classID %s", email, class.ID),
+		)
+	}
+
+	if !errors.Is(err, errs.ErrNotFound) {
+		return fmt.Errorf("could not get booking for email %s and classID %s: %w", email, class.ID, err)
+	}
+
+	err = s.checkClassAvailability(ctx, repos, class, email)
+	if err != nil {
+		return fmt.Errorf("class unavailable: %w", err)
+	}
+
+	return nil
+}
+
+// insertConfirmedBooking saves the contact and the booking, with the first pass that
+// still has a free slot for the class. Pass slots are returned for the confirmation.
+func (s *service) insertConfirmedBooking(
+	ctx context.Context,
+	repos repositories.Repositories,
+	booking models.Booking,
+) (models.Booking, []models.PassSlot, error) {
+	// I need to make sure that I will check if previous pass will not have some empty slots.
+	passes, err := repos.Passes.ListByEmail(ctx, booking.Email, s.bookingPolicy.PassesToCheck())
+	if err != nil {
+		return models.Booking{}, nil, fmt.Errorf("could not get pass: %w", err)
+	}
+
+	_, err = repos.Contacts.Insert(ctx, booking.Email, booking.FirstName, booking.LastName)
+	if err != nil {
+		if !errors.Is(err, errs.ErrAlreadyExist) {
+			return models.Booking{}, nil, fmt.Errorf("could not insert contact: %w", err)
+		}
+	}
+
+	// later emails, like class updates, follow the language the student booked in, a walk-in
+	// is added by the staff in their own language
+	if locale, ok := i18n.FromContext(ctx); ok && !booking.WalkIn {
+		err = repos.Contacts.UpdateLanguage(ctx, booking.Email, locale)
+		if err != nil {
+			return models.Booking{}, nil, fmt.Errorf("could not update contact language: %w", err)
+		}
+	}
+
+	err = repos.Waitlist.DeleteByClassIDAndEmail(ctx, booking.ClassID, booking.Email)
+	if err != nil {
+		return models.Booking{}, nil, fmt.Errorf("could not delete waitlist entry: %w", err)
+	}
+
+	// a paid drop-in is not taken from the pass
+	if booking.PaymentID.Exists() {
+		passes = nil
+	}
+
+	for _, pass := range passes {
+		if !pass.IsUsableAt(booking.Class.StartTime) {
+			continue
+		}
+
+		bookingsWithPassCount, err := repos.Bookings.CountForPassID(ctx, pass.ID)
+		if err != nil {
+			return models.Booking{}, nil, fmt.Errorf("could not count bookings for passID %d: %w", pass.ID, err)
+		}
+
+		if bookingsWithPassCount < pass.TotalSlots {
+			booking.PassID = optional.Of(pass.ID)
+			booking.Pass = optional.Of(pass)
+
+			break
+		}
+	}
+
+	_, err = repos.Bookings.Insert(ctx, booking)
+	if err != nil {
+		return models.Booking{}, nil, fmt.Errorf("could not insert booking: %w", err)
+	}
+
+	if booking.Status == "" {
+		booking.Status = models.BookingConfirmed
+	}
+
+	var passSlots []models.PassSlot
+
+	if booking.Pass.Exists() {
+		pass := booking.Pass.Get()
+
+		bookings, err := repos.Bookings.ListByPassID(ctx, pass.ID)
+		if err != nil {
+			return models.Booking{}, nil, fmt.Errorf("could not list bookings by passID %d: %w", pass.ID, err)
+		}
+
+		passSlots = s.passManager.BuildPassSlots(bookings, pass.TotalSlots)
+	}
+
+	return booking, passSlots, nil
 }
 
 func (s *service) checkClassAvailability(
 	ctx context.Context,
 	repos repositories.Repositories,
 	class models.Class,
+	email string,
 ) error {
 	if class.StartTime.Before(time.Now()) {
 		return viewErrors.ErrClassExpired(class.ID, fmt.Errorf("class %s has expired at %v", class.ID, class.StartTime))
 	}
 
-	bookingCount, err := repos.Bookings.CountForClassID(ctx, class.ID)
+	heldSpots, err := countHeldSpots(ctx, repos, class.ID, email)
 	if err != nil {
-		return fmt.Errorf("could not count bookings for class %v: %w ", class.ID, err)
+		return fmt.Errorf("could not count held spots: %w", err)
 	}
 
-	if bookingCount == class.MaxCapacity {
+	if heldSpots >= class.MaxCapacity {
 		return viewErrors.ErrSomeoneBookedClassFaster(fmt.Errorf("max capacity of class %d exceeded", class.MaxCapacity))
 	}
 
 	return nil
 }
 
-func (s *service) sendConfirmation(
-	pendingBooking models.PendingBooking,
+// countHeldSpots counts the bookings of the class, the spots offered to people from the
+// waitlist and the spots of drop-ins being paid for, which are held until the offer or the
+// checkout expires. The spots held for email are not counted, it is the one the student is
+// taking now. The class stays locked until the transaction ends, so a concurrent booking
+// counts after this one is inserted.
+func countHeldSpots(
+	ctx context.Context,
+	repos repositories.Repositories,
+	classID uuid.UUID,
+	email string,
+) (int, error) {
+	err := repos.Classes.Lock(ctx, classID)
+	if err != nil {
+		return 0, fmt.Errorf("could not lock class %v: %w", classID, err)
+	}
+
+	bookingCount, err := repos.Bookings.CountForClassID(ctx, classID)
+	if err != nil {
+		return 0, fmt.Errorf("could not count bookings for class %v: %w", classID, err)
+	}
+
+	offeredSince := time.Now().Add(-models.WaitlistOfferTTL)
+
+	offeredCount, err := repos.Waitlist.CountOfferedSince(ctx, classID, offeredSince)
+	if err != nil {
+		return 0, fmt.Errorf("could not count waitlist offers for class %v: %w", classID, err)
+	}
+
+	entry, err := repos.Waitlist.GetByClassIDAndEmail(ctx, classID, email)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return 0, fmt.Errorf("could not get waitlist entry for class %v: %w", classID, err)
+	}
+
+	if err == nil && entry.OfferedAt != nil && entry.OfferedAt.After(offeredSince) {
+		offeredCount--
+	}
+
+	checkoutCount, err := repos.Payments.CountPendingDropIns(
+		ctx, classID, email, time.Now().Add(-models.DropInCheckoutTTL),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("could not count pending drop-ins for class %v: %w", classID, err)
+	}
+
+	return bookingCount + offeredCount + checkoutCount, nil
+}
+
+func (s *service) enqueueConfirmation(
+	ctx context.Context,
+	repos repositories.Repositories,
+	booking models.Booking,
 	passSlots []models.PassSlot,
-	token string,
-	bookingID uuid.UUID,
 ) error {
-	notifierParams := models.NotifierParams{
-		RecipientEmail:     pendingBooking.Email,
-		RecipientFirstName: pendingBooking.FirstName,
-		RecipientLastName:  pendingBooking.LastName,
-		ClassName:          pendingBooking.Class.ClassName,
-		ClassLevel:         pendingBooking.Class.ClassLevel,
-		StartTime:          pendingBooking.Class.StartTime,
-		Location:           pendingBooking.Class.Location,
+	locale, _ := i18n.FromContext(ctx)
+
+	err := repos.Outbox.Enqueue(ctx, models.Notification{
+		Kind:   models.NotificationBookingConfirmation,
+		Params: bookingNotifierParams(booking, passSlots),
+		Link:   s.cancellationLink(booking),
+		Locale: locale,
+	})
+	if err != nil {
+		return fmt.Errorf("could not enqueue booking confirmation: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) cancellationLink(booking models.Booking) string {
+	return fmt.Sprintf(
+		"%s/bookings/%s/cancel_form?token=%s", s.domainAddr, booking.ID, booking.ConfirmationToken,
+	)
+}
+
+func bookingNotifierParams(booking models.Booking, passSlots []models.PassSlot) models.NotifierParams {
+	return models.NotifierParams{
+		RecipientEmail:     booking.Email,
+		RecipientFirstName: booking.FirstName,
+		RecipientLastName:  booking.LastName,
+		ClassID:            booking.Class.ID,
+		ClassSequence:      booking.Class.Sequence,
+		ClassName:          booking.Class.ClassName,
+		ClassLevel:         booking.Class.ClassLevel,
+		StartTime:          booking.Class.StartTime,
+		Location:           booking.Class.Location,
+		Instructor:         booking.Class.Instructor,
+		Duration:           booking.Class.Duration,
 		PassSlots:          passSlots,
 	}
+}
 
-	cancellationLink := fmt.Sprintf(
-		"%s/bookings/%s/cancel_form?token=%s", s.domainAddr, bookingID, token,
+// RescheduleBooking moves the booking of a student to another class. It is allowed with
+// the cancellation link token until the late cancellation cutoff of the booked class.
+func (s *service) RescheduleBooking(
+	ctx context.Context, bookingID uuid.UUID, token string, classID uuid.UUID,
+) (models.Booking, error) {
+	var (
+		booking         models.Booking
+		previousClassID uuid.UUID
 	)
 
-	err := s.notifier.NotifyBookingConfirmation(notifierParams, cancellationLink)
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		current, err := s.ensureBookingCancellationAllowed(ctx, repos, bookingID, token)
+		if err != nil {
+			return fmt.Errorf("booking reschedule not allowed for bookingID %s: %w", bookingID, err)
+		}
+
+		// moving away after the cutoff would free the pass slot a late cancellation keeps
+		if s.bookingPolicy.IsLateCancellation(current.Class, time.Now()) {
+			return viewErrors.ErrTooLateToReschedule(
+				current.ClassID,
+				fmt.Errorf("booking %s for class %s is past the cancellation cutoff", bookingID, current.ClassID),
+			)
+		}
+
+		class, err := repos.Classes.Get(ctx, classID)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return viewErrors.ErrClassNotFound(fmt.Errorf("class %s not found", classID))
+			}
+
+			return fmt.Errorf("could not get class %s: %w", classID, err)
+		}
+
+		bookingCount, err := repos.Bookings.CountForClassID(ctx, classID)
+		if err != nil {
+			return fmt.Errorf("could not count bookings for class %s: %w", classID, err)
+		}
+
+		err = s.bookingPolicy.CheckBookingWindow(class, bookingCount, time.Now())
+		if err != nil {
+			return fmt.Errorf("booking window closed: %w", err)
+		}
+
+		err = ensurePassUsable(ctx, repos, current, class)
+		if err != nil {
+			return fmt.Errorf("pass not usable for class %s: %w", classID, err)
+		}
+
+		previousClassID = current.ClassID
+
+		booking, err = s.moveBooking(ctx, repos, current, class)
+		if err != nil {
+			return fmt.Errorf("could not move booking %s to class %s: %w", bookingID, classID, err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("could not notify booking confirmation: %w", err)
+		return models.Booking{}, fmt.Errorf("reschedule booking transaction failed: %w", err)
 	}
 
-	return nil
+	s.promoteWaitlist(ctx, previousClassID)
+
+	return booking, nil
 }
 
-func (s *service) CancelBooking(ctx context.Context, bookingID uuid.UUID, token string) error {
+// MoveBooking moves a confirmed booking to another class for the admin, without the
+// limits of the booking policy.
+func (s *service) MoveBooking(ctx context.Context, bookingID, classID uuid.UUID) (models.Booking, error) {
 	var (
-		booking   models.Booking
-		passSlots []models.PassSlot
+		booking         models.Booking
+		previousClassID uuid.UUID
 	)
 
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		current, err := repos.Bookings.GetByID(ctx, bookingID)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return api.ErrNotFound(fmt.Errorf("booking %s not found", bookingID))
+			}
+
+			return fmt.Errorf("could not get booking %s: %w", bookingID, err)
+		}
+
+		if current.Status != models.BookingConfirmed {
+			return api.ErrValidation(
+				fmt.Errorf("booking %s is %s, only confirmed bookings can be moved", bookingID, current.Status),
+			)
+		}
+
+		class, err := repos.Classes.Get(ctx, classID)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return api.ErrNotFound(fmt.Errorf("class %s not found", classID))
+			}
+
+			return fmt.Errorf("could not get class %s: %w", classID, err)
+		}
+
+		previousClassID = current.ClassID
+
+		booking, err = s.moveBooking(ctx, repos, current, class)
+		if err != nil {
+			return fmt.Errorf("could not move booking %s to class %s: %w", bookingID, classID, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.Booking{}, fmt.Errorf("move booking transaction failed: %w", err)
+	}
+
+	s.promoteWaitlist(ctx, previousClassID)
+
+	return booking, nil
+}
+
+// ensurePassUsable checks that the pass of the booking still covers the day of class,
+// the student would otherwise keep a slot of a pass that is not valid then.
+func ensurePassUsable(
+	ctx context.Context,
+	repos repositories.Repositories,
+	booking models.Booking,
+	class models.Class,
+) error {
+	if !booking.PassID.Exists() {
+		return nil
+	}
+
+	// the freezes of the pass are not loaded with the booking
+	pass, err := repos.Passes.Get(ctx, booking.PassID.Get())
+	if err != nil {
+		return fmt.Errorf("could not get pass %d: %w", booking.PassID.Get(), err)
+	}
+
+	if !pass.IsUsableAt(class.StartTime) {
+		return viewErrors.ErrPassNotUsable(
+			class.ID, fmt.Errorf("pass %d is not usable at %v", pass.ID, class.StartTime),
+		)
+	}
+
+	return nil
+}
+
+// moveBooking points the booking to class once the class has a free spot. The pass and
+// the confirmation token stay, so the pass slot and the cancellation link still work.
+func (s *service) moveBooking(
+	ctx context.Context,
+	repos repositories.Repositories,
+	booking models.Booking,
+	class models.Class,
+) (models.Booking, error) {
+	if class.StartTime.Before(time.Now()) {
+		return models.Booking{}, viewErrors.ErrClassExpired(
+			class.ID, fmt.Errorf("class %s has expired at %v", class.ID, class.StartTime),
+		)
+	}
+
+	// it also rejects moving the booking to the class it is already in
+	_, err := repos.Bookings.GetByEmailAndClassID(ctx, class.ID, booking.Email)
+	if err == nil {
+		return models.Booking{}, viewErrors.ErrBookingAlreadyExists(
+			class.ID,
+			booking.Email,
+			fmt.Errorf("booking already exists for email %s and classID %s", booking.Email, class.ID),
+		)
+	}
+
+	if !errors.Is(err, errs.ErrNotFound) {
+		return models.Booking{}, fmt.Errorf(
+			"could not get booking for email %s and classID %s: %w", booking.Email, class.ID, err,
+		)
+	}
+
+	heldSpots, err := countHeldSpots(ctx, repos, class.ID, booking.Email)
+	if err != nil {
+		return models.Booking{}, fmt.Errorf("could not count held spots: %w", err)
+	}
+
+	if heldSpots >= class.MaxCapacity {
+		return models.Booking{}, viewErrors.ErrClassFullyBooked(
+			class.ID, fmt.Errorf("no spots left in class %s", class.ID),
+		)
+	}
+
+	// the reminder has to be sent again before the new class
+	update := map[string]any{"class_id": class.ID, "reminded_at": nil}
+
+	err = repos.Bookings.Update(ctx, booking.ID, update)
+	if err != nil {
+		return models.Booking{}, fmt.Errorf("could not update booking %s with %v: %w", booking.ID, update, err)
+	}
+
+	err = repos.Waitlist.DeleteByClassIDAndEmail(ctx, class.ID, booking.Email)
+	if err != nil {
+		return models.Booking{}, fmt.Errorf("could not delete waitlist entry: %w", err)
+	}
+
+	previous := booking
+
+	booking.ClassID = class.ID
+	booking.Class = class
+	booking.RemindedAt = nil
+
+	var passSlots []models.PassSlot
+
+	if booking.Pass.Exists() {
+		pass := booking.Pass.Get()
+
+		bookings, err := repos.Bookings.ListByPassID(ctx, pass.ID)
+		if err != nil {
+			return models.Booking{}, fmt.Errorf("could not list bookings by passID %d: %w", pass.ID, err)
+		}
+
+		passSlots = s.passManager.BuildPassSlots(bookings, pass.TotalSlots)
+	}
+
+	// the cancellation of the previous class must outrank the invitation the student got
+	previousParams := bookingNotifierParams(previous, nil)
+	previousParams.ClassSequence++
+
+	locale, _ := i18n.FromContext(ctx)
+
+	err = repos.Outbox.Enqueue(ctx, models.Notification{
+		Kind:     models.NotificationBookingMoved,
+		Params:   bookingNotifierParams(booking, passSlots),
+		Previous: previousParams,
+		Link:     s.cancellationLink(booking),
+		Locale:   locale,
+	})
+	if err != nil {
+		return models.Booking{}, fmt.Errorf("could not enqueue booking moved: %w", err)
+	}
+
+	return booking, nil
+}
+
+func (s *service) CancelBooking(ctx context.Context, bookingID uuid.UUID, token string) error {
+	var booking models.Booking
+
 	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
 		var err error
 
@@ -223,26 +734,26 @@ func (s *service) CancelBooking(ctx context.Context, bookingID uuid.UUID, token
 			return fmt.Errorf("booking cancellation not allowed for bookingID %s: %w", bookingID, err)
 		}
 
-		err = repos.Bookings.Delete(ctx, bookingID)
+		// a late cancellation frees the spot in class, but still consumes the pass slot
+		status := models.BookingCancelled
+		if s.bookingPolicy.IsLateCancellation(booking.Class, time.Now()) {
+			status = models.BookingLateCancelled
+		}
+
+		err = repos.Bookings.UpdateStatus(ctx, bookingID, status)
 		if err != nil {
 			if errors.Is(err, errs.ErrNoRowsAffected) {
 				return viewErrors.ErrBookingNotFound(
-					fmt.Errorf("delete booking failure, booking with email %s for class %s not found", booking.Email, booking.ClassID),
+					fmt.Errorf("cancel booking failure, booking with email %s for class %s not found", booking.Email, booking.ClassID),
 				)
 			}
 
-			return fmt.Errorf("could not delete booking: %w", err)
+			return fmt.Errorf("could not cancel booking: %w", err)
 		}
 
-		if booking.Pass.Exists() {
-			pass := booking.Pass.Get()
-
-			usedBookings, err := repos.Bookings.ListByPassID(ctx, pass.ID)
-			if err != nil {
-				return fmt.Errorf("could not list bookings by pass id %d: %w", pass.ID, err)
-			}
-
-			passSlots = s.passManager.BuildPassSlots(usedBookings, pass.TotalSlots)
+		err = s.enqueueCancellation(ctx, repos, booking)
+		if err != nil {
+			return fmt.Errorf("could not enqueue booking cancellation: %w", err)
 		}
 
 		return nil
@@ -251,20 +762,51 @@ func (s *service) CancelBooking(ctx context.Context, bookingID uuid.UUID, token
 		return fmt.Errorf("cancel booking transaction failed: %w", err)
 	}
 
+	s.promoteWaitlist(ctx, booking.ClassID)
+
+	return nil
+}
+
+func (s *service) enqueueCancellation(
+	ctx context.Context,
+	repos repositories.Repositories,
+	booking models.Booking,
+) error {
+	// the cancellation must outrank the invitation the student got for this class
 	notifierParams := models.NotifierParams{
 		RecipientFirstName: booking.FirstName,
 		RecipientLastName:  booking.LastName,
 		RecipientEmail:     booking.Email,
+		ClassID:            booking.Class.ID,
+		ClassSequence:      booking.Class.Sequence + 1,
 		ClassName:          booking.Class.ClassName,
 		ClassLevel:         booking.Class.ClassLevel,
 		StartTime:          booking.Class.StartTime,
 		Location:           booking.Class.Location,
-		PassSlots:          passSlots,
+		Instructor:         booking.Class.Instructor,
+		Duration:           booking.Class.Duration,
 	}
 
-	err = s.notifier.NotifyBookingCancellation(notifierParams)
+	if booking.Pass.Exists() {
+		pass := booking.Pass.Get()
+
+		usedBookings, err := repos.Bookings.ListByPassID(ctx, pass.ID)
+		if err != nil {
+			return fmt.Errorf("could not list bookings by pass id %d: %w", pass.ID, err)
+		}
+
+		notifierParams.PassSlots = s.passManager.BuildPassSlots(usedBookings, pass.TotalSlots)
+	}
+
+	locale, _ := i18n.FromContext(ctx)
+
+	err := repos.Outbox.Enqueue(ctx, models.Notification{
+		Kind:   models.NotificationBookingCancellation,
+		Params: notifierParams,
+		Locale: locale,
+	})
 	if err != nil {
-		return fmt.Errorf("could not notify booking cancellation with %+v: %w", notifierParams, err)
+		return fmt.Errorf("could not enqueue booking cancellation with %+v: %w", notifierParams, err)
 	}
 
 	return nil
@@ -302,30 +844,30 @@ func (s *service) ensureBookingCancellationAllowed(
 
 func (s *service) GetBookingForCancellation(
 	ctx context.Context, bookingID uuid.UUID, token string,
-) (models.Booking, error) {
+) (models.BookingCancellation, error) {
 	booking, err := s.bookingsRepo.GetByID(ctx, bookingID)
 	if err != nil {
 		if errors.Is(err, errs.ErrNotFound) {
-			return models.Booking{}, viewErrors.ErrBookingNotFound(
+			return models.BookingCancellation{}, viewErrors.ErrBookingNotFound(
 				fmt.Errorf("booking with id %s not found: %w", bookingID, err),
 			)
 		}
 
-		return models.Booking{}, fmt.Errorf("could not get booking for id %s: %w", bookingID, err)
+		return models.BookingCancellation{}, fmt.Errorf("could not get booking for id %s: %w", bookingID, err)
 	}
 
 	if booking.ConfirmationToken != token {
-		return models.Booking{}, viewErrors.ErrInvalidCancellationLink(err)
+		return models.BookingCancellation{}, viewErrors.ErrInvalidCancellationLink(err)
 	}
 
-	return booking, nil
+	return models.BookingCancellation{
+		Booking: booking,
+		Late:    s.bookingPolicy.IsLateCancellation(booking.Class, time.Now()),
+	}, nil
 }
 
 func (s *service) DeleteBooking(ctx context.Context, bookingID uuid.UUID) error {
-	var (
-		booking   models.Booking
-		passSlots []models.PassSlot
-	)
+	var booking models.Booking
 
 	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
 		var err error
@@ -335,19 +877,14 @@ func (s *service) DeleteBooking(ctx context.Context, bookingID uuid.UUID) error
 			return fmt.Errorf("could get booking for id %s: %w", bookingID, err)
 		}
 
-		err = repos.Bookings.Delete(ctx, bookingID)
+		err = repos.Bookings.UpdateStatus(ctx, bookingID, models.BookingCancelled)
 		if err != nil {
-			return fmt.Errorf("could not delete booking for id %s: %w", bookingID, err)
+			return fmt.Errorf("could not cancel booking for id %s: %w", bookingID, err)
 		}
 
-		if booking.Pass.Exists() {
-			pass := booking.Pass.Get()
-			usedBookings, err := repos.Bookings.ListByPassID(ctx, pass.ID)
-			if err != nil {
-				return fmt.Errorf("could not list bookings by pass id %d: %w", pass.ID, err)
-			}
-
-			passSlots = s.passManager.BuildPassSlots(usedBookings, pass.TotalSlots)
+		err = s.enqueueCancellation(ctx, repos, booking)
+		if err != nil {
+			return fmt.Errorf("could not enqueue booking cancellation: %w", err)
 		}
 
 		return nil
@@ -356,21 +893,16 @@ func (s *service) DeleteBooking(ctx context.Context, bookingID uuid.UUID) error
 		return fmt.Errorf("delete booking transaction failed: %w", err)
 	}
 
-	notifierParams := models.NotifierParams{
-		RecipientFirstName: booking.FirstName,
-		RecipientLastName:  booking.LastName,
-		RecipientEmail:     booking.Email,
-		ClassName:          booking.Class.ClassName,
-		ClassLevel:         booking.Class.ClassLevel,
-		StartTime:          booking.Class.StartTime,
-		Location:           booking.Class.Location,
-		PassSlots:          passSlots,
-	}
+	s.promoteWaitlist(ctx, booking.ClassID)
 
-	err = s.notifier.NotifyBookingCancellation(notifierParams)
+	return nil
+}
+
+// promoteWaitlist offers the freed spot once the booking change is committed. The change
+// stands even if this fails, the next expired offer or freed spot promotes the waitlist again.
+func (s *service) promoteWaitlist(ctx context.Context, classID uuid.UUID) {
+	err := s.waitlistService.PromoteFromWaitlist(ctx, classID)
 	if err != nil {
-		return fmt.Errorf("could not nofify booking cancellation with %+v: %w", notifierParams, err)
+		slog.Error("Bookings: could not promote waitlist", "class_id", classID, "err", err.Error())
 	}
-
-	return nil
 }
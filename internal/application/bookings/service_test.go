@@ -0,0 +1,503 @@
+package bookings
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"main/internal/application/waitlist"
+	viewErrors "main/internal/domain/errs/view"
+	"main/internal/domain/models"
+	"main/internal/domain/repositories"
+	"main/internal/domain/services"
+	"main/internal/infrastructure/generator/token"
+	"main/internal/infrastructure/repository/repositorytest"
+	"main/pkg/optional"
+
+	"github.com/google/uuid"
+)
+
+const studentEmail = "anna@example.com"
+
+// brokenWaitlist fails every promotion, the booking change has to stand anyway.
+type brokenWaitlist struct {
+	services.IWaitlistService
+}
+
+func (brokenWaitlist) PromoteFromWaitlist(context.Context, uuid.UUID) error {
+	return errors.New("waitlist is down")
+}
+
+func TestCreateBooking(t *testing.T) {
+	ctx := context.Background()
+	repos, s := newTestService(t, false)
+
+	class := repositorytest.InsertClass(t, repos, time.Now().Add(48*time.Hour), 2)
+	pendingBooking := models.PendingBooking{
+		ID:                uuid.New(),
+		ClassID:           class.ID,
+		Email:             studentEmail,
+		FirstName:         "Anna",
+		LastName:          "Kowalska",
+		ConfirmationToken: uuid.NewString(),
+		CreatedAt:         time.Now().UTC(),
+	}
+
+	if err := repos.PendingBookings.Insert(ctx, pendingBooking); err != nil {
+		t.Fatalf("could not insert pending booking: %v", err)
+	}
+
+	if _, err := s.CreateBooking(ctx, pendingBooking.ConfirmationToken); err != nil {
+		t.Fatalf("CreateBooking() error = %v", err)
+	}
+
+	// the link is used up, clicking it again books nothing
+	_, err := s.CreateBooking(ctx, pendingBooking.ConfirmationToken)
+	if !hasCode(err, code(viewErrors.PendingBookingNotFoundCode)) {
+		t.Errorf("second CreateBooking() error = %v, want pending booking not found", err)
+	}
+
+	count, err := repos.Bookings.CountForClassID(ctx, class.ID)
+	if err != nil || count != 1 {
+		t.Errorf("bookings = %d (%v), want 1", count, err)
+	}
+}
+
+func TestCreateConfirmedBooking(t *testing.T) {
+	tests := []struct {
+		name             string
+		maxCapacity      int
+		bookedBy         []string
+		offeredTo        string
+		withPass         bool
+		sendConfirmation bool
+		wantCode         *int
+		wantPass         bool
+		wantEmails       int
+	}{
+		{
+			name:             "booking with confirmation email",
+			maxCapacity:      2,
+			sendConfirmation: true,
+			wantEmails:       1,
+		},
+		{
+			name:        "booking without confirmation email",
+			maxCapacity: 2,
+		},
+		{
+			name:             "booking takes a pass slot",
+			maxCapacity:      2,
+			withPass:         true,
+			sendConfirmation: true,
+			wantPass:         true,
+			wantEmails:       1,
+		},
+		{
+			name:        "student takes the spot offered to them from the waitlist",
+			maxCapacity: 1,
+			offeredTo:   studentEmail,
+		},
+		{
+			name:        "spot offered to someone else is held",
+			maxCapacity: 2,
+			bookedBy:    []string{"bob@example.com"},
+			offeredTo:   "ewa@example.com",
+			wantCode:    code(viewErrors.SomeoneBookedClassFasterCode),
+		},
+		{
+			name:        "already booked",
+			maxCapacity: 2,
+			bookedBy:    []string{studentEmail},
+			wantCode:    code(viewErrors.BookingAlreadyExistsCode),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos, s := newTestService(t, false)
+			class := repositorytest.InsertClass(t, repos, time.Now().Add(48*time.Hour), tt.maxCapacity)
+
+			for _, email := range tt.bookedBy {
+				repositorytest.InsertBooking(t, repos, class.ID, email)
+			}
+
+			if tt.offeredTo != "" {
+				joinWaitlist(t, repos, class.ID, tt.offeredTo, true)
+			}
+
+			if tt.withPass {
+				_, err := repos.Passes.Insert(ctx, models.Pass{
+					Email:      studentEmail,
+					TotalSlots: 4,
+					ValidFrom:  time.Now().AddDate(0, -1, 0).UTC(),
+					CreatedAt:  time.Now().UTC(),
+				})
+				if err != nil {
+					t.Fatalf("could not insert pass: %v", err)
+				}
+			}
+
+			booking, err := s.CreateConfirmedBooking(ctx, models.ConfirmedBookingParams{
+				ClassID:          class.ID,
+				FirstName:        "Anna",
+				LastName:         "Kowalska",
+				Email:            studentEmail,
+				SendConfirmation: tt.sendConfirmation,
+			})
+			if !hasCode(err, tt.wantCode) {
+				t.Fatalf("CreateConfirmedBooking() error = %v, want code %v", err, tt.wantCode)
+			}
+
+			if tt.wantCode != nil {
+				return
+			}
+
+			stored, err := repos.Bookings.GetByID(ctx, booking.ID)
+			if err != nil {
+				t.Fatalf("could not get booking: %v", err)
+			}
+
+			if stored.Status != models.BookingConfirmed {
+				t.Errorf("booking is %s, want %s", stored.Status, models.BookingConfirmed)
+			}
+
+			if stored.PassID.Exists() != tt.wantPass {
+				t.Errorf("booking has pass %v, want %v", stored.PassID.Exists(), tt.wantPass)
+			}
+
+			_, err = repos.Waitlist.GetByClassIDAndEmail(ctx, class.ID, studentEmail)
+			if err == nil {
+				t.Error("student is still on the waitlist")
+			}
+
+			messages, err := repos.Outbox.ListByStatus(ctx, models.OutboxStatusPending)
+			if err != nil {
+				t.Fatalf("could not list outbox messages: %v", err)
+			}
+
+			if len(messages) != tt.wantEmails {
+				t.Fatalf("notifications = %d, want %d", len(messages), tt.wantEmails)
+			}
+
+			for _, message := range messages {
+				if message.Notification.Kind != models.NotificationBookingConfirmation {
+					t.Errorf("notification kind = %v, want %v", message.Notification.Kind,
+						models.NotificationBookingConfirmation)
+				}
+			}
+		})
+	}
+}
+
+func TestRescheduleBooking(t *testing.T) {
+	tests := []struct {
+		name             string
+		targetCapacity   int
+		targetBookedBy   []string
+		offeredTo        string
+		missingTarget    bool
+		passValidFor     time.Duration
+		brokenWaitlist   bool
+		wantCode         *int
+		wantOfferedSpots int
+	}{
+		{
+			name:             "booking moves and the freed spot is offered from the waitlist",
+			targetCapacity:   2,
+			wantOfferedSpots: 1,
+		},
+		{
+			name:           "new class does not exist",
+			targetCapacity: 2,
+			missingTarget:  true,
+			wantCode:       code(viewErrors.ClassNotFoundCode),
+		},
+		{
+			name:           "failed waitlist promotion does not fail the reschedule",
+			targetCapacity: 2,
+			brokenWaitlist: true,
+		},
+		{
+			name:             "pass valid on the day of the new class",
+			targetCapacity:   2,
+			passValidFor:     30 * 24 * time.Hour,
+			wantOfferedSpots: 1,
+		},
+		{
+			name:           "pass expires before the new class",
+			targetCapacity: 2,
+			passValidFor:   72 * time.Hour,
+			wantCode:       code(viewErrors.PassNotUsableCode),
+		},
+		{
+			name:           "spot offered to someone else is held",
+			targetCapacity: 2,
+			targetBookedBy: []string{"bob@example.com"},
+			offeredTo:      "ewa@example.com",
+			wantCode:       code(viewErrors.ClassFullyBookedCode),
+		},
+		{
+			name:             "spot offered to the student is theirs",
+			targetCapacity:   1,
+			offeredTo:        studentEmail,
+			wantOfferedSpots: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos, s := newTestService(t, tt.brokenWaitlist)
+
+			current := repositorytest.InsertClass(t, repos, time.Now().Add(48*time.Hour), 1)
+			target := repositorytest.InsertClass(t, repos, time.Now().Add(7*24*time.Hour), tt.targetCapacity)
+
+			booking := insertBooking(t, repos, current.ID, tt.passValidFor)
+			joinWaitlist(t, repos, current.ID, "waiting@example.com", false)
+
+			for _, email := range tt.targetBookedBy {
+				repositorytest.InsertBooking(t, repos, target.ID, email)
+			}
+
+			if tt.offeredTo != "" {
+				joinWaitlist(t, repos, target.ID, tt.offeredTo, true)
+			}
+
+			targetID := target.ID
+			if tt.missingTarget {
+				targetID = uuid.New()
+			}
+
+			_, err := s.RescheduleBooking(ctx, booking.ID, booking.ConfirmationToken, targetID)
+			if !hasCode(err, tt.wantCode) {
+				t.Fatalf("RescheduleBooking() error = %v, want code %v", err, tt.wantCode)
+			}
+
+			wantClassID := target.ID
+			if tt.wantCode != nil {
+				wantClassID = current.ID
+			}
+
+			assertBookingClass(t, repos, booking.ID, wantClassID)
+			assertOfferedSpots(t, repos, current.ID, tt.wantOfferedSpots)
+		})
+	}
+}
+
+func TestMoveBooking(t *testing.T) {
+	tests := []struct {
+		name             string
+		passValidFor     time.Duration
+		brokenWaitlist   bool
+		wantOfferedSpots int
+	}{
+		{
+			name:             "booking moves and the freed spot is offered from the waitlist",
+			wantOfferedSpots: 1,
+		},
+		{
+			name:           "failed waitlist promotion does not fail the move",
+			brokenWaitlist: true,
+		},
+		{
+			name:             "admin moves the booking past the end of the pass",
+			passValidFor:     72 * time.Hour,
+			wantOfferedSpots: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos, s := newTestService(t, tt.brokenWaitlist)
+
+			current := repositorytest.InsertClass(t, repos, time.Now().Add(48*time.Hour), 1)
+			target := repositorytest.InsertClass(t, repos, time.Now().Add(7*24*time.Hour), 2)
+
+			booking := insertBooking(t, repos, current.ID, tt.passValidFor)
+			joinWaitlist(t, repos, current.ID, "waiting@example.com", false)
+
+			if _, err := s.MoveBooking(ctx, booking.ID, target.ID); err != nil {
+				t.Fatalf("MoveBooking() error = %v", err)
+			}
+
+			assertBookingClass(t, repos, booking.ID, target.ID)
+			assertOfferedSpots(t, repos, current.ID, tt.wantOfferedSpots)
+		})
+	}
+}
+
+func TestDeleteBooking(t *testing.T) {
+	ctx := context.Background()
+	repos, s := newTestService(t, false)
+
+	class := repositorytest.InsertClass(t, repos, time.Now().Add(48*time.Hour), 1)
+
+	class, err := repos.Classes.Update(ctx, class.ID, map[string]any{"sequence": 2})
+	if err != nil {
+		t.Fatalf("could not update class: %v", err)
+	}
+
+	booking := insertBooking(t, repos, class.ID, 0)
+
+	if err = s.DeleteBooking(ctx, booking.ID); err != nil {
+		t.Fatalf("DeleteBooking() error = %v", err)
+	}
+
+	messages, err := repos.Outbox.ListByStatus(ctx, models.OutboxStatusPending)
+	if err != nil {
+		t.Fatalf("could not list outbox messages: %v", err)
+	}
+
+	if len(messages) != 1 || messages[0].Notification.Kind != models.NotificationBookingCancellation {
+		t.Fatalf("notifications = %+v, want one booking cancellation", messages)
+	}
+
+	// the calendar cancellation has to outrank the invitation of the class
+	params := messages[0].Notification.Params
+	if params.ClassID != class.ID || params.ClassSequence != class.Sequence+1 {
+		t.Errorf("class = %s sequence %d, want %s sequence %d",
+			params.ClassID, params.ClassSequence, class.ID, class.Sequence+1)
+	}
+}
+
+func newTestService(t *testing.T, withBrokenWaitlist bool) (repositories.Repositories, *service) {
+	t.Helper()
+
+	repos, unitOfWork := repositorytest.OpenSQLite(t)
+	tokenGenerator := token.NewGenerator()
+
+	var waitlistService services.IWaitlistService = waitlist.NewService(unitOfWork, tokenGenerator, "")
+	if withBrokenWaitlist {
+		waitlistService = brokenWaitlist{}
+	}
+
+	s := NewService(
+		unitOfWork,
+		repos.Bookings,
+		tokenGenerator,
+		&services.PassManager{},
+		services.NewBookingPolicy(200, 3, models.BookingRules{}, nil),
+		waitlistService,
+		"",
+	)
+
+	return repos, s
+}
+
+// insertBooking books the student into the class, on a pass valid for passValidFor
+// when it is not zero.
+func insertBooking(
+	t *testing.T, repos repositories.Repositories, classID uuid.UUID, passValidFor time.Duration,
+) models.Booking {
+	t.Helper()
+
+	ctx := context.Background()
+	booking := models.Booking{
+		ID:                uuid.New(),
+		ClassID:           classID,
+		FirstName:         "Anna",
+		LastName:          "Kowalska",
+		Email:             studentEmail,
+		Status:            models.BookingConfirmed,
+		CreatedAt:         time.Now().UTC(),
+		ConfirmationToken: uuid.NewString(),
+	}
+
+	if passValidFor > 0 {
+		validUntil := time.Now().Add(passValidFor).UTC()
+
+		pass, err := repos.Passes.Insert(ctx, models.Pass{
+			Email:      studentEmail,
+			TotalSlots: 4,
+			ValidFrom:  time.Now().AddDate(0, -1, 0).UTC(),
+			ValidUntil: &validUntil,
+			CreatedAt:  time.Now().UTC(),
+		})
+		if err != nil {
+			t.Fatalf("could not insert pass: %v", err)
+		}
+
+		booking.PassID = optional.Of(pass.ID)
+		booking.Pass = optional.Of(pass)
+	}
+
+	if _, err := repos.Bookings.Insert(ctx, booking); err != nil {
+		t.Fatalf("could not insert booking: %v", err)
+	}
+
+	return booking
+}
+
+func joinWaitlist(t *testing.T, repos repositories.Repositories, classID uuid.UUID, email string, offered bool) {
+	t.Helper()
+
+	now := time.Now().Add(-time.Minute).UTC()
+	entry := models.WaitlistEntry{
+		ID:        uuid.New(),
+		ClassID:   classID,
+		Email:     email,
+		FirstName: "Ewa",
+		LastName:  "Nowak",
+		CreatedAt: now,
+	}
+
+	if offered {
+		entry.OfferedAt = &now
+	}
+
+	if err := repos.Waitlist.Insert(context.Background(), entry); err != nil {
+		t.Fatalf("could not insert waitlist entry: %v", err)
+	}
+}
+
+func assertBookingClass(t *testing.T, repos repositories.Repositories, bookingID, wantClassID uuid.UUID) {
+	t.Helper()
+
+	booking, err := repos.Bookings.GetByID(context.Background(), bookingID)
+	if err != nil {
+		t.Fatalf("could not get booking: %v", err)
+	}
+
+	if booking.ClassID != wantClassID {
+		t.Errorf("booking is in class %s, want %s", booking.ClassID, wantClassID)
+	}
+}
+
+func assertOfferedSpots(t *testing.T, repos repositories.Repositories, classID uuid.UUID, want int) {
+	t.Helper()
+
+	entries, err := repos.Waitlist.ListByClassID(context.Background(), classID)
+	if err != nil {
+		t.Fatalf("could not list waitlist entries: %v", err)
+	}
+
+	var offered int
+
+	for _, entry := range entries {
+		if entry.OfferedAt != nil {
+			offered++
+		}
+	}
+
+	if offered != want {
+		t.Errorf("offered spots = %d, want %d", offered, want)
+	}
+}
+
+func code(c int) *int {
+	return &c
+}
+
+func hasCode(err error, want *int) bool {
+	if want == nil {
+		return err == nil
+	}
+
+	var businessError *viewErrors.BusinessError
+
+	return errors.As(err, &businessError) && businessError.Code == *want
+}
@@ -0,0 +1,154 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	viewErrors "main/internal/domain/errs/view"
+	"main/internal/domain/models"
+	"main/internal/domain/repositories"
+	"main/internal/domain/services"
+	"main/internal/infrastructure/errs"
+	"main/pkg/i18n"
+	"main/pkg/ical"
+)
+
+const tokenLength = 32
+
+type service struct {
+	classesService    services.IClassesService
+	bookingsRepo      repositories.IBookings
+	calendarFeedsRepo repositories.ICalendarFeeds
+	tokenGenerator    services.ITokenGenerator
+	domainAddr        string
+}
+
+func NewService(
+	classesService services.IClassesService,
+	bookingsRepo repositories.IBookings,
+	calendarFeedsRepo repositories.ICalendarFeeds,
+	tokenGenerator services.ITokenGenerator,
+	domainAddr string,
+) *service {
+	return &service{
+		classesService:    classesService,
+		bookingsRepo:      bookingsRepo,
+		calendarFeedsRepo: calendarFeedsRepo,
+		tokenGenerator:    tokenGenerator,
+		domainAddr:        domainAddr,
+	}
+}
+
+// GetPublicFeed lists every upcoming class with the number of free spots, named in the
+// language of the request.
+func (s *service) GetPublicFeed(ctx context.Context) (ical.Calendar, error) {
+	locale, _ := i18n.FromContext(ctx)
+
+	classes, err := s.classesService.ListClasses(ctx, true, models.ClassFilter{}, nil)
+	if err != nil {
+		return ical.Calendar{}, fmt.Errorf("could not list classes: %w", err)
+	}
+
+	events := make([]ical.Event, 0, len(classes))
+
+	for _, class := range classes {
+		event := services.ClassEvent(models.Class{
+			ID:          class.ID,
+			StartTime:   class.StartTime,
+			ClassLevel:  class.ClassLevel,
+			ClassName:   class.ClassName,
+			MaxCapacity: class.MaxCapacity,
+			Location:    class.Location,
+			Duration:    class.Duration,
+			Sequence:    class.Sequence,
+		}, ical.StatusConfirmed)
+		event.Description = i18n.T(locale, "calendar.free_spots", class.CurrentCapacity, class.MaxCapacity)
+
+		events = append(events, event)
+	}
+
+	return ical.Calendar{
+		ProdID: services.CalendarProdID,
+		Name:   i18n.T(locale, "calendar.public_name"),
+		Method: ical.MethodPublish,
+		Events: events,
+	}, nil
+}
+
+// GetStudentFeed lists the classes booked by the owner of the feed token.
+func (s *service) GetStudentFeed(ctx context.Context, token string) (ical.Calendar, error) {
+	locale, _ := i18n.FromContext(ctx)
+
+	feed, err := s.calendarFeedsRepo.GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return ical.Calendar{}, viewErrors.ErrCalendarFeedNotFound(
+				fmt.Errorf("calendar feed for token %s not found", token),
+			)
+		}
+
+		return ical.Calendar{}, fmt.Errorf("could not get calendar feed: %w", err)
+	}
+
+	bookings, err := s.bookingsRepo.ListByEmail(ctx, feed.Email)
+	if err != nil {
+		return ical.Calendar{}, fmt.Errorf("could not list bookings for %s: %w", feed.Email, err)
+	}
+
+	events := make([]ical.Event, 0, len(bookings))
+
+	for _, booking := range bookings {
+		events = append(events, services.ClassEvent(booking.Class, ical.StatusConfirmed))
+	}
+
+	return ical.Calendar{
+		ProdID: services.CalendarProdID,
+		Name:   i18n.T(locale, "calendar.student_name"),
+		Method: ical.MethodPublish,
+		Events: events,
+	}, nil
+}
+
+// GetStudentFeedLink returns the personal feed URL, creating its token on first use.
+func (s *service) GetStudentFeedLink(ctx context.Context, email string) (string, error) {
+	feed, err := s.calendarFeedsRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if !errors.Is(err, errs.ErrNotFound) {
+			return "", fmt.Errorf("could not get calendar feed for %s: %w", email, err)
+		}
+
+		feed, err = s.createFeed(ctx, email)
+		if err != nil {
+			return "", fmt.Errorf("could not create calendar feed for %s: %w", email, err)
+		}
+	}
+
+	return fmt.Sprintf("%s/my_bookings/calendar.ics?token=%s", s.domainAddr, url.QueryEscape(feed.Token)), nil
+}
+
+func (s *service) createFeed(ctx context.Context, email string) (models.CalendarFeed, error) {
+	token, err := s.tokenGenerator.Generate(tokenLength)
+	if err != nil {
+		return models.CalendarFeed{}, fmt.Errorf("could not generate calendar token: %w", err)
+	}
+
+	err = s.calendarFeedsRepo.Insert(ctx, models.CalendarFeed{
+		Email:     email,
+		Token:     token,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return models.CalendarFeed{}, fmt.Errorf("could not insert calendar feed: %w", err)
+	}
+
+	// a concurrent request may have created the feed first, its token wins
+	feed, err := s.calendarFeedsRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return models.CalendarFeed{}, fmt.Errorf("could not get calendar feed: %w", err)
+	}
+
+	return feed, nil
+}
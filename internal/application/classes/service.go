@@ -4,45 +4,61 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	"main/internal/domain/errs/api"
 	"main/internal/domain/models"
-	"main/internal/domain/notifier"
 	"main/internal/domain/repositories"
 	"main/internal/domain/services"
 	repositoryError "main/internal/infrastructure/errs"
+	"main/pkg/converter"
 
 	"github.com/google/uuid"
 )
 
 type service struct {
-	classesRepo  repositories.IClasses
-	bookingsRepo repositories.IBookings
-	unitOfWork   repositories.IUnitOfWork
-	passManager  services.IPassManager
-	notifier     notifier.INotifier
+	classesRepo     repositories.IClasses
+	bookingsRepo    repositories.IBookings
+	locationsRepo   repositories.ILocations
+	instructorsRepo repositories.IInstructors
+	classTypesRepo  repositories.IClassTypes
+	waitlistRepo    repositories.IWaitlist
+	unitOfWork      repositories.IUnitOfWork
+	passManager     services.IPassManager
+	waitlistService services.IWaitlistService
 }
 
 func NewService(
 	classesRepo repositories.IClasses,
 	bookingsRepo repositories.IBookings,
+	locationsRepo repositories.ILocations,
+	instructorsRepo repositories.IInstructors,
+	classTypesRepo repositories.IClassTypes,
+	waitlistRepo repositories.IWaitlist,
 	unitOfWork repositories.IUnitOfWork,
 	passManager services.IPassManager,
-	notifier notifier.INotifier,
+	waitlistService services.IWaitlistService,
 ) *service {
 	return &service{
-		classesRepo:  classesRepo,
-		bookingsRepo: bookingsRepo,
-		unitOfWork:   unitOfWork,
-		passManager:  passManager,
-		notifier:     notifier,
+		classesRepo:     classesRepo,
+		bookingsRepo:    bookingsRepo,
+		locationsRepo:   locationsRepo,
+		instructorsRepo: instructorsRepo,
+		classTypesRepo:  classTypesRepo,
+		waitlistRepo:    waitlistRepo,
+		unitOfWork:      unitOfWork,
+		passManager:     passManager,
+		waitlistService: waitlistService,
 	}
 }
 
 func (s *service) ListClasses(
 	ctx context.Context,
 	onlyUpcomingClasses bool,
+	filter models.ClassFilter,
 	classesLimit *int,
 ) ([]models.ClassWithCurrentCapacity, error) {
 	if classesLimit != nil && *classesLimit < 0 {
@@ -59,20 +75,16 @@ func (s *service) ListClasses(
 	result := make([]models.ClassWithCurrentCapacity, 0, len(classes))
 
 	for _, class := range classes {
-		bookingCount, err := s.bookingsRepo.CountForClassID(ctx, class.ID)
+		if !filter.Matches(class) {
+			continue
+		}
+
+		classWithCapacity, err := s.withCurrentCapacity(ctx, class)
 		if err != nil {
-			return nil, fmt.Errorf("could not get bookings for class %v: %w", class.ID, err)
+			return nil, err
 		}
 
-		result = append(result, models.ClassWithCurrentCapacity{
-			ID:              class.ID,
-			StartTime:       class.StartTime,
-			ClassLevel:      class.ClassLevel,
-			ClassName:       class.ClassName,
-			CurrentCapacity: class.MaxCapacity - bookingCount,
-			MaxCapacity:     class.MaxCapacity,
-			Location:        class.Location,
-		})
+		result = append(result, classWithCapacity)
 	}
 
 	if onlyUpcomingClasses {
@@ -96,6 +108,187 @@ func (s *service) ListClasses(
 	return result, nil
 }
 
+// ListSchedule lists the classes starting between from and to which match the filter,
+// along with what every class of that time can be filtered by.
+func (s *service) ListSchedule(
+	ctx context.Context, from, to time.Time, filter models.ClassFilter,
+) (models.Schedule, error) {
+	if !from.Before(to) {
+		return models.Schedule{}, api.ErrValidation(
+			fmt.Errorf("schedule from %v must be before to %v", from, to),
+		)
+	}
+
+	classes, err := s.classesRepo.ListBetween(ctx, from, to)
+	if err != nil {
+		return models.Schedule{}, fmt.Errorf("could not list classes between %v and %v: %w", from, to, err)
+	}
+
+	schedule := models.Schedule{
+		From:    from,
+		To:      to,
+		Classes: make([]models.ClassWithCurrentCapacity, 0, len(classes)),
+	}
+
+	for _, class := range classes {
+		if !slices.Contains(schedule.ClassLevels, class.ClassLevel) {
+			schedule.ClassLevels = append(schedule.ClassLevels, class.ClassLevel)
+		}
+
+		if !slices.Contains(schedule.ClassNames, class.ClassName) {
+			schedule.ClassNames = append(schedule.ClassNames, class.ClassName)
+		}
+
+		if !slices.ContainsFunc(schedule.Locations, func(location models.Location) bool {
+			return location.ID == class.LocationID
+		}) {
+			schedule.Locations = append(schedule.Locations, class.Location)
+		}
+
+		if !filter.Matches(class) {
+			continue
+		}
+
+		classWithCapacity, err := s.withCurrentCapacity(ctx, class)
+		if err != nil {
+			return models.Schedule{}, err
+		}
+
+		schedule.Classes = append(schedule.Classes, classWithCapacity)
+	}
+
+	slices.Sort(schedule.ClassLevels)
+	slices.Sort(schedule.ClassNames)
+	slices.SortFunc(schedule.Locations, func(a, b models.Location) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return schedule, nil
+}
+
+const (
+	publicScheduleDays    = 14
+	maxPublicScheduleDays = 62
+)
+
+// ListPublicSchedule lists the upcoming classes of the days of params.
+func (s *service) ListPublicSchedule(
+	ctx context.Context, params models.PublicScheduleParams,
+) (models.PublicSchedule, error) {
+	studio, err := converter.LoadLocation("")
+	if err != nil {
+		return models.PublicSchedule{}, fmt.Errorf("could not load studio location: %w", err)
+	}
+
+	now := time.Now().In(studio)
+
+	firstDay, lastDay, err := publicDayRange(params, now, studio)
+	if err != nil {
+		return models.PublicSchedule{}, api.ErrValidation(err)
+	}
+
+	schedule := models.PublicSchedule{
+		FirstDay: firstDay,
+		LastDay:  lastDay,
+		Classes:  []models.ClassWithCurrentCapacity{},
+	}
+
+	// the classes of today which have already started are not listed
+	from := firstDay
+	if from.Before(now) {
+		from = now
+	}
+
+	to := lastDay.AddDate(0, 0, 1)
+
+	if !from.Before(to) {
+		return schedule, nil
+	}
+
+	listed, err := s.ListSchedule(ctx, from, to, params.Filter)
+	if err != nil {
+		return models.PublicSchedule{}, err
+	}
+
+	schedule.Classes = listed.Classes
+
+	return schedule, nil
+}
+
+func publicDayRange(
+	params models.PublicScheduleParams, now time.Time, studio *time.Location,
+) (time.Time, time.Time, error) {
+	firstDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, studio)
+
+	if params.From != "" {
+		day, err := time.ParseInLocation(time.DateOnly, params.From, studio)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("could not parse from: %w", err)
+		}
+
+		firstDay = day
+	}
+
+	lastDay := firstDay.AddDate(0, 0, publicScheduleDays-1)
+
+	if params.To != "" {
+		day, err := time.ParseInLocation(time.DateOnly, params.To, studio)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("could not parse to: %w", err)
+		}
+
+		lastDay = day
+	}
+
+	if lastDay.Before(firstDay) {
+		return time.Time{}, time.Time{}, errors.New("to can not be before from")
+	}
+
+	if lastDay.After(firstDay.AddDate(0, 0, maxPublicScheduleDays-1)) {
+		return time.Time{}, time.Time{}, fmt.Errorf(
+			"the range can not be longer than %d days", maxPublicScheduleDays,
+		)
+	}
+
+	return firstDay, lastDay, nil
+}
+
+func (s *service) withCurrentCapacity(
+	ctx context.Context, class models.Class,
+) (models.ClassWithCurrentCapacity, error) {
+	bookingCount, err := s.bookingsRepo.CountForClassID(ctx, class.ID)
+	if err != nil {
+		return models.ClassWithCurrentCapacity{}, fmt.Errorf(
+			"could not get bookings for class %v: %w", class.ID, err,
+		)
+	}
+
+	// a spot offered from the waitlist is held until the offer expires
+	offeredSince := time.Now().Add(-models.WaitlistOfferTTL)
+
+	offeredCount, err := s.waitlistRepo.CountOfferedSince(ctx, class.ID, offeredSince)
+	if err != nil {
+		return models.ClassWithCurrentCapacity{}, fmt.Errorf(
+			"could not count waitlist offers for class %v: %w", class.ID, err,
+		)
+	}
+
+	return models.ClassWithCurrentCapacity{
+		ID:              class.ID,
+		StartTime:       class.StartTime,
+		ClassLevel:      class.ClassLevel,
+		ClassName:       class.ClassName,
+		CurrentCapacity: max(class.MaxCapacity-bookingCount-offeredCount, 0),
+		MaxCapacity:     class.MaxCapacity,
+		Location:        class.Location,
+		Instructor:      class.Instructor,
+		ClassTypeID:     class.ClassTypeID,
+		Duration:        class.Duration,
+		Sequence:        class.Sequence,
+		UpdatedAt:       class.UpdatedAt,
+	}, nil
+}
+
 func (s *service) CreateClasses(
 	ctx context.Context, newClasses []models.Class,
 ) ([]models.Class, error) {
@@ -109,6 +302,21 @@ func (s *service) CreateClasses(
 		return nil, api.ErrValidation(err)
 	}
 
+	newClasses, err = s.withClassTypes(ctx, newClasses)
+	if err != nil {
+		return nil, err
+	}
+
+	newClasses, err = s.withLocations(ctx, newClasses)
+	if err != nil {
+		return nil, err
+	}
+
+	newClasses, err = s.withInstructors(ctx, newClasses)
+	if err != nil {
+		return nil, err
+	}
+
 	insertedClasses, err := s.classesRepo.Insert(ctx, newClasses)
 	if err != nil {
 		return nil, fmt.Errorf("could not insert classes: %w", err)
@@ -118,8 +326,6 @@ func (s *service) CreateClasses(
 }
 
 func (s *service) DeleteClass(ctx context.Context, classID uuid.UUID, msg *string) error {
-	var notifierParamsList []models.NotifierParams
-
 	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
 		bookings, err := repos.Bookings.ListByClassID(ctx, classID)
 		if err != nil {
@@ -132,20 +338,39 @@ func (s *service) DeleteClass(ctx context.Context, classID uuid.UUID, msg *strin
 			)
 		}
 
+		// attendance is the history of a class that took place, it stays with the class
 		for _, booking := range bookings {
-			err := repos.Bookings.Delete(ctx, booking.ID)
-			if err != nil {
-				return fmt.Errorf("could not delete booking for id %v: %w", booking.ID, err)
+			if booking.Status.IsAttendanceStatus() {
+				return api.ErrClassHasAttendance(
+					fmt.Errorf("class %v has attendance recorded", classID),
+				)
+			}
+		}
+
+		// the class is gone, so none of its bookings consumes a pass slot anymore
+		err = repos.Bookings.DeleteByClassID(ctx, classID)
+		if err != nil {
+			return fmt.Errorf("could not delete bookings for class %v: %w", classID, err)
+		}
+
+		for _, booking := range bookings {
+			if booking.Class.ID == uuid.Nil {
+				return errors.New("class field should not be empty")
 			}
 
+			// the cancellation must outrank every invitation sent for this class
 			notifierParams := models.NotifierParams{
 				RecipientFirstName: booking.FirstName,
 				RecipientLastName:  booking.LastName,
 				RecipientEmail:     booking.Email,
+				ClassID:            booking.Class.ID,
+				ClassSequence:      booking.Class.Sequence + 1,
 				ClassName:          booking.Class.ClassName,
 				ClassLevel:         booking.Class.ClassLevel,
 				StartTime:          booking.Class.StartTime,
 				Location:           booking.Class.Location,
+				Instructor:         booking.Class.Instructor,
+				Duration:           booking.Class.Duration,
 			}
 
 			if booking.Pass.Exists() {
@@ -159,7 +384,19 @@ func (s *service) DeleteClass(ctx context.Context, classID uuid.UUID, msg *strin
 				notifierParams.PassSlots = s.passManager.BuildPassSlots(usedBookings, pass.TotalSlots)
 			}
 
-			notifierParamsList = append(notifierParamsList, notifierParams)
+			err = repos.Outbox.Enqueue(ctx, models.Notification{
+				Kind:    models.NotificationClassCancellation,
+				Params:  notifierParams,
+				Message: *msg,
+			})
+			if err != nil {
+				return fmt.Errorf("could not enqueue class cancellation with %+v: %w", notifierParams, err)
+			}
+		}
+
+		err = repos.Waitlist.DeleteByClassID(ctx, classID)
+		if err != nil {
+			return fmt.Errorf("could not delete waitlist for class: %w", err)
 		}
 
 		err = repos.Classes.Delete(ctx, classID)
@@ -173,13 +410,6 @@ func (s *service) DeleteClass(ctx context.Context, classID uuid.UUID, msg *strin
 		return fmt.Errorf("delete class transaction failed: %w", err)
 	}
 
-	for _, notifierParams := range notifierParamsList {
-		err = s.notifier.NotifyClassCancellation(notifierParams, *msg)
-		if err != nil {
-			return fmt.Errorf("could not notify class cancellation with %+v: %w", notifierParams, err)
-		}
-	}
-
 	return nil
 }
 
@@ -202,13 +432,11 @@ func (s *service) UpdateClass(
 		}
 	}
 
-	_, err = s.classesRepo.Get(ctx, classID)
-	if err != nil {
-		if errors.Is(err, repositoryError.ErrNotFound) {
-			return models.Class{}, api.ErrNotFound(err)
+	if update.LocationID != nil {
+		_, err := s.getLocation(ctx, *update.LocationID)
+		if err != nil {
+			return models.Class{}, err
 		}
-
-		return models.Class{}, fmt.Errorf("could not get class for class_id %v: %w", classID, err)
 	}
 
 	updateData, err := getDataForClassUpdate(update)
@@ -216,34 +444,69 @@ func (s *service) UpdateClass(
 		return models.Class{}, fmt.Errorf("could not get data for class update: %w", err)
 	}
 
-	updatedClass, err := s.classesRepo.Update(ctx, classID, updateData)
+	var class, updatedClass models.Class
+
+	err = s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		var err error
+
+		class, err = repos.Classes.Get(ctx, classID)
+		if err != nil {
+			if errors.Is(err, repositoryError.ErrNotFound) {
+				return api.ErrNotFound(err)
+			}
+
+			return fmt.Errorf("could not get class for class_id %v: %w", classID, err)
+		}
+
+		updateData["sequence"] = class.Sequence + 1
+
+		updatedClass, err = repos.Classes.Update(ctx, classID, updateData)
+		if err != nil {
+			return fmt.Errorf("could not update class: %w", err)
+		}
+
+		err = s.enqueueClassUpdateNotifications(ctx, repos, update, class, updatedClass)
+		if err != nil {
+			return fmt.Errorf("could not enqueue class update notifications: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return models.Class{}, fmt.Errorf("could not update class: %w", err)
+		return models.Class{}, fmt.Errorf("update class transaction failed: %w", err)
 	}
 
-	err = s.sendInformationAboutClassUpdateToUsers(ctx, update, updatedClass)
-	if err != nil {
-		return models.Class{}, fmt.Errorf("could not get class after update: %w", err)
+	// the update stands even if the promotion fails, the next freed spot or expired offer
+	// promotes the waitlist again
+	if update.MaxCapacity != nil && *update.MaxCapacity > class.MaxCapacity {
+		err = s.waitlistService.PromoteFromWaitlist(ctx, classID)
+		if err != nil {
+			slog.Error("Classes: could not promote waitlist", "class_id", classID, "err", err.Error())
+		}
 	}
 
 	return updatedClass, nil
 }
 
-func (s *service) sendInformationAboutClassUpdateToUsers(
-	ctx context.Context, update models.UpdateClass, updatedClass models.Class,
+func (s *service) enqueueClassUpdateNotifications(
+	ctx context.Context,
+	repos repositories.Repositories,
+	update models.UpdateClass,
+	class, updatedClass models.Class,
 ) error {
-	if update.Location == nil && update.StartTime == nil {
+	locationChanged := update.LocationID != nil && *update.LocationID != class.LocationID
+	if !locationChanged && update.StartTime == nil {
 		return nil
 	}
 
-	bookings, err := s.bookingsRepo.ListByClassID(ctx, updatedClass.ID)
+	bookings, err := repos.Bookings.ListByClassID(ctx, updatedClass.ID)
 	if err != nil {
 		return fmt.Errorf("could not get bookings for class %v: %w", updatedClass.ID, err)
 	}
 
-	msg, err := setMessageForNotification(update.StartTime, update.Location)
+	change, err := getClassChange(update.StartTime, locationChanged)
 	if err != nil {
-		return fmt.Errorf("could not set msg for notification: %w", err)
+		return fmt.Errorf("could not get class change for notification: %w", err)
 	}
 
 	for _, booking := range bookings {
@@ -251,21 +514,104 @@ func (s *service) sendInformationAboutClassUpdateToUsers(
 			RecipientEmail:     booking.Email,
 			RecipientFirstName: booking.FirstName,
 			RecipientLastName:  booking.LastName,
+			ClassID:            updatedClass.ID,
+			ClassSequence:      updatedClass.Sequence,
 			ClassName:          updatedClass.ClassName,
 			ClassLevel:         updatedClass.ClassLevel,
 			StartTime:          updatedClass.StartTime,
 			Location:           updatedClass.Location,
+			Instructor:         updatedClass.Instructor,
+			Duration:           updatedClass.Duration,
 		}
 
-		err = s.notifier.NotifyClassUpdate(notifierParams, msg)
+		err = repos.Outbox.Enqueue(ctx, models.Notification{
+			Kind:   models.NotificationClassUpdate,
+			Params: notifierParams,
+			Change: change,
+		})
 		if err != nil {
-			return fmt.Errorf("could not notify class update to with %+v: %w", notifierParams, err)
+			return fmt.Errorf("could not enqueue class update with %+v: %w", notifierParams, err)
 		}
 	}
 
 	return nil
 }
 
+// SubstituteInstructor hands the class over to another instructor and tells the booked
+// students who teaches them instead.
+func (s *service) SubstituteInstructor(
+	ctx context.Context, classID, instructorID uuid.UUID,
+) (models.Class, error) {
+	instructor, err := s.getInstructor(ctx, instructorID)
+	if err != nil {
+		return models.Class{}, err
+	}
+
+	var updatedClass models.Class
+
+	err = s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		class, err := repos.Classes.Get(ctx, classID)
+		if err != nil {
+			if errors.Is(err, repositoryError.ErrNotFound) {
+				return api.ErrNotFound(err)
+			}
+
+			return fmt.Errorf("could not get class for class_id %v: %w", classID, err)
+		}
+
+		if class.InstructorID.Exists() && class.InstructorID.Get() == instructor.ID {
+			updatedClass = class
+
+			return nil
+		}
+
+		// every change of the class bumps its sequence, as in UpdateClass
+		update := map[string]any{"instructor_id": instructor.ID, "sequence": class.Sequence + 1}
+
+		updatedClass, err = repos.Classes.Update(ctx, classID, update)
+		if err != nil {
+			return fmt.Errorf("could not update instructor of class %v: %w", classID, err)
+		}
+
+		bookings, err := repos.Bookings.ListByClassID(ctx, classID)
+		if err != nil {
+			return fmt.Errorf("could not get bookings for class %v: %w", classID, err)
+		}
+
+		for _, booking := range bookings {
+			notifierParams := models.NotifierParams{
+				RecipientEmail:     booking.Email,
+				RecipientFirstName: booking.FirstName,
+				RecipientLastName:  booking.LastName,
+				ClassID:            updatedClass.ID,
+				ClassSequence:      updatedClass.Sequence,
+				ClassName:          updatedClass.ClassName,
+				ClassLevel:         updatedClass.ClassLevel,
+				StartTime:          updatedClass.StartTime,
+				Location:           updatedClass.Location,
+				Instructor:         updatedClass.Instructor,
+				Duration:           updatedClass.Duration,
+			}
+
+			err = repos.Outbox.Enqueue(ctx, models.Notification{
+				Kind:     models.NotificationInstructorSubstitution,
+				Params:   notifierParams,
+				Previous: models.NotifierParams{Instructor: class.Instructor},
+			})
+			if err != nil {
+				return fmt.Errorf("could not enqueue instructor substitution with %+v: %w", notifierParams, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.Class{}, fmt.Errorf("substitute instructor transaction failed: %w", err)
+	}
+
+	return updatedClass, nil
+}
+
 func getDataForClassUpdate(update models.UpdateClass) (map[string]any, error) {
 	updateData := map[string]any{}
 	if update.StartTime != nil {
@@ -284,8 +630,8 @@ func getDataForClassUpdate(update models.UpdateClass) (map[string]any, error) {
 		updateData["max_capacity"] = *update.MaxCapacity
 	}
 
-	if update.Location != nil {
-		updateData["location"] = *update.Location
+	if update.LocationID != nil {
+		updateData["location_id"] = *update.LocationID
 	}
 
 	if len(updateData) == 0 {
@@ -295,24 +641,195 @@ func getDataForClassUpdate(update models.UpdateClass) (map[string]any, error) {
 	return updateData, nil
 }
 
-// TODO: move polish from here (keep all this messages somewhere in notifier?)
-func setMessageForNotification(
+func getClassChange(
 	startTime *time.Time,
-	location *string,
-) (string, error) {
-	if location != nil && startTime != nil {
-		return "Wyjątkowo musiałem zmienić lokalizację i czas rozpoczęcia zajęć.", nil
+	locationChanged bool,
+) (models.ClassChange, error) {
+	if locationChanged && startTime != nil {
+		return models.ClassChangeLocationAndStartTime, nil
 	}
 
-	if location != nil {
-		return "Wyjątkowo musiałem zmienić lokalizację zajęć.", nil
+	if locationChanged {
+		return models.ClassChangeLocation, nil
 	}
 
 	if startTime != nil {
-		return "Wyjątkowo musiałem zmienić czas rozpoczęcia zajęć.", nil
+		return models.ClassChangeStartTime, nil
+	}
+
+	return "", errors.New("class change for notification should not be empty")
+}
+
+// withClassTypes fills in what classes created from a class type leave out with the
+// defaults of the type, the name and the level always come from the type.
+func (s *service) withClassTypes(ctx context.Context, classes []models.Class) ([]models.Class, error) {
+	classTypes := make(map[uuid.UUID]models.ClassType)
+	result := make([]models.Class, len(classes))
+
+	for i, class := range classes {
+		if class.ClassTypeID.Exists() {
+			classTypeID := class.ClassTypeID.Get()
+
+			classType, ok := classTypes[classTypeID]
+			if !ok {
+				var err error
+
+				classType, err = s.getClassType(ctx, classTypeID)
+				if err != nil {
+					return nil, err
+				}
+
+				classTypes[classTypeID] = classType
+			}
+
+			class = applyClassTypeDefaults(class, classType)
+		}
+
+		if class.ClassName == "" || class.ClassLevel == "" {
+			return nil, api.ErrValidation(
+				errors.New("className and classLevel: are required for classes without class type"),
+			)
+		}
+
+		if class.LocationID == uuid.Nil {
+			return nil, api.ErrValidation(
+				errors.New("locationID: is required, the class type has no default location"),
+			)
+		}
+
+		if class.Duration == 0 {
+			class.Duration = models.ClassDuration
+		}
+
+		result[i] = class
+	}
+
+	return result, nil
+}
+
+func applyClassTypeDefaults(class models.Class, classType models.ClassType) models.Class {
+	class.ClassName = classType.Name
+	class.ClassLevel = classType.Level
+
+	if class.Duration == 0 {
+		class.Duration = classType.DefaultDuration
+	}
+
+	if class.MaxCapacity == 0 {
+		class.MaxCapacity = classType.DefaultCapacity
+	}
+
+	if class.LocationID == uuid.Nil {
+		class.LocationID = classType.DefaultLocationID.GetOrElse(uuid.Nil)
+	}
+
+	return class
+}
+
+func (s *service) getClassType(ctx context.Context, id uuid.UUID) (models.ClassType, error) {
+	classType, err := s.classTypesRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositoryError.ErrNotFound) {
+			return models.ClassType{}, api.ErrValidation(fmt.Errorf("class type %s not found", id))
+		}
+
+		return models.ClassType{}, fmt.Errorf("could not get class type %s: %w", id, err)
+	}
+
+	return classType, nil
+}
+
+// withLocations fills in the location of every class, classes created without max
+// capacity get the default capacity of their location.
+func (s *service) withLocations(ctx context.Context, classes []models.Class) ([]models.Class, error) {
+	locations := make(map[uuid.UUID]models.Location)
+	result := make([]models.Class, len(classes))
+
+	for i, class := range classes {
+		location, ok := locations[class.LocationID]
+		if !ok {
+			var err error
+
+			location, err = s.getLocation(ctx, class.LocationID)
+			if err != nil {
+				return nil, err
+			}
+
+			locations[class.LocationID] = location
+		}
+
+		class.Location = location
+
+		if class.MaxCapacity == 0 {
+			class.MaxCapacity = location.DefaultCapacity
+		}
+
+		if class.MaxCapacity < 1 {
+			return nil, api.ErrValidation(fmt.Errorf(
+				"maxCapacity: is required, location %s has no default capacity", location.Name,
+			))
+		}
+
+		result[i] = class
+	}
+
+	return result, nil
+}
+
+func (s *service) getLocation(ctx context.Context, id uuid.UUID) (models.Location, error) {
+	location, err := s.locationsRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositoryError.ErrNotFound) {
+			return models.Location{}, api.ErrValidation(fmt.Errorf("location %s not found", id))
+		}
+
+		return models.Location{}, fmt.Errorf("could not get location %s: %w", id, err)
+	}
+
+	return location, nil
+}
+
+// withInstructors fills in the instructor of every class assigned to one.
+func (s *service) withInstructors(ctx context.Context, classes []models.Class) ([]models.Class, error) {
+	instructors := make(map[uuid.UUID]models.Instructor)
+	result := make([]models.Class, len(classes))
+
+	for i, class := range classes {
+		if class.InstructorID.Exists() {
+			instructorID := class.InstructorID.Get()
+
+			instructor, ok := instructors[instructorID]
+			if !ok {
+				var err error
+
+				instructor, err = s.getInstructor(ctx, instructorID)
+				if err != nil {
+					return nil, err
+				}
+
+				instructors[instructorID] = instructor
+			}
+
+			class.Instructor = instructor
+		}
+
+		result[i] = class
+	}
+
+	return result, nil
+}
+
+func (s *service) getInstructor(ctx context.Context, id uuid.UUID) (models.Instructor, error) {
+	instructor, err := s.instructorsRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositoryError.ErrNotFound) {
+			return models.Instructor{}, api.ErrValidation(fmt.Errorf("instructor %s not found", id))
+		}
+
+		return models.Instructor{}, fmt.Errorf("could not get instructor %s: %w", id, err)
 	}
 
-	return "", errors.New("message for notification should not be empty")
+	return instructor, nil
 }
 
 func validateClasses(newClasses, existingClasses []models.Class) error {
@@ -11,8 +11,8 @@ import (
 
 	"main/internal/domain/errs/api"
 	"main/internal/domain/models"
-	"main/internal/domain/notifier"
 	"main/internal/domain/repositories"
+	"main/internal/domain/services"
 	"main/pkg/optional"
 
 	"github.com/google/uuid"
@@ -30,6 +30,12 @@ var (
 	testID4     = uuid.New()
 )
 
+var testLocation = models.Location{
+	ID:              uuid.New(),
+	Name:            "Studio A",
+	DefaultCapacity: 10,
+}
+
 var expiredAndFutureClassesWithCurrentCap = []models.ClassWithCurrentCapacity{
 	{
 		ID:              testID1,
@@ -38,7 +44,7 @@ var expiredAndFutureClassesWithCurrentCap = []models.ClassWithCurrentCapacity{
 		ClassName:       "Morning Yoga",
 		CurrentCapacity: 9,
 		MaxCapacity:     10,
-		Location:        "Studio A",
+		Location:        models.Location{Name: "Studio A"},
 	},
 	{
 		ID:              testID2,
@@ -47,7 +53,7 @@ var expiredAndFutureClassesWithCurrentCap = []models.ClassWithCurrentCapacity{
 		ClassName:       "Afternoon Yoga",
 		CurrentCapacity: 14,
 		MaxCapacity:     15,
-		Location:        "Studio B",
+		Location:        models.Location{Name: "Studio B"},
 	},
 	{
 		ID:              testID3,
@@ -56,7 +62,7 @@ var expiredAndFutureClassesWithCurrentCap = []models.ClassWithCurrentCapacity{
 		ClassName:       "Evening Yoga",
 		CurrentCapacity: 11,
 		MaxCapacity:     12,
-		Location:        "Studio C",
+		Location:        models.Location{Name: "Studio C"},
 	},
 	{
 		ID:              testID4,
@@ -65,7 +71,7 @@ var expiredAndFutureClassesWithCurrentCap = []models.ClassWithCurrentCapacity{
 		ClassName:       "Night Yoga",
 		CurrentCapacity: 19,
 		MaxCapacity:     20,
-		Location:        "Studio D",
+		Location:        models.Location{Name: "Studio D"},
 	},
 }
 
@@ -76,7 +82,7 @@ var expiredAndFutureClasses = []models.Class{
 		ClassLevel:  "Beginner",
 		ClassName:   "Morning Yoga",
 		MaxCapacity: 10,
-		Location:    "Studio A",
+		Location:    models.Location{Name: "Studio A"},
 	},
 	{
 		ID:          testID2,
@@ -84,7 +90,7 @@ var expiredAndFutureClasses = []models.Class{
 		ClassLevel:  "Intermediate",
 		ClassName:   "Afternoon Yoga",
 		MaxCapacity: 15,
-		Location:    "Studio B",
+		Location:    models.Location{Name: "Studio B"},
 	},
 	{
 		ID:          testID3,
@@ -92,7 +98,7 @@ var expiredAndFutureClasses = []models.Class{
 		ClassLevel:  "Advanced",
 		ClassName:   "Evening Yoga",
 		MaxCapacity: 12,
-		Location:    "Studio C",
+		Location:    models.Location{Name: "Studio C"},
 	},
 	{
 		ID:          testID4,
@@ -100,7 +106,7 @@ var expiredAndFutureClasses = []models.Class{
 		ClassLevel:  "Beginner",
 		ClassName:   "Night Yoga",
 		MaxCapacity: 20,
-		Location:    "Studio D",
+		Location:    models.Location{Name: "Studio D"},
 	},
 }
 
@@ -110,7 +116,9 @@ var validClass = models.Class{
 	ClassLevel:  "Beginner",
 	ClassName:   "Vinyasa",
 	MaxCapacity: 5,
-	Location:    "Studio A",
+	Duration:    models.ClassDuration,
+	LocationID:  testLocation.ID,
+	Location:    testLocation,
 }
 
 var futureClasses = []models.Class{
@@ -120,7 +128,9 @@ var futureClasses = []models.Class{
 		ClassLevel:  "Intermediate",
 		ClassName:   "Ashtanga",
 		MaxCapacity: 15,
-		Location:    "Studio B",
+		Duration:    models.ClassDuration,
+		LocationID:  testLocation.ID,
+		Location:    testLocation,
 	},
 	{
 		ID:          testID3,
@@ -128,7 +138,9 @@ var futureClasses = []models.Class{
 		ClassLevel:  "Advanced",
 		ClassName:   "Vinyasa",
 		MaxCapacity: 12,
-		Location:    "Studio C",
+		Duration:    models.ClassDuration,
+		LocationID:  testLocation.ID,
+		Location:    testLocation,
 	},
 }
 
@@ -138,16 +150,21 @@ var expiredClass = models.Class{
 	ClassLevel:  "Beginner",
 	ClassName:   "Vinyasa",
 	MaxCapacity: 5,
-	Location:    "Studio A",
+	LocationID:  testLocation.ID,
+	Location:    testLocation,
 }
 
 func anyValuePtr[T any](v T) *T {
 	return &v
 }
 
+// mockClassesRepo embeds IClasses for the methods the service does not call.
 type mockClassesRepo struct {
-	classes []models.Class
-	error   error
+	repositories.IClasses
+	classes     []models.Class
+	updates     []map[string]any
+	error       error
+	insertError error
 }
 
 func newMockClassesRepo(classes []models.Class, err error) *mockClassesRepo {
@@ -169,9 +186,17 @@ func (m *mockClassesRepo) List(_ context.Context) ([]models.Class, error) {
 	return m.classes, m.error
 }
 
+func (m *mockClassesRepo) ListBetween(_ context.Context, _, _ time.Time) ([]models.Class, error) {
+	return m.classes, m.error
+}
+
 func (m *mockClassesRepo) Insert(
 	_ context.Context, classes []models.Class,
 ) ([]models.Class, error) {
+	if m.insertError != nil {
+		return nil, m.insertError
+	}
+
 	return classes, m.error
 }
 
@@ -179,62 +204,26 @@ func (m *mockClassesRepo) Delete(_ context.Context, _ uuid.UUID) error {
 	return m.error
 }
 
-func (m *mockClassesRepo) Update(_ context.Context, _ uuid.UUID, _ map[string]any) error {
-	return m.error
-}
-
-type mockNotifier struct{}
-
-func newMockNotifier() *mockNotifier {
-	return &mockNotifier{}
-}
-
-func (m *mockNotifier) NotifyConfirmationLink(_, _, _ string) error {
-	return nil
-}
-
-func (m *mockNotifier) NotifyBookingConfirmation(_ models.NotifierParams, _ string) error {
-	return nil
-}
-
-func (m *mockNotifier) NotifyClassCancellation(_ models.NotifierParams, _ string) error {
-	return nil
-}
-
-func (m *mockNotifier) NotifyClassUpdate(_ models.NotifierParams, _ string) error {
-	return nil
-}
-
-func (m *mockNotifier) NotifyBookingCancellation(_ models.NotifierParams) error {
-	return nil
-}
-
-func (m *mockNotifier) NotifyPassActivation(_ models.Pass) error {
-	return nil
-}
+func (m *mockClassesRepo) Update(
+	_ context.Context, _ uuid.UUID, update map[string]any,
+) (models.Class, error) {
+	m.updates = append(m.updates, update)
 
-type mockPassesRepo struct{}
+	if m.error != nil || len(m.classes) == 0 {
+		return models.Class{}, m.error
+	}
 
-func newMockPassesRepo() *mockPassesRepo {
-	return &mockPassesRepo{}
-}
+	class := m.classes[0]
 
-func (m *mockPassesRepo) GetByEmail(
-	ctx context.Context, email string,
-) (optional.Optional[models.Pass], error) {
-	return optional.Empty[models.Pass](), nil
-}
+	if sequence, ok := update["sequence"].(int); ok {
+		class.Sequence = sequence
+	}
 
-func (m *mockPassesRepo) Update(
-	ctx context.Context, id int, usedBookingIDs []uuid.UUID, totalSlots int,
-) error {
-	return nil
-}
+	if instructorID, ok := update["instructor_id"].(uuid.UUID); ok {
+		class.InstructorID = optional.Of(instructorID)
+	}
 
-func (m *mockPassesRepo) Insert(
-	ctx context.Context, email string, usedBookingIDs []uuid.UUID, totalSlots int,
-) (models.Pass, error) {
-	return models.Pass{}, nil
+	return class, nil
 }
 
 var testBooking = models.Booking{
@@ -245,7 +234,7 @@ var testBooking = models.Booking{
 	Email:             "jan.kowalski@example.com",
 	CreatedAt:         time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC),
 	ConfirmationToken: "confirm_abc123xyz",
-	Class: &models.Class{
+	Class: models.Class{
 		ID: testID1,
 	},
 }
@@ -260,10 +249,27 @@ var testBookingWithoutClass = models.Booking{
 	ConfirmationToken: "confirm_abcxxxxxx",
 }
 
+var testAttendedBooking = models.Booking{
+	ID:                uuid.MustParse("3f1d2a7e-9b4c-4e8a-8d6f-2c5b7a9e1f03"),
+	ClassID:           testID1,
+	FirstName:         "Ewa",
+	LastName:          "Nowak",
+	Email:             "ewa.nowak@example.com",
+	Status:            models.BookingAttended,
+	CreatedAt:         time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC),
+	ConfirmationToken: "confirm_attended",
+	Class: models.Class{
+		ID: testID1,
+	},
+}
+
+// mockBookingsRepo embeds IBookings for the methods the service does not call.
 type mockBookingsRepo struct {
+	repositories.IBookings
 	count       int
 	testBooking models.Booking
 	error       error
+	deleteError error
 }
 
 func newMockBookingsRepo(booking models.Booking, err error) *mockBookingsRepo {
@@ -280,10 +286,6 @@ func (m *mockBookingsRepo) GetByEmailAndClassID(
 	return models.Booking{}, m.error
 }
 
-func (m *mockBookingsRepo) GetIDsByEmail(_ context.Context, _ string, _ int) ([]uuid.UUID, error) {
-	return nil, m.error
-}
-
 func (m *mockBookingsRepo) List(_ context.Context) ([]models.Booking, error) {
 	return []models.Booking{}, m.error
 }
@@ -298,6 +300,10 @@ func (m *mockBookingsRepo) ListByClassID(
 	return []models.Booking{m.testBooking}, m.error
 }
 
+func (m *mockBookingsRepo) ListByPassID(_ context.Context, _ int) ([]models.Booking, error) {
+	return []models.Booking{m.testBooking}, m.error
+}
+
 func (m *mockBookingsRepo) CountForClassID(_ context.Context, _ uuid.UUID) (int, error) {
 	return m.count, m.error
 }
@@ -306,10 +312,110 @@ func (m *mockBookingsRepo) Insert(_ context.Context, _ models.Booking) (uuid.UUI
 	return uuid.Nil, m.error
 }
 
-func (m *mockBookingsRepo) Delete(_ context.Context, _ uuid.UUID) error {
+func (m *mockBookingsRepo) DeleteByClassID(_ context.Context, _ uuid.UUID) error {
+	return m.deleteError
+}
+
+// mockWaitlistRepo holds the spots offered at offeredAt.
+type mockWaitlistRepo struct {
+	repositories.IWaitlist
+	offeredAt []time.Time
+}
+
+func (m *mockWaitlistRepo) CountOfferedSince(
+	_ context.Context, _ uuid.UUID, since time.Time,
+) (int, error) {
+	count := 0
+
+	for _, offeredAt := range m.offeredAt {
+		if !offeredAt.Before(since) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (m *mockWaitlistRepo) DeleteByClassID(_ context.Context, _ uuid.UUID) error {
+	return nil
+}
+
+type mockOutboxRepo struct {
+	repositories.IOutbox
+	notifications []models.Notification
+	error         error
+}
+
+func (m *mockOutboxRepo) Enqueue(_ context.Context, notification models.Notification) error {
+	if m.error != nil {
+		return m.error
+	}
+
+	m.notifications = append(m.notifications, notification)
+
+	return nil
+}
+
+type mockLocationsRepo struct {
+	repositories.ILocations
+}
+
+func (m *mockLocationsRepo) Get(_ context.Context, _ uuid.UUID) (models.Location, error) {
+	return testLocation, nil
+}
+
+type mockInstructorsRepo struct {
+	repositories.IInstructors
+	instructor models.Instructor
+}
+
+func (m *mockInstructorsRepo) Get(_ context.Context, _ uuid.UUID) (models.Instructor, error) {
+	return m.instructor, nil
+}
+
+type mockWaitlistService struct {
+	services.IWaitlistService
+	promoted int
+	error    error
+}
+
+func (m *mockWaitlistService) PromoteFromWaitlist(_ context.Context, _ uuid.UUID) error {
+	m.promoted++
+
 	return m.error
 }
 
+// newMockService runs the transactions on repos, the repositories left out get empty mocks.
+func newMockService(repos repositories.Repositories) *service {
+	if repos.Waitlist == nil {
+		repos.Waitlist = &mockWaitlistRepo{}
+	}
+
+	if repos.Outbox == nil {
+		repos.Outbox = &mockOutboxRepo{}
+	}
+
+	if repos.Locations == nil {
+		repos.Locations = &mockLocationsRepo{}
+	}
+
+	if repos.Instructors == nil {
+		repos.Instructors = &mockInstructorsRepo{}
+	}
+
+	return NewService(
+		repos.Classes,
+		repos.Bookings,
+		repos.Locations,
+		repos.Instructors,
+		repos.ClassTypes,
+		repos.Waitlist,
+		repositories.InTransaction(repos),
+		&services.PassManager{},
+		&mockWaitlistService{},
+	)
+}
+
 func TestService_ListClasses(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -317,7 +423,6 @@ func TestService_ListClasses(t *testing.T) {
 		classesLimit        *int
 		classesRepo         repositories.IClasses
 		bookingsRepo        repositories.IBookings
-		passesRepo          repositories.IPasses
 		wantClasses         []models.ClassWithCurrentCapacity
 		wantError           bool
 		error               error
@@ -328,7 +433,6 @@ func TestService_ListClasses(t *testing.T) {
 			classesLimit:        nil,
 			classesRepo:         newMockClassesRepo(expiredAndFutureClasses, nil),
 			bookingsRepo:        newMockBookingsRepo(testBooking, nil),
-			passesRepo:          newMockPassesRepo(),
 			wantClasses:         expiredAndFutureClassesWithCurrentCap,
 		},
 		{
@@ -336,7 +440,6 @@ func TestService_ListClasses(t *testing.T) {
 			onlyUpcomingClasses: true,
 			classesLimit:        nil,
 			classesRepo:         newMockClassesRepo(expiredAndFutureClasses, nil),
-			passesRepo:          newMockPassesRepo(),
 			bookingsRepo:        newMockBookingsRepo(testBooking, nil),
 			wantClasses: []models.ClassWithCurrentCapacity{
 				expiredAndFutureClassesWithCurrentCap[1],
@@ -348,7 +451,6 @@ func TestService_ListClasses(t *testing.T) {
 			name:                "List all classes with limit",
 			onlyUpcomingClasses: false,
 			classesLimit:        anyValuePtr(2),
-			passesRepo:          newMockPassesRepo(),
 			classesRepo:         newMockClassesRepo(expiredAndFutureClasses, nil),
 			bookingsRepo:        newMockBookingsRepo(testBooking, nil),
 			wantClasses: []models.ClassWithCurrentCapacity{
@@ -360,7 +462,6 @@ func TestService_ListClasses(t *testing.T) {
 			name:                "List upcoming classes with limit",
 			onlyUpcomingClasses: true,
 			classesLimit:        anyValuePtr(2),
-			passesRepo:          newMockPassesRepo(),
 			classesRepo:         newMockClassesRepo(expiredAndFutureClasses, nil),
 			bookingsRepo:        newMockBookingsRepo(testBooking, nil),
 			wantClasses: []models.ClassWithCurrentCapacity{
@@ -372,7 +473,6 @@ func TestService_ListClasses(t *testing.T) {
 			name:                "List upcoming classes with limit larger than available",
 			onlyUpcomingClasses: true,
 			classesLimit:        anyValuePtr(10),
-			passesRepo:          newMockPassesRepo(),
 			classesRepo:         newMockClassesRepo(expiredAndFutureClasses, nil),
 			bookingsRepo:        newMockBookingsRepo(testBooking, nil),
 			wantClasses: []models.ClassWithCurrentCapacity{
@@ -385,7 +485,6 @@ func TestService_ListClasses(t *testing.T) {
 			name:                "List classes with limit larger than available",
 			onlyUpcomingClasses: false,
 			classesLimit:        anyValuePtr(10),
-			passesRepo:          newMockPassesRepo(),
 			classesRepo:         newMockClassesRepo(expiredAndFutureClasses, nil),
 			bookingsRepo:        newMockBookingsRepo(testBooking, nil),
 			wantClasses:         expiredAndFutureClassesWithCurrentCap,
@@ -395,7 +494,6 @@ func TestService_ListClasses(t *testing.T) {
 			onlyUpcomingClasses: true,
 			classesLimit:        anyValuePtr(0),
 			classesRepo:         newMockClassesRepo(expiredAndFutureClasses, nil),
-			passesRepo:          newMockPassesRepo(),
 			bookingsRepo:        newMockBookingsRepo(testBooking, nil),
 			wantClasses:         []models.ClassWithCurrentCapacity{},
 		},
@@ -404,7 +502,6 @@ func TestService_ListClasses(t *testing.T) {
 			onlyUpcomingClasses: false,
 			classesLimit:        anyValuePtr(0),
 			classesRepo:         newMockClassesRepo(expiredAndFutureClasses, nil),
-			passesRepo:          newMockPassesRepo(),
 			bookingsRepo:        newMockBookingsRepo(testBooking, nil),
 			wantClasses:         []models.ClassWithCurrentCapacity{},
 		},
@@ -412,7 +509,6 @@ func TestService_ListClasses(t *testing.T) {
 			name:                "List classes from empty repository",
 			onlyUpcomingClasses: false,
 			classesLimit:        nil,
-			passesRepo:          newMockPassesRepo(),
 			classesRepo:         newMockClassesRepo([]models.Class{}, nil),
 			bookingsRepo:        newMockBookingsRepo(testBooking, nil),
 			wantClasses:         []models.ClassWithCurrentCapacity{},
@@ -421,7 +517,6 @@ func TestService_ListClasses(t *testing.T) {
 			name:                "List upcoming classes from empty repository",
 			onlyUpcomingClasses: true,
 			classesLimit:        nil,
-			passesRepo:          newMockPassesRepo(),
 			classesRepo:         newMockClassesRepo([]models.Class{}, nil),
 			bookingsRepo:        newMockBookingsRepo(testBooking, nil),
 			wantClasses:         []models.ClassWithCurrentCapacity{},
@@ -430,7 +525,6 @@ func TestService_ListClasses(t *testing.T) {
 			name:                "try to list past classes with upcoming filter",
 			onlyUpcomingClasses: true,
 			classesLimit:        nil,
-			passesRepo:          newMockPassesRepo(),
 			classesRepo:         newMockClassesRepo([]models.Class{expiredAndFutureClasses[0]}, nil),
 			bookingsRepo:        newMockBookingsRepo(testBooking, nil),
 			wantClasses:         []models.ClassWithCurrentCapacity{},
@@ -439,7 +533,6 @@ func TestService_ListClasses(t *testing.T) {
 			name:                "List upcoming classes with limit of one",
 			onlyUpcomingClasses: true,
 			classesLimit:        anyValuePtr(1),
-			passesRepo:          newMockPassesRepo(),
 			classesRepo:         newMockClassesRepo(expiredAndFutureClasses, nil),
 			bookingsRepo:        newMockBookingsRepo(testBooking, nil),
 			wantClasses: []models.ClassWithCurrentCapacity{
@@ -449,7 +542,6 @@ func TestService_ListClasses(t *testing.T) {
 		{
 			name:                "List classes with negative limit - should return error",
 			onlyUpcomingClasses: false,
-			passesRepo:          newMockPassesRepo(),
 			classesLimit:        anyValuePtr(-1),
 			wantError:           true,
 			error:               api.ErrValidation(fmt.Errorf("classes_limit must be greater than or equal to 0, got: %d", -1)),
@@ -457,7 +549,6 @@ func TestService_ListClasses(t *testing.T) {
 		{
 			name:                "List upcoming classes with negative limit - should return error",
 			onlyUpcomingClasses: true,
-			passesRepo:          newMockPassesRepo(),
 			classesLimit:        anyValuePtr(-5),
 			wantError:           true,
 			error: api.ErrValidation(
@@ -468,7 +559,6 @@ func TestService_ListClasses(t *testing.T) {
 			name:                "Repository error",
 			onlyUpcomingClasses: false,
 			classesLimit:        nil,
-			passesRepo:          newMockPassesRepo(),
 			classesRepo:         newMockClassesRepo(futureClasses, errors.New("db error")),
 			wantError:           true,
 			error:               errors.New("db error"),
@@ -477,12 +567,13 @@ func TestService_ListClasses(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			notifier := newMockNotifier()
-
-			service := NewService(tt.classesRepo, tt.bookingsRepo, tt.passesRepo, notifier)
+			service := newMockService(repositories.Repositories{
+				Classes:  tt.classesRepo,
+				Bookings: tt.bookingsRepo,
+			})
 			ctx := context.Background()
 
-			classes, err := service.ListClasses(ctx, tt.onlyUpcomingClasses, tt.classesLimit)
+			classes, err := service.ListClasses(ctx, tt.onlyUpcomingClasses, models.ClassFilter{}, tt.classesLimit)
 			if tt.wantError {
 				if err == nil {
 					t.Fatalf("expected error %v, but got nil", tt.error)
@@ -506,6 +597,79 @@ func TestService_ListClasses(t *testing.T) {
 	}
 }
 
+func TestService_ListSchedule(t *testing.T) {
+	tests := []struct {
+		name          string
+		bookings      int
+		offeredAgo    []time.Duration
+		wantSpotsLeft int
+	}{
+		{
+			name:          "spots left of an empty class",
+			wantSpotsLeft: 3,
+		},
+		{
+			name:          "booked spots are taken",
+			bookings:      2,
+			wantSpotsLeft: 1,
+		},
+		{
+			name:          "spot offered from the waitlist is held",
+			bookings:      1,
+			offeredAgo:    []time.Duration{10 * time.Minute},
+			wantSpotsLeft: 1,
+		},
+		{
+			name:          "spot of an expired offer is free again",
+			bookings:      1,
+			offeredAgo:    []time.Duration{models.WaitlistOfferTTL + time.Minute},
+			wantSpotsLeft: 2,
+		},
+		{
+			name:          "fully booked and offered class has no spots left",
+			bookings:      2,
+			offeredAgo:    []time.Duration{time.Minute},
+			wantSpotsLeft: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class := futureClasses[0]
+			class.MaxCapacity = 3
+
+			bookingsRepo := newMockBookingsRepo(testBooking, nil)
+			bookingsRepo.count = tt.bookings
+
+			waitlistRepo := &mockWaitlistRepo{}
+			for _, offeredAgo := range tt.offeredAgo {
+				waitlistRepo.offeredAt = append(waitlistRepo.offeredAt, time.Now().Add(-offeredAgo))
+			}
+
+			service := newMockService(repositories.Repositories{
+				Classes:  newMockClassesRepo([]models.Class{class}, nil),
+				Bookings: bookingsRepo,
+				Waitlist: waitlistRepo,
+			})
+
+			schedule, err := service.ListSchedule(
+				context.Background(), now, now.Add(7*24*time.Hour), models.ClassFilter{},
+			)
+			if err != nil {
+				t.Fatalf("ListSchedule() error = %v", err)
+			}
+
+			if len(schedule.Classes) != 1 {
+				t.Fatalf("classes = %d, want 1", len(schedule.Classes))
+			}
+
+			if got := schedule.Classes[0].CurrentCapacity; got != tt.wantSpotsLeft {
+				t.Errorf("spots left = %d, want %d", got, tt.wantSpotsLeft)
+			}
+		})
+	}
+}
+
 func TestService_CreateClasses(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -518,35 +682,37 @@ func TestService_CreateClasses(t *testing.T) {
 		{
 			name:        "Create one valid class",
 			classes:     []models.Class{validClass},
-			classesRepo: newMockClassesRepo([]models.Class{validClass}, nil),
+			classesRepo: newMockClassesRepo(nil, nil),
 			want:        []models.Class{validClass},
 		},
 		{
 			name:        "Create valid classes",
 			classes:     futureClasses,
-			classesRepo: newMockClassesRepo(futureClasses, nil),
+			classesRepo: newMockClassesRepo(nil, nil),
 			want:        futureClasses,
 		},
 		{
-			name:      "Validation error - expired class",
-			classes:   []models.Class{expiredClass},
-			wantError: true,
+			name:        "Validation error - expired class",
+			classes:     []models.Class{expiredClass},
+			classesRepo: newMockClassesRepo(nil, nil),
+			wantError:   true,
 			error: api.ErrValidation(
-				fmt.Errorf("class start_time: %v expired", expiredClass.StartTime),
+				fmt.Errorf("class startTime: %v expired", expiredClass.StartTime),
 			),
 		},
 		{
-			name:      "Validation error - all class should start in future",
-			classes:   expiredAndFutureClasses,
-			wantError: true,
+			name:        "Validation error - all class should start in future",
+			classes:     expiredAndFutureClasses,
+			classesRepo: newMockClassesRepo(nil, nil),
+			wantError:   true,
 			error: api.ErrValidation(
-				fmt.Errorf("class start_time: %v expired", expiredAndFutureClasses[0].StartTime),
+				fmt.Errorf("class startTime: %v expired", expiredAndFutureClasses[0].StartTime),
 			),
 		},
 		{
 			name:        "Repository insert error",
 			classes:     []models.Class{validClass},
-			classesRepo: newMockClassesRepo([]models.Class{}, errors.New("db error")),
+			classesRepo: &mockClassesRepo{insertError: errors.New("db error")},
 			wantError:   true,
 			error:       fmt.Errorf("could not insert classes: %w", errors.New("db error")),
 		},
@@ -554,12 +720,10 @@ func TestService_CreateClasses(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			notifier := &mockNotifier{}
-			passesRepo := &mockPassesRepo{}
-
-			bookingsRepo := newMockBookingsRepo(testBooking, nil)
-
-			service := NewService(tt.classesRepo, bookingsRepo, passesRepo, notifier)
+			service := newMockService(repositories.Repositories{
+				Classes:  tt.classesRepo,
+				Bookings: newMockBookingsRepo(testBooking, nil),
+			})
 			ctx := context.Background()
 
 			result, err := service.CreateClasses(ctx, tt.classes)
@@ -594,7 +758,7 @@ func TestService_DeleteClass(t *testing.T) {
 		reasonMsg    *string
 		classesRepo  repositories.IClasses
 		bookingsRepo repositories.IBookings
-		notifier     notifier.INotifier
+		outboxRepo   *mockOutboxRepo
 		wantError    bool
 		error        error
 	}{
@@ -604,21 +768,21 @@ func TestService_DeleteClass(t *testing.T) {
 			reasonMsg:    anyValuePtr("testReason"),
 			classesRepo:  newMockClassesRepo(futureClasses, nil),
 			bookingsRepo: newMockBookingsRepo(testBooking, nil),
-			notifier:     &mockNotifier{},
+			outboxRepo:   &mockOutboxRepo{},
 		},
 		{
 			name:         "delete class: success with no bookings and no reason msg",
 			classID:      testID2,
 			classesRepo:  newMockClassesRepo(futureClasses, nil),
 			bookingsRepo: newMockBookingsRepo(testBooking, nil),
-			notifier:     &mockNotifier{},
+			outboxRepo:   &mockOutboxRepo{},
 		},
 		{
 			name:         "delete class: error reasonMsg empty",
 			classID:      testID1,
 			classesRepo:  newMockClassesRepo(futureClasses, nil),
 			bookingsRepo: newMockBookingsRepo(testBooking, nil),
-			notifier:     &mockNotifier{},
+			outboxRepo:   &mockOutboxRepo{},
 			wantError:    true,
 			error: api.ErrValidation(
 				errors.New("reason msg can not be empty, when classes has bookings"),
@@ -630,17 +794,29 @@ func TestService_DeleteClass(t *testing.T) {
 			classesRepo:  newMockClassesRepo(futureClasses, nil),
 			bookingsRepo: newMockBookingsRepo(testBookingWithoutClass, nil),
 			reasonMsg:    anyValuePtr("testReason"),
-			notifier:     &mockNotifier{},
+			outboxRepo:   &mockOutboxRepo{},
 			wantError:    true,
 			error:        errors.New("class field should not be empty"),
 		},
+		{
+			name:         "delete class: error class has attendance",
+			classID:      testID1,
+			classesRepo:  newMockClassesRepo(futureClasses, nil),
+			bookingsRepo: newMockBookingsRepo(testAttendedBooking, nil),
+			reasonMsg:    anyValuePtr("testReason"),
+			outboxRepo:   &mockOutboxRepo{},
+			wantError:    true,
+			error: api.ErrClassHasAttendance(
+				fmt.Errorf("class %v has attendance recorded", testID1),
+			),
+		},
 		{
 			name:         "delete class: notifier error",
 			classID:      testID1,
 			classesRepo:  newMockClassesRepo(futureClasses, nil),
 			bookingsRepo: newMockBookingsRepo(testBooking, nil),
 			reasonMsg:    anyValuePtr("testReason"),
-			notifier:     newMockNotifier(),
+			outboxRepo:   &mockOutboxRepo{error: errors.New("notifier error")},
 			wantError:    true,
 			error:        errors.New("notifier error"),
 		},
@@ -649,37 +825,52 @@ func TestService_DeleteClass(t *testing.T) {
 			classID:      testID2,
 			classesRepo:  newMockClassesRepo(futureClasses, errors.New("db error")),
 			bookingsRepo: newMockBookingsRepo(testBooking, nil),
-			notifier:     &mockNotifier{},
+			outboxRepo:   &mockOutboxRepo{},
 			wantError:    true,
 			error:        errors.New("db error"),
 		},
 		{
 			name:         "delete class: classRepo.Delete() error",
 			classID:      testID1,
-			classesRepo:  newMockClassesRepo(futureClasses, nil),
-			bookingsRepo: newMockBookingsRepo(testBooking, errors.New("db error")),
 			reasonMsg:    anyValuePtr("testReason"),
-			notifier:     &mockNotifier{},
+			classesRepo:  newMockClassesRepo(futureClasses, errors.New("db error")),
+			bookingsRepo: newMockBookingsRepo(testBooking, nil),
+			outboxRepo:   &mockOutboxRepo{},
 			wantError:    true,
 			error:        errors.New("db error"),
 		},
 		{
-			name:         "delete class: classRepo.Delete() error",
+			name:         "delete class: bookingsRepo.ListByClassID() error",
 			classID:      testID1,
 			classesRepo:  newMockClassesRepo(futureClasses, nil),
 			bookingsRepo: newMockBookingsRepo(testBooking, errors.New("db error")),
 			reasonMsg:    anyValuePtr("testReason"),
-			notifier:     &mockNotifier{},
+			outboxRepo:   &mockOutboxRepo{},
 			wantError:    true,
 			error:        errors.New("db error"),
 		},
+		{
+			name:        "delete class: bookingsRepo.DeleteByClassID() error",
+			classID:     testID1,
+			classesRepo: newMockClassesRepo(futureClasses, nil),
+			bookingsRepo: &mockBookingsRepo{
+				testBooking: testBooking,
+				deleteError: errors.New("db error"),
+			},
+			reasonMsg:  anyValuePtr("testReason"),
+			outboxRepo: &mockOutboxRepo{},
+			wantError:  true,
+			error:      errors.New("db error"),
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			passesRepo := mockPassesRepo{}
-
-			service := NewService(tt.classesRepo, tt.bookingsRepo, &passesRepo, tt.notifier)
+			service := newMockService(repositories.Repositories{
+				Classes:  tt.classesRepo,
+				Bookings: tt.bookingsRepo,
+				Outbox:   tt.outboxRepo,
+			})
 			ctx := context.Background()
 
 			err := service.DeleteClass(ctx, tt.classID, tt.reasonMsg)
@@ -701,3 +892,107 @@ func TestService_DeleteClass(t *testing.T) {
 		})
 	}
 }
+
+func TestService_UpdateClass(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxCapacity  int
+		promoteError error
+		wantPromoted int
+	}{
+		{
+			name:         "update class: raised capacity promotes the waitlist",
+			maxCapacity:  validClass.MaxCapacity + 2,
+			wantPromoted: 1,
+		},
+		{
+			name:         "update class: failed promotion does not fail the saved update",
+			maxCapacity:  validClass.MaxCapacity + 2,
+			promoteError: errors.New("db error"),
+			wantPromoted: 1,
+		},
+		{
+			name:        "update class: lowered capacity does not promote the waitlist",
+			maxCapacity: validClass.MaxCapacity - 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classesRepo := newMockClassesRepo([]models.Class{validClass}, nil)
+			waitlistService := &mockWaitlistService{error: tt.promoteError}
+
+			service := newMockService(repositories.Repositories{
+				Classes:  classesRepo,
+				Bookings: newMockBookingsRepo(testBooking, nil),
+			})
+			service.waitlistService = waitlistService
+
+			update := models.UpdateClass{MaxCapacity: anyValuePtr(tt.maxCapacity)}
+
+			_, err := service.UpdateClass(context.Background(), validClass.ID, update)
+			if err != nil {
+				t.Fatalf("got error: %v, but want none", err)
+			}
+
+			if len(classesRepo.updates) != 1 {
+				t.Errorf("class updated %d times, want once", len(classesRepo.updates))
+			}
+
+			if waitlistService.promoted != tt.wantPromoted {
+				t.Errorf("waitlist promoted %d times, want %d", waitlistService.promoted, tt.wantPromoted)
+			}
+		})
+	}
+}
+
+func TestService_SubstituteInstructor(t *testing.T) {
+	instructor := models.Instructor{ID: uuid.New(), Name: "Ola"}
+	substitute := models.Instructor{ID: uuid.New(), Name: "Kasia"}
+
+	class := validClass
+	class.Sequence = 3
+	class.InstructorID = optional.Of(instructor.ID)
+	class.Instructor = instructor
+
+	classesRepo := newMockClassesRepo([]models.Class{class}, nil)
+	outboxRepo := &mockOutboxRepo{}
+
+	service := newMockService(repositories.Repositories{
+		Classes:     classesRepo,
+		Bookings:    newMockBookingsRepo(testBooking, nil),
+		Outbox:      outboxRepo,
+		Instructors: &mockInstructorsRepo{instructor: substitute},
+	})
+
+	updated, err := service.SubstituteInstructor(context.Background(), class.ID, substitute.ID)
+	if err != nil {
+		t.Fatalf("SubstituteInstructor() error = %v", err)
+	}
+
+	// the invitations sent before the substitution are outranked by the update
+	if updated.Sequence != class.Sequence+1 {
+		t.Errorf("class sequence = %d, want %d", updated.Sequence, class.Sequence+1)
+	}
+
+	if len(outboxRepo.notifications) != 1 {
+		t.Fatalf("notifications = %d, want 1", len(outboxRepo.notifications))
+	}
+
+	if got := outboxRepo.notifications[0].Params.ClassSequence; got != class.Sequence+1 {
+		t.Errorf("notification sequence = %d, want %d", got, class.Sequence+1)
+	}
+
+	// substituting the instructor already teaching the class changes nothing
+	classesRepo.updates = nil
+	classesRepo.classes = []models.Class{updated}
+	service.instructorsRepo = &mockInstructorsRepo{instructor: substitute}
+
+	if _, err = service.SubstituteInstructor(context.Background(), class.ID, substitute.ID); err != nil {
+		t.Fatalf("SubstituteInstructor() error = %v", err)
+	}
+
+	if len(classesRepo.updates) != 0 {
+		t.Errorf("class updated again with %v", classesRepo.updates)
+	}
+}
@@ -0,0 +1,742 @@
+package classseries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"main/internal/domain/errs/api"
+	"main/internal/domain/models"
+	"main/internal/domain/repositories"
+	"main/internal/domain/services"
+	repositoryError "main/internal/infrastructure/errs"
+	"main/pkg/converter"
+	"main/pkg/optional"
+
+	"github.com/google/uuid"
+)
+
+const (
+	weeklyIntervalDays   = 7
+	biweeklyIntervalDays = 14
+)
+
+type service struct {
+	classSeriesRepo   repositories.IClassSeries
+	classesRepo       repositories.IClasses
+	bookingsRepo      repositories.IBookings
+	locationsRepo     repositories.ILocations
+	instructorsRepo   repositories.IInstructors
+	classesService    services.IClassesService
+	unitOfWork        repositories.IUnitOfWork
+	classesServiceFor func(repos repositories.Repositories) services.IClassesService
+	horizon           time.Duration
+}
+
+// NewService takes classesServiceFor to create and delete the classes of a series in the
+// same transaction as the series itself.
+func NewService(
+	classSeriesRepo repositories.IClassSeries,
+	classesRepo repositories.IClasses,
+	bookingsRepo repositories.IBookings,
+	locationsRepo repositories.ILocations,
+	instructorsRepo repositories.IInstructors,
+	classesService services.IClassesService,
+	unitOfWork repositories.IUnitOfWork,
+	classesServiceFor func(repos repositories.Repositories) services.IClassesService,
+	horizon time.Duration,
+) *service {
+	return &service{
+		classSeriesRepo:   classSeriesRepo,
+		classesRepo:       classesRepo,
+		bookingsRepo:      bookingsRepo,
+		locationsRepo:     locationsRepo,
+		instructorsRepo:   instructorsRepo,
+		classesService:    classesService,
+		unitOfWork:        unitOfWork,
+		classesServiceFor: classesServiceFor,
+		horizon:           horizon,
+	}
+}
+
+// inTransaction returns the service working with repositories bound to a transaction.
+func (s *service) inTransaction(repos repositories.Repositories) *service {
+	return &service{
+		classSeriesRepo:   repos.ClassSeries,
+		classesRepo:       repos.Classes,
+		bookingsRepo:      repos.Bookings,
+		locationsRepo:     repos.Locations,
+		instructorsRepo:   repos.Instructors,
+		classesService:    s.classesServiceFor(repos),
+		unitOfWork:        repositories.InTransaction(repos),
+		classesServiceFor: s.classesServiceFor,
+		horizon:           s.horizon,
+	}
+}
+
+func (s *service) ListClassSeries(ctx context.Context) ([]models.ClassSeries, error) {
+	series, err := s.classSeriesRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list class series: %w", err)
+	}
+
+	return series, nil
+}
+
+func (s *service) CreateClassSeries(
+	ctx context.Context, series models.ClassSeries,
+) (models.ClassSeries, error) {
+	err := validateClassSeries(series)
+	if err != nil {
+		return models.ClassSeries{}, api.ErrValidation(err)
+	}
+
+	// a series without its first occurrences would be retried by every materialization run,
+	// so it is only kept together with them
+	err = s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		series, err = s.inTransaction(repos).createClassSeries(ctx, series)
+
+		return err
+	})
+	if err != nil {
+		return models.ClassSeries{}, fmt.Errorf("create class series transaction failed: %w", err)
+	}
+
+	return series, nil
+}
+
+func (s *service) createClassSeries(
+	ctx context.Context, series models.ClassSeries,
+) (models.ClassSeries, error) {
+	var err error
+
+	series.Location, err = s.getLocation(ctx, series.LocationID)
+	if err != nil {
+		return models.ClassSeries{}, err
+	}
+
+	if series.MaxCapacity == 0 {
+		series.MaxCapacity = series.Location.DefaultCapacity
+	}
+
+	if series.MaxCapacity < 1 {
+		return models.ClassSeries{}, api.ErrValidation(fmt.Errorf(
+			"maxCapacity: is required, location %s has no default capacity", series.Location.Name,
+		))
+	}
+
+	if series.InstructorID.Exists() {
+		err = s.validateInstructor(ctx, series.InstructorID.Get())
+		if err != nil {
+			return models.ClassSeries{}, err
+		}
+	}
+
+	err = s.classSeriesRepo.Insert(ctx, series)
+	if err != nil {
+		return models.ClassSeries{}, fmt.Errorf("could not insert class series: %w", err)
+	}
+
+	materializedSeries, err := s.materialize(ctx, series, time.Now(), true)
+	if err != nil {
+		return models.ClassSeries{}, fmt.Errorf("could not materialize class series %v: %w", series.ID, err)
+	}
+
+	return materializedSeries, nil
+}
+
+// UpdateClassSeries changes the series together with its upcoming classes, so a failure
+// does not leave the classes apart from their series.
+func (s *service) UpdateClassSeries(
+	ctx context.Context, id uuid.UUID, update models.UpdateClassSeries,
+) (models.ClassSeries, error) {
+	var updatedSeries models.ClassSeries
+
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		var err error
+
+		updatedSeries, err = s.inTransaction(repos).updateClassSeries(ctx, id, update)
+
+		return err
+	})
+	if err != nil {
+		return models.ClassSeries{}, fmt.Errorf("update class series transaction failed: %w", err)
+	}
+
+	return updatedSeries, nil
+}
+
+func (s *service) updateClassSeries(
+	ctx context.Context, id uuid.UUID, update models.UpdateClassSeries,
+) (models.ClassSeries, error) {
+	series, err := s.classSeriesRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositoryError.ErrNotFound) {
+			return models.ClassSeries{}, api.ErrNotFound(err)
+		}
+
+		return models.ClassSeries{}, fmt.Errorf("could not get class series %v: %w", id, err)
+	}
+
+	if update.EndDate != nil && update.EndDate.Before(series.StartTime) {
+		return models.ClassSeries{}, api.ErrValidation(
+			fmt.Errorf("class series endDate: %v is before startTime: %v", *update.EndDate, series.StartTime),
+		)
+	}
+
+	updatedSeries, err := applyClassSeriesUpdate(series, update)
+	if err != nil {
+		return models.ClassSeries{}, api.ErrValidation(err)
+	}
+
+	if update.LocationID != nil {
+		updatedSeries.Location, err = s.getLocation(ctx, *update.LocationID)
+		if err != nil {
+			return models.ClassSeries{}, err
+		}
+	}
+
+	if update.InstructorID != nil {
+		err = s.validateInstructor(ctx, *update.InstructorID)
+		if err != nil {
+			return models.ClassSeries{}, err
+		}
+	}
+
+	err = s.classSeriesRepo.Update(ctx, updatedSeries)
+	if err != nil {
+		return models.ClassSeries{}, fmt.Errorf("could not update class series %v: %w", id, err)
+	}
+
+	err = s.applyUpdateToOccurrences(ctx, updatedSeries, update)
+	if err != nil {
+		return models.ClassSeries{}, fmt.Errorf("could not apply update to class series occurrences: %w", err)
+	}
+
+	now := time.Now()
+
+	err = s.materializeNewlyCovered(ctx, series, updatedSeries, now)
+	if err != nil {
+		return models.ClassSeries{}, fmt.Errorf("could not materialize class series %v: %w", id, err)
+	}
+
+	updatedSeries, err = s.materialize(ctx, updatedSeries, now, false)
+	if err != nil {
+		return models.ClassSeries{}, fmt.Errorf("could not materialize class series %v: %w", id, err)
+	}
+
+	return updatedSeries, nil
+}
+
+// DeleteClassSeries removes the series with its upcoming classes at once, so a failure
+// does not leave the series with only a part of its classes.
+func (s *service) DeleteClassSeries(ctx context.Context, id uuid.UUID, msg *string) error {
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		return s.inTransaction(repos).deleteClassSeries(ctx, id, msg)
+	})
+	if err != nil {
+		return fmt.Errorf("delete class series transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) deleteClassSeries(ctx context.Context, id uuid.UUID, msg *string) error {
+	_, err := s.classSeriesRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositoryError.ErrNotFound) {
+			return api.ErrNotFound(err)
+		}
+
+		return fmt.Errorf("could not get class series %v: %w", id, err)
+	}
+
+	classes, err := s.classesRepo.ListBySeriesID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("could not list classes for series %v: %w", id, err)
+	}
+
+	now := time.Now()
+
+	if msg == nil {
+		err = s.ensureNoBookings(ctx, classes, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, class := range classes {
+		if class.StartTime.After(now) {
+			err = s.classesService.DeleteClass(ctx, class.ID, msg)
+			if err != nil {
+				return fmt.Errorf("could not delete class %v: %w", class.ID, err)
+			}
+
+			continue
+		}
+
+		// past classes stay for the history, only the link to removed series is dropped
+		_, err = s.classesRepo.Update(ctx, class.ID, map[string]any{"series_id": nil})
+		if err != nil {
+			return fmt.Errorf("could not detach class %v from series: %w", class.ID, err)
+		}
+	}
+
+	err = s.classSeriesRepo.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("could not delete class series %v: %w", id, err)
+	}
+
+	return nil
+}
+
+// MaterializeClassSeries creates classes for every series up to the rolling horizon.
+// Occurrences conflicting with already existing classes are skipped.
+func (s *service) MaterializeClassSeries(ctx context.Context) error {
+	allSeries, err := s.classSeriesRepo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("could not list class series: %w", err)
+	}
+
+	now := time.Now()
+
+	for _, series := range allSeries {
+		_, err = s.materialize(ctx, series, now, false)
+		if err != nil {
+			return fmt.Errorf("could not materialize class series %v: %w", series.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// materialize inserts classes for occurrences between the last materialization and
+// now + horizon. In strict mode a single conflicting occurrence fails whole operation.
+func (s *service) materialize(
+	ctx context.Context,
+	series models.ClassSeries,
+	now time.Time,
+	strict bool,
+) (models.ClassSeries, error) {
+	from := now
+	if series.MaterializedUntil != nil && series.MaterializedUntil.After(from) {
+		from = *series.MaterializedUntil
+	}
+
+	until := now.Add(s.horizon)
+
+	occurrences, err := expandOccurrences(series, from, until)
+	if err != nil {
+		return models.ClassSeries{}, fmt.Errorf("could not expand occurrences: %w", err)
+	}
+
+	existingClasses, err := s.classesRepo.ListBySeriesID(ctx, series.ID)
+	if err != nil {
+		return models.ClassSeries{}, fmt.Errorf("could not list classes for series %v: %w", series.ID, err)
+	}
+
+	newClasses := make([]models.Class, 0, len(occurrences))
+
+	for _, occurrence := range occurrences {
+		if isAlreadyMaterialized(occurrence, existingClasses) {
+			continue
+		}
+
+		newClasses = append(newClasses, occurrenceClass(series, occurrence))
+	}
+
+	err = s.createClasses(ctx, newClasses, strict)
+	if err != nil {
+		return models.ClassSeries{}, err
+	}
+
+	materializedUntil := until.UTC()
+	series.MaterializedUntil = &materializedUntil
+
+	err = s.classSeriesRepo.Update(ctx, series)
+	if err != nil {
+		return models.ClassSeries{}, fmt.Errorf("could not update class series %v: %w", series.ID, err)
+	}
+
+	return series, nil
+}
+
+// materializeNewlyCovered creates the occurrences up to the last materialization which the
+// update brought back with a later end date or a removed exception date. The occurrences
+// materialized before are left alone, the admin may have deleted or moved them since.
+func (s *service) materializeNewlyCovered(
+	ctx context.Context, previous, series models.ClassSeries, now time.Time,
+) error {
+	if series.MaterializedUntil == nil || !series.MaterializedUntil.After(now) {
+		return nil
+	}
+
+	occurrences, err := expandOccurrences(series, now, *series.MaterializedUntil)
+	if err != nil {
+		return fmt.Errorf("could not expand occurrences: %w", err)
+	}
+
+	existingClasses, err := s.classesRepo.ListBySeriesID(ctx, series.ID)
+	if err != nil {
+		return fmt.Errorf("could not list classes for series %v: %w", series.ID, err)
+	}
+
+	var newClasses []models.Class
+
+	for _, occurrence := range occurrences {
+		occurredBefore, err := occursOn(previous, occurrence)
+		if err != nil {
+			return err
+		}
+
+		if occurredBefore || isAlreadyMaterialized(occurrence, existingClasses) {
+			continue
+		}
+
+		newClasses = append(newClasses, occurrenceClass(series, occurrence))
+	}
+
+	return s.createClasses(ctx, newClasses, false)
+}
+
+func occurrenceClass(series models.ClassSeries, occurrence time.Time) models.Class {
+	return models.Class{
+		ID:           uuid.New(),
+		StartTime:    occurrence.UTC(),
+		ClassLevel:   series.ClassLevel,
+		ClassName:    series.ClassName,
+		MaxCapacity:  series.MaxCapacity,
+		LocationID:   series.LocationID,
+		InstructorID: series.InstructorID,
+		SeriesID:     optional.Of(series.ID),
+	}
+}
+
+func (s *service) createClasses(ctx context.Context, classes []models.Class, strict bool) error {
+	if len(classes) == 0 {
+		return nil
+	}
+
+	if strict {
+		_, err := s.classesService.CreateClasses(ctx, classes)
+		if err != nil {
+			return fmt.Errorf("could not create classes: %w", err)
+		}
+
+		return nil
+	}
+
+	for _, class := range classes {
+		_, err := s.classesService.CreateClasses(ctx, []models.Class{class})
+		if err != nil {
+			var apiError *api.APIError
+			if errors.As(err, &apiError) && apiError.Code == api.BadRequestCode {
+				slog.Warn("ClassSeries: skipping occurrence",
+					"series_id", class.SeriesID.Get(), "start_time", class.StartTime, "err", err.Error(),
+				)
+
+				continue
+			}
+
+			return fmt.Errorf("could not create class for %v: %w", class.StartTime, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *service) applyUpdateToOccurrences(
+	ctx context.Context, series models.ClassSeries, update models.UpdateClassSeries,
+) error {
+	classes, err := s.classesRepo.ListBySeriesID(ctx, series.ID)
+	if err != nil {
+		return fmt.Errorf("could not list classes for series %v: %w", series.ID, err)
+	}
+
+	classUpdate := models.UpdateClass{
+		ClassLevel:  update.ClassLevel,
+		ClassName:   update.ClassName,
+		MaxCapacity: update.MaxCapacity,
+		LocationID:  update.LocationID,
+	}
+	hasClassUpdate := classUpdate.ClassLevel != nil || classUpdate.ClassName != nil ||
+		classUpdate.MaxCapacity != nil || classUpdate.LocationID != nil
+
+	now := time.Now()
+
+	for _, class := range classes {
+		if !class.StartTime.After(now) {
+			continue
+		}
+
+		occurs, err := occursOn(series, class.StartTime)
+		if err != nil {
+			return fmt.Errorf("could not check occurrence of class %v: %w", class.ID, err)
+		}
+
+		if !occurs {
+			err = s.removeOccurrence(ctx, class)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if hasClassUpdate {
+			_, err = s.classesService.UpdateClass(ctx, class.ID, classUpdate)
+			if err != nil {
+				return fmt.Errorf("could not update class %v: %w", class.ID, err)
+			}
+		}
+
+		if update.InstructorID != nil {
+			_, err = s.classesService.SubstituteInstructor(ctx, class.ID, *update.InstructorID)
+			if err != nil {
+				return fmt.Errorf("could not substitute instructor of class %v: %w", class.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// removeOccurrence deletes class which is no longer a part of the series. Classes
+// with bookings are kept, they have to be cancelled explicitly with a reason message.
+func (s *service) removeOccurrence(ctx context.Context, class models.Class) error {
+	bookingCount, err := s.bookingsRepo.CountForClassID(ctx, class.ID)
+	if err != nil {
+		return fmt.Errorf("could not count bookings for class %v: %w", class.ID, err)
+	}
+
+	if bookingCount > 0 {
+		slog.Warn("ClassSeries: keeping class with bookings outside of series",
+			"class_id", class.ID, "start_time", class.StartTime, "bookings", bookingCount,
+		)
+
+		return nil
+	}
+
+	err = s.classesService.DeleteClass(ctx, class.ID, nil)
+	if err != nil {
+		return fmt.Errorf("could not delete class %v: %w", class.ID, err)
+	}
+
+	return nil
+}
+
+func (s *service) ensureNoBookings(ctx context.Context, classes []models.Class, now time.Time) error {
+	for _, class := range classes {
+		if !class.StartTime.After(now) {
+			continue
+		}
+
+		bookingCount, err := s.bookingsRepo.CountForClassID(ctx, class.ID)
+		if err != nil {
+			return fmt.Errorf("could not count bookings for class %v: %w", class.ID, err)
+		}
+
+		if bookingCount > 0 {
+			return api.ErrValidation(
+				errors.New("reason msg can not be empty, when series has classes with bookings"),
+			)
+		}
+	}
+
+	return nil
+}
+
+// expandOccurrences returns start times of the series in (from, until]. Steps are made
+// in the time zone of the series location, so classes keep their wall clock hour across
+// DST changes.
+func expandOccurrences(series models.ClassSeries, from, until time.Time) ([]time.Time, error) {
+	intervalDays, err := getIntervalDays(series.Frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	start, err := converter.ConvertToLocationTime(series.StartTime, series.Location.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert startTime to local time: %w", err)
+	}
+
+	var occurrences []time.Time
+
+	for i := 0; ; i++ {
+		occurrence := start.AddDate(0, 0, i*intervalDays)
+		if occurrence.After(until) {
+			break
+		}
+
+		occurs, err := occursOn(series, occurrence)
+		if err != nil {
+			return nil, err
+		}
+
+		if !occurs {
+			if series.EndDate != nil && occurrence.After(*series.EndDate) {
+				break
+			}
+
+			continue
+		}
+
+		if occurrence.After(from) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+
+	return occurrences, nil
+}
+
+// occursOn checks end date and exception dates of the series, both compared by day in the
+// time zone of the series location.
+func occursOn(series models.ClassSeries, startTime time.Time) (bool, error) {
+	day, err := localDay(startTime, series.Location.TimeZone)
+	if err != nil {
+		return false, err
+	}
+
+	if series.EndDate != nil {
+		endDay, err := localDay(*series.EndDate, series.Location.TimeZone)
+		if err != nil {
+			return false, err
+		}
+
+		if day.After(endDay) {
+			return false, nil
+		}
+	}
+
+	for _, exceptionDate := range series.ExceptionDates {
+		exceptionDay, err := localDay(exceptionDate, series.Location.TimeZone)
+		if err != nil {
+			return false, err
+		}
+
+		if day.Equal(exceptionDay) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func localDay(t time.Time, timeZone string) (time.Time, error) {
+	localTime, err := converter.ConvertToLocationTime(t, timeZone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not convert %v to local time: %w", t, err)
+	}
+
+	return time.Date(localTime.Year(), localTime.Month(), localTime.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+func (s *service) getLocation(ctx context.Context, id uuid.UUID) (models.Location, error) {
+	location, err := s.locationsRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositoryError.ErrNotFound) {
+			return models.Location{}, api.ErrValidation(fmt.Errorf("location %s not found", id))
+		}
+
+		return models.Location{}, fmt.Errorf("could not get location %s: %w", id, err)
+	}
+
+	return location, nil
+}
+
+func (s *service) validateInstructor(ctx context.Context, id uuid.UUID) error {
+	_, err := s.instructorsRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositoryError.ErrNotFound) {
+			return api.ErrValidation(fmt.Errorf("instructor %s not found", id))
+		}
+
+		return fmt.Errorf("could not get instructor %s: %w", id, err)
+	}
+
+	return nil
+}
+
+func getIntervalDays(frequency models.ClassSeriesFrequency) (int, error) {
+	switch frequency {
+	case models.ClassSeriesWeekly:
+		return weeklyIntervalDays, nil
+	case models.ClassSeriesBiweekly:
+		return biweeklyIntervalDays, nil
+	default:
+		return 0, fmt.Errorf("unknown class series frequency: %s", frequency)
+	}
+}
+
+func isAlreadyMaterialized(occurrence time.Time, existingClasses []models.Class) bool {
+	for _, class := range existingClasses {
+		if class.StartTime.Equal(occurrence) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateClassSeries(series models.ClassSeries) error {
+	_, err := getIntervalDays(series.Frequency)
+	if err != nil {
+		return err
+	}
+
+	if series.EndDate != nil && series.EndDate.Before(series.StartTime) {
+		return fmt.Errorf("class series endDate: %v is before startTime: %v", *series.EndDate, series.StartTime)
+	}
+
+	return nil
+}
+
+func applyClassSeriesUpdate(
+	series models.ClassSeries, update models.UpdateClassSeries,
+) (models.ClassSeries, error) {
+	updated := false
+
+	if update.EndDate != nil {
+		endDate := update.EndDate.UTC()
+		series.EndDate = &endDate
+		updated = true
+	}
+
+	if update.ExceptionDates != nil {
+		series.ExceptionDates = *update.ExceptionDates
+		updated = true
+	}
+
+	if update.ClassLevel != nil {
+		series.ClassLevel = *update.ClassLevel
+		updated = true
+	}
+
+	if update.ClassName != nil {
+		series.ClassName = *update.ClassName
+		updated = true
+	}
+
+	if update.MaxCapacity != nil {
+		series.MaxCapacity = *update.MaxCapacity
+		updated = true
+	}
+
+	if update.LocationID != nil {
+		series.LocationID = *update.LocationID
+		updated = true
+	}
+
+	if update.InstructorID != nil {
+		series.InstructorID = optional.Of(*update.InstructorID)
+		updated = true
+	}
+
+	if !updated {
+		return models.ClassSeries{}, errors.New("no fields to update class series")
+	}
+
+	return series, nil
+}
@@ -0,0 +1,455 @@
+package classseries
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"main/internal/application/classes"
+	"main/internal/application/waitlist"
+	"main/internal/domain/models"
+	"main/internal/domain/repositories"
+	"main/internal/domain/services"
+	repositoryError "main/internal/infrastructure/errs"
+	"main/internal/infrastructure/generator/token"
+	"main/internal/infrastructure/repository/repositorytest"
+	"main/pkg/converter"
+
+	"github.com/google/uuid"
+)
+
+func TestExpandOccurrences(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Fatalf("could not load location: %v", err)
+	}
+
+	start := time.Date(2026, 3, 17, 18, 0, 0, 0, warsaw)
+	endDate := time.Date(2026, 4, 7, 0, 0, 0, 0, warsaw)
+
+	tests := []struct {
+		name   string
+		series models.ClassSeries
+		from   time.Time
+		until  time.Time
+		want   []time.Time
+	}{
+		{
+			name: "weekly keeps wall clock hour across DST change",
+			series: models.ClassSeries{
+				Frequency: models.ClassSeriesWeekly,
+				StartTime: start,
+			},
+			from:  start.Add(-time.Hour),
+			until: time.Date(2026, 4, 1, 0, 0, 0, 0, warsaw),
+			want: []time.Time{
+				time.Date(2026, 3, 17, 18, 0, 0, 0, warsaw),
+				time.Date(2026, 3, 24, 18, 0, 0, 0, warsaw),
+				time.Date(2026, 3, 31, 18, 0, 0, 0, warsaw),
+			},
+		},
+		{
+			name: "biweekly",
+			series: models.ClassSeries{
+				Frequency: models.ClassSeriesBiweekly,
+				StartTime: start,
+			},
+			from:  start.Add(-time.Hour),
+			until: time.Date(2026, 4, 15, 0, 0, 0, 0, warsaw),
+			want: []time.Time{
+				time.Date(2026, 3, 17, 18, 0, 0, 0, warsaw),
+				time.Date(2026, 3, 31, 18, 0, 0, 0, warsaw),
+				time.Date(2026, 4, 14, 18, 0, 0, 0, warsaw),
+			},
+		},
+		{
+			name: "end date is inclusive and exception dates are skipped",
+			series: models.ClassSeries{
+				Frequency:      models.ClassSeriesWeekly,
+				StartTime:      start,
+				EndDate:        &endDate,
+				ExceptionDates: []time.Time{time.Date(2026, 3, 24, 0, 0, 0, 0, warsaw)},
+			},
+			from:  start.Add(-time.Hour),
+			until: time.Date(2026, 6, 1, 0, 0, 0, 0, warsaw),
+			want: []time.Time{
+				time.Date(2026, 3, 17, 18, 0, 0, 0, warsaw),
+				time.Date(2026, 3, 31, 18, 0, 0, 0, warsaw),
+				time.Date(2026, 4, 7, 18, 0, 0, 0, warsaw),
+			},
+		},
+		{
+			name: "occurrences up to from are already materialized",
+			series: models.ClassSeries{
+				Frequency: models.ClassSeriesWeekly,
+				StartTime: start,
+			},
+			from:  time.Date(2026, 3, 24, 18, 0, 0, 0, warsaw),
+			until: time.Date(2026, 4, 1, 0, 0, 0, 0, warsaw),
+			want: []time.Time{
+				time.Date(2026, 3, 31, 18, 0, 0, 0, warsaw),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandOccurrences(tt.series, tt.from, tt.until)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d occurrences %v, want %d %v", len(got), got, len(tt.want), tt.want)
+			}
+
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d: got %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCreateClassSeries(t *testing.T) {
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Minute).UTC()
+
+	tests := []struct {
+		name        string
+		existing    []time.Time
+		wantErr     bool
+		wantClasses int
+	}{
+		{
+			name:        "series is created with its first occurrences",
+			wantClasses: 3,
+		},
+		{
+			name:        "conflicting occurrence leaves neither the series nor its classes",
+			existing:    []time.Time{start},
+			wantErr:     true,
+			wantClasses: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos, unitOfWork := repositorytest.OpenSQLite(t)
+
+			location := repositorytest.InsertLocation(t, repos)
+
+			for _, startTime := range tt.existing {
+				repositorytest.InsertClass(t, repos, startTime, 10)
+			}
+
+			s := newService(repos, unitOfWork, 20*24*time.Hour)
+
+			_, err := s.CreateClassSeries(ctx, models.ClassSeries{
+				ID:          uuid.New(),
+				Frequency:   models.ClassSeriesWeekly,
+				StartTime:   start,
+				ClassLevel:  "beginner",
+				ClassName:   "hatha",
+				MaxCapacity: 10,
+				LocationID:  location.ID,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateClassSeries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			series, err := repos.ClassSeries.List(ctx)
+			if err != nil {
+				t.Fatalf("could not list class series: %v", err)
+			}
+
+			if wantSeries := !tt.wantErr; (len(series) == 1) != wantSeries {
+				t.Errorf("class series = %d, want series %v", len(series), wantSeries)
+			}
+
+			if classes := mustListClasses(t, repos); len(classes) != tt.wantClasses {
+				t.Errorf("classes = %d, want %d", len(classes), tt.wantClasses)
+			}
+		})
+	}
+}
+
+func TestDeleteClassSeries(t *testing.T) {
+	ctx := context.Background()
+	repos, unitOfWork := repositorytest.OpenSQLite(t)
+	location := repositorytest.InsertLocation(t, repos)
+
+	s := newService(repos, unitOfWork, 20*24*time.Hour)
+
+	series, err := s.CreateClassSeries(ctx, models.ClassSeries{
+		ID:          uuid.New(),
+		Frequency:   models.ClassSeriesWeekly,
+		StartTime:   time.Now().Add(24 * time.Hour).Truncate(time.Minute).UTC(),
+		ClassLevel:  "beginner",
+		ClassName:   "hatha",
+		MaxCapacity: 10,
+		LocationID:  location.ID,
+	})
+	if err != nil {
+		t.Fatalf("could not create class series: %v", err)
+	}
+
+	classes, err := repos.Classes.ListBySeriesID(ctx, series.ID)
+	if err != nil || len(classes) == 0 {
+		t.Fatalf("could not list classes of the series: %v", err)
+	}
+
+	repositorytest.InsertBooking(t, repos, classes[len(classes)-1].ID, "anna@example.com")
+
+	if err = s.DeleteClassSeries(ctx, series.ID, nil); err == nil {
+		t.Fatal("DeleteClassSeries() without a message deleted series with bookings")
+	}
+
+	msg := "studio is closed"
+
+	if err = s.DeleteClassSeries(ctx, series.ID, &msg); err != nil {
+		t.Fatalf("DeleteClassSeries() error = %v", err)
+	}
+
+	if _, err = repos.ClassSeries.Get(ctx, series.ID); !errors.Is(err, repositoryError.ErrNotFound) {
+		t.Errorf("class series still exists, err = %v", err)
+	}
+
+	if classes := mustListClasses(t, repos); len(classes) != 0 {
+		t.Errorf("classes = %d, want none", len(classes))
+	}
+
+	messages, err := repos.Outbox.ListByStatus(ctx, models.OutboxStatusPending)
+	if err != nil {
+		t.Fatalf("could not list outbox messages: %v", err)
+	}
+
+	if len(messages) != 1 || messages[0].Notification.Kind != models.NotificationClassCancellation {
+		t.Errorf("notifications = %+v, want one class cancellation", messages)
+	}
+}
+
+// substituteFailing fails substituting the instructor, other calls go to the classes service.
+type substituteFailing struct {
+	services.IClassesService
+}
+
+func (substituteFailing) SubstituteInstructor(
+	context.Context, uuid.UUID, uuid.UUID,
+) (models.Class, error) {
+	return models.Class{}, errors.New("db error")
+}
+
+func TestUpdateClassSeriesIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	repos, unitOfWork := repositorytest.OpenSQLite(t)
+	location := repositorytest.InsertLocation(t, repos)
+
+	instructor := models.Instructor{
+		ID:        uuid.New(),
+		Name:      "Ola",
+		Email:     "ola@example.com",
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+
+	if err := repos.Instructors.Insert(ctx, instructor); err != nil {
+		t.Fatalf("could not insert instructor: %v", err)
+	}
+
+	s := newService(repos, unitOfWork, 20*24*time.Hour)
+
+	series, err := s.CreateClassSeries(ctx, models.ClassSeries{
+		ID:          uuid.New(),
+		Frequency:   models.ClassSeriesWeekly,
+		StartTime:   time.Now().Add(24 * time.Hour).Truncate(time.Minute).UTC(),
+		ClassLevel:  "beginner",
+		ClassName:   "hatha",
+		MaxCapacity: 10,
+		LocationID:  location.ID,
+	})
+	if err != nil {
+		t.Fatalf("could not create class series: %v", err)
+	}
+
+	classesServiceFor := s.classesServiceFor
+	s.classesServiceFor = func(repos repositories.Repositories) services.IClassesService {
+		return substituteFailing{classesServiceFor(repos)}
+	}
+
+	// the classes are renamed before the substitution fails
+	_, err = s.UpdateClassSeries(ctx, series.ID, models.UpdateClassSeries{
+		ClassName:    anyValuePtr("vinyasa"),
+		InstructorID: anyValuePtr(instructor.ID),
+	})
+	if err == nil {
+		t.Fatal("UpdateClassSeries() error = nil, want the substitution error")
+	}
+
+	stored, err := repos.ClassSeries.Get(ctx, series.ID)
+	if err != nil {
+		t.Fatalf("could not get class series: %v", err)
+	}
+
+	if stored.ClassName != "hatha" || stored.InstructorID.Exists() {
+		t.Errorf("class series is %s with instructor %v, want it unchanged",
+			stored.ClassName, stored.InstructorID.Exists())
+	}
+
+	for _, class := range mustListClasses(t, repos) {
+		if class.ClassName != "hatha" {
+			t.Errorf("class %v is %s, want it unchanged", class.ID, class.ClassName)
+		}
+	}
+}
+
+func TestUpdateClassSeriesKeepsMaterializedOccurrences(t *testing.T) {
+	studio, err := converter.LoadLocation("")
+	if err != nil {
+		t.Fatalf("could not load studio location: %v", err)
+	}
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Minute).In(studio)
+
+	// occurrence keeps the wall clock hour of the first class across DST changes
+	occurrence := func(week int) time.Time {
+		return start.AddDate(0, 0, 7*week).UTC()
+	}
+
+	tests := []struct {
+		name       string
+		endDate    *time.Time
+		exceptions []int
+		// deleted occurrences are removed by the admin before the update
+		deleted         []int
+		update          models.UpdateClassSeries
+		wantOccurrences []int
+	}{
+		{
+			name:            "removed exception date brings back only its occurrence",
+			exceptions:      []int{1},
+			deleted:         []int{2},
+			update:          models.UpdateClassSeries{ExceptionDates: &[]time.Time{}},
+			wantOccurrences: []int{0, 1},
+		},
+		{
+			name:            "later end date brings back the occurrences after the previous end",
+			endDate:         anyValuePtr(occurrence(0)),
+			deleted:         []int{0},
+			update:          models.UpdateClassSeries{EndDate: anyValuePtr(occurrence(4))},
+			wantOccurrences: []int{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos, unitOfWork := repositorytest.OpenSQLite(t)
+			location := repositorytest.InsertLocation(t, repos)
+
+			s := newService(repos, unitOfWork, 20*24*time.Hour)
+
+			exceptions := make([]time.Time, 0, len(tt.exceptions))
+			for _, week := range tt.exceptions {
+				exceptions = append(exceptions, occurrence(week))
+			}
+
+			series, err := s.CreateClassSeries(ctx, models.ClassSeries{
+				ID:             uuid.New(),
+				Frequency:      models.ClassSeriesWeekly,
+				StartTime:      occurrence(0),
+				EndDate:        tt.endDate,
+				ExceptionDates: exceptions,
+				ClassLevel:     "beginner",
+				ClassName:      "hatha",
+				MaxCapacity:    10,
+				LocationID:     location.ID,
+			})
+			if err != nil {
+				t.Fatalf("could not create class series: %v", err)
+			}
+
+			for _, class := range mustListClasses(t, repos) {
+				for _, week := range tt.deleted {
+					if class.StartTime.Equal(occurrence(week)) {
+						if err = repos.Classes.Delete(ctx, class.ID); err != nil {
+							t.Fatalf("could not delete class: %v", err)
+						}
+					}
+				}
+			}
+
+			if _, err = s.UpdateClassSeries(ctx, series.ID, tt.update); err != nil {
+				t.Fatalf("UpdateClassSeries() error = %v", err)
+			}
+
+			classes := mustListClasses(t, repos)
+
+			gotTimes := make([]time.Time, 0, len(classes))
+			for _, class := range classes {
+				gotTimes = append(gotTimes, class.StartTime.UTC())
+			}
+
+			wantTimes := make([]time.Time, 0, len(tt.wantOccurrences))
+			for _, week := range tt.wantOccurrences {
+				wantTimes = append(wantTimes, occurrence(week))
+			}
+
+			slices.SortFunc(gotTimes, time.Time.Compare)
+
+			if !slices.EqualFunc(gotTimes, wantTimes, time.Time.Equal) {
+				t.Errorf("classes at %v, want %v", gotTimes, wantTimes)
+			}
+		})
+	}
+}
+
+func anyValuePtr[T any](v T) *T {
+	return &v
+}
+
+func newService(
+	repos repositories.Repositories, unitOfWork repositories.IUnitOfWork, horizon time.Duration,
+) *service {
+	classesServiceFor := func(repos repositories.Repositories) services.IClassesService {
+		unitOfWork := repositories.InTransaction(repos)
+
+		return classes.NewService(
+			repos.Classes,
+			repos.Bookings,
+			repos.Locations,
+			repos.Instructors,
+			repos.ClassTypes,
+			repos.Waitlist,
+			unitOfWork,
+			&services.PassManager{},
+			waitlist.NewService(unitOfWork, token.NewGenerator(), ""),
+		)
+	}
+
+	return NewService(
+		repos.ClassSeries,
+		repos.Classes,
+		repos.Bookings,
+		repos.Locations,
+		repos.Instructors,
+		classesServiceFor(repos),
+		unitOfWork,
+		classesServiceFor,
+		horizon,
+	)
+}
+
+func mustListClasses(t *testing.T, repos repositories.Repositories) []models.Class {
+	t.Helper()
+
+	classes, err := repos.Classes.List(context.Background())
+	if err != nil {
+		t.Fatalf("could not list classes: %v", err)
+	}
+
+	return classes
+}
@@ -0,0 +1,248 @@
+package classtypes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"main/internal/domain/errs/api"
+	viewErrors "main/internal/domain/errs/view"
+	"main/internal/domain/models"
+	"main/internal/domain/repositories"
+	"main/internal/domain/services"
+	"main/internal/infrastructure/errs"
+	"main/pkg/optional"
+
+	"github.com/google/uuid"
+)
+
+type service struct {
+	classTypesRepo repositories.IClassTypes
+	locationsRepo  repositories.ILocations
+	classesService services.IClassesService
+}
+
+func NewService(
+	classTypesRepo repositories.IClassTypes,
+	locationsRepo repositories.ILocations,
+	classesService services.IClassesService,
+) *service {
+	return &service{
+		classTypesRepo: classTypesRepo,
+		locationsRepo:  locationsRepo,
+		classesService: classesService,
+	}
+}
+
+func (s *service) ListClassTypes(ctx context.Context) ([]models.ClassType, error) {
+	classTypes, err := s.classTypesRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list class types: %w", err)
+	}
+
+	return classTypes, nil
+}
+
+func (s *service) GetClassType(ctx context.Context, id uuid.UUID) (models.ClassType, error) {
+	classType, err := s.classTypesRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return models.ClassType{}, api.ErrNotFound(fmt.Errorf("class type %s not found", id))
+		}
+
+		return models.ClassType{}, fmt.Errorf("could not get class type %s: %w", id, err)
+	}
+
+	return classType, nil
+}
+
+// DescribeClassType returns the class type with its upcoming classes for the public
+// page of the type.
+func (s *service) DescribeClassType(
+	ctx context.Context, id uuid.UUID,
+) (models.ClassTypeDescription, error) {
+	classType, err := s.classTypesRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return models.ClassTypeDescription{}, viewErrors.ErrClassTypeNotFound(
+				fmt.Errorf("class type %s not found", id),
+			)
+		}
+
+		return models.ClassTypeDescription{}, fmt.Errorf("could not get class type %s: %w", id, err)
+	}
+
+	classes, err := s.classesService.ListClasses(
+		ctx, true, models.ClassFilter{ClassTypeID: optional.Of(id)}, nil,
+	)
+	if err != nil {
+		return models.ClassTypeDescription{}, fmt.Errorf("could not list classes of type %s: %w", id, err)
+	}
+
+	return models.ClassTypeDescription{
+		ClassType:       classType,
+		UpcomingClasses: classes,
+	}, nil
+}
+
+func (s *service) CreateClassType(
+	ctx context.Context, params models.ClassTypeParams,
+) (models.ClassType, error) {
+	err := s.validateDefaultLocation(ctx, params.DefaultLocationID)
+	if err != nil {
+		return models.ClassType{}, err
+	}
+
+	now := time.Now().UTC()
+
+	classType := models.ClassType{
+		ID:                uuid.New(),
+		Name:              params.Name,
+		Level:             params.Level,
+		Description:       params.Description,
+		DefaultDuration:   params.DefaultDuration,
+		DefaultCapacity:   params.DefaultCapacity,
+		DefaultLocationID: params.DefaultLocationID,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	if classType.DefaultDuration == 0 {
+		classType.DefaultDuration = models.ClassDuration
+	}
+
+	err = s.classTypesRepo.Insert(ctx, classType)
+	if err != nil {
+		if errors.Is(err, errs.ErrAlreadyExist) {
+			return models.ClassType{}, api.ErrClassTypeAlreadyExists(
+				fmt.Errorf("class type %s (%s) already exists", params.Name, params.Level),
+			)
+		}
+
+		return models.ClassType{}, fmt.Errorf("could not insert class type %s: %w", params.Name, err)
+	}
+
+	return classType, nil
+}
+
+// UpdateClassType changes the defaults for the classes created from now on, the existing
+// classes of the type keep their settings.
+func (s *service) UpdateClassType(
+	ctx context.Context, id uuid.UUID, update models.UpdateClassType,
+) (models.ClassType, error) {
+	classType, err := s.GetClassType(ctx, id)
+	if err != nil {
+		return models.ClassType{}, err
+	}
+
+	classType, err = applyClassTypeUpdate(classType, update)
+	if err != nil {
+		return models.ClassType{}, api.ErrValidation(err)
+	}
+
+	err = s.validateDefaultLocation(ctx, classType.DefaultLocationID)
+	if err != nil {
+		return models.ClassType{}, err
+	}
+
+	classType.UpdatedAt = time.Now().UTC()
+
+	err = s.classTypesRepo.Update(ctx, classType)
+	if err != nil {
+		if errors.Is(err, errs.ErrAlreadyExist) {
+			return models.ClassType{}, api.ErrClassTypeAlreadyExists(
+				fmt.Errorf("class type %s (%s) already exists", classType.Name, classType.Level),
+			)
+		}
+
+		return models.ClassType{}, fmt.Errorf("could not update class type %s: %w", id, err)
+	}
+
+	return classType, nil
+}
+
+// DeleteClassType removes a class type no class was created from.
+func (s *service) DeleteClassType(ctx context.Context, id uuid.UUID) error {
+	_, err := s.GetClassType(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	inUse, err := s.classTypesRepo.IsInUse(ctx, id)
+	if err != nil {
+		return fmt.Errorf("could not check usage of class type %s: %w", id, err)
+	}
+
+	if inUse {
+		return api.ErrClassTypeInUse(fmt.Errorf("class type %s has classes", id))
+	}
+
+	err = s.classTypesRepo.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("could not delete class type %s: %w", id, err)
+	}
+
+	return nil
+}
+
+func (s *service) validateDefaultLocation(
+	ctx context.Context, locationID optional.Optional[uuid.UUID],
+) error {
+	if !locationID.Exists() {
+		return nil
+	}
+
+	_, err := s.locationsRepo.Get(ctx, locationID.Get())
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return api.ErrValidation(fmt.Errorf("location %s not found", locationID.Get()))
+		}
+
+		return fmt.Errorf("could not get location %s: %w", locationID.Get(), err)
+	}
+
+	return nil
+}
+
+func applyClassTypeUpdate(
+	classType models.ClassType, update models.UpdateClassType,
+) (models.ClassType, error) {
+	updated := false
+
+	if update.Name != nil {
+		classType.Name = *update.Name
+		updated = true
+	}
+
+	if update.Level != nil {
+		classType.Level = *update.Level
+		updated = true
+	}
+
+	if update.Description != nil {
+		classType.Description = *update.Description
+		updated = true
+	}
+
+	if update.DefaultDuration != nil {
+		classType.DefaultDuration = *update.DefaultDuration
+		updated = true
+	}
+
+	if update.DefaultCapacity != nil {
+		classType.DefaultCapacity = *update.DefaultCapacity
+		updated = true
+	}
+
+	if update.DefaultLocationID != nil {
+		classType.DefaultLocationID = optional.Of(*update.DefaultLocationID)
+		updated = true
+	}
+
+	if !updated {
+		return models.ClassType{}, errors.New("no fields to update class type")
+	}
+
+	return classType, nil
+}
@@ -0,0 +1,163 @@
+package instructors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"main/internal/domain/errs/api"
+	"main/internal/domain/models"
+	"main/internal/domain/repositories"
+	"main/internal/infrastructure/errs"
+
+	"github.com/google/uuid"
+)
+
+type service struct {
+	instructorsRepo repositories.IInstructors
+}
+
+func NewService(instructorsRepo repositories.IInstructors) *service {
+	return &service{
+		instructorsRepo: instructorsRepo,
+	}
+}
+
+func (s *service) ListInstructors(ctx context.Context) ([]models.Instructor, error) {
+	instructors, err := s.instructorsRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list instructors: %w", err)
+	}
+
+	return instructors, nil
+}
+
+func (s *service) GetInstructor(ctx context.Context, id uuid.UUID) (models.Instructor, error) {
+	instructor, err := s.instructorsRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return models.Instructor{}, api.ErrNotFound(fmt.Errorf("instructor %s not found", id))
+		}
+
+		return models.Instructor{}, fmt.Errorf("could not get instructor %s: %w", id, err)
+	}
+
+	return instructor, nil
+}
+
+func (s *service) CreateInstructor(
+	ctx context.Context, params models.InstructorParams,
+) (models.Instructor, error) {
+	now := time.Now().UTC()
+
+	instructor := models.Instructor{
+		ID:        uuid.New(),
+		Name:      params.Name,
+		Bio:       params.Bio,
+		Email:     params.Email,
+		Signature: params.Signature,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err := s.instructorsRepo.Insert(ctx, instructor)
+	if err != nil {
+		if errors.Is(err, errs.ErrAlreadyExist) {
+			return models.Instructor{}, api.ErrInstructorAlreadyExists(
+				fmt.Errorf("instructor %s already exists", params.Email),
+			)
+		}
+
+		return models.Instructor{}, fmt.Errorf("could not insert instructor %s: %w", params.Email, err)
+	}
+
+	return instructor, nil
+}
+
+// UpdateInstructor does not touch emails already in the outbox, they keep the signature
+// the instructor had when they were queued.
+func (s *service) UpdateInstructor(
+	ctx context.Context, id uuid.UUID, update models.UpdateInstructor,
+) (models.Instructor, error) {
+	instructor, err := s.GetInstructor(ctx, id)
+	if err != nil {
+		return models.Instructor{}, err
+	}
+
+	instructor, err = applyInstructorUpdate(instructor, update)
+	if err != nil {
+		return models.Instructor{}, api.ErrValidation(err)
+	}
+
+	instructor.UpdatedAt = time.Now().UTC()
+
+	err = s.instructorsRepo.Update(ctx, instructor)
+	if err != nil {
+		if errors.Is(err, errs.ErrAlreadyExist) {
+			return models.Instructor{}, api.ErrInstructorAlreadyExists(
+				fmt.Errorf("instructor %s already exists", instructor.Email),
+			)
+		}
+
+		return models.Instructor{}, fmt.Errorf("could not update instructor %s: %w", id, err)
+	}
+
+	return instructor, nil
+}
+
+// DeleteInstructor removes an instructor teaching no class nor class series.
+func (s *service) DeleteInstructor(ctx context.Context, id uuid.UUID) error {
+	_, err := s.GetInstructor(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	inUse, err := s.instructorsRepo.IsInUse(ctx, id)
+	if err != nil {
+		return fmt.Errorf("could not check usage of instructor %s: %w", id, err)
+	}
+
+	if inUse {
+		return api.ErrInstructorInUse(fmt.Errorf("instructor %s has classes", id))
+	}
+
+	err = s.instructorsRepo.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("could not delete instructor %s: %w", id, err)
+	}
+
+	return nil
+}
+
+func applyInstructorUpdate(
+	instructor models.Instructor, update models.UpdateInstructor,
+) (models.Instructor, error) {
+	updated := false
+
+	if update.Name != nil {
+		instructor.Name = *update.Name
+		updated = true
+	}
+
+	if update.Bio != nil {
+		instructor.Bio = *update.Bio
+		updated = true
+	}
+
+	if update.Email != nil {
+		instructor.Email = *update.Email
+		updated = true
+	}
+
+	if update.Signature != nil {
+		instructor.Signature = *update.Signature
+		updated = true
+	}
+
+	if !updated {
+		return models.Instructor{}, errors.New("no fields to update instructor")
+	}
+
+	return instructor, nil
+}
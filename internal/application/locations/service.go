@@ -0,0 +1,177 @@
+package locations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"main/internal/domain/errs/api"
+	"main/internal/domain/models"
+	"main/internal/domain/repositories"
+	"main/internal/infrastructure/errs"
+
+	"github.com/google/uuid"
+)
+
+type service struct {
+	locationsRepo repositories.ILocations
+}
+
+func NewService(locationsRepo repositories.ILocations) *service {
+	return &service{
+		locationsRepo: locationsRepo,
+	}
+}
+
+func (s *service) ListLocations(ctx context.Context) ([]models.Location, error) {
+	locations, err := s.locationsRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list locations: %w", err)
+	}
+
+	return locations, nil
+}
+
+func (s *service) GetLocation(ctx context.Context, id uuid.UUID) (models.Location, error) {
+	location, err := s.locationsRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return models.Location{}, api.ErrNotFound(fmt.Errorf("location %s not found", id))
+		}
+
+		return models.Location{}, fmt.Errorf("could not get location %s: %w", id, err)
+	}
+
+	return location, nil
+}
+
+func (s *service) CreateLocation(ctx context.Context, params models.LocationParams) (models.Location, error) {
+	now := time.Now().UTC()
+
+	location := models.Location{
+		ID:              uuid.New(),
+		Name:            params.Name,
+		Address:         params.Address,
+		Directions:      params.Directions,
+		MapURL:          params.MapURL,
+		DefaultCapacity: params.DefaultCapacity,
+		AccessNotes:     params.AccessNotes,
+		TimeZone:        params.TimeZone,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	err := s.locationsRepo.Insert(ctx, location)
+	if err != nil {
+		if errors.Is(err, errs.ErrAlreadyExist) {
+			return models.Location{}, api.ErrLocationAlreadyExists(
+				fmt.Errorf("location %s already exists", params.Name),
+			)
+		}
+
+		return models.Location{}, fmt.Errorf("could not insert location %s: %w", params.Name, err)
+	}
+
+	return location, nil
+}
+
+// UpdateLocation changes the location of every class taking place there, the classes
+// refer to it.
+func (s *service) UpdateLocation(
+	ctx context.Context, id uuid.UUID, update models.UpdateLocation,
+) (models.Location, error) {
+	location, err := s.GetLocation(ctx, id)
+	if err != nil {
+		return models.Location{}, err
+	}
+
+	location, err = applyLocationUpdate(location, update)
+	if err != nil {
+		return models.Location{}, api.ErrValidation(err)
+	}
+
+	location.UpdatedAt = time.Now().UTC()
+
+	err = s.locationsRepo.Update(ctx, location)
+	if err != nil {
+		if errors.Is(err, errs.ErrAlreadyExist) {
+			return models.Location{}, api.ErrLocationAlreadyExists(
+				fmt.Errorf("location %s already exists", location.Name),
+			)
+		}
+
+		return models.Location{}, fmt.Errorf("could not update location %s: %w", id, err)
+	}
+
+	return location, nil
+}
+
+// DeleteLocation removes a location no class nor class series takes place at.
+func (s *service) DeleteLocation(ctx context.Context, id uuid.UUID) error {
+	_, err := s.GetLocation(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	inUse, err := s.locationsRepo.IsInUse(ctx, id)
+	if err != nil {
+		return fmt.Errorf("could not check usage of location %s: %w", id, err)
+	}
+
+	if inUse {
+		return api.ErrLocationInUse(fmt.Errorf("location %s has classes", id))
+	}
+
+	err = s.locationsRepo.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("could not delete location %s: %w", id, err)
+	}
+
+	return nil
+}
+
+func applyLocationUpdate(location models.Location, update models.UpdateLocation) (models.Location, error) {
+	updated := false
+
+	if update.Name != nil {
+		location.Name = *update.Name
+		updated = true
+	}
+
+	if update.Address != nil {
+		location.Address = *update.Address
+		updated = true
+	}
+
+	if update.Directions != nil {
+		location.Directions = *update.Directions
+		updated = true
+	}
+
+	if update.MapURL != nil {
+		location.MapURL = *update.MapURL
+		updated = true
+	}
+
+	if update.DefaultCapacity != nil {
+		location.DefaultCapacity = *update.DefaultCapacity
+		updated = true
+	}
+
+	if update.AccessNotes != nil {
+		location.AccessNotes = *update.AccessNotes
+		updated = true
+	}
+
+	if update.TimeZone != nil {
+		location.TimeZone = *update.TimeZone
+		updated = true
+	}
+
+	if !updated {
+		return models.Location{}, errors.New("no fields to update location")
+	}
+
+	return location, nil
+}
@@ -0,0 +1,250 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"main/internal/domain/errs/api"
+	"main/internal/domain/models"
+	"main/internal/domain/notifier"
+	"main/internal/domain/repositories"
+	"main/internal/infrastructure/errs"
+	"main/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+const (
+	batchSize  = 20
+	maxBackoff = 6 * time.Hour
+)
+
+type dispatcher struct {
+	outboxRepo   repositories.IOutbox
+	contactsRepo repositories.IContacts
+	notifier     notifier.INotifier
+	pollInterval time.Duration
+	maxAttempts  int
+	baseBackoff  time.Duration
+}
+
+func NewDispatcher(
+	outboxRepo repositories.IOutbox,
+	contactsRepo repositories.IContacts,
+	notifier notifier.INotifier,
+	pollInterval time.Duration,
+	maxAttempts int,
+	baseBackoff time.Duration,
+) *dispatcher {
+	return &dispatcher{
+		outboxRepo:   outboxRepo,
+		contactsRepo: contactsRepo,
+		notifier:     notifier,
+		pollInterval: pollInterval,
+		maxAttempts:  maxAttempts,
+		baseBackoff:  baseBackoff,
+	}
+}
+
+// Start sends due outbox messages on every poll until ctx is done.
+func (d *dispatcher) Start(ctx context.Context) {
+	slog.Info("OutboxDispatcher: started")
+
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		err := d.DispatchDue(ctx)
+		if err != nil {
+			slog.Error("OutboxDispatcher: could not dispatch messages", "err", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("OutboxDispatcher: stopped")
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *dispatcher) DispatchDue(ctx context.Context) error {
+	messages, err := d.outboxRepo.ListDue(ctx, time.Now().UTC(), batchSize)
+	if err != nil {
+		return fmt.Errorf("could not list due outbox messages: %w", err)
+	}
+
+	var dispatchErrs []error
+
+	// a message which can not be dispatched stays due and must not hold back the others
+	for _, message := range messages {
+		err = d.dispatch(ctx, message)
+		if err != nil {
+			dispatchErrs = append(dispatchErrs,
+				fmt.Errorf("could not dispatch outbox message %v: %w", message.ID, err),
+			)
+		}
+	}
+
+	return errors.Join(dispatchErrs...)
+}
+
+func (d *dispatcher) ListMessages(
+	ctx context.Context, status models.OutboxStatus,
+) ([]models.OutboxMessage, error) {
+	messages, err := d.outboxRepo.ListByStatus(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("could not list outbox messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+// RetryMessage moves a dead message back to the queue with a fresh attempts budget.
+func (d *dispatcher) RetryMessage(ctx context.Context, id uuid.UUID) error {
+	message, err := d.outboxRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return api.ErrNotFound(fmt.Errorf("outbox message %v not found", id))
+		}
+
+		return fmt.Errorf("could not get outbox message %v: %w", id, err)
+	}
+
+	if message.Status != models.OutboxStatusDead {
+		return api.ErrValidation(
+			fmt.Errorf("only dead messages can be retried, message %v is %s", id, message.Status),
+		)
+	}
+
+	err = d.outboxRepo.Update(ctx, id, map[string]any{
+		"status":          models.OutboxStatusPending,
+		"attempts":        0,
+		"next_attempt_at": time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("could not update outbox message %v: %w", id, err)
+	}
+
+	return nil
+}
+
+func (d *dispatcher) dispatch(ctx context.Context, message models.OutboxMessage) error {
+	locale, err := d.locale(ctx, message.Notification)
+	if err != nil {
+		return fmt.Errorf("could not get locale for outbox message: %w", err)
+	}
+
+	sendErr := d.send(locale, message.Notification)
+	if sendErr == nil {
+		err := d.outboxRepo.Update(ctx, message.ID, map[string]any{
+			"status":     models.OutboxStatusSent,
+			"attempts":   message.Attempts + 1,
+			"sent_at":    time.Now().UTC(),
+			"last_error": nil,
+		})
+		if err != nil {
+			return fmt.Errorf("could not mark outbox message as sent: %w", err)
+		}
+
+		return nil
+	}
+
+	attempts := message.Attempts + 1
+	update := map[string]any{
+		"attempts":        attempts,
+		"last_error":      sendErr.Error(),
+		"next_attempt_at": time.Now().UTC().Add(backoff(d.baseBackoff, attempts)),
+	}
+
+	if attempts >= d.maxAttempts {
+		update["status"] = models.OutboxStatusDead
+
+		slog.Error("OutboxDispatcher: message is dead",
+			"id", message.ID, "kind", message.Notification.Kind, "attempts", attempts, "err", sendErr.Error(),
+		)
+	} else {
+		slog.Warn("OutboxDispatcher: could not send message",
+			"id", message.ID, "kind", message.Notification.Kind, "attempts", attempts, "err", sendErr.Error(),
+		)
+	}
+
+	err = d.outboxRepo.Update(ctx, message.ID, update)
+	if err != nil {
+		return fmt.Errorf("could not record failed attempt: %w", err)
+	}
+
+	return nil
+}
+
+// locale prefers the language of the request that queued the email, then the language
+// the recipient last used on the site.
+func (d *dispatcher) locale(ctx context.Context, notification models.Notification) (i18n.Locale, error) {
+	if locale, ok := i18n.Parse(string(notification.Locale)); ok {
+		return locale, nil
+	}
+
+	contact, err := d.contactsRepo.GetByEmail(ctx, notification.Params.RecipientEmail)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return i18n.Default, nil
+		}
+
+		return "", fmt.Errorf("could not get contact %s: %w", notification.Params.RecipientEmail, err)
+	}
+
+	if locale, ok := i18n.Parse(string(contact.Language)); ok {
+		return locale, nil
+	}
+
+	return i18n.Default, nil
+}
+
+func (d *dispatcher) send(locale i18n.Locale, notification models.Notification) error {
+	params := notification.Params
+
+	switch notification.Kind {
+	case models.NotificationPassActivation:
+		return d.notifier.NotifyPassActivation(locale, params.RecipientEmail, params.PassSlots)
+	case models.NotificationConfirmationLink:
+		return d.notifier.NotifyConfirmationLink(locale, params, notification.Link)
+	case models.NotificationBookingConfirmation:
+		return d.notifier.NotifyBookingConfirmation(locale, params, notification.Link)
+	case models.NotificationBookingCancellation:
+		return d.notifier.NotifyBookingCancellation(locale, params)
+	case models.NotificationBookingMoved:
+		return d.notifier.NotifyBookingMoved(locale, params, notification.Previous, notification.Link)
+	case models.NotificationClassUpdate:
+		return d.notifier.NotifyClassUpdate(locale, params, notification.Change)
+	case models.NotificationInstructorSubstitution:
+		return d.notifier.NotifyInstructorSubstitution(locale, params, notification.Previous.Instructor)
+	case models.NotificationClassCancellation:
+		return d.notifier.NotifyClassCancellation(locale, params, notification.Message)
+	case models.NotificationBookingReminder:
+		return d.notifier.NotifyBookingReminder(locale, params, notification.Link)
+	case models.NotificationWaitlistSpotAvailable:
+		return d.notifier.NotifyWaitlistSpotAvailable(locale, params, notification.Link)
+	case models.NotificationStudentLoginLink:
+		return d.notifier.NotifyStudentLoginLink(locale, params.RecipientEmail, notification.Link)
+	default:
+		return fmt.Errorf("unknown notification kind: %s", notification.Kind)
+	}
+}
+
+// backoff doubles the delay with every attempt: base, 2*base, 4*base... up to maxBackoff.
+func backoff(base time.Duration, attempts int) time.Duration {
+	delay := base
+
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+
+	return delay
+}
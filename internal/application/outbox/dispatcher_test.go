@@ -0,0 +1,226 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"main/internal/domain/models"
+	"main/internal/domain/notifier"
+	"main/internal/domain/repositories"
+	"main/internal/infrastructure/repository/repositorytest"
+	"main/pkg/i18n"
+)
+
+func TestBackoff(t *testing.T) {
+	base := 30 * time.Second
+
+	tests := []struct {
+		name     string
+		attempts int
+		want     time.Duration
+	}{
+		{
+			name:     "first attempt waits base",
+			attempts: 1,
+			want:     30 * time.Second,
+		},
+		{
+			name:     "third attempt waits four times base",
+			attempts: 3,
+			want:     2 * time.Minute,
+		},
+		{
+			name:     "many attempts are capped",
+			attempts: 100,
+			want:     maxBackoff,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := backoff(base, tt.attempts)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+const (
+	failingEmail       = "failing@example.com"
+	unknownLocaleEmail = "unknown-locale@example.com"
+)
+
+// fakeNotifier sends only student login links and fails those sent to failingEmail.
+type fakeNotifier struct {
+	notifier.INotifier
+
+	sent []string
+}
+
+func (n *fakeNotifier) NotifyStudentLoginLink(_ i18n.Locale, email, _ string) error {
+	if email == failingEmail {
+		return errors.New("smtp is down")
+	}
+
+	n.sent = append(n.sent, email)
+
+	return nil
+}
+
+// brokenContacts fails to look up the language of unknownLocaleEmail.
+type brokenContacts struct {
+	repositories.IContacts
+}
+
+func (c brokenContacts) GetByEmail(ctx context.Context, email string) (models.Contact, error) {
+	if email == unknownLocaleEmail {
+		return models.Contact{}, errors.New("database is locked")
+	}
+
+	return c.IContacts.GetByEmail(ctx, email)
+}
+
+func TestDispatchDue(t *testing.T) {
+	const (
+		maxAttempts = 3
+		baseBackoff = time.Minute
+	)
+
+	tests := []struct {
+		name         string
+		email        string
+		attempts     int
+		wantErr      bool
+		wantSent     bool
+		wantStatus   models.OutboxStatus
+		wantAttempts int
+		wantBackoff  time.Duration
+	}{
+		{
+			name:         "sent message is marked as sent",
+			email:        "anna@example.com",
+			wantSent:     true,
+			wantStatus:   models.OutboxStatusSent,
+			wantAttempts: 1,
+		},
+		{
+			name:         "failed message is retried with backoff",
+			email:        failingEmail,
+			attempts:     1,
+			wantStatus:   models.OutboxStatusPending,
+			wantAttempts: 2,
+			wantBackoff:  2 * baseBackoff,
+		},
+		{
+			name:         "message failing on the last attempt is dead",
+			email:        failingEmail,
+			attempts:     maxAttempts - 1,
+			wantStatus:   models.OutboxStatusDead,
+			wantAttempts: maxAttempts,
+			wantBackoff:  4 * baseBackoff,
+		},
+		{
+			name:         "message without locale stays due",
+			email:        unknownLocaleEmail,
+			wantErr:      true,
+			wantStatus:   models.OutboxStatusPending,
+			wantAttempts: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos, _ := repositorytest.OpenSQLite(t)
+
+			// every batch also has a message which is sent, whatever happens to the other
+			for _, email := range []string{tt.email, "bob@example.com"} {
+				err := repos.Outbox.Enqueue(ctx, models.Notification{
+					Kind:   models.NotificationStudentLoginLink,
+					Params: models.NotifierParams{RecipientEmail: email},
+				})
+				if err != nil {
+					t.Fatalf("could not enqueue notification: %v", err)
+				}
+			}
+
+			message := outboxMessageTo(t, repos, tt.email)
+
+			err := repos.Outbox.Update(ctx, message.ID, map[string]any{"attempts": tt.attempts})
+			if err != nil {
+				t.Fatalf("could not update outbox message: %v", err)
+			}
+
+			fake := &fakeNotifier{}
+			d := NewDispatcher(
+				repos.Outbox, brokenContacts{repos.Contacts}, fake, time.Minute, maxAttempts, baseBackoff,
+			)
+
+			before := time.Now().UTC()
+
+			err = d.DispatchDue(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DispatchDue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got, err := repos.Outbox.Get(ctx, message.ID)
+			if err != nil {
+				t.Fatalf("could not get outbox message: %v", err)
+			}
+
+			if got.Status != tt.wantStatus || got.Attempts != tt.wantAttempts {
+				t.Errorf("status = %s after %d attempts, want %s after %d",
+					got.Status, got.Attempts, tt.wantStatus, tt.wantAttempts)
+			}
+
+			if (got.SentAt != nil) != tt.wantSent {
+				t.Errorf("sentAt = %v, want sent %v", got.SentAt, tt.wantSent)
+			}
+
+			if tt.wantBackoff > 0 {
+				delay := got.NextAttemptAt.Sub(before)
+				if delay < tt.wantBackoff || delay > tt.wantBackoff+time.Minute {
+					t.Errorf("next attempt in %v, want %v", delay, tt.wantBackoff)
+				}
+
+				if got.LastError == nil {
+					t.Error("last error is not recorded")
+				}
+			}
+
+			if other := outboxMessageTo(t, repos, "bob@example.com"); other.Status != models.OutboxStatusSent {
+				t.Errorf("other message is %s, want sent", other.Status)
+			}
+		})
+	}
+}
+
+func outboxMessageTo(t *testing.T, repos repositories.Repositories, email string) models.OutboxMessage {
+	t.Helper()
+
+	var messages []models.OutboxMessage
+
+	for _, status := range []models.OutboxStatus{
+		models.OutboxStatusPending, models.OutboxStatusSent, models.OutboxStatusDead,
+	} {
+		withStatus, err := repos.Outbox.ListByStatus(context.Background(), status)
+		if err != nil {
+			t.Fatalf("could not list outbox messages: %v", err)
+		}
+
+		messages = append(messages, withStatus...)
+	}
+
+	for _, message := range messages {
+		if message.Notification.Params.RecipientEmail == email {
+			return message
+		}
+	}
+
+	t.Fatalf("no outbox message to %s", email)
+
+	return models.OutboxMessage{}
+}
@@ -2,41 +2,61 @@ package passes
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"main/internal/domain/errs/api"
 	"main/internal/domain/models"
-	"main/internal/domain/notifier"
 	"main/internal/domain/repositories"
 	"main/internal/domain/services"
+	"main/internal/infrastructure/errs"
 
 	"github.com/google/uuid"
 )
 
 type service struct {
+	unitOfWork   repositories.IUnitOfWork
 	passesRepo   repositories.IPasses
 	bookingsRepo repositories.IBookings
-	notifier     notifier.INotifier
+	productsRepo repositories.IProducts
 	passManager  services.IPassManager
+	passValidity time.Duration
 }
 
+// NewService creates passes service, passValidity is used for passes activated without
+// an explicit expiry date, zero means such passes never expire.
 func NewService(
+	unitOfWork repositories.IUnitOfWork,
 	passesRepo repositories.IPasses,
 	bookingsRepo repositories.IBookings,
-	notifier notifier.INotifier,
+	productsRepo repositories.IProducts,
 	passManager services.IPassManager,
+	passValidity time.Duration,
 ) *service {
 	return &service{
+		unitOfWork:   unitOfWork,
 		passesRepo:   passesRepo,
 		bookingsRepo: bookingsRepo,
-		notifier:     notifier,
+		productsRepo: productsRepo,
 		passManager:  passManager,
+		passValidity: passValidity,
 	}
 }
 
 func (s *service) ActivatePass(
 	ctx context.Context, params models.PassActivationParams,
 ) (models.PassActivation, error) {
+	params, passValidity, err := s.applyProduct(ctx, params)
+	if err != nil {
+		return models.PassActivation{}, err
+	}
+
+	if params.TotalSlots < 1 {
+		return models.PassActivation{},
+			api.ErrValidation(fmt.Errorf("totalSlots: %d, give totalSlots or a productID", params.TotalSlots))
+	}
+
 	if params.InitialAssignedSlots > params.TotalSlots {
 		return models.PassActivation{},
 			api.ErrValidation(
@@ -46,59 +66,288 @@ func (s *service) ActivatePass(
 			)
 	}
 
-	pass, err := s.passesRepo.Insert(
-		ctx,
-		params.Email,
-		params.TotalSlots,
-	)
+	validFrom := time.Now().UTC()
+	if params.ValidFrom != nil {
+		validFrom = params.ValidFrom.UTC()
+	}
+
+	validUntil := params.ValidUntil
+	if validUntil == nil && passValidity > 0 {
+		defaultValidUntil := validFrom.Add(passValidity)
+		validUntil = &defaultValidUntil
+	}
+
+	if validUntil != nil && !validUntil.After(validFrom) {
+		return models.PassActivation{},
+			api.ErrValidation(fmt.Errorf("validUntil: %v must be after validFrom: %v", *validUntil, validFrom))
+	}
+
+	var passActivation models.PassActivation
+
+	err = s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		pass, err := repos.Passes.Insert(ctx, models.Pass{
+			Email:      params.Email,
+			TotalSlots: params.TotalSlots,
+			ValidFrom:  validFrom,
+			ValidUntil: validUntil,
+			ProductID:  params.ProductID,
+		})
+		if err != nil {
+			return fmt.Errorf("could not insert pass for %s: %w", params.Email, err)
+		}
+
+		bookingsToAssignToPass := make([]models.Booking, 0, params.InitialAssignedSlots)
+		bookingIDsAssignedToPass := make([]uuid.UUID, 0, params.InitialAssignedSlots)
+
+		// user may want to add one or more existing future bookings - system needs to assign those to Pass
+		if params.InitialAssignedSlots > 0 {
+			bookingsToAssignToPass, err = repos.Bookings.ListWithoutPassByEmail(
+				ctx, params.Email, params.InitialAssignedSlots,
+			)
+			if err != nil {
+				return fmt.Errorf("could not list bookings for email %s: %w", params.Email, err)
+			}
+
+			if params.InitialAssignedSlots != len(bookingsToAssignToPass) {
+				return api.ErrValidation(
+					fmt.Errorf("number of initialUsedSlots should be exactly equal to number of bookingsToAssignToPass: %d != %d",
+						params.InitialAssignedSlots,
+						len(bookingsToAssignToPass),
+					),
+				)
+			}
+
+			for _, booking := range bookingsToAssignToPass {
+				err = repos.Bookings.Update(ctx, booking.ID, map[string]any{
+					"pass_id": pass.ID,
+				})
+				if err != nil {
+					return fmt.Errorf("could not update booking %s with pass_id %d: %w", booking.ID, pass.ID, err)
+				}
+
+				bookingIDsAssignedToPass = append(bookingIDsAssignedToPass, booking.ID)
+			}
+		}
+
+		passSlots := s.passManager.BuildPassSlots(bookingsToAssignToPass, params.TotalSlots)
+
+		err = repos.Outbox.Enqueue(ctx, models.Notification{
+			Kind: models.NotificationPassActivation,
+			Params: models.NotifierParams{
+				RecipientEmail: params.Email,
+				PassSlots:      passSlots,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("could not enqueue pass activation with %v: %w", pass, err)
+		}
+
+		passActivation = models.PassActivation{
+			Pass:               pass,
+			BookingIDsAssigned: bookingIDsAssignedToPass,
+		}
+
+		return nil
+	})
 	if err != nil {
-		return models.PassActivation{}, fmt.Errorf("could not insert pass for %s: %w", params.Email, err)
+		return models.PassActivation{}, fmt.Errorf("activate pass transaction failed: %w", err)
+	}
+
+	return passActivation, nil
+}
+
+// applyProduct fills the params in from the product the pass is sold as and returns the
+// validity of passes without an explicit expiry date.
+func (s *service) applyProduct(
+	ctx context.Context, params models.PassActivationParams,
+) (models.PassActivationParams, time.Duration, error) {
+	if !params.ProductID.Exists() {
+		return params, s.passValidity, nil
 	}
 
-	bookingsToAssignToPass := make([]models.Booking, 0, params.InitialAssignedSlots)
-	bookingIDsAssignedToPass := make([]uuid.UUID, 0, params.InitialAssignedSlots)
+	productID := params.ProductID.Get()
+
+	product, err := s.productsRepo.Get(ctx, productID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return params, 0, api.ErrNotFound(fmt.Errorf("product %s not found", productID))
+		}
 
-	// user may want to add one or more existing future bookings - system needs to assign those to Pass
-	if params.InitialAssignedSlots > 0 {
-		bookingsToAssignToPass, err = s.bookingsRepo.ListWithoutPassByEmail(
-			ctx, params.Email, params.InitialAssignedSlots,
+		return params, 0, fmt.Errorf("could not get product %s: %w", productID, err)
+	}
+
+	if product.Kind != models.ProductPass {
+		return params, 0, api.ErrValidation(fmt.Errorf("product %s is a %s, not a pass", productID, product.Kind))
+	}
+
+	if params.TotalSlots == 0 {
+		params.TotalSlots = product.TotalSlots
+	}
+
+	if params.TotalSlots != product.TotalSlots {
+		return params, 0, api.ErrValidation(
+			fmt.Errorf("totalSlots: %d does not match %d slots of product %s",
+				params.TotalSlots, product.TotalSlots, productID),
 		)
+	}
+
+	if product.ValidityDays > 0 {
+		return params, time.Duration(product.ValidityDays) * 24 * time.Hour, nil
+	}
+
+	return params, s.passValidity, nil
+}
+
+func (s *service) ListPasses(
+	ctx context.Context, filter models.PassFilter,
+) ([]models.PassWithSlots, error) {
+	passes, err := s.passesRepo.List(ctx, filter.Email)
+	if err != nil {
+		return nil, fmt.Errorf("could not list passes: %w", err)
+	}
+
+	result := make([]models.PassWithSlots, 0, len(passes))
+	now := time.Now()
+
+	for _, pass := range passes {
+		passWithSlots, err := s.withSlots(ctx, pass)
 		if err != nil {
-			return models.PassActivation{},
-				fmt.Errorf("could not list bookings for email %s: %w", params.Email, err)
+			return nil, fmt.Errorf("could not build slots for pass %d: %w", pass.ID, err)
 		}
 
-		if params.InitialAssignedSlots != len(bookingsToAssignToPass) {
-			return models.PassActivation{}, api.ErrValidation(
-				fmt.Errorf("number of initialUsedSlots should be exactly equal to number of bookingsToAssignToPass: %d != %d",
-					params.InitialAssignedSlots,
-					len(bookingsToAssignToPass),
-				),
-			)
+		if filter.Matches(passWithSlots, now) {
+			result = append(result, passWithSlots)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *service) GetPass(ctx context.Context, id int) (models.PassWithSlots, error) {
+	pass, err := s.getPass(ctx, s.passesRepo, id)
+	if err != nil {
+		return models.PassWithSlots{}, err
+	}
+
+	passWithSlots, err := s.withSlots(ctx, pass)
+	if err != nil {
+		return models.PassWithSlots{}, fmt.Errorf("could not build slots for pass %d: %w", id, err)
+	}
+
+	return passWithSlots, nil
+}
+
+// ExtendPass moves the expiry date of the pass by the given number of days.
+func (s *service) ExtendPass(ctx context.Context, id int, days int) (models.PassWithSlots, error) {
+	if days <= 0 {
+		return models.PassWithSlots{}, api.ErrValidation(fmt.Errorf("days must be positive: %d", days))
+	}
+
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		pass, err := s.getPass(ctx, repos.Passes, id)
+		if err != nil {
+			return err
+		}
+
+		if pass.ValidUntil == nil {
+			return api.ErrValidation(fmt.Errorf("pass %d has no expiry date", id))
+		}
+
+		err = repos.Passes.Update(ctx, id, map[string]any{
+			"valid_until": pass.ValidUntil.AddDate(0, 0, days),
+		})
+		if err != nil {
+			return fmt.Errorf("could not update pass %d: %w", id, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.PassWithSlots{}, fmt.Errorf("extend pass transaction failed: %w", err)
+	}
+
+	return s.GetPass(ctx, id)
+}
+
+// FreezePass stops the pass from being used between params.StartsAt and params.EndsAt.
+// The expiry date is moved by the length of the freeze so no days are lost.
+func (s *service) FreezePass(
+	ctx context.Context, id int, params models.PassFreezeParams,
+) (models.PassWithSlots, error) {
+	if !params.EndsAt.After(params.StartsAt) {
+		return models.PassWithSlots{}, api.ErrValidation(
+			fmt.Errorf("endsAt: %v must be after startsAt: %v", params.EndsAt, params.StartsAt),
+		)
+	}
+
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		pass, err := s.getPass(ctx, repos.Passes, id)
+		if err != nil {
+			return err
 		}
 
-		for _, booking := range bookingsToAssignToPass {
-			err = s.bookingsRepo.Update(ctx, booking.ID, map[string]any{
-				"pass_id": pass.ID,
+		if pass.IsExpiredAt(params.StartsAt) {
+			return api.ErrValidation(fmt.Errorf("pass %d expires before the freeze starts", id))
+		}
+
+		for _, freeze := range pass.Freezes {
+			if params.StartsAt.Before(freeze.EndsAt) && freeze.StartsAt.Before(params.EndsAt) {
+				return api.ErrValidation(
+					fmt.Errorf("freeze overlaps with freeze %d of pass %d", freeze.ID, id),
+				)
+			}
+		}
+
+		_, err = repos.Passes.InsertFreeze(ctx, models.PassFreeze{
+			PassID:   id,
+			StartsAt: params.StartsAt.UTC(),
+			EndsAt:   params.EndsAt.UTC(),
+			Reason:   params.Reason,
+		})
+		if err != nil {
+			return fmt.Errorf("could not insert freeze for pass %d: %w", id, err)
+		}
+
+		if pass.ValidUntil != nil {
+			err = repos.Passes.Update(ctx, id, map[string]any{
+				"valid_until": pass.ValidUntil.Add(params.EndsAt.Sub(params.StartsAt)),
 			})
 			if err != nil {
-				return models.PassActivation{},
-					fmt.Errorf("could not update booking %s with pass_id %d: %w", booking.ID, pass.ID, err)
+				return fmt.Errorf("could not update pass %d: %w", id, err)
 			}
+		}
 
-			bookingIDsAssignedToPass = append(bookingIDsAssignedToPass, booking.ID)
+		return nil
+	})
+	if err != nil {
+		return models.PassWithSlots{}, fmt.Errorf("freeze pass transaction failed: %w", err)
+	}
+
+	return s.GetPass(ctx, id)
+}
+
+func (s *service) getPass(ctx context.Context, passesRepo repositories.IPasses, id int) (models.Pass, error) {
+	pass, err := passesRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return models.Pass{}, api.ErrNotFound(fmt.Errorf("pass %d not found", id))
 		}
+
+		return models.Pass{}, fmt.Errorf("could not get pass %d: %w", id, err)
 	}
 
-	passSlots := s.passManager.BuildPassSlots(bookingsToAssignToPass, params.TotalSlots)
+	return pass, nil
+}
 
-	err = s.notifier.NotifyPassActivation(params.Email, passSlots)
+func (s *service) withSlots(ctx context.Context, pass models.Pass) (models.PassWithSlots, error) {
+	bookings, err := s.bookingsRepo.ListByPassID(ctx, pass.ID)
 	if err != nil {
-		return models.PassActivation{}, fmt.Errorf("could notify pass activation with %v: %w", pass, err)
+		return models.PassWithSlots{}, fmt.Errorf("could not list bookings by passID %d: %w", pass.ID, err)
 	}
 
-	return models.PassActivation{
-		Pass:               pass,
-		BookingIDsAssigned: bookingIDsAssignedToPass,
+	return models.PassWithSlots{
+		Pass:     pass,
+		Bookings: bookings,
+		Slots:    s.passManager.BuildPassSlots(bookings, pass.TotalSlots),
 	}, nil
 }
@@ -0,0 +1,395 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"main/internal/domain/errs/api"
+	viewErrors "main/internal/domain/errs/view"
+	"main/internal/domain/models"
+	"main/internal/domain/payments"
+	"main/internal/domain/repositories"
+	"main/internal/domain/services"
+	"main/internal/infrastructure/errs"
+	"main/pkg/i18n"
+	"main/pkg/optional"
+
+	"github.com/google/uuid"
+)
+
+type service struct {
+	unitOfWork         repositories.IUnitOfWork
+	paymentsRepo       repositories.IPayments
+	productsRepo       repositories.IProducts
+	provider           payments.IPaymentProvider
+	passesServiceFor   func(repos repositories.Repositories) services.IPassesService
+	bookingsServiceFor func(repos repositories.Repositories) services.IBookingsService
+	bookingPolicy      services.IBookingPolicy
+	domainAddr         string
+}
+
+// NewService takes passesServiceFor and bookingsServiceFor to give the pass or the booking
+// in the same transaction as the payment is marked paid.
+func NewService(
+	unitOfWork repositories.IUnitOfWork,
+	paymentsRepo repositories.IPayments,
+	productsRepo repositories.IProducts,
+	provider payments.IPaymentProvider,
+	passesServiceFor func(repos repositories.Repositories) services.IPassesService,
+	bookingsServiceFor func(repos repositories.Repositories) services.IBookingsService,
+	bookingPolicy services.IBookingPolicy,
+	domainAddr string,
+) *service {
+	return &service{
+		unitOfWork:         unitOfWork,
+		paymentsRepo:       paymentsRepo,
+		productsRepo:       productsRepo,
+		provider:           provider,
+		passesServiceFor:   passesServiceFor,
+		bookingsServiceFor: bookingsServiceFor,
+		bookingPolicy:      bookingPolicy,
+		domainAddr:         domainAddr,
+	}
+}
+
+func (s *service) StartPassCheckout(
+	ctx context.Context, params models.PassPurchaseParams,
+) (models.Checkout, error) {
+	product, err := s.productsRepo.Get(ctx, params.ProductID)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return models.Checkout{}, fmt.Errorf("could not get product %s: %w", params.ProductID, err)
+	}
+
+	if err != nil || !product.Active || product.Kind != models.ProductPass {
+		return models.Checkout{}, viewErrors.ErrPassNotOffered(
+			fmt.Errorf("product %s is not an offered pass", params.ProductID),
+		)
+	}
+
+	locale, _ := i18n.FromContext(ctx)
+
+	payment := models.Payment{
+		ID:         uuid.New(),
+		Kind:       models.PaymentKindPass,
+		Status:     models.PaymentPending,
+		Provider:   s.provider.Name(),
+		Email:      params.Email,
+		TotalSlots: product.TotalSlots,
+		ProductID:  optional.Of(product.ID),
+		Amount:     product.Price,
+		Currency:   product.Currency,
+		Language:   locale,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	err = s.paymentsRepo.Insert(ctx, payment)
+	if err != nil {
+		return models.Checkout{}, fmt.Errorf("could not insert payment for %s: %w", params.Email, err)
+	}
+
+	return s.createCheckout(ctx, payment)
+}
+
+func (s *service) StartDropInCheckout(
+	ctx context.Context, params models.DropInPurchaseParams,
+) (models.Checkout, error) {
+	locale, _ := i18n.FromContext(ctx)
+
+	payment := models.Payment{
+		ID:        uuid.New(),
+		Kind:      models.PaymentKindDropIn,
+		Status:    models.PaymentPending,
+		Provider:  s.provider.Name(),
+		Email:     params.Email,
+		FirstName: params.FirstName,
+		LastName:  params.LastName,
+		ClassID:   optional.Of(params.ClassID),
+		Language:  locale,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		product, err := dropInProduct(ctx, repos)
+		if err != nil {
+			return fmt.Errorf("could not get drop-in product: %w", err)
+		}
+
+		payment.ProductID = optional.Of(product.ID)
+		payment.Amount = product.Price
+		payment.Currency = product.Currency
+
+		class, err := repos.Classes.Get(ctx, params.ClassID)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return viewErrors.ErrClassNotFound(fmt.Errorf("class %s not found", params.ClassID))
+			}
+
+			return fmt.Errorf("could not get class %s: %w", params.ClassID, err)
+		}
+
+		// the inserted payment holds the spot until the checkout expires
+		err = s.checkDropInAvailability(ctx, repos, class, params.Email)
+		if err != nil {
+			return fmt.Errorf("class %s not available: %w", params.ClassID, err)
+		}
+
+		err = repos.Payments.Insert(ctx, payment)
+		if err != nil {
+			return fmt.Errorf("could not insert payment for %s: %w", params.Email, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.Checkout{}, fmt.Errorf("start drop-in checkout transaction failed: %w", err)
+	}
+
+	return s.createCheckout(ctx, payment)
+}
+
+// HandleWebhook applies a payment event of the provider. Events are delivered at least
+// once, each status change of the payment is claimed once, so repeated events are no-ops.
+func (s *service) HandleWebhook(ctx context.Context, payload []byte, header http.Header) error {
+	event, err := s.provider.ParseWebhook(payload, header)
+	if err != nil {
+		return fmt.Errorf("could not parse webhook: %w", err)
+	}
+
+	payment, err := s.paymentsRepo.GetByProviderRef(ctx, event.ProviderRef)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return api.ErrNotFound(fmt.Errorf("payment with provider ref %s not found", event.ProviderRef))
+		}
+
+		return fmt.Errorf("could not get payment by provider ref %s: %w", event.ProviderRef, err)
+	}
+
+	switch event.Status {
+	case models.PaymentPaid:
+		return s.fulfil(ctx, payment, event)
+	case models.PaymentFailed:
+		err = s.paymentsRepo.UpdateStatus(ctx, payment.ID, models.PaymentPending, models.PaymentFailed)
+		if err != nil && !errors.Is(err, errs.ErrNoRowsAffected) {
+			return fmt.Errorf("could not mark payment %s as failed: %w", payment.ID, err)
+		}
+
+		return nil
+	default:
+		slog.Info("PaymentWebhook: ignored event",
+			slog.String("event_id", event.EventID),
+			slog.String("status", string(event.Status)),
+		)
+
+		return nil
+	}
+}
+
+func (s *service) GetPayment(ctx context.Context, id uuid.UUID) (models.Payment, error) {
+	payment, err := s.paymentsRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return models.Payment{}, viewErrors.ErrPaymentNotFound(fmt.Errorf("payment %s not found", id))
+		}
+
+		return models.Payment{}, fmt.Errorf("could not get payment %s: %w", id, err)
+	}
+
+	return payment, nil
+}
+
+func (s *service) ListPayments(ctx context.Context) ([]models.Payment, error) {
+	payments, err := s.paymentsRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+func (s *service) createCheckout(ctx context.Context, payment models.Payment) (models.Checkout, error) {
+	returnURL := fmt.Sprintf("%s/payments/%s", s.domainAddr, payment.ID)
+
+	checkout, err := s.provider.CreateCheckout(ctx, payment, returnURL)
+	if err != nil {
+		return models.Checkout{}, fmt.Errorf("could not create checkout for payment %s: %w", payment.ID, err)
+	}
+
+	err = s.paymentsRepo.Update(ctx, payment.ID, map[string]any{"provider_ref": checkout.ProviderRef})
+	if err != nil {
+		return models.Checkout{}, fmt.Errorf("could not save provider ref of payment %s: %w", payment.ID, err)
+	}
+
+	return checkout, nil
+}
+
+// fulfil gives the student what they paid for. The payment is marked paid in the same
+// transaction, so on errors it stays pending and the provider retries the event. A purchase
+// which can not be fulfilled anymore stays unfulfilled for the admin.
+func (s *service) fulfil(ctx context.Context, payment models.Payment, event models.PaymentEvent) error {
+	if payment.Language != "" {
+		ctx = i18n.WithLocale(ctx, payment.Language)
+	}
+
+	var alreadyProcessed bool
+
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		err := repos.Payments.UpdateStatus(ctx, payment.ID, models.PaymentPending, models.PaymentPaid)
+		if err != nil {
+			if errors.Is(err, errs.ErrNoRowsAffected) {
+				alreadyProcessed = true
+
+				return nil
+			}
+
+			return fmt.Errorf("could not mark payment %s as paid: %w", payment.ID, err)
+		}
+
+		update, err := s.grant(ctx, repos, payment)
+		if err != nil {
+			return fmt.Errorf("could not fulfil payment %s: %w", payment.ID, err)
+		}
+
+		err = repos.Payments.Update(ctx, payment.ID, update)
+		if err != nil {
+			return fmt.Errorf("could not save fulfilment of payment %s: %w", payment.ID, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		var apiError *api.APIError
+		var businessError *viewErrors.BusinessError
+
+		if errors.As(err, &apiError) || errors.As(err, &businessError) {
+			return s.markUnfulfilled(ctx, payment, err)
+		}
+
+		return fmt.Errorf("fulfil payment transaction failed: %w", err)
+	}
+
+	if alreadyProcessed {
+		slog.Info("PaymentWebhook: payment already processed",
+			slog.String("event_id", event.EventID),
+			slog.String("payment_id", payment.ID.String()),
+		)
+	}
+
+	return nil
+}
+
+// markUnfulfilled keeps a paid purchase which can not be given, with the reason for the admin.
+func (s *service) markUnfulfilled(ctx context.Context, payment models.Payment, cause error) error {
+	reason := cause.Error()
+
+	slog.Warn("PaymentWebhook: paid purchase not fulfilled",
+		slog.String("payment_id", payment.ID.String()),
+		slog.String("reason", reason),
+	)
+
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		err := repos.Payments.UpdateStatus(
+			ctx, payment.ID, models.PaymentPending, models.PaymentUnfulfilled,
+		)
+		if err != nil {
+			return fmt.Errorf("could not mark payment %s as unfulfilled: %w", payment.ID, err)
+		}
+
+		err = repos.Payments.Update(ctx, payment.ID, map[string]any{"failure_reason": reason})
+		if err != nil {
+			return fmt.Errorf("could not save failure reason of payment %s: %w", payment.ID, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		// a concurrent delivery of the event has already processed the payment
+		if errors.Is(err, errs.ErrNoRowsAffected) {
+			return nil
+		}
+
+		return fmt.Errorf("mark payment unfulfilled transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+// grant activates the pass or confirms the booking, the returned update links it to the payment.
+func (s *service) grant(
+	ctx context.Context, repos repositories.Repositories, payment models.Payment,
+) (map[string]any, error) {
+	switch payment.Kind {
+	case models.PaymentKindPass:
+		passActivation, err := s.passesServiceFor(repos).ActivatePass(ctx, models.PassActivationParams{
+			Email:      payment.Email,
+			TotalSlots: payment.TotalSlots,
+			ProductID:  payment.ProductID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not activate pass for %s: %w", payment.Email, err)
+		}
+
+		return map[string]any{"pass_id": passActivation.Pass.ID}, nil
+	case models.PaymentKindDropIn:
+		bookingsService := s.bookingsServiceFor(repos)
+
+		booking, err := bookingsService.CreateConfirmedBooking(ctx, models.ConfirmedBookingParams{
+			ClassID:          payment.ClassID.Get(),
+			FirstName:        payment.FirstName,
+			LastName:         payment.LastName,
+			Email:            payment.Email,
+			SendConfirmation: true,
+			PaymentID:        optional.Of(payment.ID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not confirm booking for %s: %w", payment.Email, err)
+		}
+
+		return map[string]any{"booking_id": booking.ID}, nil
+	default:
+		return nil, fmt.Errorf("unknown payment kind %s", payment.Kind)
+	}
+}
+
+func (s *service) checkDropInAvailability(
+	ctx context.Context,
+	repos repositories.Repositories,
+	class models.Class,
+	email string,
+) error {
+	err := s.bookingsServiceFor(repos).EnsureSpotAvailable(ctx, class, email)
+	if err != nil {
+		return fmt.Errorf("could not book class %s: %w", class.ID, err)
+	}
+
+	bookingCount, err := repos.Bookings.CountForClassID(ctx, class.ID)
+	if err != nil {
+		return fmt.Errorf("could not count bookings for class %s: %w", class.ID, err)
+	}
+
+	err = s.bookingPolicy.CheckBookingWindow(class, bookingCount, time.Now())
+	if err != nil {
+		return fmt.Errorf("booking window closed: %w", err)
+	}
+
+	return nil
+}
+
+// dropInProduct is the drop-in of the price list, the cheapest one if there are more.
+func dropInProduct(ctx context.Context, repos repositories.Repositories) (models.Product, error) {
+	products, err := repos.Products.ListActive(ctx)
+	if err != nil {
+		return models.Product{}, fmt.Errorf("could not list products: %w", err)
+	}
+
+	for _, product := range products {
+		if product.Kind == models.ProductDropIn {
+			return product, nil
+		}
+	}
+
+	return models.Product{}, viewErrors.ErrDropInNotOffered(errors.New("no drop-in is offered"))
+}
@@ -0,0 +1,512 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"main/internal/application/bookings"
+	"main/internal/application/passes"
+	"main/internal/application/waitlist"
+	viewErrors "main/internal/domain/errs/view"
+	"main/internal/domain/models"
+	"main/internal/domain/payments"
+	"main/internal/domain/repositories"
+	"main/internal/domain/services"
+	"main/internal/infrastructure/generator/token"
+	"main/internal/infrastructure/payments/fake"
+	"main/internal/infrastructure/repository/repositorytest"
+	"main/pkg/optional"
+
+	"github.com/google/uuid"
+)
+
+const (
+	webhookSecret = "secret"
+	buyerEmail    = "anna@example.com"
+)
+
+// signingProvider is the fake provider, which also signs the webhooks of the tests.
+type signingProvider interface {
+	payments.IPaymentProvider
+	Sign(payload []byte) string
+}
+
+// brokenPasses saves the pass and fails afterwards, like a connection lost mid-transaction.
+type brokenPasses struct {
+	services.IPassesService
+	repos repositories.Repositories
+}
+
+func (p brokenPasses) ActivatePass(
+	ctx context.Context, params models.PassActivationParams,
+) (models.PassActivation, error) {
+	_, err := p.repos.Passes.Insert(ctx, models.Pass{
+		Email:      params.Email,
+		TotalSlots: params.TotalSlots,
+		ValidFrom:  time.Now().UTC(),
+		CreatedAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		return models.PassActivation{}, fmt.Errorf("could not insert pass: %w", err)
+	}
+
+	return models.PassActivation{}, errors.New("connection reset")
+}
+
+func TestHandleWebhook(t *testing.T) {
+	tests := []struct {
+		name             string
+		kind             models.PaymentKind
+		classFull        bool
+		deliveries       int
+		wantStatus       models.PaymentStatus
+		wantPasses       int
+		wantBooking      bool
+		wantNotification models.NotificationKind
+	}{
+		{
+			name:             "paid pass is activated",
+			kind:             models.PaymentKindPass,
+			deliveries:       1,
+			wantStatus:       models.PaymentPaid,
+			wantPasses:       1,
+			wantNotification: models.NotificationPassActivation,
+		},
+		{
+			name:             "paid drop-in is booked",
+			kind:             models.PaymentKindDropIn,
+			deliveries:       1,
+			wantStatus:       models.PaymentPaid,
+			wantBooking:      true,
+			wantNotification: models.NotificationBookingConfirmation,
+		},
+		{
+			name:             "repeated event activates the pass once",
+			kind:             models.PaymentKindPass,
+			deliveries:       3,
+			wantStatus:       models.PaymentPaid,
+			wantPasses:       1,
+			wantNotification: models.NotificationPassActivation,
+		},
+		{
+			name:             "repeated event books the drop-in once",
+			kind:             models.PaymentKindDropIn,
+			deliveries:       2,
+			wantStatus:       models.PaymentPaid,
+			wantBooking:      true,
+			wantNotification: models.NotificationBookingConfirmation,
+		},
+		{
+			name:       "drop-in of a class filled up during the checkout is unfulfilled",
+			kind:       models.PaymentKindDropIn,
+			classFull:  true,
+			deliveries: 2,
+			wantStatus: models.PaymentUnfulfilled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos, unitOfWork := repositorytest.OpenSQLite(t)
+			provider := fake.NewProvider(webhookSecret)
+			s := newTestService(repos, unitOfWork, provider, nil)
+
+			payment := insertPayment(t, repos, tt.kind, tt.classFull)
+
+			for range tt.deliveries {
+				if err := deliver(s, provider, payment, models.PaymentPaid); err != nil {
+					t.Fatalf("HandleWebhook() error = %v", err)
+				}
+			}
+
+			stored := assertPayment(t, repos, payment.ID, tt.wantStatus, tt.wantPasses, tt.wantBooking)
+
+			if tt.wantStatus == models.PaymentUnfulfilled && stored.FailureReason == nil {
+				t.Error("unfulfilled payment has no failure reason")
+			}
+
+			assertNotifications(t, repos, tt.wantNotification)
+		})
+	}
+}
+
+func TestHandleWebhookRetriesFailedFulfilment(t *testing.T) {
+	repos, unitOfWork := repositorytest.OpenSQLite(t)
+	provider := fake.NewProvider(webhookSecret)
+
+	broken := true
+	s := newTestService(repos, unitOfWork, provider, &broken)
+
+	payment := insertPayment(t, repos, models.PaymentKindPass, false)
+
+	err := deliver(s, provider, payment, models.PaymentPaid)
+	if err == nil {
+		t.Fatal("HandleWebhook() error = nil, want the provider to retry the event")
+	}
+
+	// the payment is not left paid without the pass it was paid for
+	assertPayment(t, repos, payment.ID, models.PaymentPending, 0, false)
+	assertNotifications(t, repos, "")
+
+	broken = false
+
+	if err = deliver(s, provider, payment, models.PaymentPaid); err != nil {
+		t.Fatalf("HandleWebhook() retry error = %v", err)
+	}
+
+	assertPayment(t, repos, payment.ID, models.PaymentPaid, 1, false)
+	assertNotifications(t, repos, models.NotificationPassActivation)
+}
+
+func TestHandleWebhookFailedPayment(t *testing.T) {
+	repos, unitOfWork := repositorytest.OpenSQLite(t)
+	provider := fake.NewProvider(webhookSecret)
+	s := newTestService(repos, unitOfWork, provider, nil)
+
+	payment := insertPayment(t, repos, models.PaymentKindDropIn, false)
+
+	if err := deliver(s, provider, payment, models.PaymentFailed); err != nil {
+		t.Fatalf("HandleWebhook() error = %v", err)
+	}
+
+	// a late paid event of a failed payment does not book the class
+	if err := deliver(s, provider, payment, models.PaymentPaid); err != nil {
+		t.Fatalf("HandleWebhook() error = %v", err)
+	}
+
+	assertPayment(t, repos, payment.ID, models.PaymentFailed, 0, false)
+	assertNotifications(t, repos, "")
+}
+
+func TestStartDropInCheckout(t *testing.T) {
+	tests := []struct {
+		name         string
+		noDropIn     bool
+		missingClass bool
+		// offeredTo and checkoutBy hold the single spot of the class before the buyer checks out
+		offeredTo  string
+		checkoutBy string
+		wantCode   *int
+	}{
+		{
+			name: "free spot",
+		},
+		{
+			name:      "spot offered to the buyer from the waitlist",
+			offeredTo: buyerEmail,
+		},
+		{
+			name:      "spot offered to someone else",
+			offeredTo: "bob@example.com",
+			wantCode:  code(viewErrors.SomeoneBookedClassFasterCode),
+		},
+		{
+			name:       "spot held by the checkout of someone else",
+			checkoutBy: "bob@example.com",
+			wantCode:   code(viewErrors.SomeoneBookedClassFasterCode),
+		},
+		{
+			name:     "no drop-in offered",
+			noDropIn: true,
+			wantCode: code(viewErrors.DropInNotOfferedCode),
+		},
+		{
+			name:         "class does not exist",
+			missingClass: true,
+			wantCode:     code(viewErrors.ClassNotFoundCode),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos, unitOfWork := repositorytest.OpenSQLite(t)
+			s := newTestService(repos, unitOfWork, fake.NewProvider(webhookSecret), nil)
+
+			if !tt.noDropIn {
+				insertDropInProduct(t, repos)
+			}
+
+			class := repositorytest.InsertClass(t, repos, time.Now().Add(48*time.Hour), 1)
+
+			if tt.offeredTo != "" {
+				offeredAt := time.Now().Add(-time.Minute).UTC()
+
+				err := repos.Waitlist.Insert(ctx, models.WaitlistEntry{
+					ID:        uuid.New(),
+					ClassID:   class.ID,
+					Email:     tt.offeredTo,
+					CreatedAt: offeredAt,
+					OfferedAt: &offeredAt,
+				})
+				if err != nil {
+					t.Fatalf("could not insert waitlist entry: %v", err)
+				}
+			}
+
+			if tt.checkoutBy != "" {
+				_, err := s.StartDropInCheckout(ctx, models.DropInPurchaseParams{
+					ClassID: class.ID,
+					Email:   tt.checkoutBy,
+				})
+				if err != nil {
+					t.Fatalf("StartDropInCheckout() of %s error = %v", tt.checkoutBy, err)
+				}
+			}
+
+			classID := class.ID
+			if tt.missingClass {
+				classID = uuid.New()
+			}
+
+			checkout, err := s.StartDropInCheckout(ctx, models.DropInPurchaseParams{
+				ClassID:   classID,
+				FirstName: "Anna",
+				LastName:  "Kowalska",
+				Email:     buyerEmail,
+			})
+			if !hasCode(err, tt.wantCode) {
+				t.Fatalf("StartDropInCheckout() error = %v, want code %v", err, tt.wantCode)
+			}
+
+			if tt.wantCode == nil {
+				assertPayment(t, repos, checkout.PaymentID, models.PaymentPending, 0, false)
+			}
+		})
+	}
+}
+
+// newTestService fulfils passes with brokenPasses while *broken is true.
+func newTestService(
+	repos repositories.Repositories,
+	unitOfWork repositories.IUnitOfWork,
+	provider signingProvider,
+	broken *bool,
+) *service {
+	tokenGenerator := token.NewGenerator()
+	passManager := &services.PassManager{}
+	bookingPolicy := services.NewBookingPolicy(200, 3, models.BookingRules{}, nil)
+
+	return NewService(
+		unitOfWork,
+		repos.Payments,
+		repos.Products,
+		provider,
+		func(repos repositories.Repositories) services.IPassesService {
+			if broken != nil && *broken {
+				return brokenPasses{repos: repos}
+			}
+
+			return passes.NewService(
+				repositories.InTransaction(repos),
+				repos.Passes,
+				repos.Bookings,
+				repos.Products,
+				passManager,
+				0,
+			)
+		},
+		func(repos repositories.Repositories) services.IBookingsService {
+			unitOfWork := repositories.InTransaction(repos)
+
+			return bookings.NewService(
+				unitOfWork,
+				repos.Bookings,
+				tokenGenerator,
+				passManager,
+				bookingPolicy,
+				waitlist.NewService(unitOfWork, tokenGenerator, ""),
+				"",
+			)
+		},
+		bookingPolicy,
+		"",
+	)
+}
+
+// insertPayment starts a checkout of kind, a drop-in is for a class that is full when classFull.
+func insertPayment(
+	t *testing.T, repos repositories.Repositories, kind models.PaymentKind, classFull bool,
+) models.Payment {
+	t.Helper()
+
+	ctx := context.Background()
+	product := models.Product{
+		ID:         uuid.New(),
+		Name:       "4 classes",
+		Kind:       models.ProductPass,
+		TotalSlots: 4,
+		Price:      20000,
+		Currency:   "PLN",
+		Active:     true,
+		CreatedAt:  time.Now().UTC(),
+		UpdatedAt:  time.Now().UTC(),
+	}
+
+	if err := repos.Products.Insert(ctx, product); err != nil {
+		t.Fatalf("could not insert product: %v", err)
+	}
+
+	id := uuid.New()
+	payment := models.Payment{
+		ID:          id,
+		Kind:        kind,
+		Status:      models.PaymentPending,
+		Provider:    "fake",
+		ProviderRef: "fake_" + id.String(),
+		Email:       buyerEmail,
+		FirstName:   "Anna",
+		LastName:    "Kowalska",
+		Amount:      product.Price,
+		Currency:    product.Currency,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	switch kind {
+	case models.PaymentKindPass:
+		payment.ProductID = optional.Of(product.ID)
+		payment.TotalSlots = product.TotalSlots
+	case models.PaymentKindDropIn:
+		class := repositorytest.InsertClass(t, repos, time.Now().Add(48*time.Hour), 1)
+		payment.ClassID = optional.Of(class.ID)
+
+		if classFull {
+			repositorytest.InsertBooking(t, repos, class.ID, "bob@example.com")
+		}
+	}
+
+	if err := repos.Payments.Insert(ctx, payment); err != nil {
+		t.Fatalf("could not insert payment: %v", err)
+	}
+
+	return payment
+}
+
+func insertDropInProduct(t *testing.T, repos repositories.Repositories) {
+	t.Helper()
+
+	err := repos.Products.Insert(context.Background(), models.Product{
+		ID:        uuid.New(),
+		Name:      "drop-in",
+		Kind:      models.ProductDropIn,
+		Price:     4000,
+		Currency:  "PLN",
+		Active:    true,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		t.Fatalf("could not insert product: %v", err)
+	}
+}
+
+func code(c int) *int {
+	return &c
+}
+
+func hasCode(err error, want *int) bool {
+	if want == nil {
+		return err == nil
+	}
+
+	var businessError *viewErrors.BusinessError
+
+	return errors.As(err, &businessError) && businessError.Code == *want
+}
+
+// deliver sends a signed webhook of the provider, each with its own event id.
+func deliver(
+	s *service, provider signingProvider, payment models.Payment, status models.PaymentStatus,
+) error {
+	payload := fmt.Appendf(nil, `{"event_id":"ev_%s","reference":"%s","status":"%s"}`,
+		uuid.NewString(), payment.ProviderRef, status)
+
+	header := http.Header{}
+	header.Set(fake.SignatureHeader, provider.Sign(payload))
+
+	return s.HandleWebhook(context.Background(), payload, header)
+}
+
+func assertPayment(
+	t *testing.T,
+	repos repositories.Repositories,
+	paymentID uuid.UUID,
+	wantStatus models.PaymentStatus,
+	wantPasses int,
+	wantBooking bool,
+) models.Payment {
+	t.Helper()
+
+	ctx := context.Background()
+
+	payment, err := repos.Payments.Get(ctx, paymentID)
+	if err != nil {
+		t.Fatalf("could not get payment: %v", err)
+	}
+
+	if payment.Status != wantStatus {
+		t.Errorf("payment is %s, want %s", payment.Status, wantStatus)
+	}
+
+	paid := wantStatus == models.PaymentPaid || wantStatus == models.PaymentUnfulfilled
+	if (payment.PaidAt != nil) != paid {
+		t.Errorf("payment paid at %v, want paid %v", payment.PaidAt, paid)
+	}
+
+	buyerPasses, err := repos.Passes.ListByEmail(ctx, buyerEmail, 10)
+	if err != nil {
+		t.Fatalf("could not list passes: %v", err)
+	}
+
+	if len(buyerPasses) != wantPasses {
+		t.Errorf("passes = %d, want %d", len(buyerPasses), wantPasses)
+	}
+
+	if payment.PassID.Exists() != (wantPasses > 0) {
+		t.Errorf("payment has pass %v, want %v", payment.PassID.Exists(), wantPasses > 0)
+	}
+
+	if payment.BookingID.Exists() != wantBooking {
+		t.Fatalf("payment has booking %v, want %v", payment.BookingID.Exists(), wantBooking)
+	}
+
+	if wantBooking {
+		booking, err := repos.Bookings.GetByID(ctx, payment.BookingID.Get())
+		if err != nil {
+			t.Fatalf("could not get booking: %v", err)
+		}
+
+		if !booking.PaymentID.Exists() || booking.PaymentID.Get() != paymentID {
+			t.Errorf("booking is paid with %v, want %s", booking.PaymentID, paymentID)
+		}
+	}
+
+	return payment
+}
+
+// assertNotifications checks the single notification of a fulfilled payment, none when want is empty.
+func assertNotifications(t *testing.T, repos repositories.Repositories, want models.NotificationKind) {
+	t.Helper()
+
+	messages, err := repos.Outbox.ListByStatus(context.Background(), models.OutboxStatusPending)
+	if err != nil {
+		t.Fatalf("could not list outbox messages: %v", err)
+	}
+
+	wantCount := 1
+	if want == "" {
+		wantCount = 0
+	}
+
+	if len(messages) != wantCount {
+		t.Fatalf("notifications = %d, want %d", len(messages), wantCount)
+	}
+
+	if wantCount == 1 && messages[0].Notification.Kind != want {
+		t.Errorf("notification kind = %v, want %v", messages[0].Notification.Kind, want)
+	}
+}
@@ -4,41 +4,41 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	viewErrors "main/internal/domain/errs/view"
 	"main/internal/domain/models"
-	"main/internal/domain/notifier"
 	"main/internal/domain/repositories"
 	"main/internal/domain/services"
 	"main/internal/infrastructure/errs"
+	"main/pkg/i18n"
 
 	"github.com/google/uuid"
 )
 
 const (
-	allowedTotalPendingBookingsLimit = 200
-	tokenLength                      = 32
-	deadlineBeforeClassStart         = 3 * time.Hour
+	tokenLength            = 32
+	pendingBookingLifetime = time.Hour
 )
 
 type service struct {
 	unitOfWork     repositories.IUnitOfWork
 	tokenGenerator services.ITokenGenerator
-	notifier       notifier.INotifier
+	bookingPolicy  services.IBookingPolicy
 	domainAddr     string
 }
 
 func NewService(
 	unitOfWork repositories.IUnitOfWork,
 	tokenGenerator services.ITokenGenerator,
-	notifier notifier.INotifier,
+	bookingPolicy services.IBookingPolicy,
 	domainAddr string,
 ) *service {
 	return &service{
 		unitOfWork:     unitOfWork,
 		tokenGenerator: tokenGenerator,
-		notifier:       notifier,
+		bookingPolicy:  bookingPolicy,
 		domainAddr:     domainAddr,
 	}
 }
@@ -47,28 +47,23 @@ func (s *service) CreatePendingBooking(
 	ctx context.Context,
 	pendingBookingParams models.PendingBookingParams,
 ) error {
-	var (
-		confirmationToken string
-		class             models.Class
-	)
-
 	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
-		err := s.ensurePendingBookingCreationAllowed(
-			ctx,
-			repos,
-			pendingBookingParams.ClassID,
-			pendingBookingParams.Email,
-		)
+		class, err := repos.Classes.Get(ctx, pendingBookingParams.ClassID)
+		if err != nil {
+			return fmt.Errorf("could not get class: %w", err)
+		}
+
+		err = s.ensurePendingBookingCreationAllowed(ctx, repos, class, pendingBookingParams.Email)
 		if err != nil {
 			return fmt.Errorf("pending booking creation not allowed: %w", err)
 		}
 
-		class, err = s.checkClassAvailability(ctx, repos, pendingBookingParams.ClassID)
+		err = s.checkClassAvailability(ctx, repos, class)
 		if err != nil {
 			return fmt.Errorf("class not available: %w", err)
 		}
 
-		confirmationToken, err = s.tokenGenerator.Generate(tokenLength)
+		confirmationToken, err := s.tokenGenerator.Generate(tokenLength)
 		if err != nil {
 			return fmt.Errorf("could not generate confirmation token: %w", err)
 		}
@@ -88,31 +83,67 @@ func (s *service) CreatePendingBooking(
 			return fmt.Errorf("could not insert pending booking: %w", err)
 		}
 
+		locale, _ := i18n.FromContext(ctx)
+
+		err = repos.Outbox.Enqueue(ctx, models.Notification{
+			Kind: models.NotificationConfirmationLink,
+			Params: models.NotifierParams{
+				RecipientEmail:     pendingBookingParams.Email,
+				RecipientFirstName: pendingBookingParams.FirstName,
+				StartTime:          class.StartTime,
+				Location:           class.Location,
+				Instructor:         class.Instructor,
+				Duration:           class.Duration,
+			},
+			Link:   fmt.Sprintf("%s/bookings?token=%s", s.domainAddr, confirmationToken),
+			Locale: locale,
+		})
+		if err != nil {
+			return fmt.Errorf("could not enqueue confirmation link: %w", err)
+		}
+
 		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("create pending booking transaction failed: %w", err)
 	}
 
-	err = s.notifier.NotifyConfirmationLink(
-		pendingBookingParams.Email,
-		pendingBookingParams.FirstName,
-		fmt.Sprintf("%s/bookings?token=%s", s.domainAddr, confirmationToken),
-		class.StartTime,
-	)
+	return nil
+}
+
+// CleanUpPendingBookings removes pending bookings whose confirmation link has expired.
+func (s *service) CleanUpPendingBookings(ctx context.Context) error {
+	var deleted int
+
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		var err error
+
+		deleted, err = repos.PendingBookings.DeleteCreatedBefore(
+			ctx, time.Now().UTC().Add(-pendingBookingLifetime),
+		)
+		if err != nil {
+			return fmt.Errorf("could not delete expired pending bookings: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("could not notify confirmation link: %w", err)
+		return fmt.Errorf("clean up pending bookings transaction failed: %w", err)
 	}
 
+	slog.Info("PendingBookingCleaner: cleaned up pending bookings", slog.Int("deleted", deleted))
+
 	return nil
 }
 
 func (s *service) ensurePendingBookingCreationAllowed(
 	ctx context.Context,
 	repos repositories.Repositories,
-	classID uuid.UUID,
+	class models.Class,
 	email string,
 ) error {
+	classID := class.ID
+
 	_, err := repos.Bookings.GetByEmailAndClassID(ctx, classID, email)
 	if err == nil {
 		return viewErrors.ErrBookingAlreadyExists(
@@ -131,8 +162,8 @@ func (s *service) ensurePendingBookingCreationAllowed(
 		return fmt.Errorf("could not list pending bookings: %w", err)
 	}
 
-	if len(pendingBookings) >= allowedTotalPendingBookingsLimit {
-		return fmt.Errorf("limit: %d of pending bookings exceeded", allowedTotalPendingBookingsLimit)
+	if len(pendingBookings) >= s.bookingPolicy.MaxPendingBookings() {
+		return fmt.Errorf("limit: %d of pending bookings exceeded", s.bookingPolicy.MaxPendingBookings())
 	}
 
 	var count int
@@ -143,7 +174,7 @@ func (s *service) ensurePendingBookingCreationAllowed(
 		}
 	}
 
-	if count >= 2 {
+	if count >= s.bookingPolicy.RulesFor(class.ClassLevel).MaxPendingBookingsPerEmail {
 		return viewErrors.ErrTooManyPendingBookings(
 			classID,
 			email,
@@ -151,35 +182,51 @@ func (s *service) ensurePendingBookingCreationAllowed(
 		)
 	}
 
+	bookings, err := repos.Bookings.ListByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("could not list bookings for %s: %w", email, err)
+	}
+
+	err = s.bookingPolicy.CheckActiveBookings(class, email, bookings, time.Now())
+	if err != nil {
+		return fmt.Errorf("booking limit reached: %w", err)
+	}
+
 	return nil
 }
 
 func (s *service) checkClassAvailability(
 	ctx context.Context,
 	repos repositories.Repositories,
-	classID uuid.UUID,
-) (models.Class, error) {
+	class models.Class,
+) error {
+	classID := class.ID
+
 	bookingCount, err := repos.Bookings.CountForClassID(ctx, classID)
 	if err != nil {
-		return models.Class{}, fmt.Errorf("could not count bookings for class %v: %w ", classID, err)
+		return fmt.Errorf("could not count bookings for class %v: %w ", classID, err)
 	}
 
-	class, err := repos.Classes.Get(ctx, classID)
+	// spots offered to people from the waitlist are held until the offer expires
+	offeredCount, err := repos.Waitlist.CountOfferedSince(
+		ctx, classID, time.Now().Add(-models.WaitlistOfferTTL),
+	)
 	if err != nil {
-		return models.Class{}, fmt.Errorf("could not get class: %w", err)
+		return fmt.Errorf("could not count waitlist offers for class %v: %w ", classID, err)
 	}
 
-	if bookingCount == class.MaxCapacity {
-		return models.Class{}, viewErrors.ErrClassFullyBooked(classID, fmt.Errorf("no spots left in class with id: %d", classID))
+	if bookingCount+offeredCount >= class.MaxCapacity {
+		return viewErrors.ErrClassFullyBooked(classID, fmt.Errorf("no spots left in class with id: %d", classID))
 	}
 
 	if class.StartTime.Before(time.Now()) {
-		return models.Class{}, viewErrors.ErrClassExpired(classID, fmt.Errorf("class %s has expired at %v", classID, class.StartTime))
+		return viewErrors.ErrClassExpired(classID, fmt.Errorf("class %s has expired at %v", classID, class.StartTime))
 	}
 
-	if bookingCount == 0 && time.Until(class.StartTime) < deadlineBeforeClassStart {
-		return models.Class{}, viewErrors.ErrTooLateToBook(classID, fmt.Errorf("class %s is empty and it is to late to book", class.ID))
+	err = s.bookingPolicy.CheckBookingWindow(class, bookingCount, time.Now())
+	if err != nil {
+		return fmt.Errorf("booking window closed: %w", err)
 	}
 
-	return class, nil
+	return nil
 }
@@ -0,0 +1,155 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"main/internal/domain/errs/api"
+	"main/internal/domain/models"
+	"main/internal/domain/repositories"
+	"main/internal/infrastructure/errs"
+
+	"github.com/google/uuid"
+)
+
+type service struct {
+	productsRepo repositories.IProducts
+}
+
+func NewService(productsRepo repositories.IProducts) *service {
+	return &service{
+		productsRepo: productsRepo,
+	}
+}
+
+func (s *service) ListProducts(ctx context.Context, onlyActive bool) ([]models.Product, error) {
+	list := s.productsRepo.List
+	if onlyActive {
+		list = s.productsRepo.ListActive
+	}
+
+	products, err := list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list products: %w", err)
+	}
+
+	return products, nil
+}
+
+func (s *service) GetProduct(ctx context.Context, id uuid.UUID) (models.Product, error) {
+	product, err := s.productsRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return models.Product{}, api.ErrNotFound(fmt.Errorf("product %s not found", id))
+		}
+
+		return models.Product{}, fmt.Errorf("could not get product %s: %w", id, err)
+	}
+
+	return product, nil
+}
+
+func (s *service) CreateProduct(ctx context.Context, params models.ProductParams) (models.Product, error) {
+	switch params.Kind {
+	case models.ProductPass:
+		if params.TotalSlots < 1 {
+			return models.Product{}, api.ErrValidation(
+				fmt.Errorf("totalSlots: %d, a pass needs at least one slot", params.TotalSlots),
+			)
+		}
+	case models.ProductDropIn:
+		if params.TotalSlots != 0 || params.ValidityDays != 0 {
+			return models.Product{}, api.ErrValidation(
+				errors.New("a drop-in is a single class, it has no totalSlots nor validityDays"),
+			)
+		}
+	default:
+		return models.Product{}, api.ErrValidation(fmt.Errorf("unknown product kind %q", params.Kind))
+	}
+
+	now := time.Now().UTC()
+
+	product := models.Product{
+		ID:           uuid.New(),
+		Name:         params.Name,
+		Kind:         params.Kind,
+		TotalSlots:   params.TotalSlots,
+		ValidityDays: params.ValidityDays,
+		Price:        params.Price,
+		Currency:     params.Currency,
+		Active:       true,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	err := s.productsRepo.Insert(ctx, product)
+	if err != nil {
+		return models.Product{}, fmt.Errorf("could not insert product %s: %w", params.Name, err)
+	}
+
+	return product, nil
+}
+
+func (s *service) UpdateProduct(
+	ctx context.Context, id uuid.UUID, update models.UpdateProduct,
+) (models.Product, error) {
+	product, err := s.GetProduct(ctx, id)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	if update.ValidityDays != nil && *update.ValidityDays != 0 && product.Kind == models.ProductDropIn {
+		return models.Product{}, api.ErrValidation(errors.New("a drop-in has no validityDays"))
+	}
+
+	product, err = applyProductUpdate(product, update)
+	if err != nil {
+		return models.Product{}, api.ErrValidation(err)
+	}
+
+	product.UpdatedAt = time.Now().UTC()
+
+	err = s.productsRepo.Update(ctx, product)
+	if err != nil {
+		return models.Product{}, fmt.Errorf("could not update product %s: %w", id, err)
+	}
+
+	return product, nil
+}
+
+func applyProductUpdate(product models.Product, update models.UpdateProduct) (models.Product, error) {
+	updated := false
+
+	if update.Name != nil {
+		product.Name = *update.Name
+		updated = true
+	}
+
+	if update.ValidityDays != nil {
+		product.ValidityDays = *update.ValidityDays
+		updated = true
+	}
+
+	if update.Price != nil {
+		product.Price = *update.Price
+		updated = true
+	}
+
+	if update.Currency != nil {
+		product.Currency = *update.Currency
+		updated = true
+	}
+
+	if update.Active != nil {
+		product.Active = *update.Active
+		updated = true
+	}
+
+	if !updated {
+		return models.Product{}, errors.New("no fields to update product")
+	}
+
+	return product, nil
+}
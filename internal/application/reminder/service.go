@@ -7,11 +7,9 @@ import (
 	"time"
 
 	"main/internal/domain/models"
-	"main/internal/domain/notifier"
 	"main/internal/domain/repositories"
 	"main/internal/domain/services"
-
-	"github.com/google/uuid"
+	"main/pkg/converter"
 )
 
 type IReminderService interface {
@@ -22,7 +20,6 @@ type service struct {
 	unitOfWork   repositories.IUnitOfWork
 	classesRepo  repositories.IClasses
 	bookingsRepo repositories.IBookings
-	notifier     notifier.INotifier
 	passManager  services.IPassManager
 	domainAddr   string
 }
@@ -31,7 +28,6 @@ func New(
 	unitOfWork repositories.IUnitOfWork,
 	classesRepo repositories.IClasses,
 	bookingsRepo repositories.IBookings,
-	notifier notifier.INotifier,
 	passManager services.IPassManager,
 	domainAddr string,
 ) *service {
@@ -39,7 +35,6 @@ func New(
 		unitOfWork:   unitOfWork,
 		classesRepo:  classesRepo,
 		bookingsRepo: bookingsRepo,
-		notifier:     notifier,
 		passManager:  passManager,
 		domainAddr:   domainAddr,
 	}
@@ -65,7 +60,7 @@ func (s *service) RemindBookings(ctx context.Context) error {
 
 	for _, class := range futureClasses {
 		if isTimeToRemind(class.StartTime, now) {
-			err := s.sendReminders(ctx, class.ID, class.StartTime)
+			err := s.sendReminders(ctx, class)
 			if err != nil {
 				return fmt.Errorf("could not send reminders for class %v: %w", class.ID, err)
 			}
@@ -89,7 +84,14 @@ func isTimeToRemind(classStartTime, now time.Time) bool {
 	return diff > 0 && diff < 24*time.Hour
 }
 
-func (s *service) sendReminders(ctx context.Context, classID uuid.UUID, classStartTime time.Time) error {
+func (s *service) sendReminders(ctx context.Context, class models.Class) error {
+	classID := class.ID
+
+	loc, err := converter.LoadLocation(class.Location.TimeZone)
+	if err != nil {
+		return fmt.Errorf("could not load time zone of class %v: %w", classID, err)
+	}
+
 	bookings, err := s.bookingsRepo.ListByClassID(ctx, classID)
 	if err != nil {
 		return fmt.Errorf("could not list bookings for %v: %w", classID, err)
@@ -104,7 +106,7 @@ func (s *service) sendReminders(ctx context.Context, classID uuid.UUID, classSta
 	slog.Info(fmt.Sprintf("Reminder: found bookings: %d", len(bookings)), "class_id", classID)
 
 	for _, booking := range bookings {
-		if !shouldRemindBooking(booking, classStartTime) {
+		if !shouldRemindBooking(booking, class.StartTime, loc) {
 			continue
 		}
 
@@ -134,6 +136,8 @@ func (s *service) remindBooking(ctx context.Context, booking models.Booking) err
 			ClassLevel:         booking.Class.ClassLevel,
 			StartTime:          booking.Class.StartTime,
 			Location:           booking.Class.Location,
+			Instructor:         booking.Class.Instructor,
+			Duration:           booking.Class.Duration,
 		}
 
 		if booking.Pass.Exists() {
@@ -153,9 +157,13 @@ func (s *service) remindBooking(ctx context.Context, booking models.Booking) err
 			"%s/bookings/%s/cancel_form?token=%s", s.domainAddr, booking.ID, booking.ConfirmationToken,
 		)
 
-		err = s.notifier.NotifyBookingReminder(notifierParams, cancellationLink)
+		err = repos.Outbox.Enqueue(ctx, models.Notification{
+			Kind:   models.NotificationBookingReminder,
+			Params: notifierParams,
+			Link:   cancellationLink,
+		})
 		if err != nil {
-			return fmt.Errorf("could not nofify booking with %v: %w", notifierParams, err)
+			return fmt.Errorf("could not enqueue booking reminder with %v: %w", notifierParams, err)
 		}
 
 		slog.Info("Reminder: booking reminded",
@@ -171,7 +179,7 @@ func (s *service) remindBooking(ctx context.Context, booking models.Booking) err
 	return nil
 }
 
-func shouldRemindBooking(booking models.Booking, classStartTime time.Time) bool {
+func shouldRemindBooking(booking models.Booking, classStartTime time.Time, loc *time.Location) bool {
 	if booking.RemindedAt != nil {
 		slog.Info(
 			"Reminder: skipping already reminded booking",
@@ -181,7 +189,7 @@ func shouldRemindBooking(booking models.Booking, classStartTime time.Time) bool
 		return false
 	}
 
-	if isBookedSameOrPreviousDayAsClassDay(booking.CreatedAt, classStartTime) {
+	if isBookedSameOrPreviousDayAsClassDay(booking.CreatedAt, classStartTime, loc) {
 		slog.Info(
 			"Reminder: skipping booking created at the same or previous day as class day",
 			"email", booking.Email, "created_at", booking.CreatedAt, "class_start_time", classStartTime,
@@ -193,11 +201,16 @@ func shouldRemindBooking(booking models.Booking, classStartTime time.Time) bool
 	return true
 }
 
-func isBookedSameOrPreviousDayAsClassDay(bookingCreatedAt, classStartTime time.Time) bool {
+// isBookedSameOrPreviousDayAsClassDay compares calendar days in loc, the time zone of the
+// class location.
+func isBookedSameOrPreviousDayAsClassDay(bookingCreatedAt, classStartTime time.Time, loc *time.Location) bool {
 	if bookingCreatedAt.IsZero() || classStartTime.IsZero() {
 		return false
 	}
 
+	bookingCreatedAt = bookingCreatedAt.In(loc)
+	classStartTime = classStartTime.In(loc)
+
 	aDate := time.Date(
 		bookingCreatedAt.Year(), bookingCreatedAt.Month(), bookingCreatedAt.Day(), 0, 0, 0, 0, time.UTC,
 	)
@@ -205,7 +218,7 @@ func isBookedSameOrPreviousDayAsClassDay(bookingCreatedAt, classStartTime time.T
 		classStartTime.Year(), classStartTime.Month(), classStartTime.Day(), 0, 0, 0, 0, time.UTC,
 	)
 
-	prevDay := bDate.Add(-24 * time.Hour)
+	prevDay := bDate.AddDate(0, 0, -1)
 
 	return aDate.Equal(bDate) || aDate.Equal(prevDay)
 }
@@ -63,47 +63,93 @@ func TestIsTimeToRemind(t *testing.T) {
 func TestIsBookedSameOrPreviousDayAsClassDay(t *testing.T) {
 	loc := time.UTC
 
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Fatalf("could not load location: %v", err)
+	}
+
 	tests := []struct {
 		name string
 		a    time.Time
 		b    time.Time
+		zone *time.Location
 		want bool
 	}{
 		{
 			name: "same day",
 			a:    time.Date(2026, 4, 6, 10, 0, 0, 0, loc),
 			b:    time.Date(2026, 4, 6, 18, 0, 0, 0, loc),
+			zone: loc,
 			want: true,
 		},
 		{
 			name: "previous day",
 			a:    time.Date(2026, 4, 5, 23, 59, 0, 0, loc),
 			b:    time.Date(2026, 4, 6, 0, 1, 0, 0, loc),
+			zone: loc,
 			want: true,
 		},
 		{
 			name: "two days before",
 			a:    time.Date(2026, 4, 4, 12, 0, 0, 0, loc),
 			b:    time.Date(2026, 4, 6, 12, 0, 0, 0, loc),
+			zone: loc,
 			want: false,
 		},
 		{
 			name: "next day",
 			a:    time.Date(2026, 4, 7, 10, 0, 0, 0, loc),
 			b:    time.Date(2026, 4, 6, 10, 0, 0, 0, loc),
+			zone: loc,
 			want: false,
 		},
 		{
 			name: "zero time values",
 			a:    time.Time{},
 			b:    time.Time{},
+			zone: loc,
+			want: false,
+		},
+		{
+			name: "previous day in studio zone is two days before in UTC",
+			a:    time.Date(2026, 3, 28, 23, 30, 0, 0, time.UTC),
+			b:    time.Date(2026, 3, 30, 6, 0, 0, 0, warsaw),
+			zone: warsaw,
+			want: true,
+		},
+		{
+			name: "previous day across spring forward",
+			a:    time.Date(2026, 3, 28, 0, 30, 0, 0, warsaw),
+			b:    time.Date(2026, 3, 29, 23, 30, 0, 0, warsaw),
+			zone: warsaw,
+			want: true,
+		},
+		{
+			name: "two days before across spring forward",
+			a:    time.Date(2026, 3, 27, 23, 30, 0, 0, warsaw),
+			b:    time.Date(2026, 3, 29, 0, 30, 0, 0, warsaw),
+			zone: warsaw,
+			want: false,
+		},
+		{
+			name: "previous day across fall back",
+			a:    time.Date(2026, 10, 24, 0, 15, 0, 0, warsaw),
+			b:    time.Date(2026, 10, 25, 23, 45, 0, 0, warsaw),
+			zone: warsaw,
+			want: true,
+		},
+		{
+			name: "two days before across fall back",
+			a:    time.Date(2026, 10, 23, 23, 45, 0, 0, warsaw),
+			b:    time.Date(2026, 10, 25, 0, 15, 0, 0, warsaw),
+			zone: warsaw,
 			want: false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := isBookedSameOrPreviousDayAsClassDay(tt.a, tt.b)
+			got := isBookedSameOrPreviousDayAsClassDay(tt.a, tt.b, tt.zone)
 			if got != tt.want {
 				t.Errorf("got %v, want %v", got, tt.want)
 			}
@@ -0,0 +1,161 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"main/internal/domain/models"
+	"main/internal/domain/repositories"
+	"main/internal/infrastructure/errs"
+)
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+type scheduler struct {
+	jobRunsRepo  repositories.IJobRuns
+	jobs         []Job
+	tickInterval time.Duration
+	jobTimeout   time.Duration
+}
+
+func New(
+	jobRunsRepo repositories.IJobRuns,
+	tickInterval time.Duration,
+	jobTimeout time.Duration,
+	jobs ...Job,
+) *scheduler {
+	return &scheduler{
+		jobRunsRepo:  jobRunsRepo,
+		jobs:         jobs,
+		tickInterval: tickInterval,
+		jobTimeout:   jobTimeout,
+	}
+}
+
+// Start runs due jobs immediately and then on every tick until ctx is done.
+// Jobs missed while the process was stopped are run once at start, not once per missed interval.
+func (s *scheduler) Start(ctx context.Context) {
+	slog.Info("Scheduler: started", slog.Int("jobs", len(s.jobs)))
+
+	s.runDueJobs(ctx)
+
+	ticker := time.NewTicker(s.tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Scheduler: stopped")
+
+			return
+		case <-ticker.C:
+			s.runDueJobs(ctx)
+		}
+	}
+}
+
+func (s *scheduler) ListJobs(ctx context.Context) ([]models.JobStatus, error) {
+	jobRuns, err := s.jobRunsRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list job runs: %w", err)
+	}
+
+	jobRunsByName := make(map[string]models.JobRun, len(jobRuns))
+	for _, jobRun := range jobRuns {
+		jobRunsByName[jobRun.Name] = jobRun
+	}
+
+	statuses := make([]models.JobStatus, 0, len(s.jobs))
+
+	for _, job := range s.jobs {
+		status := models.JobStatus{
+			Name:     job.Name,
+			Interval: job.Interval,
+		}
+
+		if jobRun, ok := jobRunsByName[job.Name]; ok {
+			nextRunAt := jobRun.NextRunAt
+			status.LastRunAt = jobRun.LastRunAt
+			status.NextRunAt = &nextRunAt
+			status.LastError = jobRun.LastError
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (s *scheduler) runDueJobs(ctx context.Context) {
+	for _, job := range s.jobs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		due, err := s.isDue(ctx, job, time.Now())
+		if err != nil {
+			slog.Error("Scheduler: could not check job", "job", job.Name, "err", err.Error())
+
+			continue
+		}
+
+		if !due {
+			continue
+		}
+
+		err = s.runJob(ctx, job)
+		if err != nil {
+			slog.Error("Scheduler: could not record job run", "job", job.Name, "err", err.Error())
+		}
+	}
+}
+
+func (s *scheduler) isDue(ctx context.Context, job Job, now time.Time) (bool, error) {
+	jobRun, err := s.jobRunsRepo.Get(ctx, job.Name)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return true, nil
+		}
+
+		return false, fmt.Errorf("could not get job run: %w", err)
+	}
+
+	return !now.Before(jobRun.NextRunAt), nil
+}
+
+func (s *scheduler) runJob(ctx context.Context, job Job) error {
+	startedAt := time.Now().UTC()
+
+	jobCtx, cancel := context.WithTimeout(ctx, s.jobTimeout)
+	defer cancel()
+
+	jobRun := models.JobRun{
+		Name:      job.Name,
+		LastRunAt: &startedAt,
+		NextRunAt: startedAt.Add(job.Interval),
+	}
+
+	err := job.Run(jobCtx)
+	if err != nil {
+		errMsg := err.Error()
+		jobRun.LastError = &errMsg
+
+		slog.Error("Scheduler: job failed", "job", job.Name, "err", errMsg)
+	} else {
+		slog.Info("Scheduler: job done", "job", job.Name, "duration", time.Since(startedAt).String())
+	}
+
+	err = s.jobRunsRepo.Upsert(ctx, jobRun)
+	if err != nil {
+		return fmt.Errorf("could not upsert job run: %w", err)
+	}
+
+	return nil
+}
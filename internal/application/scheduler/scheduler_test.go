@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"main/internal/domain/models"
+	"main/internal/infrastructure/errs"
+)
+
+type mockJobRunsRepo struct {
+	jobRuns map[string]models.JobRun
+}
+
+func (m *mockJobRunsRepo) Get(_ context.Context, name string) (models.JobRun, error) {
+	jobRun, ok := m.jobRuns[name]
+	if !ok {
+		return models.JobRun{}, errs.ErrNotFound
+	}
+
+	return jobRun, nil
+}
+
+func (m *mockJobRunsRepo) List(_ context.Context) ([]models.JobRun, error) {
+	jobRuns := make([]models.JobRun, 0, len(m.jobRuns))
+	for _, jobRun := range m.jobRuns {
+		jobRuns = append(jobRuns, jobRun)
+	}
+
+	return jobRuns, nil
+}
+
+func (m *mockJobRunsRepo) Upsert(_ context.Context, jobRun models.JobRun) error {
+	m.jobRuns[jobRun.Name] = jobRun
+
+	return nil
+}
+
+func TestRunDueJobs(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		jobRuns  map[string]models.JobRun
+		wantRuns int
+	}{
+		{
+			name:     "never run job is due",
+			jobRuns:  map[string]models.JobRun{},
+			wantRuns: 1,
+		},
+		{
+			name: "job missed many intervals runs once",
+			jobRuns: map[string]models.JobRun{
+				"job": {Name: "job", NextRunAt: now.Add(-10 * time.Hour)},
+			},
+			wantRuns: 1,
+		},
+		{
+			name: "job not due yet is skipped",
+			jobRuns: map[string]models.JobRun{
+				"job": {Name: "job", NextRunAt: now.Add(time.Hour)},
+			},
+			wantRuns: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := 0
+			repo := &mockJobRunsRepo{jobRuns: tt.jobRuns}
+			s := New(repo, time.Minute, time.Second, Job{
+				Name:     "job",
+				Interval: time.Hour,
+				Run: func(_ context.Context) error {
+					runs++
+
+					return nil
+				},
+			})
+
+			s.runDueJobs(context.Background())
+
+			if runs != tt.wantRuns {
+				t.Errorf("got %d runs, want %d", runs, tt.wantRuns)
+			}
+
+			if tt.wantRuns > 0 && !repo.jobRuns["job"].NextRunAt.After(now) {
+				t.Errorf("next run was not moved to the future: %v", repo.jobRuns["job"].NextRunAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package studentbookings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	viewErrors "main/internal/domain/errs/view"
+	"main/internal/domain/models"
+	"main/internal/domain/repositories"
+	"main/internal/domain/services"
+	"main/internal/infrastructure/errs"
+	"main/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+const (
+	tokenLength = 32
+)
+
+type service struct {
+	unitOfWork          repositories.IUnitOfWork
+	studentSessionsRepo repositories.IStudentSessions
+	bookingsRepo        repositories.IBookings
+	passesRepo          repositories.IPasses
+	tokenGenerator      services.ITokenGenerator
+	passManager         services.IPassManager
+	bookingPolicy       services.IBookingPolicy
+	calendarService     services.ICalendarService
+	domainAddr          string
+}
+
+func NewService(
+	unitOfWork repositories.IUnitOfWork,
+	studentSessionsRepo repositories.IStudentSessions,
+	bookingsRepo repositories.IBookings,
+	passesRepo repositories.IPasses,
+	tokenGenerator services.ITokenGenerator,
+	passManager services.IPassManager,
+	bookingPolicy services.IBookingPolicy,
+	calendarService services.ICalendarService,
+	domainAddr string,
+) *service {
+	return &service{
+		unitOfWork:          unitOfWork,
+		studentSessionsRepo: studentSessionsRepo,
+		bookingsRepo:        bookingsRepo,
+		passesRepo:          passesRepo,
+		tokenGenerator:      tokenGenerator,
+		passManager:         passManager,
+		bookingPolicy:       bookingPolicy,
+		calendarService:     calendarService,
+		domainAddr:          domainAddr,
+	}
+}
+
+// RequestLoginLink emails a single use login link. Nothing is sent to addresses without
+// any booking or pass, but the caller is not told about it so emails cannot be probed.
+func (s *service) RequestLoginLink(ctx context.Context, email string) error {
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		known, err := s.isKnownEmail(ctx, repos, email)
+		if err != nil {
+			return fmt.Errorf("could not check email %s: %w", email, err)
+		}
+
+		if !known {
+			slog.Info("StudentBookings: login link requested for unknown email", "email", email)
+
+			return nil
+		}
+
+		loginToken, err := s.tokenGenerator.Generate(tokenLength)
+		if err != nil {
+			return fmt.Errorf("could not generate login token: %w", err)
+		}
+
+		now := time.Now().UTC()
+
+		err = repos.StudentSessions.Insert(ctx, models.StudentSession{
+			ID:        uuid.New(),
+			Email:     email,
+			Token:     loginToken,
+			ExpiresAt: now.Add(models.StudentLoginLinkTTL),
+			CreatedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("could not insert student session: %w", err)
+		}
+
+		locale, _ := i18n.FromContext(ctx)
+
+		err = repos.Outbox.Enqueue(ctx, models.Notification{
+			Kind:   models.NotificationStudentLoginLink,
+			Params: models.NotifierParams{RecipientEmail: email},
+			Link:   fmt.Sprintf("%s/my_bookings/auth?token=%s", s.domainAddr, loginToken),
+			Locale: locale,
+		})
+		if err != nil {
+			return fmt.Errorf("could not enqueue login link: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("request login link transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) isKnownEmail(
+	ctx context.Context, repos repositories.Repositories, email string,
+) (bool, error) {
+	bookings, err := repos.Bookings.ListByEmail(ctx, email)
+	if err != nil {
+		return false, fmt.Errorf("could not list bookings: %w", err)
+	}
+
+	if len(bookings) > 0 {
+		return true, nil
+	}
+
+	passes, err := repos.Passes.ListByEmail(ctx, email, 1)
+	if err != nil {
+		return false, fmt.Errorf("could not list passes: %w", err)
+	}
+
+	return len(passes) > 0, nil
+}
+
+// LogIn exchanges the login token for a new session token, so the emailed link works only once.
+func (s *service) LogIn(ctx context.Context, loginToken string) (models.StudentSession, error) {
+	var session models.StudentSession
+
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		var err error
+
+		session, err = repos.StudentSessions.GetByToken(ctx, loginToken)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return viewErrors.ErrInvalidLoginLink(fmt.Errorf("student session for token %s not found", loginToken))
+			}
+
+			return fmt.Errorf("could not get student session: %w", err)
+		}
+
+		now := time.Now().UTC()
+
+		if session.ActivatedAt != nil || now.After(session.ExpiresAt) {
+			return viewErrors.ErrInvalidLoginLink(
+				fmt.Errorf("login link for student session %s already used or expired", session.ID),
+			)
+		}
+
+		sessionToken, err := s.tokenGenerator.Generate(tokenLength)
+		if err != nil {
+			return fmt.Errorf("could not generate session token: %w", err)
+		}
+
+		session.Token = sessionToken
+		session.ActivatedAt = &now
+		session.ExpiresAt = now.Add(models.StudentSessionTTL)
+
+		err = repos.StudentSessions.Update(ctx, session.ID, map[string]any{
+			"token":        session.Token,
+			"activated_at": session.ActivatedAt,
+			"expires_at":   session.ExpiresAt,
+		})
+		if err != nil {
+			return fmt.Errorf("could not update student session %s: %w", session.ID, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.StudentSession{}, fmt.Errorf("log in transaction failed: %w", err)
+	}
+
+	return session, nil
+}
+
+func (s *service) LogOut(ctx context.Context, sessionToken string) error {
+	session, err := s.studentSessionsRepo.GetByToken(ctx, sessionToken)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("could not get student session: %w", err)
+	}
+
+	err = s.studentSessionsRepo.Delete(ctx, session.ID)
+	if err != nil && !errors.Is(err, errs.ErrNoRowsAffected) {
+		return fmt.Errorf("could not delete student session %s: %w", session.ID, err)
+	}
+
+	return nil
+}
+
+func (s *service) GetStudentBookings(
+	ctx context.Context, sessionToken string,
+) (models.StudentBookings, error) {
+	session, err := s.getActiveSession(ctx, sessionToken)
+	if err != nil {
+		return models.StudentBookings{}, fmt.Errorf("could not get active session: %w", err)
+	}
+
+	bookings, err := s.bookingsRepo.ListByEmail(ctx, session.Email)
+	if err != nil {
+		return models.StudentBookings{}, fmt.Errorf("could not list bookings for %s: %w", session.Email, err)
+	}
+
+	studentBookings := models.StudentBookings{
+		Email: session.Email,
+	}
+
+	now := time.Now()
+
+	for _, booking := range bookings {
+		if booking.Class.StartTime.Before(now) {
+			studentBookings.Past = append(studentBookings.Past, booking)
+		} else {
+			studentBookings.Upcoming = append(studentBookings.Upcoming, booking)
+		}
+	}
+
+	sort.Slice(studentBookings.Upcoming, func(i, j int) bool {
+		return studentBookings.Upcoming[i].Class.StartTime.Before(studentBookings.Upcoming[j].Class.StartTime)
+	})
+	sort.Slice(studentBookings.Past, func(i, j int) bool {
+		return studentBookings.Past[i].Class.StartTime.After(studentBookings.Past[j].Class.StartTime)
+	})
+
+	passes, err := s.passesRepo.ListByEmail(ctx, session.Email, s.bookingPolicy.PassesToCheck())
+	if err != nil {
+		return models.StudentBookings{}, fmt.Errorf("could not list passes for %s: %w", session.Email, err)
+	}
+
+	for _, pass := range passes {
+		passBookings, err := s.bookingsRepo.ListByPassID(ctx, pass.ID)
+		if err != nil {
+			return models.StudentBookings{}, fmt.Errorf("could not list bookings by passID %d: %w", pass.ID, err)
+		}
+
+		studentBookings.Passes = append(studentBookings.Passes, models.PassWithSlots{
+			Pass:     pass,
+			Bookings: passBookings,
+			Slots:    s.passManager.BuildPassSlots(passBookings, pass.TotalSlots),
+		})
+	}
+
+	studentBookings.CalendarFeedLink, err = s.calendarService.GetStudentFeedLink(ctx, session.Email)
+	if err != nil {
+		return models.StudentBookings{}, fmt.Errorf("could not get calendar feed link for %s: %w", session.Email, err)
+	}
+
+	return studentBookings, nil
+}
+
+func (s *service) getActiveSession(ctx context.Context, sessionToken string) (models.StudentSession, error) {
+	session, err := s.studentSessionsRepo.GetByToken(ctx, sessionToken)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return models.StudentSession{}, viewErrors.ErrStudentSessionExpired(
+				errors.New("student session not found"),
+			)
+		}
+
+		return models.StudentSession{}, fmt.Errorf("could not get student session: %w", err)
+	}
+
+	if session.ActivatedAt == nil || time.Now().After(session.ExpiresAt) {
+		return models.StudentSession{}, viewErrors.ErrStudentSessionExpired(
+			fmt.Errorf("student session %s is not active", session.ID),
+		)
+	}
+
+	return session, nil
+}
+
+// CleanUpSessions removes unused login links and sessions that have expired.
+func (s *service) CleanUpSessions(ctx context.Context) error {
+	deleted, err := s.studentSessionsRepo.DeleteExpiredBefore(ctx, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("could not delete expired student sessions: %w", err)
+	}
+
+	slog.Info("StudentSessionCleaner: cleaned up student sessions", slog.Int("deleted", deleted))
+
+	return nil
+}
@@ -0,0 +1,301 @@
+package waitlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	viewErrors "main/internal/domain/errs/view"
+	"main/internal/domain/models"
+	"main/internal/domain/repositories"
+	"main/internal/domain/services"
+	"main/internal/infrastructure/errs"
+	"main/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+const tokenLength = 32
+
+type service struct {
+	unitOfWork     repositories.IUnitOfWork
+	tokenGenerator services.ITokenGenerator
+	domainAddr     string
+}
+
+func NewService(
+	unitOfWork repositories.IUnitOfWork,
+	tokenGenerator services.ITokenGenerator,
+	domainAddr string,
+) *service {
+	return &service{
+		unitOfWork:     unitOfWork,
+		tokenGenerator: tokenGenerator,
+		domainAddr:     domainAddr,
+	}
+}
+
+func (s *service) JoinWaitlist(ctx context.Context, params models.WaitlistEntryParams) error {
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		_, err := repos.Bookings.GetByEmailAndClassID(ctx, params.ClassID, params.Email)
+		if err == nil {
+			return viewErrors.ErrBookingAlreadyExists(
+				params.ClassID,
+				params.Email,
+				fmt.Errorf("booking for class %v and email %s already exists", params.ClassID, params.Email),
+			)
+		}
+
+		if !errors.Is(err, errs.ErrNotFound) {
+			return fmt.Errorf("could not get booking: %w", err)
+		}
+
+		_, err = repos.Waitlist.GetByClassIDAndEmail(ctx, params.ClassID, params.Email)
+		if err == nil {
+			return viewErrors.ErrAlreadyOnWaitlist(
+				params.ClassID,
+				params.Email,
+				fmt.Errorf("waitlist entry for class %v and email %s already exists", params.ClassID, params.Email),
+			)
+		}
+
+		if !errors.Is(err, errs.ErrNotFound) {
+			return fmt.Errorf("could not get waitlist entry: %w", err)
+		}
+
+		class, err := repos.Classes.Get(ctx, params.ClassID)
+		if err != nil {
+			return fmt.Errorf("could not get class: %w", err)
+		}
+
+		if class.StartTime.Before(time.Now()) {
+			return viewErrors.ErrClassExpired(
+				class.ID, fmt.Errorf("class %s has expired at %v", class.ID, class.StartTime),
+			)
+		}
+
+		freeSpots, err := countFreeSpots(ctx, repos, class)
+		if err != nil {
+			return fmt.Errorf("could not count free spots: %w", err)
+		}
+
+		if freeSpots > 0 {
+			return viewErrors.ErrClassNotFullyBooked(
+				class.ID, fmt.Errorf("class %s has %d free spots", class.ID, freeSpots),
+			)
+		}
+
+		locale, _ := i18n.FromContext(ctx)
+
+		entry := models.WaitlistEntry{
+			ID:        uuid.New(),
+			ClassID:   params.ClassID,
+			Email:     params.Email,
+			FirstName: params.FirstName,
+			LastName:  params.LastName,
+			Language:  locale,
+			CreatedAt: time.Now().UTC(),
+		}
+
+		err = repos.Waitlist.Insert(ctx, entry)
+		if err != nil {
+			return fmt.Errorf("could not insert waitlist entry: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("join waitlist transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+// PromoteFromWaitlist offers every spot that is free in the class to the next
+// people in line. Offers are sent as pending bookings, so the regular
+// confirmation link flow creates the booking.
+func (s *service) PromoteFromWaitlist(ctx context.Context, classID uuid.UUID) error {
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		class, err := repos.Classes.Get(ctx, classID)
+		if err != nil {
+			return fmt.Errorf("could not get class: %w", err)
+		}
+
+		if class.StartTime.Before(time.Now()) {
+			return nil
+		}
+
+		candidates, err := s.dropExpiredOffers(ctx, repos, classID)
+		if err != nil {
+			return fmt.Errorf("could not drop expired offers: %w", err)
+		}
+
+		freeSpots, err := countFreeSpots(ctx, repos, class)
+		if err != nil {
+			return fmt.Errorf("could not count free spots: %w", err)
+		}
+
+		for i := 0; i < freeSpots && i < len(candidates); i++ {
+			err = s.offerSpot(ctx, repos, class, candidates[i])
+			if err != nil {
+				return fmt.Errorf("could not offer spot to %s: %w", candidates[i].Email, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("promote from waitlist transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+// ExpireOffers releases spots held by offers which were not used in time and passes
+// them to the next people in line.
+func (s *service) ExpireOffers(ctx context.Context) error {
+	var classIDs []uuid.UUID
+
+	err := s.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		var err error
+
+		classIDs, err = repos.Waitlist.ListClassIDsWithOffersBefore(
+			ctx, time.Now().Add(-models.WaitlistOfferTTL),
+		)
+		if err != nil {
+			return fmt.Errorf("could not list classes with expired offers: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("list expired offers transaction failed: %w", err)
+	}
+
+	for _, classID := range classIDs {
+		err = s.PromoteFromWaitlist(ctx, classID)
+		if err != nil {
+			return fmt.Errorf("could not promote waitlist for class %v: %w", classID, err)
+		}
+	}
+
+	return nil
+}
+
+// dropExpiredOffers removes entries whose offer was not used in time and returns
+// entries which are still waiting for a spot, in queue order.
+func (s *service) dropExpiredOffers(
+	ctx context.Context,
+	repos repositories.Repositories,
+	classID uuid.UUID,
+) ([]models.WaitlistEntry, error) {
+	entries, err := repos.Waitlist.ListByClassID(ctx, classID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list waitlist entries for class %v: %w", classID, err)
+	}
+
+	offerDeadline := time.Now().Add(-models.WaitlistOfferTTL)
+	candidates := make([]models.WaitlistEntry, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.OfferedAt == nil {
+			candidates = append(candidates, entry)
+
+			continue
+		}
+
+		if entry.OfferedAt.Before(offerDeadline) {
+			err = repos.Waitlist.Delete(ctx, entry.ID)
+			if err != nil {
+				return nil, fmt.Errorf("could not delete expired waitlist entry %v: %w", entry.ID, err)
+			}
+		}
+	}
+
+	return candidates, nil
+}
+
+func (s *service) offerSpot(
+	ctx context.Context,
+	repos repositories.Repositories,
+	class models.Class,
+	entry models.WaitlistEntry,
+) error {
+	confirmationToken, err := s.tokenGenerator.Generate(tokenLength)
+	if err != nil {
+		return fmt.Errorf("could not generate confirmation token: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	pendingBooking := models.PendingBooking{
+		ID:                uuid.New(),
+		ClassID:           entry.ClassID,
+		Email:             entry.Email,
+		FirstName:         entry.FirstName,
+		LastName:          entry.LastName,
+		ConfirmationToken: confirmationToken,
+		CreatedAt:         now,
+	}
+
+	err = repos.PendingBookings.Insert(ctx, pendingBooking)
+	if err != nil {
+		return fmt.Errorf("could not insert pending booking: %w", err)
+	}
+
+	err = repos.Waitlist.Update(ctx, entry.ID, map[string]any{"offered_at": now})
+	if err != nil {
+		return fmt.Errorf("could not update waitlist entry %v: %w", entry.ID, err)
+	}
+
+	err = repos.Outbox.Enqueue(ctx, models.Notification{
+		Kind: models.NotificationWaitlistSpotAvailable,
+		Params: models.NotifierParams{
+			RecipientEmail:     entry.Email,
+			RecipientFirstName: entry.FirstName,
+			ClassID:            class.ID,
+			ClassName:          class.ClassName,
+			ClassLevel:         class.ClassLevel,
+			StartTime:          class.StartTime,
+			Location:           class.Location,
+			Instructor:         class.Instructor,
+			Duration:           class.Duration,
+		},
+		Link:   fmt.Sprintf("%s/bookings?token=%s", s.domainAddr, confirmationToken),
+		Locale: entry.Language,
+	})
+	if err != nil {
+		return fmt.Errorf("could not enqueue waitlist spot available: %w", err)
+	}
+
+	return nil
+}
+
+// countFreeSpots treats spots offered to the waitlist as taken until the offer expires.
+// It locks the class like the bookings do, so an offer and a booking don't take the
+// same spot.
+func countFreeSpots(
+	ctx context.Context,
+	repos repositories.Repositories,
+	class models.Class,
+) (int, error) {
+	err := repos.Classes.Lock(ctx, class.ID)
+	if err != nil {
+		return 0, fmt.Errorf("could not lock class %v: %w", class.ID, err)
+	}
+
+	bookingCount, err := repos.Bookings.CountForClassID(ctx, class.ID)
+	if err != nil {
+		return 0, fmt.Errorf("could not count bookings for class %v: %w", class.ID, err)
+	}
+
+	offeredCount, err := repos.Waitlist.CountOfferedSince(
+		ctx, class.ID, time.Now().Add(-models.WaitlistOfferTTL),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("could not count waitlist offers for class %v: %w", class.ID, err)
+	}
+
+	return class.MaxCapacity - bookingCount - offeredCount, nil
+}
@@ -0,0 +1,263 @@
+package waitlist
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"main/internal/domain/models"
+	"main/internal/domain/repositories"
+	"main/internal/infrastructure/generator/token"
+	"main/internal/infrastructure/repository/repositorytest"
+	"main/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+// waiting is a waitlist entry of the test, offeredAgo is zero when no spot was offered.
+type waiting struct {
+	email      string
+	offeredAgo time.Duration
+}
+
+func TestPromoteFromWaitlist(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxCapacity   int
+		bookings      int
+		entries       []waiting
+		wantOffered   []string
+		wantRemaining []string
+		wantNewOffers int
+	}{
+		{
+			name:          "free spot goes to the first in line",
+			maxCapacity:   2,
+			bookings:      1,
+			entries:       []waiting{{email: "a@example.com"}, {email: "b@example.com"}},
+			wantOffered:   []string{"a@example.com"},
+			wantRemaining: []string{"a@example.com", "b@example.com"},
+			wantNewOffers: 1,
+		},
+		{
+			name:        "free spots are offered in queue order",
+			maxCapacity: 3,
+			bookings:    1,
+			entries: []waiting{
+				{email: "a@example.com"}, {email: "b@example.com"}, {email: "c@example.com"},
+			},
+			wantOffered:   []string{"a@example.com", "b@example.com"},
+			wantRemaining: []string{"a@example.com", "b@example.com", "c@example.com"},
+			wantNewOffers: 2,
+		},
+		{
+			name:        "active offer keeps the spot",
+			maxCapacity: 2,
+			bookings:    1,
+			entries: []waiting{
+				{email: "a@example.com", offeredAgo: 10 * time.Minute}, {email: "b@example.com"},
+			},
+			wantOffered:   []string{"a@example.com"},
+			wantRemaining: []string{"a@example.com", "b@example.com"},
+		},
+		{
+			name:        "expired offer is dropped and the spot is offered to the next in line",
+			maxCapacity: 2,
+			bookings:    1,
+			entries: []waiting{
+				{email: "a@example.com", offeredAgo: models.WaitlistOfferTTL + time.Minute},
+				{email: "b@example.com"},
+				{email: "c@example.com"},
+			},
+			wantOffered:   []string{"b@example.com"},
+			wantRemaining: []string{"b@example.com", "c@example.com"},
+			wantNewOffers: 1,
+		},
+		{
+			name:          "no free spot",
+			maxCapacity:   1,
+			bookings:      1,
+			entries:       []waiting{{email: "a@example.com"}},
+			wantRemaining: []string{"a@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos, unitOfWork := repositorytest.OpenSQLite(t)
+			class := setupClass(t, repos, tt.maxCapacity, tt.bookings, tt.entries)
+
+			s := NewService(unitOfWork, token.NewGenerator(), "https://yoga.example")
+
+			if err := s.PromoteFromWaitlist(ctx, class.ID); err != nil {
+				t.Fatalf("PromoteFromWaitlist() error = %v", err)
+			}
+
+			assertWaitlist(t, repos, class.ID, tt.wantOffered, tt.wantRemaining)
+			assertNewOffers(t, repos, tt.wantNewOffers)
+		})
+	}
+}
+
+func TestExpireOffers(t *testing.T) {
+	ctx := context.Background()
+	repos, unitOfWork := repositorytest.OpenSQLite(t)
+
+	expired := setupClass(t, repos, 1, 0, []waiting{
+		{email: "a@example.com", offeredAgo: models.WaitlistOfferTTL + time.Minute},
+		{email: "b@example.com"},
+	})
+	active := setupClass(t, repos, 1, 0, []waiting{
+		{email: "c@example.com", offeredAgo: time.Minute},
+		{email: "d@example.com"},
+	})
+
+	s := NewService(unitOfWork, token.NewGenerator(), "https://yoga.example")
+
+	if err := s.ExpireOffers(ctx); err != nil {
+		t.Fatalf("ExpireOffers() error = %v", err)
+	}
+
+	assertWaitlist(t, repos, expired.ID, []string{"b@example.com"}, []string{"b@example.com"})
+	assertWaitlist(t, repos, active.ID,
+		[]string{"c@example.com"}, []string{"c@example.com", "d@example.com"})
+	assertNewOffers(t, repos, 1)
+}
+
+func TestJoinWaitlist(t *testing.T) {
+	ctx := i18n.WithLocale(context.Background(), i18n.English)
+	repos, unitOfWork := repositorytest.OpenSQLite(t)
+	class := setupClass(t, repos, 1, 1, nil)
+
+	s := NewService(unitOfWork, token.NewGenerator(), "https://yoga.example")
+
+	err := s.JoinWaitlist(ctx, models.WaitlistEntryParams{
+		ClassID:   class.ID,
+		FirstName: "Anna",
+		LastName:  "Kowalska",
+		Email:     "a@example.com",
+	})
+	if err != nil {
+		t.Fatalf("JoinWaitlist() error = %v", err)
+	}
+
+	entry, err := repos.Waitlist.GetByClassIDAndEmail(ctx, class.ID, "a@example.com")
+	if err != nil {
+		t.Fatalf("could not get waitlist entry: %v", err)
+	}
+
+	if entry.Language != i18n.English {
+		t.Errorf("language = %v, want %v", entry.Language, i18n.English)
+	}
+}
+
+func setupClass(
+	t *testing.T, repos repositories.Repositories, maxCapacity, bookings int, entries []waiting,
+) models.Class {
+	t.Helper()
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	class := repositorytest.InsertClass(t, repos, now.Add(48*time.Hour), maxCapacity)
+
+	for range bookings {
+		repositorytest.InsertBooking(t, repos, class.ID, uuid.NewString()+"@example.com")
+	}
+
+	for i, entry := range entries {
+		waitlistEntry := models.WaitlistEntry{
+			ID:        uuid.New(),
+			ClassID:   class.ID,
+			Email:     entry.email,
+			FirstName: "Anna",
+			LastName:  "Kowalska",
+			Language:  i18n.English,
+			CreatedAt: now.Add(time.Duration(i-len(entries)) * time.Minute),
+		}
+
+		if entry.offeredAgo > 0 {
+			offeredAt := now.Add(-entry.offeredAgo)
+			waitlistEntry.OfferedAt = &offeredAt
+		}
+
+		if err := repos.Waitlist.Insert(ctx, waitlistEntry); err != nil {
+			t.Fatalf("could not insert waitlist entry: %v", err)
+		}
+	}
+
+	return class
+}
+
+func assertWaitlist(
+	t *testing.T, repos repositories.Repositories, classID uuid.UUID, wantOffered, wantRemaining []string,
+) {
+	t.Helper()
+
+	entries, err := repos.Waitlist.ListByClassID(context.Background(), classID)
+	if err != nil {
+		t.Fatalf("could not list waitlist entries: %v", err)
+	}
+
+	offered := []string{}
+	remaining := []string{}
+
+	for _, entry := range entries {
+		remaining = append(remaining, entry.Email)
+
+		if entry.OfferedAt != nil {
+			offered = append(offered, entry.Email)
+		}
+	}
+
+	if !slices.Equal(offered, wantOffered) {
+		t.Errorf("offered = %v, want %v", offered, wantOffered)
+	}
+
+	if !slices.Equal(remaining, wantRemaining) {
+		t.Errorf("remaining = %v, want %v", remaining, wantRemaining)
+	}
+}
+
+// assertNewOffers checks that every offer made now has a pending booking and a notification.
+func assertNewOffers(t *testing.T, repos repositories.Repositories, want int) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	pendingBookings, err := repos.PendingBookings.List(ctx)
+	if err != nil {
+		t.Fatalf("could not list pending bookings: %v", err)
+	}
+
+	if len(pendingBookings) != want {
+		t.Errorf("pending bookings = %d, want %d", len(pendingBookings), want)
+	}
+
+	messages, err := repos.Outbox.ListByStatus(ctx, models.OutboxStatusPending)
+	if err != nil {
+		t.Fatalf("could not list outbox messages: %v", err)
+	}
+
+	for _, message := range messages {
+		if message.Notification.Kind != models.NotificationWaitlistSpotAvailable {
+			t.Errorf("notification kind = %v, want %v", message.Notification.Kind,
+				models.NotificationWaitlistSpotAvailable)
+		}
+
+		// the offer goes out in the language the student joined in
+		if message.Notification.Locale != i18n.English {
+			t.Errorf("notification locale = %v, want %v", message.Notification.Locale, i18n.English)
+		}
+
+		params := message.Notification.Params
+		if params.ClassID == uuid.Nil || params.ClassName == "" {
+			t.Errorf("notification params = %+v, want the class", params)
+		}
+	}
+
+	if len(messages) != want {
+		t.Errorf("notifications = %d, want %d", len(messages), want)
+	}
+}
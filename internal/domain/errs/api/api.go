@@ -42,3 +42,66 @@ func ErrNotFound(err error) *APIError {
 		Err:  err,
 	}
 }
+
+func ErrBookingAlreadyExists(err error) *APIError {
+	return &APIError{
+		Code: ConflictCode,
+		Err:  err,
+	}
+}
+
+func ErrClassFull(err error) *APIError {
+	return &APIError{
+		Code: ConflictCode,
+		Err:  err,
+	}
+}
+
+func ErrClassHasAttendance(err error) *APIError {
+	return &APIError{
+		Code: ConflictCode,
+		Err:  err,
+	}
+}
+
+func ErrLocationAlreadyExists(err error) *APIError {
+	return &APIError{
+		Code: ConflictCode,
+		Err:  err,
+	}
+}
+
+func ErrLocationInUse(err error) *APIError {
+	return &APIError{
+		Code: ConflictCode,
+		Err:  err,
+	}
+}
+
+func ErrInstructorAlreadyExists(err error) *APIError {
+	return &APIError{
+		Code: ConflictCode,
+		Err:  err,
+	}
+}
+
+func ErrInstructorInUse(err error) *APIError {
+	return &APIError{
+		Code: ConflictCode,
+		Err:  err,
+	}
+}
+
+func ErrClassTypeAlreadyExists(err error) *APIError {
+	return &APIError{
+		Code: ConflictCode,
+		Err:  err,
+	}
+}
+
+func ErrClassTypeInUse(err error) *APIError {
+	return &APIError{
+		Code: ConflictCode,
+		Err:  err,
+	}
+}
@@ -1,7 +1,7 @@
 package view
 
 import (
-	"fmt"
+	"main/pkg/i18n"
 
 	"github.com/google/uuid"
 )
@@ -17,95 +17,238 @@ const (
 	SomeoneBookedClassFasterCode
 	InvalidCancellationLinkCode
 	TooLateToBook
+	ClassNotFullyBookedCode
+	AlreadyOnWaitlistCode
+	InvalidLoginLinkCode
+	StudentSessionExpiredCode
+	CalendarFeedNotFoundCode
+	BookingNotOpenYetCode
+	TooManyActiveBookingsCode
+	TooLateToRescheduleCode
+	PassNotOfferedCode
+	PaymentNotFoundCode
+	ClassTypeNotFoundCode
+	PassNotUsableCode
+	ClassNotFoundCode
+	DropInNotOfferedCode
 )
 
+// BusinessError is shown to the student, MessageKey points into the i18n catalogs
+// and is rendered in the language of the page.
 type BusinessError struct {
-	ClassID *uuid.UUID
-	Code    int
-	Message string
-	Err     error
+	ClassID     *uuid.UUID
+	Code        int
+	MessageKey  string
+	MessageArgs []any
+	Err         error
 }
 
 func (e *BusinessError) Error() string {
 	return e.Err.Error()
 }
 
+func (e *BusinessError) Message(locale i18n.Locale) string {
+	return i18n.T(locale, e.MessageKey, e.MessageArgs...)
+}
+
 func ErrBookingAlreadyExists(classID uuid.UUID, email string, err error) *BusinessError {
 	return &BusinessError{
-		ClassID: &classID,
-		Code:    BookingAlreadyExistsCode,
-		Message: "Wygląda na to, że rezerwacja dla: " + email + " już istnieje. " +
-			"Sprawdź skrzynkę mailową, aby znaleźć wcześniejsze potwierdzenie.",
-		Err: err,
+		ClassID:     &classID,
+		Code:        BookingAlreadyExistsCode,
+		MessageKey:  "error.booking_already_exists",
+		MessageArgs: []any{email},
+		Err:         err,
 	}
 }
 
 func ErrBookingNotFound(err error) *BusinessError {
 	return &BusinessError{
-		Code:    BookingNotFoundCode,
-		Message: "Nie znaleziono rezerwacji na te zajęcia. Została już wcześniej odwołana albo usunięta.",
-		Err:     err,
+		Code:       BookingNotFoundCode,
+		MessageKey: "error.booking_not_found",
+		Err:        err,
 	}
 }
 
 func ErrClassExpired(classID uuid.UUID, err error) *BusinessError {
 	return &BusinessError{
-		ClassID: &classID,
-		Code:    ClassExpiredCode,
-		Message: "Rezerwacja niedostępna – zajęcia już się zaczęły albo odbyły.",
-		Err:     err,
+		ClassID:    &classID,
+		Code:       ClassExpiredCode,
+		MessageKey: "error.class_expired",
+		Err:        err,
 	}
 }
 
 func ErrPendingBookingNotFound(err error) *BusinessError {
 	return &BusinessError{
-		Code: PendingBookingNotFoundCode,
-		Message: "Link potwierdzający rezerwację wygasł bądź został już wykorzystany, " +
-			"rozpocznij nową rezerwację lub poszukaj potwierdzania w skrzynkce mailowej.",
-		Err: err,
+		Code:       PendingBookingNotFoundCode,
+		MessageKey: "error.pending_booking_not_found",
+		Err:        err,
 	}
 }
 
 func ErrTooManyPendingBookings(classID uuid.UUID, email string, err error) *BusinessError {
 	return &BusinessError{
-		Code:    TooManyPendingBookingsCode,
-		ClassID: &classID,
-		Message: fmt.Sprintf("Wyczerpano limit linków potwierdzających dla %s. "+
-			"Sprawdź wiadości odebrane lub spam w skrzynce mailowej.", email),
-		Err: err,
+		Code:        TooManyPendingBookingsCode,
+		ClassID:     &classID,
+		MessageKey:  "error.too_many_pending_bookings",
+		MessageArgs: []any{email},
+		Err:         err,
 	}
 }
 
 func ErrClassFullyBooked(classID uuid.UUID, err error) *BusinessError {
 	return &BusinessError{
-		Code:    ClassFullyBookedCode,
-		ClassID: &classID,
-		Message: "Brak wolnych miejsc na te zajęcia",
-		Err:     err,
+		Code:       ClassFullyBookedCode,
+		ClassID:    &classID,
+		MessageKey: "error.class_fully_booked",
+		Err:        err,
 	}
 }
 
+// ErrTooLateToBook is not reported as fully booked, that page offers the waitlist and
+// joining it can not help when the class is about to start.
 func ErrTooLateToBook(classID uuid.UUID, err error) *BusinessError {
 	return &BusinessError{
-		Code:    ClassFullyBookedCode,
-		ClassID: &classID,
-		Message: "Zajęcia nie mają żadnej innej rezerwacji, a do rozpoczęcia zostało zbyt mało czasu by zarezerwować te zajęcia.",
-		Err:     err,
+		Code:       TooLateToBook,
+		ClassID:    &classID,
+		MessageKey: "error.too_late_to_book",
+		Err:        err,
 	}
 }
 
 func ErrSomeoneBookedClassFaster(err error) *BusinessError {
 	return &BusinessError{
-		Code:    SomeoneBookedClassFasterCode,
-		Message: "Ktoś Cię uprzedził... :( Brak wolnych miejsc na te zajęcia.",
-		Err:     err,
+		Code:       SomeoneBookedClassFasterCode,
+		MessageKey: "error.someone_booked_class_faster",
+		Err:        err,
 	}
 }
 
 func ErrInvalidCancellationLink(err error) *BusinessError {
 	return &BusinessError{
-		Code:    InvalidCancellationLinkCode,
-		Message: "Link do odwołania rezerwacji wygasł albo jest nieprawidłowy, skontaktuj się ze mną.",
-		Err:     err,
+		Code:       InvalidCancellationLinkCode,
+		MessageKey: "error.invalid_cancellation_link",
+		Err:        err,
+	}
+}
+
+func ErrClassNotFullyBooked(classID uuid.UUID, err error) *BusinessError {
+	return &BusinessError{
+		Code:       ClassNotFullyBookedCode,
+		ClassID:    &classID,
+		MessageKey: "error.class_not_fully_booked",
+		Err:        err,
+	}
+}
+
+func ErrAlreadyOnWaitlist(classID uuid.UUID, email string, err error) *BusinessError {
+	return &BusinessError{
+		Code:        AlreadyOnWaitlistCode,
+		ClassID:     &classID,
+		MessageKey:  "error.already_on_waitlist",
+		MessageArgs: []any{email},
+		Err:         err,
+	}
+}
+
+func ErrInvalidLoginLink(err error) *BusinessError {
+	return &BusinessError{
+		Code:       InvalidLoginLinkCode,
+		MessageKey: "error.invalid_login_link",
+		Err:        err,
+	}
+}
+
+func ErrStudentSessionExpired(err error) *BusinessError {
+	return &BusinessError{
+		Code:       StudentSessionExpiredCode,
+		MessageKey: "error.student_session_expired",
+		Err:        err,
+	}
+}
+
+func ErrCalendarFeedNotFound(err error) *BusinessError {
+	return &BusinessError{
+		Code:       CalendarFeedNotFoundCode,
+		MessageKey: "error.calendar_feed_not_found",
+		Err:        err,
+	}
+}
+
+func ErrBookingNotOpenYet(classID uuid.UUID, opensAt string, err error) *BusinessError {
+	return &BusinessError{
+		Code:        BookingNotOpenYetCode,
+		ClassID:     &classID,
+		MessageKey:  "error.booking_not_open_yet",
+		MessageArgs: []any{opensAt},
+		Err:         err,
+	}
+}
+
+func ErrTooManyActiveBookings(classID uuid.UUID, email string, limit int, err error) *BusinessError {
+	return &BusinessError{
+		Code:        TooManyActiveBookingsCode,
+		ClassID:     &classID,
+		MessageKey:  "error.too_many_active_bookings",
+		MessageArgs: []any{email, limit},
+		Err:         err,
+	}
+}
+
+func ErrTooLateToReschedule(classID uuid.UUID, err error) *BusinessError {
+	return &BusinessError{
+		Code:       TooLateToRescheduleCode,
+		ClassID:    &classID,
+		MessageKey: "error.too_late_to_reschedule",
+		Err:        err,
+	}
+}
+
+func ErrPassNotOffered(err error) *BusinessError {
+	return &BusinessError{
+		Code:       PassNotOfferedCode,
+		MessageKey: "error.pass_not_offered",
+		Err:        err,
+	}
+}
+
+func ErrPaymentNotFound(err error) *BusinessError {
+	return &BusinessError{
+		Code:       PaymentNotFoundCode,
+		MessageKey: "error.payment_not_found",
+		Err:        err,
+	}
+}
+
+func ErrClassTypeNotFound(err error) *BusinessError {
+	return &BusinessError{
+		Code:       ClassTypeNotFoundCode,
+		MessageKey: "error.class_type_not_found",
+		Err:        err,
+	}
+}
+
+func ErrPassNotUsable(classID uuid.UUID, err error) *BusinessError {
+	return &BusinessError{
+		Code:       PassNotUsableCode,
+		ClassID:    &classID,
+		MessageKey: "error.pass_not_usable",
+		Err:        err,
+	}
+}
+
+func ErrClassNotFound(err error) *BusinessError {
+	return &BusinessError{
+		Code:       ClassNotFoundCode,
+		MessageKey: "error.class_not_found",
+		Err:        err,
+	}
+}
+
+func ErrDropInNotOffered(err error) *BusinessError {
+	return &BusinessError{
+		Code:       DropInNotOfferedCode,
+		MessageKey: "error.drop_in_not_offered",
+		Err:        err,
 	}
 }
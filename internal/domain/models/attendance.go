@@ -0,0 +1,41 @@
+package models
+
+// Roster is the attendance list of a class, checked by the instructor.
+type Roster struct {
+	Class   Class
+	Entries []RosterEntry
+}
+
+type RosterEntry struct {
+	Booking    Booking
+	Statistics ContactStatistics
+}
+
+// WalkInParams describe a student who came to class without booking.
+type WalkInParams struct {
+	FirstName string
+	LastName  string
+	Email     string
+}
+
+// ContactStatistics sums up the bookings of a contact by their status.
+type ContactStatistics struct {
+	Email             string
+	Confirmed         int
+	Attended          int
+	NoShows           int
+	LateCancellations int
+	Cancellations     int
+	WalkIns           int
+}
+
+// AttendanceRate is the share of marked classes the contact attended, zero when
+// none was marked yet.
+func (s ContactStatistics) AttendanceRate() float64 {
+	marked := s.Attended + s.NoShows
+	if marked == 0 {
+		return 0
+	}
+
+	return float64(s.Attended) / float64(marked)
+}
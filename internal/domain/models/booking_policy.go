@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// BookingRules limit when and how much students book and cancel, studio wide or for a
+// single class level.
+type BookingRules struct {
+	// MaxPendingBookingsPerEmail limits unconfirmed bookings of one email for one class.
+	MaxPendingBookingsPerEmail int
+	// EmptyClassDeadline closes booking of a class nobody booked yet this long before start.
+	EmptyClassDeadline time.Duration
+	// CancellationCutoff is how long before start a cancellation still frees the pass slot.
+	CancellationCutoff time.Duration
+	// MaxActiveBookings limits upcoming bookings of one student, 0 means no limit.
+	MaxActiveBookings int
+	// BookingWindow opens booking this long before start, 0 means right after the class is created.
+	BookingWindow time.Duration
+}
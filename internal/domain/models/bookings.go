@@ -8,6 +8,31 @@ import (
 	"github.com/google/uuid"
 )
 
+type BookingStatus string
+
+const (
+	BookingConfirmed     BookingStatus = "confirmed"
+	BookingCancelled     BookingStatus = "cancelled"
+	BookingLateCancelled BookingStatus = "late_cancelled"
+	BookingAttended      BookingStatus = "attended"
+	BookingNoShow        BookingStatus = "no_show"
+)
+
+// HoldsSpot tells whether the booking takes a spot in the class.
+func (s BookingStatus) HoldsSpot() bool {
+	return s != BookingCancelled && s != BookingLateCancelled
+}
+
+// ConsumesPassSlot is true for everything but a cancellation made in time.
+func (s BookingStatus) ConsumesPassSlot() bool {
+	return s != BookingCancelled
+}
+
+// IsAttendanceStatus tells whether the status can be set when checking attendance.
+func (s BookingStatus) IsAttendanceStatus() bool {
+	return s == BookingAttended || s == BookingNoShow
+}
+
 type Booking struct {
 	ID                uuid.UUID
 	ClassID           uuid.UUID
@@ -20,4 +45,25 @@ type Booking struct {
 	CreatedAt         time.Time
 	ConfirmationToken string
 	RemindedAt        *time.Time
+	Status            BookingStatus
+	CancelledAt       *time.Time
+	WalkIn            bool
+	PaymentID         optional.Optional[uuid.UUID]
+}
+
+// ConfirmedBookingParams describe a booking made by the admin or paid online, SendConfirmation
+// tells whether the student gets the usual confirmation email.
+type ConfirmedBookingParams struct {
+	ClassID          uuid.UUID
+	FirstName        string
+	LastName         string
+	Email            string
+	SendConfirmation bool
+	PaymentID        optional.Optional[uuid.UUID]
+}
+
+// BookingCancellation is a booking about to be cancelled, a Late one still consumes its pass slot.
+type BookingCancellation struct {
+	Booking Booking
+	Late    bool
 }
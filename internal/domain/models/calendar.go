@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// CalendarFeed holds the secret token of a student's personal calendar feed.
+type CalendarFeed struct {
+	Email     string
+	Token     string
+	CreatedAt time.Time
+}
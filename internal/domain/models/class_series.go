@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"main/pkg/optional"
+
+	"github.com/google/uuid"
+)
+
+type ClassSeriesFrequency string
+
+const (
+	ClassSeriesWeekly   ClassSeriesFrequency = "weekly"
+	ClassSeriesBiweekly ClassSeriesFrequency = "biweekly"
+)
+
+// ClassSeries is a template of classes repeated every week or every second week,
+// starting at StartTime. EndDate and ExceptionDates are compared by calendar day.
+type ClassSeries struct {
+	ID                uuid.UUID
+	Frequency         ClassSeriesFrequency
+	StartTime         time.Time
+	EndDate           *time.Time
+	ExceptionDates    []time.Time
+	ClassLevel        string
+	ClassName         string
+	MaxCapacity       int
+	LocationID        uuid.UUID
+	Location          Location
+	InstructorID      optional.Optional[uuid.UUID]
+	MaterializedUntil *time.Time
+}
+
+type UpdateClassSeries struct {
+	EndDate        *time.Time
+	ExceptionDates *[]time.Time
+	ClassLevel     *string
+	ClassName      *string
+	MaxCapacity    *int
+	LocationID     *uuid.UUID
+	InstructorID   *uuid.UUID
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"main/pkg/optional"
+
+	"github.com/google/uuid"
+)
+
+// ClassType is an entry of the class catalog, its defaults fill in the classes created
+// from it.
+type ClassType struct {
+	ID                uuid.UUID
+	Name              string
+	Level             string
+	Description       string
+	DefaultDuration   time.Duration
+	DefaultCapacity   int
+	DefaultLocationID optional.Optional[uuid.UUID]
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type ClassTypeParams struct {
+	Name              string
+	Level             string
+	Description       string
+	DefaultDuration   time.Duration
+	DefaultCapacity   int
+	DefaultLocationID optional.Optional[uuid.UUID]
+}
+
+type UpdateClassType struct {
+	Name              *string
+	Level             *string
+	Description       *string
+	DefaultDuration   *time.Duration
+	DefaultCapacity   *int
+	DefaultLocationID *uuid.UUID
+}
+
+// ClassTypeDescription is the public page of a class type.
+type ClassTypeDescription struct {
+	ClassType       ClassType
+	UpcomingClasses []ClassWithCurrentCapacity
+}
@@ -3,16 +3,50 @@ package models
 import (
 	"time"
 
+	"main/pkg/optional"
+
 	"github.com/google/uuid"
 )
 
+// ClassDuration is the length of classes created without a duration.
+const ClassDuration = time.Hour
+
+// ClassChange tells booked students what an update moved, the notifier words it.
+type ClassChange string
+
+const (
+	ClassChangeLocationAndStartTime ClassChange = "location_and_start_time"
+	ClassChangeLocation             ClassChange = "location"
+	ClassChangeStartTime            ClassChange = "start_time"
+)
+
 type Class struct {
 	ID          uuid.UUID
 	StartTime   time.Time
 	ClassLevel  string
 	ClassName   string
 	MaxCapacity int
-	Location    string
+	LocationID  uuid.UUID
+	Location    Location
+	// Instructor is the zero value for classes without InstructorID.
+	InstructorID optional.Optional[uuid.UUID]
+	Instructor   Instructor
+	SeriesID     optional.Optional[uuid.UUID]
+	ClassTypeID  optional.Optional[uuid.UUID]
+	Duration     time.Duration
+	// Sequence is bumped on every change so calendar clients replace the event.
+	Sequence  int
+	UpdatedAt time.Time
+}
+
+// EndTime falls back to ClassDuration for classes without a duration, e.g. in
+// notifications queued before classes had one.
+func (c Class) EndTime() time.Time {
+	if c.Duration <= 0 {
+		return c.StartTime.Add(ClassDuration)
+	}
+
+	return c.StartTime.Add(c.Duration)
 }
 
 type ClassWithCurrentCapacity struct {
@@ -22,7 +56,60 @@ type ClassWithCurrentCapacity struct {
 	ClassName       string
 	CurrentCapacity int
 	MaxCapacity     int
-	Location        string
+	Location        Location
+	Instructor      Instructor
+	ClassTypeID     optional.Optional[uuid.UUID]
+	Duration        time.Duration
+	Sequence        int
+	UpdatedAt       time.Time
+}
+
+// ClassFilter narrows the listed classes, its zero value matches every class.
+type ClassFilter struct {
+	ClassTypeID optional.Optional[uuid.UUID]
+	ClassLevel  string
+	ClassName   string
+	LocationID  optional.Optional[uuid.UUID]
+}
+
+func (f ClassFilter) Matches(class Class) bool {
+	if f.ClassTypeID.Exists() &&
+		(!class.ClassTypeID.Exists() || class.ClassTypeID.Get() != f.ClassTypeID.Get()) {
+		return false
+	}
+
+	if f.LocationID.Exists() && f.LocationID.Get() != class.LocationID {
+		return false
+	}
+
+	return (f.ClassLevel == "" || f.ClassLevel == class.ClassLevel) &&
+		(f.ClassName == "" || f.ClassName == class.ClassName)
+}
+
+// Schedule lists the classes between From and To matching the filter, the levels, names
+// and locations are the ones of every class in that time, to filter by.
+type Schedule struct {
+	From        time.Time
+	To          time.Time
+	Classes     []ClassWithCurrentCapacity
+	ClassLevels []string
+	ClassNames  []string
+	Locations   []Location
+}
+
+// PublicScheduleParams is a range of days in the studio time zone, To included, as
+// 2006-01-02. Without From it starts today, without To it lasts two weeks.
+type PublicScheduleParams struct {
+	From   string
+	To     string
+	Filter ClassFilter
+}
+
+// PublicSchedule lists the upcoming classes of the days FirstDay to LastDay.
+type PublicSchedule struct {
+	FirstDay time.Time
+	LastDay  time.Time
+	Classes  []ClassWithCurrentCapacity
 }
 
 type UpdateClass struct {
@@ -30,5 +117,5 @@ type UpdateClass struct {
 	ClassLevel  *string
 	ClassName   *string
 	MaxCapacity *int
-	Location    *string
+	LocationID  *uuid.UUID
 }
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"main/pkg/optional"
+
+	"github.com/google/uuid"
+)
+
+func TestClassFilter_Matches(t *testing.T) {
+	vinyasa := uuid.New()
+	studio := uuid.New()
+	class := Class{
+		ClassName:   "vinyasa",
+		ClassLevel:  "beginner",
+		LocationID:  studio,
+		ClassTypeID: optional.Of(vinyasa),
+	}
+
+	tests := []struct {
+		name   string
+		filter ClassFilter
+		class  Class
+		want   bool
+	}{
+		{name: "empty filter matches", filter: ClassFilter{}, class: class, want: true},
+		{name: "same type", filter: ClassFilter{ClassTypeID: optional.Of(vinyasa)}, class: class, want: true},
+		{name: "other type", filter: ClassFilter{ClassTypeID: optional.Of(uuid.New())}, class: class, want: false},
+		{name: "class without type", filter: ClassFilter{ClassTypeID: optional.Of(vinyasa)}, class: Class{}, want: false},
+		{name: "same level", filter: ClassFilter{ClassLevel: "beginner"}, class: class, want: true},
+		{name: "other level", filter: ClassFilter{ClassLevel: "advanced"}, class: class, want: false},
+		{name: "same name", filter: ClassFilter{ClassName: "vinyasa"}, class: class, want: true},
+		{name: "other name", filter: ClassFilter{ClassName: "hatha"}, class: class, want: false},
+		{name: "same location", filter: ClassFilter{LocationID: optional.Of(studio)}, class: class, want: true},
+		{name: "other location", filter: ClassFilter{LocationID: optional.Of(uuid.New())}, class: class, want: false},
+		{
+			name:   "type and other level",
+			filter: ClassFilter{ClassTypeID: optional.Of(vinyasa), ClassLevel: "advanced"},
+			class:  class,
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(tt.class); got != tt.want {
+				t.Errorf("Matches() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClass_EndTime(t *testing.T) {
+	start := time.Date(2026, 10, 21, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		duration time.Duration
+		want     time.Time
+	}{
+		{name: "own duration", duration: 90 * time.Minute, want: start.Add(90 * time.Minute)},
+		{name: "without duration", duration: 0, want: start.Add(ClassDuration)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class := Class{StartTime: start, Duration: tt.duration}
+			if got := class.EndTime(); !got.Equal(tt.want) {
+				t.Errorf("EndTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package models
 
+import "main/pkg/i18n"
+
 type Contact struct {
 	ID        int
 	Email     string
 	FirstName string
 	LastName  string
+	// Language is empty until the student uses the site, emails then fall back to the default.
+	Language i18n.Locale
 }
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Instructor teaches classes, Signature closes the emails about their classes.
+type Instructor struct {
+	ID        uuid.UUID
+	Name      string
+	Bio       string
+	Email     string
+	Signature string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type InstructorParams struct {
+	Name      string
+	Bio       string
+	Email     string
+	Signature string
+}
+
+type UpdateInstructor struct {
+	Name      *string
+	Bio       *string
+	Email     *string
+	Signature *string
+}
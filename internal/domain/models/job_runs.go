@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type JobRun struct {
+	Name      string
+	LastRunAt *time.Time
+	NextRunAt time.Time
+	LastError *string
+}
+
+type JobStatus struct {
+	Name      string
+	Interval  time.Duration
+	LastRunAt *time.Time
+	NextRunAt *time.Time
+	LastError *string
+}
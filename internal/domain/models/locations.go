@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Location is where classes take place. TimeZone is the IANA name of the zone the times
+// of its classes are shown in, the studio one when empty. DefaultCapacity is used for
+// classes created without max capacity.
+type Location struct {
+	ID              uuid.UUID
+	Name            string
+	Address         string
+	Directions      string
+	MapURL          string
+	DefaultCapacity int
+	AccessNotes     string
+	TimeZone        string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// FullAddress is the name followed by the address, as shown in calendars.
+func (l Location) FullAddress() string {
+	if l.Address == "" {
+		return l.Name
+	}
+
+	return l.Name + ", " + l.Address
+}
+
+// UnmarshalJSON also accepts a bare location name, the way notifications queued before
+// locations were introduced carry it.
+func (l *Location) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*l = Location{Name: name}
+
+		return nil
+	}
+
+	type location Location
+
+	var decoded location
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*l = Location(decoded)
+
+	return nil
+}
+
+type LocationParams struct {
+	Name            string
+	Address         string
+	Directions      string
+	MapURL          string
+	DefaultCapacity int
+	AccessNotes     string
+	TimeZone        string
+}
+
+type UpdateLocation struct {
+	Name            *string
+	Address         *string
+	Directions      *string
+	MapURL          *string
+	DefaultCapacity *int
+	AccessNotes     *string
+	TimeZone        *string
+}
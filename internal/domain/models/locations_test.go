@@ -0,0 +1,50 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestLocationUnmarshalJSON(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name    string
+		data    string
+		want    Location
+		wantErr bool
+	}{
+		{
+			name: "name of a notification queued before locations",
+			data: `"studio"`,
+			want: Location{Name: "studio"},
+		},
+		{
+			name: "location",
+			data: `{"ID":"` + id.String() + `","Name":"studio","Address":"Długa 1, Kraków"}`,
+			want: Location{ID: id, Name: "studio", Address: "Długa 1, Kraków"},
+		},
+		{
+			name:    "neither",
+			data:    `42`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Location
+
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got %v, want error %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
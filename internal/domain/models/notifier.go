@@ -2,22 +2,44 @@ package models
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type NotifierParams struct {
 	RecipientEmail     string
 	RecipientFirstName string
 	RecipientLastName  string
+	ClassID            uuid.UUID
+	ClassSequence      int
 	ClassName          string
 	ClassLevel         string
 	StartTime          time.Time
-	Location           string
+	Location           Location
+	Instructor         Instructor
+	Duration           time.Duration
 	PassSlots          []PassSlot
 }
 
+// Class returns the class the notification is about, as far as the params describe it.
+func (p NotifierParams) Class() Class {
+	return Class{
+		ID:         p.ClassID,
+		StartTime:  p.StartTime,
+		ClassLevel: p.ClassLevel,
+		ClassName:  p.ClassName,
+		LocationID: p.Location.ID,
+		Location:   p.Location,
+		Instructor: p.Instructor,
+		Duration:   p.Duration,
+		Sequence:   p.ClassSequence,
+	}
+}
+
 type OperationStatus string
 
 const (
 	StatusBooked    OperationStatus = "booked"
 	StatusCancelled OperationStatus = "cancelled"
+	StatusMoved     OperationStatus = "moved"
 )
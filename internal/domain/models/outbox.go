@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"main/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+type NotificationKind string
+
+const (
+	NotificationPassActivation         NotificationKind = "pass_activation"
+	NotificationConfirmationLink       NotificationKind = "confirmation_link"
+	NotificationBookingConfirmation    NotificationKind = "booking_confirmation"
+	NotificationBookingCancellation    NotificationKind = "booking_cancellation"
+	NotificationClassUpdate            NotificationKind = "class_update"
+	NotificationClassCancellation      NotificationKind = "class_cancellation"
+	NotificationBookingReminder        NotificationKind = "booking_reminder"
+	NotificationWaitlistSpotAvailable  NotificationKind = "waitlist_spot_available"
+	NotificationStudentLoginLink       NotificationKind = "student_login_link"
+	NotificationBookingMoved           NotificationKind = "booking_moved"
+	NotificationInstructorSubstitution NotificationKind = "instructor_substitution"
+)
+
+// Notification is an email stored in the outbox. Params carry recipient and class details,
+// Link is a confirmation or cancellation link and Message is a free text reason. Locale is
+// set when the recipient triggered the email, otherwise their contact language is used.
+// Previous describes the class a moved booking left, or the class before its instructor
+// was substituted.
+type Notification struct {
+	Kind     NotificationKind
+	Params   NotifierParams
+	Link     string
+	Message  string
+	Change   ClassChange
+	Previous NotifierParams
+	Locale   i18n.Locale
+}
+
+type OutboxStatus string
+
+const (
+	OutboxStatusPending OutboxStatus = "pending"
+	OutboxStatusSent    OutboxStatus = "sent"
+	OutboxStatusDead    OutboxStatus = "dead"
+)
+
+type OutboxMessage struct {
+	ID            uuid.UUID
+	Notification  Notification
+	Status        OutboxStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     *string
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
@@ -3,17 +3,54 @@ package models
 import (
 	"time"
 
+	"main/pkg/optional"
+
 	"github.com/google/uuid"
 )
 
+// Pass is valid from ValidFrom until ValidUntil, a nil ValidUntil means the pass never expires.
+// Classes starting during one of the Freezes can not be booked with the pass. ProductID is
+// the product the pass was sold as, passes activated without one have none.
 type Pass struct {
 	ID         int
 	Email      string
 	TotalSlots int
+	ValidFrom  time.Time
+	ValidUntil *time.Time
+	ProductID  optional.Optional[uuid.UUID]
+	Freezes    []PassFreeze
 	UpdatedAt  time.Time
 	CreatedAt  time.Time
 }
 
+type PassFreeze struct {
+	ID        int
+	PassID    int
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Reason    string
+	CreatedAt time.Time
+}
+
+func (p Pass) IsExpiredAt(t time.Time) bool {
+	return p.ValidUntil != nil && !t.Before(*p.ValidUntil)
+}
+
+func (p Pass) IsFrozenAt(t time.Time) bool {
+	for _, freeze := range p.Freezes {
+		if !t.Before(freeze.StartsAt) && t.Before(freeze.EndsAt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsUsableAt reports whether a class starting at t can be booked with the pass.
+func (p Pass) IsUsableAt(t time.Time) bool {
+	return !t.Before(p.ValidFrom) && !p.IsExpiredAt(t) && !p.IsFrozenAt(t)
+}
+
 type PassSlot struct {
 	Status         PassSlotStatus
 	ClassStartTime *time.Time
@@ -25,12 +62,80 @@ const (
 	Blank PassSlotStatus = iota
 	Past
 	Future
+	// LateCancelled and NoShow slots are consumed by classes the student did not attend.
+	LateCancelled
+	NoShow
 )
 
+// PassWithSlots is a pass together with bookings that consumed its slots.
+type PassWithSlots struct {
+	Pass     Pass
+	Bookings []Booking
+	Slots    []PassSlot
+}
+
+func (p PassWithSlots) UsedSlots() int {
+	used := 0
+
+	for _, slot := range p.Slots {
+		if slot.Status != Blank {
+			used++
+		}
+	}
+
+	return used
+}
+
+func (p PassWithSlots) RemainingSlots() int {
+	return max(p.Pass.TotalSlots-p.UsedSlots(), 0)
+}
+
+// PassFilter narrows the list of passes, nil fields are not applied.
+type PassFilter struct {
+	Email         *string
+	Active        *bool
+	Exhausted     *bool
+	WithFreeSlots *bool
+	ProductID     *uuid.UUID
+}
+
+// Matches checks the filter against the pass state at the given time,
+// active means the pass can be used at that time.
+func (f PassFilter) Matches(pass PassWithSlots, at time.Time) bool {
+	if f.Active != nil && pass.Pass.IsUsableAt(at) != *f.Active {
+		return false
+	}
+
+	if f.Exhausted != nil && (pass.RemainingSlots() == 0) != *f.Exhausted {
+		return false
+	}
+
+	if f.WithFreeSlots != nil && (pass.RemainingSlots() > 0) != *f.WithFreeSlots {
+		return false
+	}
+
+	if f.ProductID != nil && (!pass.Pass.ProductID.Exists() || pass.Pass.ProductID.Get() != *f.ProductID) {
+		return false
+	}
+
+	return true
+}
+
+// PassActivationParams with a ProductID take TotalSlots and the validity from the product
+// when they are not given.
 type PassActivationParams struct {
 	Email                string
 	InitialAssignedSlots int
 	TotalSlots           int
+	ValidFrom            *time.Time
+	ValidUntil           *time.Time
+	ProductID            optional.Optional[uuid.UUID]
+}
+
+type PassFreezeParams struct {
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   string
 }
 
 type PassActivation struct {
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPassIsUsableAt(t *testing.T) {
+	validFrom := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	validUntil := validFrom.AddDate(0, 0, 30)
+
+	pass := Pass{
+		ValidFrom:  validFrom,
+		ValidUntil: &validUntil,
+		Freezes: []PassFreeze{
+			{
+				StartsAt: validFrom.AddDate(0, 0, 10),
+				EndsAt:   validFrom.AddDate(0, 0, 17),
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		pass   Pass
+		at     time.Time
+		usable bool
+	}{
+		{
+			name:   "before valid from",
+			pass:   pass,
+			at:     validFrom.Add(-time.Minute),
+			usable: false,
+		},
+		{
+			name:   "at valid from",
+			pass:   pass,
+			at:     validFrom,
+			usable: true,
+		},
+		{
+			name:   "during freeze",
+			pass:   pass,
+			at:     validFrom.AddDate(0, 0, 12),
+			usable: false,
+		},
+		{
+			name:   "at freeze end",
+			pass:   pass,
+			at:     validFrom.AddDate(0, 0, 17),
+			usable: true,
+		},
+		{
+			name:   "at valid until",
+			pass:   pass,
+			at:     validUntil,
+			usable: false,
+		},
+		{
+			name:   "without expiry",
+			pass:   Pass{ValidFrom: validFrom},
+			at:     validFrom.AddDate(5, 0, 0),
+			usable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pass.IsUsableAt(tt.at); got != tt.usable {
+				t.Errorf("IsUsableAt(%v) = %v, want %v", tt.at, got, tt.usable)
+			}
+		})
+	}
+}
+
+func TestPassFilterMatches(t *testing.T) {
+	now := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	expiredAt := now.Add(-time.Hour)
+	yes, no := true, false
+
+	exhausted := PassWithSlots{
+		Pass:  Pass{ValidFrom: now.AddDate(0, -1, 0), TotalSlots: 1},
+		Slots: []PassSlot{{Status: Past}},
+	}
+	expired := PassWithSlots{
+		Pass:  Pass{ValidFrom: now.AddDate(0, -1, 0), ValidUntil: &expiredAt, TotalSlots: 2},
+		Slots: []PassSlot{{Status: Past}, {Status: Blank}},
+	}
+
+	tests := []struct {
+		name    string
+		filter  PassFilter
+		pass    PassWithSlots
+		matches bool
+	}{
+		{
+			name:    "empty filter",
+			filter:  PassFilter{},
+			pass:    expired,
+			matches: true,
+		},
+		{
+			name:    "active exhausted pass",
+			filter:  PassFilter{Active: &yes, Exhausted: &yes},
+			pass:    exhausted,
+			matches: true,
+		},
+		{
+			name:    "exhausted pass has no free slots",
+			filter:  PassFilter{WithFreeSlots: &yes},
+			pass:    exhausted,
+			matches: false,
+		},
+		{
+			name:    "expired pass is not active",
+			filter:  PassFilter{Active: &no, WithFreeSlots: &yes},
+			pass:    expired,
+			matches: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(tt.pass, now); got != tt.matches {
+				t.Errorf("Matches() = %v, want %v", got, tt.matches)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"main/pkg/i18n"
+	"main/pkg/optional"
+
+	"github.com/google/uuid"
+)
+
+// DropInCheckoutTTL is how long a started drop-in checkout holds its spot in the class.
+// Paid later, the booking is given only if the class still has a free spot.
+const DropInCheckoutTTL = 30 * time.Minute
+
+type PaymentKind string
+
+const (
+	PaymentKindPass   PaymentKind = "pass"
+	PaymentKindDropIn PaymentKind = "drop_in"
+)
+
+type PaymentStatus string
+
+const (
+	PaymentPending PaymentStatus = "pending"
+	PaymentPaid    PaymentStatus = "paid"
+	PaymentFailed  PaymentStatus = "failed"
+	// PaymentUnfulfilled is paid, but the pass or the booking could not be given,
+	// e.g. the class filled up during the checkout. The admin refunds it.
+	PaymentUnfulfilled PaymentStatus = "unfulfilled"
+)
+
+// Payment is a checkout of a pass or a single class. PassID or BookingID is set once
+// the paid purchase is fulfilled.
+type Payment struct {
+	ID            uuid.UUID
+	Kind          PaymentKind
+	Status        PaymentStatus
+	Provider      string
+	ProviderRef   string
+	Email         string
+	FirstName     string
+	LastName      string
+	ClassID       optional.Optional[uuid.UUID]
+	TotalSlots    int
+	ProductID     optional.Optional[uuid.UUID]
+	Amount        int
+	Currency      string
+	Language      i18n.Locale
+	PassID        optional.Optional[int]
+	BookingID     optional.Optional[uuid.UUID]
+	FailureReason *string
+	CreatedAt     time.Time
+	PaidAt        *time.Time
+}
+
+type PassPurchaseParams struct {
+	Email     string
+	ProductID uuid.UUID
+}
+
+type DropInPurchaseParams struct {
+	ClassID   uuid.UUID
+	FirstName string
+	LastName  string
+	Email     string
+}
+
+// Checkout is where the student is sent to pay, ProviderRef identifies it in webhooks.
+type Checkout struct {
+	PaymentID   uuid.UUID
+	ProviderRef string
+	RedirectURL string
+}
+
+// PaymentEvent is a verified webhook of the payment provider, which may deliver the
+// same event more than once.
+type PaymentEvent struct {
+	EventID     string
+	ProviderRef string
+	Status      PaymentStatus
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductKind string
+
+const (
+	ProductDropIn ProductKind = "drop_in"
+	ProductPass   ProductKind = "pass"
+)
+
+// Product is an entry of the price list, Price is in the smallest unit of Currency.
+// A pass product gives TotalSlots entries valid for ValidityDays, zero days means the
+// default pass validity. Inactive products are kept for the passes sold as them.
+type Product struct {
+	ID           uuid.UUID
+	Name         string
+	Kind         ProductKind
+	TotalSlots   int
+	ValidityDays int
+	Price        int
+	Currency     string
+	Active       bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type ProductParams struct {
+	Name         string
+	Kind         ProductKind
+	TotalSlots   int
+	ValidityDays int
+	Price        int
+	Currency     string
+}
+
+// UpdateProduct changes the offer, the kind and slots stay as they were sold.
+type UpdateProduct struct {
+	Name         *string
+	ValidityDays *int
+	Price        *int
+	Currency     *string
+	Active       *bool
+}
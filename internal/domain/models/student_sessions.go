@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// StudentLoginLinkTTL is how long a login link sent by email stays valid.
+	StudentLoginLinkTTL = 30 * time.Minute
+	// StudentSessionTTL is how long a student stays logged in after using the login link.
+	StudentSessionTTL = 30 * 24 * time.Hour
+)
+
+// StudentSession is created with a single use login token. Once the login link is used
+// the token is rotated and ActivatedAt is set, the new token identifies the session.
+type StudentSession struct {
+	ID          uuid.UUID
+	Email       string
+	Token       string
+	ActivatedAt *time.Time
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+type StudentBookings struct {
+	Email            string
+	Upcoming         []Booking
+	Past             []Booking
+	Passes           []PassWithSlots
+	CalendarFeedLink string
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"main/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+// WaitlistOfferTTL is how long a promoted waitlist entry keeps its spot before
+// it is offered to the next person in line.
+const WaitlistOfferTTL = time.Hour
+
+type WaitlistEntry struct {
+	ID        uuid.UUID
+	ClassID   uuid.UUID
+	Class     Class
+	Email     string
+	FirstName string
+	LastName  string
+	// Language is the one the student joined in, the offer of a spot is sent in it.
+	Language  i18n.Locale
+	CreatedAt time.Time
+	OfferedAt *time.Time
+}
+
+type WaitlistEntryParams struct {
+	ClassID   uuid.UUID
+	FirstName string
+	LastName  string
+	Email     string
+}
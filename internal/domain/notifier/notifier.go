@@ -1,17 +1,22 @@
 package notifier
 
 import (
-	"time"
-
 	"main/internal/domain/models"
+	"main/pkg/i18n"
 )
 
 type INotifier interface {
-	NotifyPassActivation(email string, passSlots []models.PassSlot) error
-	NotifyConfirmationLink(email, firstName, confirmationLink string, classStartTime time.Time) error
-	NotifyBookingConfirmation(params models.NotifierParams, cancellationLink string) error
-	NotifyBookingCancellation(params models.NotifierParams) error
-	NotifyClassUpdate(params models.NotifierParams, msg string) error
-	NotifyClassCancellation(params models.NotifierParams, msg string) error
-	NotifyBookingReminder(params models.NotifierParams, cancellationLink string) error
+	NotifyPassActivation(locale i18n.Locale, email string, passSlots []models.PassSlot) error
+	NotifyConfirmationLink(locale i18n.Locale, params models.NotifierParams, confirmationLink string) error
+	NotifyBookingConfirmation(locale i18n.Locale, params models.NotifierParams, cancellationLink string) error
+	NotifyBookingCancellation(locale i18n.Locale, params models.NotifierParams) error
+	NotifyBookingMoved(
+		locale i18n.Locale, params, previous models.NotifierParams, cancellationLink string,
+	) error
+	NotifyClassUpdate(locale i18n.Locale, params models.NotifierParams, change models.ClassChange) error
+	NotifyInstructorSubstitution(locale i18n.Locale, params models.NotifierParams, previous models.Instructor) error
+	NotifyClassCancellation(locale i18n.Locale, params models.NotifierParams, msg string) error
+	NotifyBookingReminder(locale i18n.Locale, params models.NotifierParams, cancellationLink string) error
+	NotifyWaitlistSpotAvailable(locale i18n.Locale, params models.NotifierParams, confirmationLink string) error
+	NotifyStudentLoginLink(locale i18n.Locale, email, loginLink string) error
 }
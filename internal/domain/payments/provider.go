@@ -0,0 +1,20 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"main/internal/domain/models"
+)
+
+// ErrInvalidWebhook is returned for webhooks that are malformed or not signed by the provider.
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+type IPaymentProvider interface {
+	Name() string
+	// CreateCheckout registers the payment with the provider, the student comes back to
+	// returnURL once done.
+	CreateCheckout(ctx context.Context, payment models.Payment, returnURL string) (models.Checkout, error)
+	ParseWebhook(payload []byte, header http.Header) (models.PaymentEvent, error)
+}
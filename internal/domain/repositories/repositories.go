@@ -2,8 +2,10 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"main/internal/domain/models"
+	"main/pkg/i18n"
 
 	"github.com/google/uuid"
 )
@@ -14,14 +16,39 @@ type Repositories struct {
 	Classes         IClasses
 	Passes          IPasses
 	Contacts        IContacts
+	Waitlist        IWaitlist
+	ClassSeries     IClassSeries
+	Outbox          IOutbox
+	StudentSessions IStudentSessions
+	JobRuns         IJobRuns
+	CalendarFeeds   ICalendarFeeds
+	Payments        IPayments
+	Products        IProducts
+	Locations       ILocations
+	Instructors     IInstructors
+	ClassTypes      IClassTypes
 }
 
 type IClasses interface {
 	Get(ctx context.Context, id uuid.UUID) (models.Class, error)
 	List(ctx context.Context) ([]models.Class, error)
+	ListBySeriesID(ctx context.Context, seriesID uuid.UUID) ([]models.Class, error)
+	// ListBetween lists the classes starting from from up to, but not including, to.
+	ListBetween(ctx context.Context, from, to time.Time) ([]models.Class, error)
 	Insert(ctx context.Context, classes []models.Class) ([]models.Class, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	Update(ctx context.Context, id uuid.UUID, update map[string]any) (models.Class, error)
+	// Lock holds the class row until the transaction ends, so the transactions booking
+	// the class count its spots one after another.
+	Lock(ctx context.Context, id uuid.UUID) error
+}
+
+type IClassSeries interface {
+	Get(ctx context.Context, id uuid.UUID) (models.ClassSeries, error)
+	List(ctx context.Context) ([]models.ClassSeries, error)
+	Insert(ctx context.Context, series models.ClassSeries) error
+	Update(ctx context.Context, series models.ClassSeries) error
+	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 type IBookings interface {
@@ -30,11 +57,14 @@ type IBookings interface {
 	List(ctx context.Context) ([]models.Booking, error)
 	ListWithoutPassByEmail(ctx context.Context, email string, limit int) ([]models.Booking, error)
 	ListByClassID(ctx context.Context, classID uuid.UUID) ([]models.Booking, error)
+	ListByEmail(ctx context.Context, email string) ([]models.Booking, error)
 	ListByPassID(ctx context.Context, passID int) ([]models.Booking, error)
 	CountForPassID(ctx context.Context, passID int) (int, error)
 	CountForClassID(ctx context.Context, classID uuid.UUID) (int, error)
+	ListStatisticsByEmails(ctx context.Context, emails []string) ([]models.ContactStatistics, error)
 	Insert(ctx context.Context, booking models.Booking) (uuid.UUID, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	UpdateStatus(ctx context.Context, id uuid.UUID, status models.BookingStatus) error
+	DeleteByClassID(ctx context.Context, classID uuid.UUID) error
 	Update(ctx context.Context, id uuid.UUID, update map[string]any) error
 }
 
@@ -42,14 +72,115 @@ type IPendingBookings interface {
 	GetByConfirmationToken(ctx context.Context, token string) (models.PendingBooking, error)
 	Insert(ctx context.Context, booking models.PendingBooking) error
 	List(ctx context.Context) ([]models.PendingBooking, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteCreatedBefore(ctx context.Context, before time.Time) (int, error)
 }
 
 type IPasses interface {
-	Insert(ctx context.Context, email string, totalSlots int) (models.Pass, error)
+	Get(ctx context.Context, id int) (models.Pass, error)
+	List(ctx context.Context, email *string) ([]models.Pass, error)
 	ListByEmail(ctx context.Context, email string, limit int) ([]models.Pass, error)
+	Insert(ctx context.Context, pass models.Pass) (models.Pass, error)
+	Update(ctx context.Context, id int, update map[string]any) error
+	InsertFreeze(ctx context.Context, freeze models.PassFreeze) (models.PassFreeze, error)
+}
+
+type IWaitlist interface {
+	GetByClassIDAndEmail(ctx context.Context, classID uuid.UUID, email string) (models.WaitlistEntry, error)
+	ListByClassID(ctx context.Context, classID uuid.UUID) ([]models.WaitlistEntry, error)
+	ListClassIDsWithOffersBefore(ctx context.Context, before time.Time) ([]uuid.UUID, error)
+	CountOfferedSince(ctx context.Context, classID uuid.UUID, since time.Time) (int, error)
+	Insert(ctx context.Context, entry models.WaitlistEntry) error
+	Update(ctx context.Context, id uuid.UUID, update map[string]any) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteByClassIDAndEmail(ctx context.Context, classID uuid.UUID, email string) error
+	DeleteByClassID(ctx context.Context, classID uuid.UUID) error
 }
 
 type IContacts interface {
 	Insert(ctx context.Context, email, firstName, lastName string) (models.Contact, error)
+	GetByEmail(ctx context.Context, email string) (models.Contact, error)
 	List(ctx context.Context) ([]models.Contact, error)
+	UpdateLanguage(ctx context.Context, email string, language i18n.Locale) error
+}
+
+type IJobRuns interface {
+	Get(ctx context.Context, name string) (models.JobRun, error)
+	List(ctx context.Context) ([]models.JobRun, error)
+	Upsert(ctx context.Context, jobRun models.JobRun) error
+}
+
+type IOutbox interface {
+	Enqueue(ctx context.Context, notification models.Notification) error
+	Get(ctx context.Context, id uuid.UUID) (models.OutboxMessage, error)
+	ListByStatus(ctx context.Context, status models.OutboxStatus) ([]models.OutboxMessage, error)
+	ListDue(ctx context.Context, now time.Time, limit int) ([]models.OutboxMessage, error)
+	Update(ctx context.Context, id uuid.UUID, update map[string]any) error
+}
+
+type IStudentSessions interface {
+	GetByToken(ctx context.Context, token string) (models.StudentSession, error)
+	Insert(ctx context.Context, session models.StudentSession) error
+	Update(ctx context.Context, id uuid.UUID, update map[string]any) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteExpiredBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+type ICalendarFeeds interface {
+	GetByEmail(ctx context.Context, email string) (models.CalendarFeed, error)
+	GetByToken(ctx context.Context, token string) (models.CalendarFeed, error)
+	Insert(ctx context.Context, feed models.CalendarFeed) error
+}
+
+type IPayments interface {
+	Get(ctx context.Context, id uuid.UUID) (models.Payment, error)
+	GetByProviderRef(ctx context.Context, providerRef string) (models.Payment, error)
+	List(ctx context.Context) ([]models.Payment, error)
+	Insert(ctx context.Context, payment models.Payment) error
+	Update(ctx context.Context, id uuid.UUID, update map[string]any) error
+	UpdateStatus(ctx context.Context, id uuid.UUID, from, to models.PaymentStatus) error
+	// CountPendingDropIns counts the drop-in checkouts of the class started since, except
+	// the ones of email.
+	CountPendingDropIns(
+		ctx context.Context, classID uuid.UUID, email string, since time.Time,
+	) (int, error)
+}
+
+type IProducts interface {
+	Get(ctx context.Context, id uuid.UUID) (models.Product, error)
+	List(ctx context.Context) ([]models.Product, error)
+	ListActive(ctx context.Context) ([]models.Product, error)
+	Insert(ctx context.Context, product models.Product) error
+	Update(ctx context.Context, product models.Product) error
+}
+
+type ILocations interface {
+	Get(ctx context.Context, id uuid.UUID) (models.Location, error)
+	List(ctx context.Context) ([]models.Location, error)
+	Insert(ctx context.Context, location models.Location) error
+	Update(ctx context.Context, location models.Location) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// IsInUse tells whether any class or class series takes place at the location, or any
+	// class type defaults to it.
+	IsInUse(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+type IInstructors interface {
+	Get(ctx context.Context, id uuid.UUID) (models.Instructor, error)
+	List(ctx context.Context) ([]models.Instructor, error)
+	Insert(ctx context.Context, instructor models.Instructor) error
+	Update(ctx context.Context, instructor models.Instructor) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// IsInUse tells whether the instructor teaches any class or class series.
+	IsInUse(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+type IClassTypes interface {
+	Get(ctx context.Context, id uuid.UUID) (models.ClassType, error)
+	List(ctx context.Context) ([]models.ClassType, error)
+	Insert(ctx context.Context, classType models.ClassType) error
+	Update(ctx context.Context, classType models.ClassType) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// IsInUse tells whether any class was created from the class type.
+	IsInUse(ctx context.Context, id uuid.UUID) (bool, error)
 }
@@ -5,3 +5,17 @@ import "context"
 type IUnitOfWork interface {
 	WithTransaction(ctx context.Context, fn func(r Repositories) error) error
 }
+
+type inTransaction struct {
+	repos Repositories
+}
+
+// InTransaction is the unit of work of repositories already bound to a transaction, the
+// work done with it is a part of that transaction and is rolled back with it.
+func InTransaction(repos Repositories) IUnitOfWork {
+	return inTransaction{repos: repos}
+}
+
+func (u inTransaction) WithTransaction(_ context.Context, fn func(r Repositories) error) error {
+	return fn(u.repos)
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	viewErrors "main/internal/domain/errs/view"
+	"main/internal/domain/models"
+	"main/pkg/converter"
+)
+
+type BookingPolicy struct {
+	maxPendingBookings int
+	passesToCheck      int
+	rules              models.BookingRules
+	levelRules         map[string]models.BookingRules
+}
+
+// NewBookingPolicy applies levelRules to classes of the matching level and rules to the rest.
+func NewBookingPolicy(
+	maxPendingBookings int,
+	passesToCheck int,
+	rules models.BookingRules,
+	levelRules map[string]models.BookingRules,
+) *BookingPolicy {
+	return &BookingPolicy{
+		maxPendingBookings: maxPendingBookings,
+		passesToCheck:      passesToCheck,
+		rules:              rules,
+		levelRules:         levelRules,
+	}
+}
+
+func (p *BookingPolicy) RulesFor(classLevel string) models.BookingRules {
+	if rules, ok := p.levelRules[classLevel]; ok {
+		return rules
+	}
+
+	return p.rules
+}
+
+// MaxPendingBookings protects the studio from flooding, it is shared by all classes.
+func (p *BookingPolicy) MaxPendingBookings() int {
+	return p.maxPendingBookings
+}
+
+// PassesToCheck is how many latest passes are searched for a free slot.
+func (p *BookingPolicy) PassesToCheck() int {
+	return p.passesToCheck
+}
+
+func (p *BookingPolicy) CheckBookingWindow(class models.Class, bookingCount int, now time.Time) error {
+	rules := p.RulesFor(class.ClassLevel)
+
+	if rules.BookingWindow > 0 {
+		opensAt := class.StartTime.Add(-rules.BookingWindow)
+		if now.Before(opensAt) {
+			localOpensAt, err := converter.ConvertToLocationTime(opensAt, class.Location.TimeZone)
+			if err != nil {
+				return fmt.Errorf("could not convert booking opening to local time: %w", err)
+			}
+
+			return viewErrors.ErrBookingNotOpenYet(
+				class.ID,
+				localOpensAt.Format(converter.DateLayout+" "+converter.HourLayout),
+				fmt.Errorf("booking for class %s opens at %v", class.ID, opensAt),
+			)
+		}
+	}
+
+	// measured in elapsed time, so a DST switch before the class does not move it by an hour
+	if bookingCount == 0 && class.StartTime.Sub(now) < rules.EmptyClassDeadline {
+		return viewErrors.ErrTooLateToBook(
+			class.ID, fmt.Errorf("class %s is empty and it is to late to book", class.ID),
+		)
+	}
+
+	return nil
+}
+
+// CheckActiveBookings counts upcoming bookings of the student, the ones of the booked class
+// excluded, against the limit of its level.
+func (p *BookingPolicy) CheckActiveBookings(
+	class models.Class, email string, bookings []models.Booking, now time.Time,
+) error {
+	rules := p.RulesFor(class.ClassLevel)
+	if rules.MaxActiveBookings <= 0 {
+		return nil
+	}
+
+	var active int
+
+	for _, booking := range bookings {
+		if booking.ClassID != class.ID && booking.Class.StartTime.After(now) {
+			active++
+		}
+	}
+
+	if active >= rules.MaxActiveBookings {
+		return viewErrors.ErrTooManyActiveBookings(
+			class.ID,
+			email,
+			rules.MaxActiveBookings,
+			fmt.Errorf("%s has %d active bookings", email, active),
+		)
+	}
+
+	return nil
+}
+
+// IsLateCancellation tells whether cancelling now still consumes the pass slot.
+func (p *BookingPolicy) IsLateCancellation(class models.Class, now time.Time) bool {
+	return class.StartTime.Sub(now) < p.RulesFor(class.ClassLevel).CancellationCutoff
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	viewErrors "main/internal/domain/errs/view"
+	"main/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+func TestBookingPolicyCheckBookingWindow(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Fatalf("could not load location: %v", err)
+	}
+
+	policy := NewBookingPolicy(200, 3, models.BookingRules{
+		EmptyClassDeadline: 3 * time.Hour,
+	}, map[string]models.BookingRules{
+		"advanced": {EmptyClassDeadline: 3 * time.Hour, BookingWindow: 7 * 24 * time.Hour},
+	})
+
+	tests := []struct {
+		name         string
+		class        models.Class
+		bookingCount int
+		now          time.Time
+		wantCode     *int
+	}{
+		{
+			name:     "empty class more than three hours before",
+			class:    models.Class{StartTime: time.Date(2026, 5, 4, 18, 0, 0, 0, warsaw)},
+			now:      time.Date(2026, 5, 4, 14, 59, 0, 0, warsaw),
+			wantCode: nil,
+		},
+		{
+			name:     "empty class less than three hours before",
+			class:    models.Class{StartTime: time.Date(2026, 5, 4, 18, 0, 0, 0, warsaw)},
+			now:      time.Date(2026, 5, 4, 15, 1, 0, 0, warsaw),
+			wantCode: code(viewErrors.TooLateToBook),
+		},
+		{
+			name:         "booked class less than three hours before",
+			class:        models.Class{StartTime: time.Date(2026, 5, 4, 18, 0, 0, 0, warsaw)},
+			bookingCount: 1,
+			now:          time.Date(2026, 5, 4, 15, 1, 0, 0, warsaw),
+			wantCode:     nil,
+		},
+		{
+			// 01:30 to 05:00 on the wall clock is only two and a half hours.
+			name:     "spring forward shortens the night",
+			class:    models.Class{StartTime: time.Date(2026, 3, 29, 5, 0, 0, 0, warsaw)},
+			now:      time.Date(2026, 3, 29, 1, 30, 0, 0, warsaw),
+			wantCode: code(viewErrors.TooLateToBook),
+		},
+		{
+			// 00:30 to 03:00 on the wall clock is three and a half hours.
+			name:     "fall back lengthens the night",
+			class:    models.Class{StartTime: time.Date(2026, 10, 25, 3, 0, 0, 0, warsaw)},
+			now:      time.Date(2026, 10, 25, 0, 30, 0, 0, warsaw),
+			wantCode: nil,
+		},
+		{
+			name: "level window not open yet",
+			class: models.Class{
+				ClassLevel: "advanced", StartTime: time.Date(2026, 5, 11, 18, 0, 0, 0, warsaw),
+			},
+			bookingCount: 1,
+			now:          time.Date(2026, 5, 4, 17, 59, 0, 0, warsaw),
+			wantCode:     code(viewErrors.BookingNotOpenYetCode),
+		},
+		{
+			name: "level window open",
+			class: models.Class{
+				ClassLevel: "advanced", StartTime: time.Date(2026, 5, 11, 18, 0, 0, 0, warsaw),
+			},
+			bookingCount: 1,
+			now:          time.Date(2026, 5, 4, 18, 0, 0, 0, warsaw),
+			wantCode:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.CheckBookingWindow(tt.class, tt.bookingCount, tt.now)
+			assertBusinessErrorCode(t, err, tt.wantCode)
+		})
+	}
+}
+
+func TestBookingPolicyCheckActiveBookings(t *testing.T) {
+	now := time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+	class := models.Class{ID: uuid.New(), StartTime: now.Add(48 * time.Hour)}
+
+	upcoming := func(classID uuid.UUID) models.Booking {
+		return models.Booking{ClassID: classID, Class: models.Class{ID: classID, StartTime: now.Add(time.Hour)}}
+	}
+	past := models.Booking{ClassID: uuid.New(), Class: models.Class{StartTime: now.Add(-time.Hour)}}
+
+	tests := []struct {
+		name     string
+		limit    int
+		bookings []models.Booking
+		wantCode *int
+	}{
+		{
+			name:     "no limit",
+			limit:    0,
+			bookings: []models.Booking{upcoming(uuid.New()), upcoming(uuid.New())},
+			wantCode: nil,
+		},
+		{
+			name:     "below limit, past bookings not counted",
+			limit:    2,
+			bookings: []models.Booking{upcoming(uuid.New()), past},
+			wantCode: nil,
+		},
+		{
+			name:     "limit reached",
+			limit:    2,
+			bookings: []models.Booking{upcoming(uuid.New()), upcoming(uuid.New())},
+			wantCode: code(viewErrors.TooManyActiveBookingsCode),
+		},
+		{
+			name:     "booking of the same class not counted",
+			limit:    2,
+			bookings: []models.Booking{upcoming(uuid.New()), upcoming(class.ID)},
+			wantCode: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := NewBookingPolicy(200, 3, models.BookingRules{MaxActiveBookings: tt.limit}, nil)
+
+			err := policy.CheckActiveBookings(class, "anna@example.com", tt.bookings, now)
+			assertBusinessErrorCode(t, err, tt.wantCode)
+		})
+	}
+}
+
+func TestBookingPolicyIsLateCancellation(t *testing.T) {
+	start := time.Date(2026, 5, 4, 18, 0, 0, 0, time.UTC)
+	policy := NewBookingPolicy(200, 3, models.BookingRules{CancellationCutoff: 12 * time.Hour},
+		map[string]models.BookingRules{"beginner": {CancellationCutoff: 0}},
+	)
+
+	tests := []struct {
+		name  string
+		class models.Class
+		now   time.Time
+		want  bool
+	}{
+		{
+			name:  "before cutoff",
+			class: models.Class{StartTime: start},
+			now:   start.Add(-13 * time.Hour),
+			want:  false,
+		},
+		{
+			name:  "after cutoff",
+			class: models.Class{StartTime: start},
+			now:   start.Add(-11 * time.Hour),
+			want:  true,
+		},
+		{
+			name:  "level without cutoff",
+			class: models.Class{ClassLevel: "beginner", StartTime: start},
+			now:   start.Add(-time.Minute),
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := policy.IsLateCancellation(tt.class, tt.now)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func code(c int) *int {
+	return &c
+}
+
+func assertBusinessErrorCode(t *testing.T, err error, wantCode *int) {
+	t.Helper()
+
+	if wantCode == nil {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		return
+	}
+
+	var businessError *viewErrors.BusinessError
+	if !errors.As(err, &businessError) || businessError.Code != *wantCode {
+		t.Errorf("got %v, want business error with code %d", err, *wantCode)
+	}
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"main/internal/domain/models"
+	"main/pkg/ical"
+
+	"github.com/google/uuid"
+)
+
+const CalendarProdID = "-//Yoga//Bookings//PL"
+
+// ClassEventUID is shared by the email attachments and the feeds, so calendar
+// clients keep a single event per class and replace it on updates.
+func ClassEventUID(classID uuid.UUID) string {
+	return fmt.Sprintf("class-%s@yoga", classID)
+}
+
+func ClassEvent(class models.Class, status ical.EventStatus) ical.Event {
+	return ical.Event{
+		UID:      ClassEventUID(class.ID),
+		Sequence: class.Sequence,
+		Stamp:    time.Now().UTC(),
+		Start:    class.StartTime,
+		End:      class.EndTime(),
+		Summary:  fmt.Sprintf("Yoga - %s (%s)", class.ClassName, class.ClassLevel),
+		Location: class.Location.FullAddress(),
+		Status:   status,
+	}
+}
@@ -16,14 +16,23 @@ func (p *PassManager) BuildPassSlots(
 	passSlots := make([]models.PassSlot, 0, totalSlots)
 
 	for _, booking := range bookings {
+		if !booking.Status.ConsumesPassSlot() {
+			continue
+		}
+
 		classStartTime := booking.Class.StartTime
 		passSlot := models.PassSlot{
 			ClassStartTime: &classStartTime,
 		}
 
-		if classStartTime.Before(time.Now()) {
+		switch {
+		case booking.Status == models.BookingLateCancelled:
+			passSlot.Status = models.LateCancelled
+		case booking.Status == models.BookingNoShow:
+			passSlot.Status = models.NoShow
+		case classStartTime.Before(time.Now()):
 			passSlot.Status = models.Past
-		} else {
+		default:
 			passSlot.Status = models.Future
 		}
 
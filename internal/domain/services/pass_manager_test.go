@@ -0,0 +1,67 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"main/internal/domain/models"
+)
+
+func TestPassManagerBuildPassSlots(t *testing.T) {
+	past := time.Now().Add(-48 * time.Hour)
+	future := time.Now().Add(48 * time.Hour)
+
+	tests := []struct {
+		name       string
+		bookings   []models.Booking
+		totalSlots int
+		want       []models.PassSlotStatus
+	}{
+		{
+			name: "confirmed bookings",
+			bookings: []models.Booking{
+				{Status: models.BookingConfirmed, Class: models.Class{StartTime: future}},
+				{Status: models.BookingConfirmed, Class: models.Class{StartTime: past}},
+			},
+			totalSlots: 3,
+			want:       []models.PassSlotStatus{models.Past, models.Future, models.Blank},
+		},
+		{
+			name: "cancelled booking frees the slot",
+			bookings: []models.Booking{
+				{Status: models.BookingCancelled, Class: models.Class{StartTime: future}},
+			},
+			totalSlots: 2,
+			want:       []models.PassSlotStatus{models.Blank, models.Blank},
+		},
+		{
+			name: "late cancellation and no-show consume slots",
+			bookings: []models.Booking{
+				{Status: models.BookingLateCancelled, Class: models.Class{StartTime: future}},
+				{Status: models.BookingNoShow, Class: models.Class{StartTime: past}},
+				{Status: models.BookingAttended, Class: models.Class{StartTime: past.Add(-time.Hour)}},
+			},
+			totalSlots: 4,
+			want: []models.PassSlotStatus{
+				models.Past, models.NoShow, models.LateCancelled, models.Blank,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passManager := PassManager{}
+
+			slots := passManager.BuildPassSlots(tt.bookings, tt.totalSlots)
+			if len(slots) != len(tt.want) {
+				t.Fatalf("got %d slots, want %d", len(slots), len(tt.want))
+			}
+
+			for i, slot := range slots {
+				if slot.Status != tt.want[i] {
+					t.Errorf("slot %d: got status %d, want %d", i, slot.Status, tt.want[i])
+				}
+			}
+		})
+	}
+}
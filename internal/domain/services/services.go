@@ -2,8 +2,11 @@ package services
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	"main/internal/domain/models"
+	"main/pkg/ical"
 
 	"github.com/google/uuid"
 )
@@ -12,22 +15,75 @@ type IClassesService interface {
 	ListClasses(
 		ctx context.Context,
 		onlyUpcomingClasses bool,
+		filter models.ClassFilter,
 		classesLimit *int,
 	) ([]models.ClassWithCurrentCapacity, error)
+	ListSchedule(
+		ctx context.Context, from, to time.Time, filter models.ClassFilter,
+	) (models.Schedule, error)
+	ListPublicSchedule(
+		ctx context.Context, params models.PublicScheduleParams,
+	) (models.PublicSchedule, error)
 	CreateClasses(ctx context.Context, classes []models.Class) ([]models.Class, error)
 	UpdateClass(ctx context.Context, id uuid.UUID, update models.UpdateClass) (models.Class, error)
 	DeleteClass(ctx context.Context, classID uuid.UUID, msg *string) error
+	SubstituteInstructor(ctx context.Context, classID, instructorID uuid.UUID) (models.Class, error)
+}
+
+type IClassSeriesService interface {
+	ListClassSeries(ctx context.Context) ([]models.ClassSeries, error)
+	CreateClassSeries(ctx context.Context, series models.ClassSeries) (models.ClassSeries, error)
+	UpdateClassSeries(
+		ctx context.Context, id uuid.UUID, update models.UpdateClassSeries,
+	) (models.ClassSeries, error)
+	DeleteClassSeries(ctx context.Context, id uuid.UUID, msg *string) error
+	MaterializeClassSeries(ctx context.Context) error
 }
 
 type IBookingsService interface {
 	CreateBooking(ctx context.Context, token string) (models.Class, error)
+	CreateConfirmedBooking(ctx context.Context, params models.ConfirmedBookingParams) (models.Booking, error)
+	CreateWalkIn(ctx context.Context, classID uuid.UUID, params models.WalkInParams) (models.Booking, error)
 	CancelBooking(ctx context.Context, id uuid.UUID, token string) error
-	GetBookingForCancellation(ctx context.Context, id uuid.UUID, token string) (models.Booking, error)
+	GetBookingForCancellation(ctx context.Context, id uuid.UUID, token string) (models.BookingCancellation, error)
+	RescheduleBooking(ctx context.Context, id uuid.UUID, token string, classID uuid.UUID) (models.Booking, error)
 	DeleteBooking(ctx context.Context, id uuid.UUID) error
+	MoveBooking(ctx context.Context, id, classID uuid.UUID) (models.Booking, error)
+	EnsureSpotAvailable(ctx context.Context, class models.Class, email string) error
 }
 
 type IPendingBookingsService interface {
 	CreatePendingBooking(ctx context.Context, params models.PendingBookingParams) error
+	CleanUpPendingBookings(ctx context.Context) error
+}
+
+type IWaitlistService interface {
+	JoinWaitlist(ctx context.Context, params models.WaitlistEntryParams) error
+	PromoteFromWaitlist(ctx context.Context, classID uuid.UUID) error
+	ExpireOffers(ctx context.Context) error
+}
+
+type IStudentBookingsService interface {
+	RequestLoginLink(ctx context.Context, email string) error
+	LogIn(ctx context.Context, loginToken string) (models.StudentSession, error)
+	LogOut(ctx context.Context, sessionToken string) error
+	GetStudentBookings(ctx context.Context, sessionToken string) (models.StudentBookings, error)
+	CleanUpSessions(ctx context.Context) error
+}
+
+type IAttendanceService interface {
+	GetRoster(ctx context.Context, classID uuid.UUID) (models.Roster, error)
+	MarkAttendance(
+		ctx context.Context, classID, bookingID uuid.UUID, status models.BookingStatus,
+	) (models.Booking, error)
+	AddWalkIn(ctx context.Context, classID uuid.UUID, params models.WalkInParams) (models.Booking, error)
+	GetContactStatistics(ctx context.Context, email string) (models.ContactStatistics, error)
+}
+
+type ICalendarService interface {
+	GetPublicFeed(ctx context.Context) (ical.Calendar, error)
+	GetStudentFeed(ctx context.Context, token string) (ical.Calendar, error)
+	GetStudentFeedLink(ctx context.Context, email string) (string, error)
 }
 
 type IPassesService interface {
@@ -35,12 +91,78 @@ type IPassesService interface {
 		ctx context.Context,
 		params models.PassActivationParams,
 	) (models.PassActivation, error)
+	ListPasses(ctx context.Context, filter models.PassFilter) ([]models.PassWithSlots, error)
+	GetPass(ctx context.Context, id int) (models.PassWithSlots, error)
+	ExtendPass(ctx context.Context, id int, days int) (models.PassWithSlots, error)
+	FreezePass(ctx context.Context, id int, params models.PassFreezeParams) (models.PassWithSlots, error)
+}
+
+type IProductsService interface {
+	ListProducts(ctx context.Context, onlyActive bool) ([]models.Product, error)
+	GetProduct(ctx context.Context, id uuid.UUID) (models.Product, error)
+	CreateProduct(ctx context.Context, params models.ProductParams) (models.Product, error)
+	UpdateProduct(ctx context.Context, id uuid.UUID, update models.UpdateProduct) (models.Product, error)
+}
+
+type ILocationsService interface {
+	ListLocations(ctx context.Context) ([]models.Location, error)
+	GetLocation(ctx context.Context, id uuid.UUID) (models.Location, error)
+	CreateLocation(ctx context.Context, params models.LocationParams) (models.Location, error)
+	UpdateLocation(ctx context.Context, id uuid.UUID, update models.UpdateLocation) (models.Location, error)
+	DeleteLocation(ctx context.Context, id uuid.UUID) error
+}
+
+type IInstructorsService interface {
+	ListInstructors(ctx context.Context) ([]models.Instructor, error)
+	GetInstructor(ctx context.Context, id uuid.UUID) (models.Instructor, error)
+	CreateInstructor(ctx context.Context, params models.InstructorParams) (models.Instructor, error)
+	UpdateInstructor(
+		ctx context.Context, id uuid.UUID, update models.UpdateInstructor,
+	) (models.Instructor, error)
+	DeleteInstructor(ctx context.Context, id uuid.UUID) error
+}
+
+type IClassTypesService interface {
+	ListClassTypes(ctx context.Context) ([]models.ClassType, error)
+	GetClassType(ctx context.Context, id uuid.UUID) (models.ClassType, error)
+	DescribeClassType(ctx context.Context, id uuid.UUID) (models.ClassTypeDescription, error)
+	CreateClassType(ctx context.Context, params models.ClassTypeParams) (models.ClassType, error)
+	UpdateClassType(
+		ctx context.Context, id uuid.UUID, update models.UpdateClassType,
+	) (models.ClassType, error)
+	DeleteClassType(ctx context.Context, id uuid.UUID) error
+}
+
+type IPaymentsService interface {
+	StartPassCheckout(ctx context.Context, params models.PassPurchaseParams) (models.Checkout, error)
+	StartDropInCheckout(ctx context.Context, params models.DropInPurchaseParams) (models.Checkout, error)
+	HandleWebhook(ctx context.Context, payload []byte, header http.Header) error
+	GetPayment(ctx context.Context, id uuid.UUID) (models.Payment, error)
+	ListPayments(ctx context.Context) ([]models.Payment, error)
+}
+
+type IJobsService interface {
+	ListJobs(ctx context.Context) ([]models.JobStatus, error)
+}
+
+type IOutboxService interface {
+	ListMessages(ctx context.Context, status models.OutboxStatus) ([]models.OutboxMessage, error)
+	RetryMessage(ctx context.Context, id uuid.UUID) error
 }
 
 type IPassManager interface {
 	BuildPassSlots(bookings []models.Booking, totalSlots int) []models.PassSlot
 }
 
+type IBookingPolicy interface {
+	RulesFor(classLevel string) models.BookingRules
+	MaxPendingBookings() int
+	PassesToCheck() int
+	CheckBookingWindow(class models.Class, bookingCount int, now time.Time) error
+	CheckActiveBookings(class models.Class, email string, bookings []models.Booking, now time.Time) error
+	IsLateCancellation(class models.Class, now time.Time) bool
+}
+
 type ITokenGenerator interface {
 	Generate(length int) (string, error)
 }
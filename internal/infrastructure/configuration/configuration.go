@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -33,17 +34,121 @@ func (d *Duration) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+const (
+	NotifierBackendSMTP   = "smtp"
+	NotifierBackendFile   = "file"
+	NotifierBackendMemory = "memory"
+	NotifierBackendLog    = "log"
+)
+
+const defaultNotifierSender = "yoga@localhost"
+
 type Notifier struct {
+	Backend   string
 	Host      string
 	Port      int
 	Login     string
 	Password  string
+	From      string
+	Dir       string
 	Signature string
 }
 
+// Sender is the From address, the SMTP login unless set explicitly.
+func (n Notifier) Sender() string {
+	if n.From != "" {
+		return n.From
+	}
+
+	if n.Login != "" {
+		return n.Login
+	}
+
+	return defaultNotifierSender
+}
+
+const PaymentsProviderFake = "fake"
+
+// Payments are disabled without a Provider, prices come from the product catalog.
+type Payments struct {
+	Provider      string
+	WebhookSecret string
+}
+
+func (p Payments) Enabled() bool {
+	return p.Provider != ""
+}
+
+// PublicAPI is the unauthenticated schedule embedded by partner websites, only the
+// AllowedOrigins may read it from a browser, "*" allows any.
+type PublicAPI struct {
+	AllowedOrigins []string
+	CacheMaxAge    Duration
+}
+
+const (
+	DBDriverSQLite   = "sqlite"
+	DBDriverPostgres = "postgres"
+)
+
+type Postgres struct {
+	DBName   string
+	User     string
+	Password string
+	Host     string
+	Port     int
+	SSLMode  string
+}
+
+func (p Postgres) DSN() string {
+	return fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		p.Host, p.Port, p.User, p.Password, p.DBName, p.SSLMode,
+	)
+}
+
+type Scheduler struct {
+	TickInterval                   Duration
+	RemindBookingsInterval         Duration
+	CleanUpPendingBookingsInterval Duration
+	MaterializeClassSeriesInterval Duration
+	ExpireWaitlistOffersInterval   Duration
+	CleanUpStudentSessionsInterval Duration
+}
+
+type Outbox struct {
+	PollInterval Duration
+	MaxAttempts  int
+	BaseBackoff  Duration
+}
+
+const (
+	defaultMaxPendingBookings         = 200
+	defaultPassesToCheck              = 3
+	defaultMaxPendingBookingsPerEmail = 2
+)
+
+type BookingRules struct {
+	MaxPendingBookingsPerEmail int
+	EmptyClassDeadline         Duration
+	CancellationCutoff         Duration
+	MaxActiveBookings          int
+	BookingWindow              Duration
+}
+
+// BookingPolicy holds the studio wide rules, Levels replace them for classes of that level.
+type BookingPolicy struct {
+	MaxPendingBookings int
+	PassesToCheck      int
+	Rules              BookingRules
+	Levels             map[string]BookingRules
+}
+
 type Configuration struct {
 	ListenAddress                    string
+	DBDriver                         string
 	DBPath                           string
+	Postgres                         Postgres
 	ReadTimeout                      Duration
 	WriteTimeout                     Duration
 	ContextTimeout                   Duration
@@ -56,6 +161,14 @@ type Configuration struct {
 	ConfirmationEmailTmplPath        string
 	BaseNotifierTmplPath             string
 	IsVacation                       bool
+	TimeZone                         string
+	ClassSeriesHorizon               Duration
+	PassValidity                     Duration
+	BookingPolicy                    BookingPolicy
+	Scheduler                        Scheduler
+	Outbox                           Outbox
+	Payments                         Payments
+	PublicAPI                        PublicAPI
 }
 
 func (c *Configuration) Pretty() string {
@@ -83,11 +196,16 @@ func GetConfig(cfgPath string) (*Configuration, error) {
 
 	loadEnvs(cfg)
 
-	if cfg.Notifier.Login == "" || cfg.Notifier.Password == "" {
+	if cfg.Notifier.Backend == NotifierBackendSMTP && (cfg.Notifier.Login == "" || cfg.Notifier.Password == "") {
 		return nil,
 			errors.New("provide envs for notifier")
 	}
 
+	if cfg.Payments.Enabled() && cfg.Payments.WebhookSecret == "" {
+		return nil,
+			errors.New("provide envs for payments")
+	}
+
 	return cfg, nil
 }
 
@@ -100,11 +218,74 @@ func loadEnvs(cfg *Configuration) {
 		cfg.Notifier.Password = password
 	}
 
+	if backend := os.Getenv("NOTIFIER_BACKEND"); backend != "" {
+		cfg.Notifier.Backend = backend
+	}
+
+	if cfg.Notifier.Backend == "" {
+		cfg.Notifier.Backend = NotifierBackendSMTP
+	}
+
 	if authSecret := os.Getenv("AUTH_SECRET"); authSecret != "" {
 		cfg.AuthSecret = authSecret
 	}
 
+	setBookingPolicyDefaults(&cfg.BookingPolicy)
+
+	if timeZone := os.Getenv("TIME_ZONE"); timeZone != "" {
+		cfg.TimeZone = timeZone
+	}
+
+	if provider := os.Getenv("PAYMENTS_PROVIDER"); provider != "" {
+		cfg.Payments.Provider = provider
+	}
+
+	if webhookSecret := os.Getenv("PAYMENTS_WEBHOOK_SECRET"); webhookSecret != "" {
+		cfg.Payments.WebhookSecret = webhookSecret
+	}
+
+	if origins := os.Getenv("PUBLIC_API_ALLOWED_ORIGINS"); origins != "" {
+		cfg.PublicAPI.AllowedOrigins = strings.Split(origins, ",")
+	}
+
 	if dbPath := os.Getenv("DATABASE_PATH"); dbPath != "" {
 		cfg.DBPath = dbPath
 	}
+
+	if dbDriver := os.Getenv("DB_DRIVER"); dbDriver != "" {
+		cfg.DBDriver = dbDriver
+	}
+
+	if cfg.DBDriver == "" {
+		cfg.DBDriver = DBDriverSQLite
+	}
+
+	if user := os.Getenv("POSTGRES_USER"); user != "" {
+		cfg.Postgres.User = user
+	}
+
+	if password := os.Getenv("POSTGRES_PASSWORD"); password != "" {
+		cfg.Postgres.Password = password
+	}
+}
+
+func setBookingPolicyDefaults(policy *BookingPolicy) {
+	if policy.MaxPendingBookings == 0 {
+		policy.MaxPendingBookings = defaultMaxPendingBookings
+	}
+
+	if policy.PassesToCheck == 0 {
+		policy.PassesToCheck = defaultPassesToCheck
+	}
+
+	if policy.Rules.MaxPendingBookingsPerEmail == 0 {
+		policy.Rules.MaxPendingBookingsPerEmail = defaultMaxPendingBookingsPerEmail
+	}
+
+	for level, rules := range policy.Levels {
+		if rules.MaxPendingBookingsPerEmail == 0 {
+			rules.MaxPendingBookingsPerEmail = defaultMaxPendingBookingsPerEmail
+			policy.Levels[level] = rules
+		}
+	}
 }
@@ -0,0 +1,275 @@
+package migrations
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed sqlite/*.sql postgres/*.sql
+var files embed.FS
+
+var (
+	ErrSchemaBehind      = errors.New("database schema is behind")
+	ErrNothingToRollBack = errors.New("no applied migrations to roll back")
+)
+
+// fileNamePattern matches e.g. 0002_add_locations.up.sql.
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    INTEGER PRIMARY KEY,
+    name       TEXT      NOT NULL,
+    applied_at TIMESTAMP NOT NULL
+)`
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+type appliedMigration struct {
+	Version   int
+	Name      string
+	AppliedAt time.Time
+}
+
+type migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator loads the migrations embedded for driver, which is the name of one of
+// the directories next to this file.
+func NewMigrator(db *gorm.DB, driver string) (*migrator, error) {
+	migrations, err := load(driver)
+	if err != nil {
+		return nil, fmt.Errorf("could not load %s migrations: %w", driver, err)
+	}
+
+	return &migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+func load(driver string) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, driver)
+	if err != nil {
+		return nil, fmt.Errorf("could not read migrations directory: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+
+	for _, entry := range entries {
+		matches := fileNamePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("unexpected migration file name: %s", entry.Name())
+		}
+
+		version, err := strconv.Atoi(matches[1])
+		if err != nil {
+			return nil, fmt.Errorf("could not parse version of %s: %w", entry.Name(), err)
+		}
+
+		content, err := files.ReadFile(path.Join(driver, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != matches[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, matches[2])
+		}
+
+		if matches[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d needs both up and down files", migration.Version)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up applies every pending migration in order, each in its own transaction.
+func (m *migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list applied migrations: %w", err)
+	}
+
+	var done []Migration
+
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return fmt.Errorf("could not run up script: %w", err)
+			}
+
+			err := tx.Exec(
+				"INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+				migration.Version, migration.Name, time.Now().UTC(),
+			).Error
+			if err != nil {
+				return fmt.Errorf("could not record migration: %w", err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return done, fmt.Errorf("could not apply migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// Down rolls back the most recently applied migration.
+func (m *migrator) Down(ctx context.Context) (Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return Migration{}, fmt.Errorf("could not list applied migrations: %w", err)
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(migration.Down).Error; err != nil {
+				return fmt.Errorf("could not run down script: %w", err)
+			}
+
+			err := tx.Exec("DELETE FROM schema_migrations WHERE version = ?", migration.Version).Error
+			if err != nil {
+				return fmt.Errorf("could not remove migration record: %w", err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return Migration{}, fmt.Errorf(
+				"could not roll back migration %d_%s: %w", migration.Version, migration.Name, err,
+			)
+		}
+
+		return migration, nil
+	}
+
+	return Migration{}, ErrNothingToRollBack
+}
+
+func (m *migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list applied migrations: %w", err)
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+
+	for _, migration := range m.migrations {
+		status := Status{
+			Version: migration.Version,
+			Name:    migration.Name,
+		}
+
+		if appliedMigration, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &appliedMigration.AppliedAt
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// EnsureUpToDate returns ErrSchemaBehind when any embedded migration has not been applied.
+func (m *migrator) EnsureUpToDate(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get migrations status: %w", err)
+	}
+
+	var pending []int
+
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, status.Version)
+		}
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: pending migrations %v, run `yoga migrate up`", ErrSchemaBehind, pending)
+	}
+
+	return nil
+}
+
+func (m *migrator) applied(ctx context.Context) (map[int]appliedMigration, error) {
+	if err := m.db.WithContext(ctx).Exec(createSchemaMigrations).Error; err != nil {
+		return nil, fmt.Errorf("could not create schema_migrations table: %w", err)
+	}
+
+	var rows []appliedMigration
+
+	err := m.db.WithContext(ctx).
+		Raw("SELECT version, name, applied_at FROM schema_migrations ORDER BY version").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not query schema_migrations: %w", err)
+	}
+
+	applied := make(map[int]appliedMigration, len(rows))
+
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,282 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	dbModels "main/internal/infrastructure/models/db"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	database, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "yoga.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("could not open sqlite: %v", err)
+	}
+
+	return database
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+
+	migrator, err := NewMigrator(openSQLite(t), "sqlite")
+	if err != nil {
+		t.Fatalf("could not create migrator: %v", err)
+	}
+
+	last := migrator.migrations[len(migrator.migrations)-1]
+
+	tests := []struct {
+		name        string
+		step        func() error
+		wantErr     error
+		wantBehind  bool
+		wantApplied int
+	}{
+		{
+			name:        "fresh database is behind",
+			step:        func() error { return nil },
+			wantBehind:  true,
+			wantApplied: 0,
+		},
+		{
+			name: "up applies every migration",
+			step: func() error {
+				_, err := migrator.Up(ctx)
+				return err
+			},
+			wantApplied: len(migrator.migrations),
+		},
+		{
+			name: "up again is a no-op",
+			step: func() error {
+				_, err := migrator.Up(ctx)
+				return err
+			},
+			wantApplied: len(migrator.migrations),
+		},
+		{
+			name: "down rolls back the latest migration",
+			step: func() error {
+				migration, err := migrator.Down(ctx)
+				if err == nil && migration.Version != last.Version {
+					t.Errorf("rolled back %d, want %d", migration.Version, last.Version)
+				}
+
+				return err
+			},
+			wantBehind:  true,
+			wantApplied: len(migrator.migrations) - 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.step()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+
+			statuses, err := migrator.Status(ctx)
+			if err != nil {
+				t.Fatalf("could not get status: %v", err)
+			}
+
+			var applied int
+
+			for _, status := range statuses {
+				if status.AppliedAt != nil {
+					applied++
+				}
+			}
+
+			if applied != tt.wantApplied {
+				t.Errorf("got %d applied, want %d", applied, tt.wantApplied)
+			}
+
+			err = migrator.EnsureUpToDate(ctx)
+			if errors.Is(err, ErrSchemaBehind) != tt.wantBehind {
+				t.Errorf("got %v, want behind %v", err, tt.wantBehind)
+			}
+		})
+	}
+}
+
+// TestMigrationsMatchModels guards against the SQL files drifting from the gorm models.
+func TestMigrationsMatchModels(t *testing.T) {
+	database := openSQLite(t)
+
+	migrator, err := NewMigrator(database, "sqlite")
+	if err != nil {
+		t.Fatalf("could not create migrator: %v", err)
+	}
+
+	if _, err = migrator.Up(context.Background()); err != nil {
+		t.Fatalf("could not migrate: %v", err)
+	}
+
+	assertMatchesModels(t, database)
+}
+
+// baselineSchema is what gorm AutoMigrate created before the versioned migrations.
+var baselineSchema = []string{
+	"CREATE TABLE `classes` (`id` uuid,`start_time` datetime NOT NULL,`class_level` text NOT NULL," +
+		"`class_name` text NOT NULL,`max_capacity` integer NOT NULL,`location` text NOT NULL,PRIMARY KEY (`id`))",
+	"CREATE TABLE `pending_bookings` (`id` uuid,`class_id` uuid NOT NULL,`email` text NOT NULL," +
+		"`first_name` text NOT NULL,`last_name` text NOT NULL,`confirmation_token` text NOT NULL," +
+		"`created_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `fk_pending_bookings_class` FOREIGN KEY " +
+		"(`class_id`) REFERENCES `classes`(`id`),CONSTRAINT `uni_pending_bookings_confirmation_token` " +
+		"UNIQUE (`confirmation_token`))",
+	"CREATE TABLE `passes` (`id` integer PRIMARY KEY AUTOINCREMENT,`email` text NOT NULL," +
+		"`updated_at` datetime,`created_at` datetime,`total_slots` integer NOT NULL)",
+	"CREATE TABLE `bookings` (`id` uuid,`class_id` uuid NOT NULL,`pass_id` integer,`email` text NOT NULL," +
+		"`first_name` text NOT NULL,`last_name` text NOT NULL,`confirmation_token` text NOT NULL," +
+		"`reminded_at` datetime,`created_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `fk_bookings_pass` " +
+		"FOREIGN KEY (`pass_id`) REFERENCES `passes`(`id`),CONSTRAINT `fk_bookings_class` FOREIGN KEY " +
+		"(`class_id`) REFERENCES `classes`(`id`),CONSTRAINT `uni_bookings_confirmation_token` " +
+		"UNIQUE (`confirmation_token`))",
+	"CREATE TABLE `contacts` (`id` integer PRIMARY KEY AUTOINCREMENT,`email` text NOT NULL," +
+		"`first_name` text NOT NULL,`last_name` text NOT NULL)",
+	"CREATE UNIQUE INDEX `idx_contacts_email` ON `contacts`(`email`)",
+	"INSERT INTO `classes` VALUES ('5f0c1e0a-4d2b-4c8e-9a61-3b7d2f1e8c90', '2026-01-05 18:00:00+00:00'," +
+		" 'beginner', 'hatha', 10, 'Studio')",
+	"INSERT INTO `passes` (`email`, `total_slots`) VALUES ('jan@example.com', 8)",
+}
+
+// TestUpgradeBaselineDatabase runs every migration on a database created by AutoMigrate
+// and rolls them all back again.
+func TestUpgradeBaselineDatabase(t *testing.T) {
+	ctx := context.Background()
+	database := openSQLite(t)
+
+	for _, statement := range baselineSchema {
+		if err := database.Exec(statement).Error; err != nil {
+			t.Fatalf("could not create baseline schema: %v", err)
+		}
+	}
+
+	migrator, err := NewMigrator(database, "sqlite")
+	if err != nil {
+		t.Fatalf("could not create migrator: %v", err)
+	}
+
+	if _, err = migrator.Up(ctx); err != nil {
+		t.Fatalf("could not upgrade baseline database: %v", err)
+	}
+
+	assertMatchesModels(t, database)
+
+	var classes int64
+
+	if err = database.Model(&dbModels.SQLClass{}).Count(&classes).Error; err != nil || classes != 1 {
+		t.Errorf("got %d classes (%v), want the baseline class kept", classes, err)
+	}
+
+	for range migrator.migrations {
+		if _, err = migrator.Down(ctx); err != nil {
+			t.Fatalf("could not roll back: %v", err)
+		}
+	}
+
+	if _, err = migrator.Down(ctx); !errors.Is(err, ErrNothingToRollBack) {
+		t.Errorf("got %v, want %v", err, ErrNothingToRollBack)
+	}
+}
+
+func assertMatchesModels(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	models := []any{
+		&dbModels.SQLClass{},
+		&dbModels.SQLPendingBooking{},
+		&dbModels.SQLBooking{},
+		&dbModels.SQLPass{},
+		&dbModels.SQLPassFreeze{},
+		&dbModels.SQLContact{},
+		&dbModels.SQLWaitlistEntry{},
+		&dbModels.SQLClassSeries{},
+		&dbModels.SQLJobRun{},
+		&dbModels.SQLOutboxMessage{},
+		&dbModels.SQLStudentSession{},
+		&dbModels.SQLCalendarFeed{},
+		&dbModels.SQLPayment{},
+		&dbModels.SQLProduct{},
+		&dbModels.SQLLocation{},
+		&dbModels.SQLInstructor{},
+		&dbModels.SQLClassType{},
+	}
+
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: database}
+		if err := stmt.Parse(model); err != nil {
+			t.Fatalf("could not parse %T: %v", model, err)
+		}
+
+		t.Run(stmt.Schema.Table, func(t *testing.T) {
+			if !database.Migrator().HasTable(model) {
+				t.Fatalf("table %s is missing", stmt.Schema.Table)
+			}
+
+			for _, field := range stmt.Schema.Fields {
+				if field.DBName == "" {
+					continue
+				}
+
+				if !database.Migrator().HasColumn(model, field.DBName) {
+					t.Errorf("column %s.%s is missing", stmt.Schema.Table, field.DBName)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver string
+	}{
+		{
+			name:   "sqlite",
+			driver: "sqlite",
+		},
+		{
+			name:   "postgres",
+			driver: "postgres",
+		},
+	}
+
+	sqliteMigrations, err := load("sqlite")
+	if err != nil {
+		t.Fatalf("could not load sqlite migrations: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := load(tt.driver)
+			if err != nil {
+				t.Fatalf("could not load migrations: %v", err)
+			}
+
+			if len(migrations) != len(sqliteMigrations) {
+				t.Fatalf("got %d migrations, want %d like sqlite", len(migrations), len(sqliteMigrations))
+			}
+
+			for i, migration := range migrations {
+				if migration.Version != i+1 || migration.Name != sqliteMigrations[i].Name {
+					t.Errorf("got %d_%s at position %d, want %d_%s",
+						migration.Version, migration.Name, i, i+1, sqliteMigrations[i].Name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS contacts CASCADE;
+DROP TABLE IF EXISTS bookings CASCADE;
+DROP TABLE IF EXISTS passes CASCADE;
+DROP TABLE IF EXISTS pending_bookings CASCADE;
+DROP TABLE IF EXISTS classes CASCADE;
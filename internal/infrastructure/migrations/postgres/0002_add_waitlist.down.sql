@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_waitlist_class_email;
+DROP TABLE IF EXISTS waitlist_entries CASCADE;
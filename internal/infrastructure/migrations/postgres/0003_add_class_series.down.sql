@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_classes_series_id;
+ALTER TABLE classes DROP COLUMN series_id;
+
+DROP TABLE IF EXISTS class_series CASCADE;
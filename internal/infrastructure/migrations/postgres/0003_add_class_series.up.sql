@@ -0,0 +1,15 @@
+CREATE TABLE class_series (
+    id                 uuid PRIMARY KEY,
+    frequency          text        NOT NULL,
+    start_time         timestamptz NOT NULL,
+    end_date           timestamptz,
+    exception_dates    text,
+    class_level        text        NOT NULL,
+    class_name         text        NOT NULL,
+    max_capacity       bigint      NOT NULL,
+    location           text        NOT NULL,
+    materialized_until timestamptz
+);
+
+ALTER TABLE classes ADD COLUMN series_id uuid;
+CREATE INDEX idx_classes_series_id ON classes (series_id);
@@ -0,0 +1,6 @@
+CREATE TABLE job_runs (
+    name        text PRIMARY KEY,
+    last_run_at timestamptz,
+    next_run_at timestamptz NOT NULL,
+    last_error  text
+);
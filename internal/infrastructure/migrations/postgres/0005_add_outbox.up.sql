@@ -0,0 +1,12 @@
+CREATE TABLE outbox (
+    id              uuid PRIMARY KEY,
+    kind            text        NOT NULL,
+    payload         text        NOT NULL,
+    status          text        NOT NULL,
+    attempts        bigint      NOT NULL,
+    next_attempt_at timestamptz NOT NULL,
+    last_error      text,
+    created_at      timestamptz,
+    sent_at         timestamptz
+);
+CREATE INDEX idx_outbox_status_next_attempt_at ON outbox (status, next_attempt_at);
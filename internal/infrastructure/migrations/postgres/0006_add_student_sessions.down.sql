@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_student_sessions_email;
+DROP INDEX IF EXISTS idx_student_sessions_token;
+DROP INDEX IF EXISTS idx_student_sessions_expires_at;
+DROP TABLE IF EXISTS student_sessions CASCADE;
@@ -0,0 +1,11 @@
+CREATE TABLE student_sessions (
+    id           uuid PRIMARY KEY,
+    email        text        NOT NULL,
+    token        text        NOT NULL,
+    activated_at timestamptz,
+    expires_at   timestamptz NOT NULL,
+    created_at   timestamptz
+);
+CREATE INDEX idx_student_sessions_expires_at ON student_sessions (expires_at);
+CREATE UNIQUE INDEX idx_student_sessions_token ON student_sessions (token);
+CREATE INDEX idx_student_sessions_email ON student_sessions (email);
@@ -0,0 +1,13 @@
+ALTER TABLE passes ADD COLUMN valid_from timestamptz;
+ALTER TABLE passes ADD COLUMN valid_until timestamptz;
+
+CREATE TABLE pass_freezes (
+    id         bigserial PRIMARY KEY,
+    pass_id    bigint      NOT NULL,
+    starts_at  timestamptz NOT NULL,
+    ends_at    timestamptz NOT NULL,
+    reason     text,
+    created_at timestamptz,
+    CONSTRAINT fk_passes_freezes FOREIGN KEY (pass_id) REFERENCES passes (id)
+);
+CREATE INDEX idx_pass_freezes_pass_id ON pass_freezes (pass_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS calendar_feeds;
+
+ALTER TABLE classes DROP COLUMN sequence;
@@ -0,0 +1,8 @@
+ALTER TABLE classes ADD COLUMN sequence bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE calendar_feeds (
+    email      text PRIMARY KEY,
+    token      text NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX idx_calendar_feeds_token ON calendar_feeds (token);
@@ -0,0 +1 @@
+ALTER TABLE bookings ADD COLUMN walk_in boolean NOT NULL DEFAULT false;
@@ -0,0 +1,25 @@
+CREATE TABLE payments (
+    id             uuid PRIMARY KEY,
+    kind           text        NOT NULL,
+    status         text        NOT NULL,
+    provider       text        NOT NULL,
+    provider_ref   text,
+    email          text        NOT NULL,
+    first_name     text        NOT NULL,
+    last_name      text        NOT NULL,
+    class_id       uuid,
+    total_slots    bigint      NOT NULL DEFAULT 0,
+    amount         bigint      NOT NULL,
+    currency       text        NOT NULL,
+    language       text        NOT NULL DEFAULT '',
+    pass_id        bigint,
+    booking_id     uuid,
+    failure_reason text,
+    created_at     timestamptz,
+    paid_at        timestamptz
+);
+CREATE UNIQUE INDEX idx_payments_provider_ref ON payments (provider_ref);
+CREATE INDEX idx_payments_status ON payments (status);
+CREATE INDEX idx_payments_email ON payments (email);
+
+ALTER TABLE bookings ADD COLUMN payment_id uuid;
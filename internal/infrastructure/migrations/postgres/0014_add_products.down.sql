@@ -0,0 +1,6 @@
+ALTER TABLE payments DROP COLUMN product_id;
+
+DROP INDEX IF EXISTS idx_passes_product_id;
+ALTER TABLE passes DROP COLUMN product_id;
+
+DROP TABLE IF EXISTS products;
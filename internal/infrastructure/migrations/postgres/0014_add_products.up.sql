@@ -0,0 +1,17 @@
+CREATE TABLE products (
+    id            uuid PRIMARY KEY,
+    name          text        NOT NULL,
+    kind          text        NOT NULL,
+    total_slots   bigint      NOT NULL DEFAULT 0,
+    validity_days bigint      NOT NULL DEFAULT 0,
+    price         bigint      NOT NULL,
+    currency      text        NOT NULL,
+    active        boolean     NOT NULL DEFAULT true,
+    created_at    timestamptz,
+    updated_at    timestamptz
+);
+
+ALTER TABLE passes ADD COLUMN product_id uuid;
+CREATE INDEX idx_passes_product_id ON passes (product_id);
+
+ALTER TABLE payments ADD COLUMN product_id uuid;
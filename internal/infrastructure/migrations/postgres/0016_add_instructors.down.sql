@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_class_series_instructor_id;
+ALTER TABLE class_series DROP COLUMN instructor_id;
+
+DROP INDEX IF EXISTS idx_classes_instructor_id;
+ALTER TABLE classes DROP COLUMN instructor_id;
+
+DROP TABLE IF EXISTS instructors;
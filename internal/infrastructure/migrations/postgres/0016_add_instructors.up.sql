@@ -0,0 +1,17 @@
+CREATE TABLE instructors (
+    id         uuid PRIMARY KEY,
+    name       text        NOT NULL,
+    bio        text        NOT NULL DEFAULT '',
+    email      text        NOT NULL,
+    signature  text        NOT NULL DEFAULT '',
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX idx_instructors_email ON instructors (email);
+
+-- existing classes keep the signature from the configuration
+ALTER TABLE classes ADD COLUMN instructor_id uuid;
+CREATE INDEX idx_classes_instructor_id ON classes (instructor_id);
+
+ALTER TABLE class_series ADD COLUMN instructor_id uuid;
+CREATE INDEX idx_class_series_instructor_id ON class_series (instructor_id);
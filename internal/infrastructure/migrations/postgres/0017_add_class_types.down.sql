@@ -0,0 +1,6 @@
+ALTER TABLE classes DROP COLUMN duration_minutes;
+
+DROP INDEX IF EXISTS idx_classes_class_type_id;
+ALTER TABLE classes DROP COLUMN class_type_id;
+
+DROP TABLE IF EXISTS class_types;
@@ -0,0 +1,19 @@
+CREATE TABLE class_types (
+    id                       uuid PRIMARY KEY,
+    name                     text        NOT NULL,
+    level                    text        NOT NULL,
+    description              text        NOT NULL DEFAULT '',
+    default_duration_minutes bigint      NOT NULL,
+    default_capacity         bigint      NOT NULL DEFAULT 0,
+    default_location_id      uuid,
+    created_at               timestamptz,
+    updated_at               timestamptz
+);
+CREATE UNIQUE INDEX idx_class_types_name_level ON class_types (name, level);
+CREATE INDEX idx_class_types_default_location_id ON class_types (default_location_id);
+
+-- existing classes keep their free class name and level, and last an hour
+ALTER TABLE classes ADD COLUMN class_type_id uuid;
+CREATE INDEX idx_classes_class_type_id ON classes (class_type_id);
+
+ALTER TABLE classes ADD COLUMN duration_minutes bigint NOT NULL DEFAULT 60;
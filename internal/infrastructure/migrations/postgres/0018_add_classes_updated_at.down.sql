@@ -0,0 +1 @@
+ALTER TABLE classes DROP COLUMN updated_at;
@@ -0,0 +1,4 @@
+-- the public schedule is cached by the last change of its classes, existing classes
+-- count as changed now
+ALTER TABLE classes ADD COLUMN updated_at timestamptz;
+UPDATE classes SET updated_at = CURRENT_TIMESTAMP;
@@ -0,0 +1 @@
+ALTER TABLE waitlist_entries DROP COLUMN language;
@@ -0,0 +1,3 @@
+-- the time zone was picked by the location name in the configuration, locations without
+-- a zone keep the studio one
+ALTER TABLE locations ADD COLUMN time_zone text NOT NULL DEFAULT '';
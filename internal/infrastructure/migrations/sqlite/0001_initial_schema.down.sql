@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS `contacts`;
+DROP TABLE IF EXISTS `bookings`;
+DROP TABLE IF EXISTS `passes`;
+DROP TABLE IF EXISTS `pending_bookings`;
+DROP TABLE IF EXISTS `classes`;
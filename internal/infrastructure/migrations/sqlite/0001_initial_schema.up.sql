@@ -0,0 +1,56 @@
+-- The schema gorm AutoMigrate created before versioned migrations, so existing databases
+-- adopt it as is. Everything added since lives in the later migrations.
+CREATE TABLE IF NOT EXISTS `classes` (
+    `id`           uuid,
+    `start_time`   datetime NOT NULL,
+    `class_level`  text     NOT NULL,
+    `class_name`   text     NOT NULL,
+    `max_capacity` integer  NOT NULL,
+    `location`     text     NOT NULL,
+    PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `pending_bookings` (
+    `id`                 uuid,
+    `class_id`           uuid NOT NULL,
+    `email`              text NOT NULL,
+    `first_name`         text NOT NULL,
+    `last_name`          text NOT NULL,
+    `confirmation_token` text NOT NULL,
+    `created_at`         datetime,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `fk_pending_bookings_class` FOREIGN KEY (`class_id`) REFERENCES `classes` (`id`),
+    CONSTRAINT `uni_pending_bookings_confirmation_token` UNIQUE (`confirmation_token`)
+);
+
+CREATE TABLE IF NOT EXISTS `passes` (
+    `id`          integer PRIMARY KEY AUTOINCREMENT,
+    `email`       text    NOT NULL,
+    `updated_at`  datetime,
+    `created_at`  datetime,
+    `total_slots` integer NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS `bookings` (
+    `id`                 uuid,
+    `class_id`           uuid NOT NULL,
+    `pass_id`            integer,
+    `email`              text NOT NULL,
+    `first_name`         text NOT NULL,
+    `last_name`          text NOT NULL,
+    `confirmation_token` text NOT NULL,
+    `reminded_at`        datetime,
+    `created_at`         datetime,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `fk_bookings_class` FOREIGN KEY (`class_id`) REFERENCES `classes` (`id`),
+    CONSTRAINT `fk_bookings_pass` FOREIGN KEY (`pass_id`) REFERENCES `passes` (`id`),
+    CONSTRAINT `uni_bookings_confirmation_token` UNIQUE (`confirmation_token`)
+);
+
+CREATE TABLE IF NOT EXISTS `contacts` (
+    `id`         integer PRIMARY KEY AUTOINCREMENT,
+    `email`      text NOT NULL,
+    `first_name` text NOT NULL,
+    `last_name`  text NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_contacts_email` ON `contacts` (`email`);
@@ -0,0 +1,12 @@
+CREATE TABLE `waitlist_entries` (
+    `id`         uuid,
+    `class_id`   uuid NOT NULL,
+    `email`      text NOT NULL,
+    `first_name` text NOT NULL,
+    `last_name`  text NOT NULL,
+    `created_at` datetime,
+    `offered_at` datetime,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `fk_waitlist_entries_class` FOREIGN KEY (`class_id`) REFERENCES `classes` (`id`)
+);
+CREATE UNIQUE INDEX `idx_waitlist_class_email` ON `waitlist_entries` (`class_id`, `email`);
@@ -0,0 +1,16 @@
+CREATE TABLE `class_series` (
+    `id`                 uuid,
+    `frequency`          text     NOT NULL,
+    `start_time`         datetime NOT NULL,
+    `end_date`           datetime,
+    `exception_dates`    text,
+    `class_level`        text     NOT NULL,
+    `class_name`         text     NOT NULL,
+    `max_capacity`       integer  NOT NULL,
+    `location`           text     NOT NULL,
+    `materialized_until` datetime,
+    PRIMARY KEY (`id`)
+);
+
+ALTER TABLE `classes` ADD COLUMN `series_id` uuid;
+CREATE INDEX `idx_classes_series_id` ON `classes` (`series_id`);
@@ -0,0 +1,7 @@
+CREATE TABLE `job_runs` (
+    `name`        text,
+    `last_run_at` datetime,
+    `next_run_at` datetime NOT NULL,
+    `last_error`  text,
+    PRIMARY KEY (`name`)
+);
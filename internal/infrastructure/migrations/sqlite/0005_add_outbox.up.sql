@@ -0,0 +1,13 @@
+CREATE TABLE `outbox` (
+    `id`              uuid,
+    `kind`            text     NOT NULL,
+    `payload`         text     NOT NULL,
+    `status`          text     NOT NULL,
+    `attempts`        integer  NOT NULL,
+    `next_attempt_at` datetime NOT NULL,
+    `last_error`      text,
+    `created_at`      datetime,
+    `sent_at`         datetime,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_outbox_status_next_attempt_at` ON `outbox` (`status`, `next_attempt_at`);
@@ -0,0 +1,12 @@
+CREATE TABLE `student_sessions` (
+    `id`           uuid,
+    `email`        text     NOT NULL,
+    `token`        text     NOT NULL,
+    `activated_at` datetime,
+    `expires_at`   datetime NOT NULL,
+    `created_at`   datetime,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_student_sessions_expires_at` ON `student_sessions` (`expires_at`);
+CREATE UNIQUE INDEX `idx_student_sessions_token` ON `student_sessions` (`token`);
+CREATE INDEX `idx_student_sessions_email` ON `student_sessions` (`email`);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS `idx_pass_freezes_pass_id`;
+DROP TABLE IF EXISTS `pass_freezes`;
+
+ALTER TABLE `passes` DROP COLUMN `valid_until`;
+ALTER TABLE `passes` DROP COLUMN `valid_from`;
@@ -0,0 +1,13 @@
+ALTER TABLE `passes` ADD COLUMN `valid_from` datetime;
+ALTER TABLE `passes` ADD COLUMN `valid_until` datetime;
+
+CREATE TABLE `pass_freezes` (
+    `id`         integer PRIMARY KEY AUTOINCREMENT,
+    `pass_id`    integer  NOT NULL,
+    `starts_at`  datetime NOT NULL,
+    `ends_at`    datetime NOT NULL,
+    `reason`     text,
+    `created_at` datetime,
+    CONSTRAINT `fk_passes_freezes` FOREIGN KEY (`pass_id`) REFERENCES `passes` (`id`)
+);
+CREATE INDEX `idx_pass_freezes_pass_id` ON `pass_freezes` (`pass_id`);
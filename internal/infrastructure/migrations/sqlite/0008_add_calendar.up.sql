@@ -0,0 +1,9 @@
+ALTER TABLE `classes` ADD COLUMN `sequence` integer NOT NULL DEFAULT 0;
+
+CREATE TABLE `calendar_feeds` (
+    `email`      text,
+    `token`      text NOT NULL,
+    `created_at` datetime,
+    PRIMARY KEY (`email`)
+);
+CREATE UNIQUE INDEX `idx_calendar_feeds_token` ON `calendar_feeds` (`token`);
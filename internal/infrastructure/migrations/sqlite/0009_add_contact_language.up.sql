@@ -0,0 +1 @@
+ALTER TABLE `contacts` ADD COLUMN `language` text NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE `bookings` ADD COLUMN `cancelled_at` datetime;
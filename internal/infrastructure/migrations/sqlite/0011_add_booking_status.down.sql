@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS `idx_bookings_class_id_status`;
+DELETE FROM `bookings` WHERE `status` = 'cancelled';
+UPDATE `bookings` SET `cancelled_at` = NULL WHERE `status` <> 'late_cancelled';
+ALTER TABLE `bookings` DROP COLUMN `status`;
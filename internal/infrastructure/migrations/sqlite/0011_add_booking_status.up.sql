@@ -0,0 +1,3 @@
+ALTER TABLE `bookings` ADD COLUMN `status` text NOT NULL DEFAULT 'confirmed';
+UPDATE `bookings` SET `status` = 'late_cancelled' WHERE `cancelled_at` IS NOT NULL;
+CREATE INDEX `idx_bookings_class_id_status` ON `bookings` (`class_id`, `status`);
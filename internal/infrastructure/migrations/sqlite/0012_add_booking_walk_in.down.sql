@@ -0,0 +1 @@
+ALTER TABLE `bookings` DROP COLUMN `walk_in`;
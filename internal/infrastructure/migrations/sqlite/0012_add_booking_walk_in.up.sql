@@ -0,0 +1 @@
+ALTER TABLE `bookings` ADD COLUMN `walk_in` numeric NOT NULL DEFAULT false;
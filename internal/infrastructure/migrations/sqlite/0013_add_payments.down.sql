@@ -0,0 +1,3 @@
+ALTER TABLE `bookings` DROP COLUMN `payment_id`;
+
+DROP TABLE IF EXISTS `payments`;
@@ -0,0 +1,26 @@
+CREATE TABLE `payments` (
+    `id`             uuid,
+    `kind`           text     NOT NULL,
+    `status`         text     NOT NULL,
+    `provider`       text     NOT NULL,
+    `provider_ref`   text,
+    `email`          text     NOT NULL,
+    `first_name`     text     NOT NULL,
+    `last_name`      text     NOT NULL,
+    `class_id`       uuid,
+    `total_slots`    integer  NOT NULL DEFAULT 0,
+    `amount`         integer  NOT NULL,
+    `currency`       text     NOT NULL,
+    `language`       text     NOT NULL DEFAULT '',
+    `pass_id`        integer,
+    `booking_id`     uuid,
+    `failure_reason` text,
+    `created_at`     datetime,
+    `paid_at`        datetime,
+    PRIMARY KEY (`id`)
+);
+CREATE UNIQUE INDEX `idx_payments_provider_ref` ON `payments` (`provider_ref`);
+CREATE INDEX `idx_payments_status` ON `payments` (`status`);
+CREATE INDEX `idx_payments_email` ON `payments` (`email`);
+
+ALTER TABLE `bookings` ADD COLUMN `payment_id` uuid;
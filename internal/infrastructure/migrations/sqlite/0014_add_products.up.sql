@@ -0,0 +1,18 @@
+CREATE TABLE `products` (
+    `id`            uuid,
+    `name`          text     NOT NULL,
+    `kind`          text     NOT NULL,
+    `total_slots`   integer  NOT NULL DEFAULT 0,
+    `validity_days` integer  NOT NULL DEFAULT 0,
+    `price`         integer  NOT NULL,
+    `currency`      text     NOT NULL,
+    `active`        numeric  NOT NULL DEFAULT true,
+    `created_at`    datetime,
+    `updated_at`    datetime,
+    PRIMARY KEY (`id`)
+);
+
+ALTER TABLE `passes` ADD COLUMN `product_id` uuid;
+CREATE INDEX `idx_passes_product_id` ON `passes` (`product_id`);
+
+ALTER TABLE `payments` ADD COLUMN `product_id` uuid;
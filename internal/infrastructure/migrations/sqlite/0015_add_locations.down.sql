@@ -0,0 +1,11 @@
+ALTER TABLE `class_series` ADD COLUMN `location` text NOT NULL DEFAULT '';
+UPDATE `class_series` SET `location` = (SELECT `name` FROM `locations` WHERE `locations`.`id` = `class_series`.`location_id`);
+DROP INDEX IF EXISTS `idx_class_series_location_id`;
+ALTER TABLE `class_series` DROP COLUMN `location_id`;
+
+ALTER TABLE `classes` ADD COLUMN `location` text NOT NULL DEFAULT '';
+UPDATE `classes` SET `location` = (SELECT `name` FROM `locations` WHERE `locations`.`id` = `classes`.`location_id`);
+DROP INDEX IF EXISTS `idx_classes_location_id`;
+ALTER TABLE `classes` DROP COLUMN `location_id`;
+
+DROP TABLE IF EXISTS `locations`;
@@ -0,0 +1,32 @@
+CREATE TABLE `locations` (
+    `id`               uuid,
+    `name`             text     NOT NULL,
+    `address`          text     NOT NULL DEFAULT '',
+    `directions`       text     NOT NULL DEFAULT '',
+    `map_url`          text     NOT NULL DEFAULT '',
+    `default_capacity` integer  NOT NULL DEFAULT 0,
+    `access_notes`     text     NOT NULL DEFAULT '',
+    `created_at`       datetime,
+    `updated_at`       datetime,
+    PRIMARY KEY (`id`)
+);
+CREATE UNIQUE INDEX `idx_locations_name` ON `locations` (`name`);
+
+-- every free-text location becomes a location, the address is filled in through the API
+INSERT INTO `locations` (`id`, `name`, `created_at`, `updated_at`)
+SELECT lower(
+           hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' ||
+           substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))
+       ),
+       `location`, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (SELECT `location` FROM `classes` UNION SELECT `location` FROM `class_series`);
+
+ALTER TABLE `classes` ADD COLUMN `location_id` uuid;
+UPDATE `classes` SET `location_id` = (SELECT `id` FROM `locations` WHERE `locations`.`name` = `classes`.`location`);
+CREATE INDEX `idx_classes_location_id` ON `classes` (`location_id`);
+ALTER TABLE `classes` DROP COLUMN `location`;
+
+ALTER TABLE `class_series` ADD COLUMN `location_id` uuid;
+UPDATE `class_series` SET `location_id` = (SELECT `id` FROM `locations` WHERE `locations`.`name` = `class_series`.`location`);
+CREATE INDEX `idx_class_series_location_id` ON `class_series` (`location_id`);
+ALTER TABLE `class_series` DROP COLUMN `location`;
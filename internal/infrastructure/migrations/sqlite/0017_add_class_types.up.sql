@@ -0,0 +1,20 @@
+CREATE TABLE `class_types` (
+    `id`                       uuid,
+    `name`                     text     NOT NULL,
+    `level`                    text     NOT NULL,
+    `description`              text     NOT NULL DEFAULT '',
+    `default_duration_minutes` integer  NOT NULL,
+    `default_capacity`         integer  NOT NULL DEFAULT 0,
+    `default_location_id`      uuid,
+    `created_at`               datetime,
+    `updated_at`               datetime,
+    PRIMARY KEY (`id`)
+);
+CREATE UNIQUE INDEX `idx_class_types_name_level` ON `class_types` (`name`, `level`);
+CREATE INDEX `idx_class_types_default_location_id` ON `class_types` (`default_location_id`);
+
+-- existing classes keep their free class name and level, and last an hour
+ALTER TABLE `classes` ADD COLUMN `class_type_id` uuid;
+CREATE INDEX `idx_classes_class_type_id` ON `classes` (`class_type_id`);
+
+ALTER TABLE `classes` ADD COLUMN `duration_minutes` integer NOT NULL DEFAULT 60;
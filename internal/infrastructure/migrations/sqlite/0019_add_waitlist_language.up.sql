@@ -0,0 +1,2 @@
+-- entries from before keep an empty language, their offers go out in the default one
+ALTER TABLE `waitlist_entries` ADD COLUMN `language` text NOT NULL DEFAULT '';
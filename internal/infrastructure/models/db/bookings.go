@@ -20,7 +20,11 @@ type SQLBooking struct {
 	LastName          string   `gorm:"not null"`
 	ConfirmationToken string   `gorm:"unique;not null"`
 	RemindedAt        *time.Time
-	CreatedAt         time.Time `gorm:"autoCreateTime"`
+	Status            string `gorm:"not null;default:confirmed"`
+	CancelledAt       *time.Time
+	WalkIn            bool       `gorm:"not null;default:false"`
+	PaymentID         *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt         time.Time  `gorm:"autoCreateTime"`
 }
 
 func (SQLBooking) TableName() string {
@@ -37,9 +41,16 @@ func (s SQLBooking) ToDomain() models.Booking {
 		Email:             s.Email,
 		CreatedAt:         s.CreatedAt,
 		RemindedAt:        s.RemindedAt,
+		Status:            models.BookingStatus(s.Status),
+		CancelledAt:       s.CancelledAt,
+		WalkIn:            s.WalkIn,
 		ConfirmationToken: s.ConfirmationToken,
 	}
 
+	if s.PaymentID != nil {
+		booking.PaymentID = optional.Of(*s.PaymentID)
+	}
+
 	if s.Pass != nil {
 		pass := s.Pass.ToDomain()
 		booking.PassID = optional.Of(pass.ID)
@@ -59,9 +70,21 @@ func SQLBookingFromDomain(domain models.Booking) SQLBooking {
 		Email:             domain.Email,
 		CreatedAt:         domain.CreatedAt,
 		RemindedAt:        domain.RemindedAt,
+		Status:            string(domain.Status),
+		CancelledAt:       domain.CancelledAt,
+		WalkIn:            domain.WalkIn,
 		ConfirmationToken: domain.ConfirmationToken,
 	}
 
+	if booking.Status == "" {
+		booking.Status = string(models.BookingConfirmed)
+	}
+
+	if domain.PaymentID.Exists() {
+		paymentID := domain.PaymentID.Get()
+		booking.PaymentID = &paymentID
+	}
+
 	if domain.Pass.Exists() {
 		pass := SQLPassFromDomain(domain.Pass.Get())
 		booking.PassID = &pass.ID
@@ -70,3 +93,26 @@ func SQLBookingFromDomain(domain models.Booking) SQLBooking {
 
 	return booking
 }
+
+// SQLContactStatistics is a row of bookings counted by status for one email.
+type SQLContactStatistics struct {
+	Email             string
+	Confirmed         int
+	Attended          int
+	NoShows           int
+	LateCancellations int
+	Cancellations     int
+	WalkIns           int
+}
+
+func (s SQLContactStatistics) ToDomain() models.ContactStatistics {
+	return models.ContactStatistics{
+		Email:             s.Email,
+		Confirmed:         s.Confirmed,
+		Attended:          s.Attended,
+		NoShows:           s.NoShows,
+		LateCancellations: s.LateCancellations,
+		Cancellations:     s.Cancellations,
+		WalkIns:           s.WalkIns,
+	}
+}
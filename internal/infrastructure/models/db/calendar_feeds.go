@@ -0,0 +1,33 @@
+package db
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+)
+
+type SQLCalendarFeed struct {
+	Email     string    `gorm:"primaryKey"`
+	Token     string    `gorm:"not null;uniqueIndex"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (SQLCalendarFeed) TableName() string {
+	return "calendar_feeds"
+}
+
+func (s SQLCalendarFeed) ToDomain() models.CalendarFeed {
+	return models.CalendarFeed{
+		Email:     s.Email,
+		Token:     s.Token,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+func SQLCalendarFeedFromDomain(domain models.CalendarFeed) SQLCalendarFeed {
+	return SQLCalendarFeed{
+		Email:     domain.Email,
+		Token:     domain.Token,
+		CreatedAt: domain.CreatedAt,
+	}
+}
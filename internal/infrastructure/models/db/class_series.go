@@ -0,0 +1,73 @@
+package db
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+	"main/pkg/optional"
+
+	"github.com/google/uuid"
+)
+
+type SQLClassSeries struct {
+	ID                uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Frequency         string    `gorm:"not null"`
+	StartTime         time.Time `gorm:"not null"`
+	EndDate           *time.Time
+	ExceptionDates    []time.Time `gorm:"serializer:json"`
+	ClassLevel        string      `gorm:"not null"`
+	ClassName         string      `gorm:"not null"`
+	MaxCapacity       int         `gorm:"not null"`
+	LocationID        uuid.UUID   `gorm:"type:uuid;index"`
+	Location          SQLLocation `gorm:"foreignKey:location_id"`
+	InstructorID      *uuid.UUID  `gorm:"type:uuid;index"`
+	MaterializedUntil *time.Time
+}
+
+func (SQLClassSeries) TableName() string {
+	return "class_series"
+}
+
+func (s SQLClassSeries) ToDomain() models.ClassSeries {
+	series := models.ClassSeries{
+		ID:                s.ID,
+		Frequency:         models.ClassSeriesFrequency(s.Frequency),
+		StartTime:         s.StartTime,
+		EndDate:           s.EndDate,
+		ExceptionDates:    s.ExceptionDates,
+		ClassLevel:        s.ClassLevel,
+		ClassName:         s.ClassName,
+		MaxCapacity:       s.MaxCapacity,
+		LocationID:        s.LocationID,
+		Location:          s.Location.ToDomain(),
+		MaterializedUntil: s.MaterializedUntil,
+	}
+
+	if s.InstructorID != nil {
+		series.InstructorID = optional.Of(*s.InstructorID)
+	}
+
+	return series
+}
+
+func SQLClassSeriesFromDomain(series models.ClassSeries) SQLClassSeries {
+	sqlSeries := SQLClassSeries{
+		ID:                series.ID,
+		Frequency:         string(series.Frequency),
+		StartTime:         series.StartTime,
+		EndDate:           series.EndDate,
+		ExceptionDates:    series.ExceptionDates,
+		ClassLevel:        series.ClassLevel,
+		ClassName:         series.ClassName,
+		MaxCapacity:       series.MaxCapacity,
+		LocationID:        series.LocationID,
+		MaterializedUntil: series.MaterializedUntil,
+	}
+
+	if series.InstructorID.Exists() {
+		instructorID := series.InstructorID.Get()
+		sqlSeries.InstructorID = &instructorID
+	}
+
+	return sqlSeries
+}
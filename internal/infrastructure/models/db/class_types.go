@@ -0,0 +1,65 @@
+package db
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+	"main/pkg/optional"
+
+	"github.com/google/uuid"
+)
+
+type SQLClassType struct {
+	ID                     uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Name                   string     `gorm:"uniqueIndex:idx_class_types_name_level;not null"`
+	Level                  string     `gorm:"uniqueIndex:idx_class_types_name_level;not null"`
+	Description            string     `gorm:"not null;default:''"`
+	DefaultDurationMinutes int        `gorm:"not null"`
+	DefaultCapacity        int        `gorm:"not null;default:0"`
+	DefaultLocationID      *uuid.UUID `gorm:"type:uuid;index"`
+	CreatedAt              time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt              time.Time  `gorm:"autoUpdateTime"`
+}
+
+func (SQLClassType) TableName() string {
+	return "class_types"
+}
+
+func (s SQLClassType) ToDomain() models.ClassType {
+	classType := models.ClassType{
+		ID:              s.ID,
+		Name:            s.Name,
+		Level:           s.Level,
+		Description:     s.Description,
+		DefaultDuration: time.Duration(s.DefaultDurationMinutes) * time.Minute,
+		DefaultCapacity: s.DefaultCapacity,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+
+	if s.DefaultLocationID != nil {
+		classType.DefaultLocationID = optional.Of(*s.DefaultLocationID)
+	}
+
+	return classType
+}
+
+func SQLClassTypeFromDomain(domain models.ClassType) SQLClassType {
+	sqlClassType := SQLClassType{
+		ID:                     domain.ID,
+		Name:                   domain.Name,
+		Level:                  domain.Level,
+		Description:            domain.Description,
+		DefaultDurationMinutes: int(domain.DefaultDuration / time.Minute),
+		DefaultCapacity:        domain.DefaultCapacity,
+		CreatedAt:              domain.CreatedAt,
+		UpdatedAt:              domain.UpdatedAt,
+	}
+
+	if domain.DefaultLocationID.Exists() {
+		locationID := domain.DefaultLocationID.Get()
+		sqlClassType.DefaultLocationID = &locationID
+	}
+
+	return sqlClassType
+}
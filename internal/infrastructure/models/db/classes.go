@@ -4,17 +4,26 @@ import (
 	"time"
 
 	"main/internal/domain/models"
+	"main/pkg/optional"
 
 	"github.com/google/uuid"
 )
 
 type SQLClass struct {
-	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
-	StartTime   time.Time `gorm:"not null"`
-	ClassLevel  string    `gorm:"not null"`
-	ClassName   string    `gorm:"not null"`
-	MaxCapacity int       `gorm:"not null"`
-	Location    string    `gorm:"not null"`
+	ID              uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	StartTime       time.Time      `gorm:"not null"`
+	ClassLevel      string         `gorm:"not null"`
+	ClassName       string         `gorm:"not null"`
+	MaxCapacity     int            `gorm:"not null"`
+	LocationID      uuid.UUID      `gorm:"type:uuid;index"`
+	Location        SQLLocation    `gorm:"foreignKey:location_id"`
+	InstructorID    *uuid.UUID     `gorm:"type:uuid;index"`
+	Instructor      *SQLInstructor `gorm:"foreignKey:instructor_id"`
+	SeriesID        *uuid.UUID     `gorm:"type:uuid;index"`
+	ClassTypeID     *uuid.UUID     `gorm:"type:uuid;index"`
+	DurationMinutes int            `gorm:"not null;default:60"`
+	Sequence        int            `gorm:"not null;default:0"`
+	UpdatedAt       time.Time
 }
 
 func (SQLClass) TableName() string {
@@ -22,23 +31,65 @@ func (SQLClass) TableName() string {
 }
 
 func (s SQLClass) ToDomain() models.Class {
-	return models.Class{
+	class := models.Class{
 		ID:          s.ID,
 		StartTime:   s.StartTime,
 		ClassLevel:  s.ClassLevel,
 		ClassName:   s.ClassName,
 		MaxCapacity: s.MaxCapacity,
-		Location:    s.Location,
+		LocationID:  s.LocationID,
+		Location:    s.Location.ToDomain(),
+		Duration:    time.Duration(s.DurationMinutes) * time.Minute,
+		Sequence:    s.Sequence,
+		UpdatedAt:   s.UpdatedAt,
 	}
+
+	if s.InstructorID != nil {
+		class.InstructorID = optional.Of(*s.InstructorID)
+	}
+
+	if s.Instructor != nil {
+		class.Instructor = s.Instructor.ToDomain()
+	}
+
+	if s.SeriesID != nil {
+		class.SeriesID = optional.Of(*s.SeriesID)
+	}
+
+	if s.ClassTypeID != nil {
+		class.ClassTypeID = optional.Of(*s.ClassTypeID)
+	}
+
+	return class
 }
 
 func SQLClassFromDomain(class models.Class) SQLClass {
-	return SQLClass{
-		ID:          class.ID,
-		StartTime:   class.StartTime,
-		ClassLevel:  class.ClassLevel,
-		ClassName:   class.ClassName,
-		MaxCapacity: class.MaxCapacity,
-		Location:    class.Location,
+	sqlClass := SQLClass{
+		ID:              class.ID,
+		StartTime:       class.StartTime,
+		ClassLevel:      class.ClassLevel,
+		ClassName:       class.ClassName,
+		MaxCapacity:     class.MaxCapacity,
+		LocationID:      class.LocationID,
+		DurationMinutes: int(class.Duration / time.Minute),
+		Sequence:        class.Sequence,
+		UpdatedAt:       class.UpdatedAt,
+	}
+
+	if class.InstructorID.Exists() {
+		instructorID := class.InstructorID.Get()
+		sqlClass.InstructorID = &instructorID
+	}
+
+	if class.SeriesID.Exists() {
+		seriesID := class.SeriesID.Get()
+		sqlClass.SeriesID = &seriesID
+	}
+
+	if class.ClassTypeID.Exists() {
+		classTypeID := class.ClassTypeID.Get()
+		sqlClass.ClassTypeID = &classTypeID
 	}
+
+	return sqlClass
 }
@@ -2,6 +2,7 @@ package db
 
 import (
 	"main/internal/domain/models"
+	"main/pkg/i18n"
 )
 
 type SQLContact struct {
@@ -9,6 +10,7 @@ type SQLContact struct {
 	Email     string `gorm:"uniqueIndex;not null"`
 	FirstName string `gorm:"not null"`
 	LastName  string `gorm:"not null"`
+	Language  string `gorm:"not null;default:''"`
 }
 
 func (SQLContact) TableName() string {
@@ -21,5 +23,6 @@ func (s SQLContact) ToDomain() models.Contact {
 		Email:     s.Email,
 		FirstName: s.FirstName,
 		LastName:  s.LastName,
+		Language:  i18n.Locale(s.Language),
 	}
 }
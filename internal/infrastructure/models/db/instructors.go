@@ -0,0 +1,47 @@
+package db
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type SQLInstructor struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name      string    `gorm:"not null"`
+	Bio       string    `gorm:"not null;default:''"`
+	Email     string    `gorm:"uniqueIndex;not null"`
+	Signature string    `gorm:"not null;default:''"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (SQLInstructor) TableName() string {
+	return "instructors"
+}
+
+func (s SQLInstructor) ToDomain() models.Instructor {
+	return models.Instructor{
+		ID:        s.ID,
+		Name:      s.Name,
+		Bio:       s.Bio,
+		Email:     s.Email,
+		Signature: s.Signature,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func SQLInstructorFromDomain(domain models.Instructor) SQLInstructor {
+	return SQLInstructor{
+		ID:        domain.ID,
+		Name:      domain.Name,
+		Bio:       domain.Bio,
+		Email:     domain.Email,
+		Signature: domain.Signature,
+		CreatedAt: domain.CreatedAt,
+		UpdatedAt: domain.UpdatedAt,
+	}
+}
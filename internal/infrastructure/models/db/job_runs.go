@@ -0,0 +1,36 @@
+package db
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+)
+
+type SQLJobRun struct {
+	Name      string `gorm:"primaryKey"`
+	LastRunAt *time.Time
+	NextRunAt time.Time `gorm:"not null"`
+	LastError *string
+}
+
+func (SQLJobRun) TableName() string {
+	return "job_runs"
+}
+
+func (s SQLJobRun) ToDomain() models.JobRun {
+	return models.JobRun{
+		Name:      s.Name,
+		LastRunAt: s.LastRunAt,
+		NextRunAt: s.NextRunAt,
+		LastError: s.LastError,
+	}
+}
+
+func SQLJobRunFromDomain(jobRun models.JobRun) SQLJobRun {
+	return SQLJobRun{
+		Name:      jobRun.Name,
+		LastRunAt: jobRun.LastRunAt,
+		NextRunAt: jobRun.NextRunAt,
+		LastError: jobRun.LastError,
+	}
+}
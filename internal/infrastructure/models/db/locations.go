@@ -0,0 +1,56 @@
+package db
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type SQLLocation struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name            string    `gorm:"uniqueIndex;not null"`
+	Address         string    `gorm:"not null;default:''"`
+	Directions      string    `gorm:"not null;default:''"`
+	MapURL          string    `gorm:"not null;default:''"`
+	DefaultCapacity int       `gorm:"not null;default:0"`
+	AccessNotes     string    `gorm:"not null;default:''"`
+	TimeZone        string    `gorm:"not null;default:''"`
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime"`
+}
+
+func (SQLLocation) TableName() string {
+	return "locations"
+}
+
+func (s SQLLocation) ToDomain() models.Location {
+	return models.Location{
+		ID:              s.ID,
+		Name:            s.Name,
+		Address:         s.Address,
+		Directions:      s.Directions,
+		MapURL:          s.MapURL,
+		DefaultCapacity: s.DefaultCapacity,
+		AccessNotes:     s.AccessNotes,
+		TimeZone:        s.TimeZone,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+}
+
+func SQLLocationFromDomain(domain models.Location) SQLLocation {
+	return SQLLocation{
+		ID:              domain.ID,
+		Name:            domain.Name,
+		Address:         domain.Address,
+		Directions:      domain.Directions,
+		MapURL:          domain.MapURL,
+		DefaultCapacity: domain.DefaultCapacity,
+		AccessNotes:     domain.AccessNotes,
+		TimeZone:        domain.TimeZone,
+		CreatedAt:       domain.CreatedAt,
+		UpdatedAt:       domain.UpdatedAt,
+	}
+}
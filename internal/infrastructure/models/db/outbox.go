@@ -0,0 +1,52 @@
+package db
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type SQLOutboxMessage struct {
+	ID            uuid.UUID           `gorm:"type:uuid;primaryKey"`
+	Kind          string              `gorm:"not null"`
+	Payload       models.Notification `gorm:"serializer:json;not null"`
+	Status        string              `gorm:"not null;index:idx_outbox_status_next_attempt_at"`
+	Attempts      int                 `gorm:"not null"`
+	NextAttemptAt time.Time           `gorm:"not null;index:idx_outbox_status_next_attempt_at"`
+	LastError     *string
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	SentAt        *time.Time
+}
+
+func (SQLOutboxMessage) TableName() string {
+	return "outbox"
+}
+
+func (s SQLOutboxMessage) ToDomain() models.OutboxMessage {
+	return models.OutboxMessage{
+		ID:            s.ID,
+		Notification:  s.Payload,
+		Status:        models.OutboxStatus(s.Status),
+		Attempts:      s.Attempts,
+		NextAttemptAt: s.NextAttemptAt,
+		LastError:     s.LastError,
+		CreatedAt:     s.CreatedAt,
+		SentAt:        s.SentAt,
+	}
+}
+
+func SQLOutboxMessageFromDomain(message models.OutboxMessage) SQLOutboxMessage {
+	return SQLOutboxMessage{
+		ID:            message.ID,
+		Kind:          string(message.Notification.Kind),
+		Payload:       message.Notification,
+		Status:        string(message.Status),
+		Attempts:      message.Attempts,
+		NextAttemptAt: message.NextAttemptAt,
+		LastError:     message.LastError,
+		CreatedAt:     message.CreatedAt,
+		SentAt:        message.SentAt,
+	}
+}
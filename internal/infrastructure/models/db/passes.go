@@ -4,6 +4,9 @@ import (
 	"time"
 
 	"main/internal/domain/models"
+	"main/pkg/optional"
+
+	"github.com/google/uuid"
 )
 
 type SQLPass struct {
@@ -12,6 +15,11 @@ type SQLPass struct {
 	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
 	CreatedAt  time.Time `gorm:"autoCreateTime"`
 	TotalSlots int       `gorm:"not null"`
+	// passes created before validity was introduced have no valid_from, they are valid since creation
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+	ProductID  *uuid.UUID      `gorm:"type:uuid;index"`
+	Freezes    []SQLPassFreeze `gorm:"foreignKey:PassID"`
 }
 
 func (SQLPass) TableName() string {
@@ -25,17 +33,77 @@ func (s SQLPass) ToDomain() models.Pass {
 		UpdatedAt:  s.UpdatedAt,
 		CreatedAt:  s.CreatedAt,
 		TotalSlots: s.TotalSlots,
+		ValidFrom:  s.CreatedAt,
+		ValidUntil: s.ValidUntil,
+	}
+
+	if s.ValidFrom != nil {
+		pass.ValidFrom = *s.ValidFrom
+	}
+
+	if s.ProductID != nil {
+		pass.ProductID = optional.Of(*s.ProductID)
+	}
+
+	for _, freeze := range s.Freezes {
+		pass.Freezes = append(pass.Freezes, freeze.ToDomain())
 	}
 
 	return pass
 }
 
 func SQLPassFromDomain(domain models.Pass) SQLPass {
-	return SQLPass{
+	validFrom := domain.ValidFrom
+
+	pass := SQLPass{
 		ID:         domain.ID,
 		Email:      domain.Email,
 		UpdatedAt:  domain.UpdatedAt,
 		CreatedAt:  domain.CreatedAt,
 		TotalSlots: domain.TotalSlots,
+		ValidFrom:  &validFrom,
+		ValidUntil: domain.ValidUntil,
+	}
+
+	if domain.ProductID.Exists() {
+		productID := domain.ProductID.Get()
+		pass.ProductID = &productID
+	}
+
+	return pass
+}
+
+type SQLPassFreeze struct {
+	ID        int       `gorm:"primaryKey"`
+	PassID    int       `gorm:"not null;index"`
+	StartsAt  time.Time `gorm:"not null"`
+	EndsAt    time.Time `gorm:"not null"`
+	Reason    string
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (SQLPassFreeze) TableName() string {
+	return "pass_freezes"
+}
+
+func (s SQLPassFreeze) ToDomain() models.PassFreeze {
+	return models.PassFreeze{
+		ID:        s.ID,
+		PassID:    s.PassID,
+		StartsAt:  s.StartsAt,
+		EndsAt:    s.EndsAt,
+		Reason:    s.Reason,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+func SQLPassFreezeFromDomain(domain models.PassFreeze) SQLPassFreeze {
+	return SQLPassFreeze{
+		ID:        domain.ID,
+		PassID:    domain.PassID,
+		StartsAt:  domain.StartsAt,
+		EndsAt:    domain.EndsAt,
+		Reason:    domain.Reason,
+		CreatedAt: domain.CreatedAt,
 	}
 }
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+	"main/pkg/i18n"
+	"main/pkg/optional"
+
+	"github.com/google/uuid"
+)
+
+type SQLPayment struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Kind          string     `gorm:"not null"`
+	Status        string     `gorm:"not null;index"`
+	Provider      string     `gorm:"not null"`
+	ProviderRef   *string    `gorm:"uniqueIndex"`
+	Email         string     `gorm:"not null;index"`
+	FirstName     string     `gorm:"not null"`
+	LastName      string     `gorm:"not null"`
+	ClassID       *uuid.UUID `gorm:"type:uuid"`
+	TotalSlots    int        `gorm:"not null;default:0"`
+	ProductID     *uuid.UUID `gorm:"type:uuid"`
+	Amount        int        `gorm:"not null"`
+	Currency      string     `gorm:"not null"`
+	Language      string     `gorm:"not null;default:''"`
+	PassID        *int
+	BookingID     *uuid.UUID `gorm:"type:uuid"`
+	FailureReason *string
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	PaidAt        *time.Time
+}
+
+func (SQLPayment) TableName() string {
+	return "payments"
+}
+
+func (s SQLPayment) ToDomain() models.Payment {
+	payment := models.Payment{
+		ID:            s.ID,
+		Kind:          models.PaymentKind(s.Kind),
+		Status:        models.PaymentStatus(s.Status),
+		Provider:      s.Provider,
+		Email:         s.Email,
+		FirstName:     s.FirstName,
+		LastName:      s.LastName,
+		TotalSlots:    s.TotalSlots,
+		Amount:        s.Amount,
+		Currency:      s.Currency,
+		Language:      i18n.Locale(s.Language),
+		FailureReason: s.FailureReason,
+		CreatedAt:     s.CreatedAt,
+		PaidAt:        s.PaidAt,
+	}
+
+	if s.ProviderRef != nil {
+		payment.ProviderRef = *s.ProviderRef
+	}
+
+	if s.ClassID != nil {
+		payment.ClassID = optional.Of(*s.ClassID)
+	}
+
+	if s.ProductID != nil {
+		payment.ProductID = optional.Of(*s.ProductID)
+	}
+
+	if s.PassID != nil {
+		payment.PassID = optional.Of(*s.PassID)
+	}
+
+	if s.BookingID != nil {
+		payment.BookingID = optional.Of(*s.BookingID)
+	}
+
+	return payment
+}
+
+func SQLPaymentFromDomain(domain models.Payment) SQLPayment {
+	payment := SQLPayment{
+		ID:            domain.ID,
+		Kind:          string(domain.Kind),
+		Status:        string(domain.Status),
+		Provider:      domain.Provider,
+		Email:         domain.Email,
+		FirstName:     domain.FirstName,
+		LastName:      domain.LastName,
+		TotalSlots:    domain.TotalSlots,
+		Amount:        domain.Amount,
+		Currency:      domain.Currency,
+		Language:      string(domain.Language),
+		FailureReason: domain.FailureReason,
+		CreatedAt:     domain.CreatedAt,
+		PaidAt:        domain.PaidAt,
+	}
+
+	// the reference is unique, payments waiting for their checkout have none
+	if domain.ProviderRef != "" {
+		payment.ProviderRef = &domain.ProviderRef
+	}
+
+	if domain.ClassID.Exists() {
+		classID := domain.ClassID.Get()
+		payment.ClassID = &classID
+	}
+
+	if domain.ProductID.Exists() {
+		productID := domain.ProductID.Get()
+		payment.ProductID = &productID
+	}
+
+	if domain.PassID.Exists() {
+		passID := domain.PassID.Get()
+		payment.PassID = &passID
+	}
+
+	if domain.BookingID.Exists() {
+		bookingID := domain.BookingID.Get()
+		payment.BookingID = &bookingID
+	}
+
+	return payment
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type SQLProduct struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name         string    `gorm:"not null"`
+	Kind         string    `gorm:"not null"`
+	TotalSlots   int       `gorm:"not null;default:0"`
+	ValidityDays int       `gorm:"not null;default:0"`
+	Price        int       `gorm:"not null"`
+	Currency     string    `gorm:"not null"`
+	Active       bool      `gorm:"not null;default:true"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+}
+
+func (SQLProduct) TableName() string {
+	return "products"
+}
+
+func (s SQLProduct) ToDomain() models.Product {
+	return models.Product{
+		ID:           s.ID,
+		Name:         s.Name,
+		Kind:         models.ProductKind(s.Kind),
+		TotalSlots:   s.TotalSlots,
+		ValidityDays: s.ValidityDays,
+		Price:        s.Price,
+		Currency:     s.Currency,
+		Active:       s.Active,
+		CreatedAt:    s.CreatedAt,
+		UpdatedAt:    s.UpdatedAt,
+	}
+}
+
+func SQLProductFromDomain(domain models.Product) SQLProduct {
+	return SQLProduct{
+		ID:           domain.ID,
+		Name:         domain.Name,
+		Kind:         string(domain.Kind),
+		TotalSlots:   domain.TotalSlots,
+		ValidityDays: domain.ValidityDays,
+		Price:        domain.Price,
+		Currency:     domain.Currency,
+		Active:       domain.Active,
+		CreatedAt:    domain.CreatedAt,
+		UpdatedAt:    domain.UpdatedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type SQLStudentSession struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Email       string    `gorm:"not null;index"`
+	Token       string    `gorm:"not null;uniqueIndex"`
+	ActivatedAt *time.Time
+	ExpiresAt   time.Time `gorm:"not null;index"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func (SQLStudentSession) TableName() string {
+	return "student_sessions"
+}
+
+func (s SQLStudentSession) ToDomain() models.StudentSession {
+	return models.StudentSession{
+		ID:          s.ID,
+		Email:       s.Email,
+		Token:       s.Token,
+		ActivatedAt: s.ActivatedAt,
+		ExpiresAt:   s.ExpiresAt,
+		CreatedAt:   s.CreatedAt,
+	}
+}
+
+func SQLStudentSessionFromDomain(domain models.StudentSession) SQLStudentSession {
+	return SQLStudentSession{
+		ID:          domain.ID,
+		Email:       domain.Email,
+		Token:       domain.Token,
+		ActivatedAt: domain.ActivatedAt,
+		ExpiresAt:   domain.ExpiresAt,
+		CreatedAt:   domain.CreatedAt,
+	}
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+	"main/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+type SQLWaitlistEntry struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ClassID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_waitlist_class_email"`
+	Class     SQLClass  `gorm:"foreignKey:class_id"`
+	Email     string    `gorm:"not null;uniqueIndex:idx_waitlist_class_email"`
+	FirstName string    `gorm:"not null"`
+	LastName  string    `gorm:"not null"`
+	Language  string    `gorm:"not null;default:''"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	OfferedAt *time.Time
+}
+
+func (SQLWaitlistEntry) TableName() string {
+	return "waitlist_entries"
+}
+
+func (s SQLWaitlistEntry) ToDomain() models.WaitlistEntry {
+	return models.WaitlistEntry{
+		ID:        s.ID,
+		ClassID:   s.ClassID,
+		Class:     s.Class.ToDomain(),
+		Email:     s.Email,
+		FirstName: s.FirstName,
+		LastName:  s.LastName,
+		Language:  i18n.Locale(s.Language),
+		CreatedAt: s.CreatedAt,
+		OfferedAt: s.OfferedAt,
+	}
+}
+
+func SQLWaitlistEntryFromDomain(domain models.WaitlistEntry) SQLWaitlistEntry {
+	return SQLWaitlistEntry{
+		ID:        domain.ID,
+		ClassID:   domain.ClassID,
+		Email:     domain.Email,
+		FirstName: domain.FirstName,
+		LastName:  domain.LastName,
+		Language:  string(domain.Language),
+		CreatedAt: domain.CreatedAt,
+		OfferedAt: domain.OfferedAt,
+	}
+}
@@ -10,6 +10,11 @@ type BaseTmplData struct {
 	Hour               string
 	Date               string
 	Location           string
+	Address            string
+	Directions         string
+	MapURL             string
+	AccessNotes        string
+	Instructor         string
 	Signature          string
 }
 
@@ -18,6 +23,12 @@ type ClassUpdateTmplData struct {
 	Message      string
 }
 
+type InstructorSubstitutionTmplData struct {
+	BaseTmplData BaseTmplData
+	Message      string
+	Bio          string
+}
+
 type ClassCancellationTmplData struct {
 	BaseTmplData  BaseTmplData
 	Message       string
@@ -35,6 +46,24 @@ type BookingCancellationTmplData struct {
 	PassSlotsView []PassSlotView
 }
 
+type BookingMovedTmplData struct {
+	BaseTmplData     BaseTmplData
+	PreviousWeekDay  string
+	PreviousDate     string
+	PreviousHour     string
+	CancellationLink string
+	PassSlotsView    []PassSlotView
+}
+
+// BookingMovedFromTmplData describes the class the booking was moved from, MovedTo* is
+// when the class it was moved to starts.
+type BookingMovedFromTmplData struct {
+	BaseTmplData   BaseTmplData
+	MovedToWeekDay string
+	MovedToDate    string
+	MovedToHour    string
+}
+
 type BookingReminderTmplData struct {
 	BaseTmplData     BaseTmplData
 	CancellationLink string
@@ -47,6 +76,22 @@ type BookingConfirmationRequestTmplData struct {
 	Signature          string
 }
 
+type WaitlistSpotAvailableTmplData struct {
+	RecipientFirstName string
+	ConfirmationLink   string
+	WeekDay            string
+	Date               string
+	Hour               string
+	OfferValidMinutes  int
+	Signature          string
+}
+
+type StudentLoginLinkTmplData struct {
+	LoginLink        string
+	LinkValidMinutes int
+	Signature        string
+}
+
 type PassActivationTmplData struct {
 	PassSlotsView []PassSlotView
 	Signature     string
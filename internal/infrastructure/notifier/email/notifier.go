@@ -0,0 +1,751 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	notifierModels "main/internal/infrastructure/models/notifier"
+	"main/pkg/converter"
+	"main/pkg/i18n"
+	"main/pkg/ical"
+
+	"github.com/google/uuid"
+	"gopkg.in/gomail.v2"
+)
+
+const (
+	PassLabel              = "KARNET"
+	calendarAttachmentName = "zajecia.ics"
+)
+
+// ISender delivers rendered messages, e.g. over SMTP or into a local directory.
+type ISender interface {
+	Send(msgs ...*gomail.Message) error
+}
+
+type notifier struct {
+	sender                             ISender
+	from                               string
+	bookingConfirmationRequestTmplPath string
+	bookingConfirmationTmplPath        string
+	classCancellationTmplPath          string
+	classUpdateTmplPath                string
+	instructorSubstitutionTmplPath     string
+	bookingCancellationTmplPath        string
+	bookingMovedTmplPath               string
+	bookingMovedFromTmplPath           string
+	passActivationTmplPath             string
+	classReminderTmplPath              string
+	passTmplPath                       string
+	classTmplPath                      string
+	waitlistSpotAvailableTmplPath      string
+	studentLoginLinkTmplPath           string
+	signature                          string
+}
+
+func NewNotifier(
+	sender ISender,
+	from string,
+	signature string,
+	baseTmplPath string,
+) *notifier {
+	return &notifier{
+		sender:                             sender,
+		from:                               from,
+		signature:                          signature,
+		bookingConfirmationRequestTmplPath: baseTmplPath + "booking_confirmation_request.tmpl",
+		bookingConfirmationTmplPath:        baseTmplPath + "booking_confirmation.tmpl",
+		classCancellationTmplPath:          baseTmplPath + "class_cancellation.tmpl",
+		classUpdateTmplPath:                baseTmplPath + "class_update.tmpl",
+		instructorSubstitutionTmplPath:     baseTmplPath + "instructor_substitution.tmpl",
+		bookingCancellationTmplPath:        baseTmplPath + "booking_cancellation.tmpl",
+		bookingMovedTmplPath:               baseTmplPath + "booking_moved.tmpl",
+		bookingMovedFromTmplPath:           baseTmplPath + "booking_moved_from.tmpl",
+		passActivationTmplPath:             baseTmplPath + "pass_activation.tmpl",
+		classReminderTmplPath:              baseTmplPath + "class_reminder.tmpl",
+		passTmplPath:                       baseTmplPath + "pass.tmpl",
+		classTmplPath:                      baseTmplPath + "class.tmpl",
+		waitlistSpotAvailableTmplPath:      baseTmplPath + "waitlist_spot_available.tmpl",
+		studentLoginLinkTmplPath:           baseTmplPath + "student_login_link.tmpl",
+	}
+}
+
+func (n *notifier) NotifyPassActivation(locale i18n.Locale, email string, passSlots []models.PassSlot) error {
+	tmplData := notifierModels.PassActivationTmplData{
+		Signature:     n.signature,
+		PassSlotsView: n.getPassSlotsView(passSlots),
+	}
+
+	tmpl, err := n.parseTemplate(locale, n.passActivationTmplPath, n.passTmplPath)
+	if err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
+
+	subject := i18n.T(locale, "email.pass_activation.subject")
+
+	msgToRecipient, err := n.buildMsgToRecipient(email, subject, tmpl, tmplData)
+	if err != nil {
+		return fmt.Errorf("could not build msg to recipient %s: %w", email, err)
+	}
+
+	if err = n.sender.Send(msgToRecipient); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+func (n *notifier) NotifyConfirmationLink(
+	locale i18n.Locale, params models.NotifierParams, confirmationLink string,
+) error {
+	email := params.RecipientEmail
+
+	tmplData := notifierModels.BookingConfirmationRequestTmplData{
+		RecipientFirstName: params.RecipientFirstName,
+		ConfirmationLink:   confirmationLink,
+		Signature:          n.signatureOf(params.Instructor),
+	}
+
+	tmpl, err := n.parseTemplate(locale, n.bookingConfirmationRequestTmplPath)
+	if err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
+
+	classStartTimeDetails, err := getClassStartTimeDetails(locale, params.StartTime, params.Location)
+	if err != nil {
+		return fmt.Errorf("could not get class start time details: %w", err)
+	}
+
+	subject := i18n.T(locale, "email.confirmation_link.subject", classStartTimeDetails.startDate)
+
+	msgToRecipient, err := n.buildMsgToRecipient(email, subject, tmpl, tmplData)
+	if err != nil {
+		return fmt.Errorf("could not build msg to recipient %s: %w", email, err)
+	}
+
+	if err = n.sender.Send(msgToRecipient); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+func (n *notifier) NotifyBookingConfirmation(
+	locale i18n.Locale, params models.NotifierParams, cancellationLink string,
+) error {
+	classStartTimeDetails, err := getClassStartTimeDetails(locale, params.StartTime, params.Location)
+	if err != nil {
+		return fmt.Errorf("could not get class start time details: %w", err)
+	}
+
+	baseTmplData := n.getBaseTmplData(params, classStartTimeDetails)
+
+	tmplData := notifierModels.BookingConfirmationTmplData{
+		BaseTmplData:     baseTmplData,
+		CancellationLink: cancellationLink,
+		PassSlotsView:    n.getPassSlotsView(params.PassSlots),
+	}
+
+	tmpl, err := n.parseTemplate(locale, n.bookingConfirmationTmplPath, n.passTmplPath, n.classTmplPath)
+	if err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
+
+	subject := i18n.T(locale, "email.booking_confirmation.subject", classStartTimeDetails.startDate)
+
+	msgToRecipient, err := n.buildMsgToRecipient(params.RecipientEmail, subject, tmpl, tmplData)
+	if err != nil {
+		return fmt.Errorf("could not build msg to recipient %s: %w", params.RecipientEmail, err)
+	}
+
+	err = n.attachClassEvent(msgToRecipient, params, ical.MethodRequest, ical.StatusConfirmed)
+	if err != nil {
+		return fmt.Errorf("could not attach class event: %w", err)
+	}
+
+	msgToOwner := n.buildMsgToOwner(
+		models.StatusBooked,
+		params.RecipientFirstName,
+		params.RecipientLastName,
+		n.getPassSlotsView(params.PassSlots),
+		classStartTimeDetails,
+	)
+
+	if err = n.sender.Send(msgToRecipient, msgToOwner); err != nil {
+		return fmt.Errorf("failed to send emails: %w", err)
+	}
+
+	return nil
+}
+
+func (n *notifier) NotifyBookingCancellation(locale i18n.Locale, params models.NotifierParams) error {
+	classStartTimeDetails, err := getClassStartTimeDetails(locale, params.StartTime, params.Location)
+	if err != nil {
+		return fmt.Errorf("could not get class start time details: %w", err)
+	}
+
+	tmplData := notifierModels.BookingCancellationTmplData{
+		BaseTmplData:  n.getBaseTmplData(params, classStartTimeDetails),
+		PassSlotsView: n.getPassSlotsView(params.PassSlots),
+	}
+
+	tmpl, err := n.parseTemplate(locale, n.bookingCancellationTmplPath, n.passTmplPath, n.classTmplPath)
+	if err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
+
+	subject := i18n.T(locale, "email.booking_cancellation.subject", classStartTimeDetails.startDate)
+
+	msgToRecipient, err := n.buildMsgToRecipient(params.RecipientEmail, subject, tmpl, tmplData)
+	if err != nil {
+		return fmt.Errorf("could not build msg to recipient %s: %w", params.RecipientEmail, err)
+	}
+
+	err = n.attachClassEvent(msgToRecipient, params, ical.MethodCancel, ical.StatusCancelled)
+	if err != nil {
+		return fmt.Errorf("could not attach class event: %w", err)
+	}
+
+	msgToOwner := n.buildMsgToOwner(
+		models.StatusCancelled,
+		params.RecipientFirstName,
+		params.RecipientLastName,
+		n.getPassSlotsView(params.PassSlots),
+		classStartTimeDetails,
+	)
+
+	if err = n.sender.Send(msgToRecipient, msgToOwner); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+func (n *notifier) NotifyBookingMoved(
+	locale i18n.Locale, params, previous models.NotifierParams, cancellationLink string,
+) error {
+	classStartTimeDetails, err := getClassStartTimeDetails(locale, params.StartTime, params.Location)
+	if err != nil {
+		return fmt.Errorf("could not get class start time details: %w", err)
+	}
+
+	previousStartTimeDetails, err := getClassStartTimeDetails(
+		locale, previous.StartTime, previous.Location,
+	)
+	if err != nil {
+		return fmt.Errorf("could not get previous class start time details: %w", err)
+	}
+
+	tmplData := notifierModels.BookingMovedTmplData{
+		BaseTmplData:     n.getBaseTmplData(params, classStartTimeDetails),
+		PreviousWeekDay:  previousStartTimeDetails.weekDay,
+		PreviousDate:     previousStartTimeDetails.startDate,
+		PreviousHour:     previousStartTimeDetails.startHour,
+		CancellationLink: cancellationLink,
+		PassSlotsView:    n.getPassSlotsView(params.PassSlots),
+	}
+
+	tmpl, err := n.parseTemplate(locale, n.bookingMovedTmplPath, n.passTmplPath, n.classTmplPath)
+	if err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
+
+	subject := i18n.T(locale, "email.booking_moved.subject", classStartTimeDetails.startDate)
+
+	msgToRecipient, err := n.buildMsgToRecipient(params.RecipientEmail, subject, tmpl, tmplData)
+	if err != nil {
+		return fmt.Errorf("could not build msg to recipient %s: %w", params.RecipientEmail, err)
+	}
+
+	err = n.attachClassEvent(msgToRecipient, params, ical.MethodRequest, ical.StatusConfirmed)
+	if err != nil {
+		return fmt.Errorf("could not attach class event: %w", err)
+	}
+
+	// an iTIP message carries a single method, the previous class is cancelled in its own
+	msgMovedFrom, err := n.buildMsgMovedFrom(
+		locale, previous, previousStartTimeDetails, classStartTimeDetails,
+	)
+	if err != nil {
+		return fmt.Errorf("could not build msg about the previous class: %w", err)
+	}
+
+	msgToOwner := n.buildMsgToOwner(
+		models.StatusMoved,
+		params.RecipientFirstName,
+		params.RecipientLastName,
+		n.getPassSlotsView(params.PassSlots),
+		classStartTimeDetails,
+	)
+
+	if err = n.sender.Send(msgMovedFrom, msgToRecipient, msgToOwner); err != nil {
+		return fmt.Errorf("failed to send emails: %w", err)
+	}
+
+	return nil
+}
+
+// buildMsgMovedFrom tells the student the booking left the previous class and takes the
+// class off their calendar.
+func (n *notifier) buildMsgMovedFrom(
+	locale i18n.Locale,
+	previous models.NotifierParams,
+	previousStartTimeDetails, movedTo timeDetails,
+) (*gomail.Message, error) {
+	tmplData := notifierModels.BookingMovedFromTmplData{
+		BaseTmplData:   n.getBaseTmplData(previous, previousStartTimeDetails),
+		MovedToWeekDay: movedTo.weekDay,
+		MovedToDate:    movedTo.startDate,
+		MovedToHour:    movedTo.startHour,
+	}
+
+	tmpl, err := n.parseTemplate(locale, n.bookingMovedFromTmplPath, n.classTmplPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse template: %w", err)
+	}
+
+	subject := i18n.T(locale, "email.booking_moved.from_subject", previousStartTimeDetails.startDate)
+
+	msg, err := n.buildMsgToRecipient(previous.RecipientEmail, subject, tmpl, tmplData)
+	if err != nil {
+		return nil, fmt.Errorf("could not build msg to recipient %s: %w", previous.RecipientEmail, err)
+	}
+
+	err = n.attachClassEvent(msg, previous, ical.MethodCancel, ical.StatusCancelled)
+	if err != nil {
+		return nil, fmt.Errorf("could not attach previous class event: %w", err)
+	}
+
+	return msg, nil
+}
+
+func (n *notifier) NotifyClassUpdate(
+	locale i18n.Locale, params models.NotifierParams, change models.ClassChange,
+) error {
+	classStartTimeDetails, err := getClassStartTimeDetails(locale, params.StartTime, params.Location)
+	if err != nil {
+		return fmt.Errorf("could not get class start time details: %w", err)
+	}
+
+	// messages queued before the change was recorded fall back to a generic wording
+	if change == "" {
+		change = "other"
+	}
+
+	tmplData := notifierModels.ClassUpdateTmplData{
+		BaseTmplData: n.getBaseTmplData(params, classStartTimeDetails),
+		Message:      i18n.T(locale, "email.class_update.change."+string(change)),
+	}
+
+	tmpl, err := n.parseTemplate(locale, n.classUpdateTmplPath, n.classTmplPath)
+	if err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
+
+	subject := i18n.T(locale, "email.class_update.subject", classStartTimeDetails.startDate)
+
+	msgToRecipient, err := n.buildMsgToRecipient(params.RecipientEmail, subject, tmpl, tmplData)
+	if err != nil {
+		return fmt.Errorf("could not build msg to recipient %s: %w", params.RecipientEmail, err)
+	}
+
+	err = n.attachClassEvent(msgToRecipient, params, ical.MethodRequest, ical.StatusConfirmed)
+	if err != nil {
+		return fmt.Errorf("could not attach class event: %w", err)
+	}
+
+	if err = n.sender.Send(msgToRecipient); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+func (n *notifier) NotifyInstructorSubstitution(
+	locale i18n.Locale, params models.NotifierParams, previous models.Instructor,
+) error {
+	classStartTimeDetails, err := getClassStartTimeDetails(locale, params.StartTime, params.Location)
+	if err != nil {
+		return fmt.Errorf("could not get class start time details: %w", err)
+	}
+
+	message := i18n.T(locale, "email.instructor_substitution.intro_new", params.Instructor.Name)
+	if previous.Name != "" {
+		message = i18n.T(locale, "email.instructor_substitution.intro", params.Instructor.Name, previous.Name)
+	}
+
+	tmplData := notifierModels.InstructorSubstitutionTmplData{
+		BaseTmplData: n.getBaseTmplData(params, classStartTimeDetails),
+		Message:      message,
+		Bio:          params.Instructor.Bio,
+	}
+
+	tmpl, err := n.parseTemplate(locale, n.instructorSubstitutionTmplPath, n.classTmplPath)
+	if err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
+
+	subject := i18n.T(locale, "email.instructor_substitution.subject", classStartTimeDetails.startDate)
+
+	msgToRecipient, err := n.buildMsgToRecipient(params.RecipientEmail, subject, tmpl, tmplData)
+	if err != nil {
+		return fmt.Errorf("could not build msg to recipient %s: %w", params.RecipientEmail, err)
+	}
+
+	if err = n.sender.Send(msgToRecipient); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+func (n *notifier) NotifyClassCancellation(
+	locale i18n.Locale, params models.NotifierParams, msg string,
+) error {
+	classStartTimeDetails, err := getClassStartTimeDetails(locale, params.StartTime, params.Location)
+	if err != nil {
+		return fmt.Errorf("could not get date details: %w", err)
+	}
+
+	tmplData := notifierModels.ClassCancellationTmplData{
+		BaseTmplData:  n.getBaseTmplData(params, classStartTimeDetails),
+		Message:       msg,
+		PassSlotsView: n.getPassSlotsView(params.PassSlots),
+	}
+
+	tmpl, err := n.parseTemplate(locale, n.classCancellationTmplPath, n.passTmplPath, n.classTmplPath)
+	if err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
+
+	subject := i18n.T(locale, "email.class_cancellation.subject", classStartTimeDetails.startDate)
+
+	msgToRecipient, err := n.buildMsgToRecipient(params.RecipientEmail, subject, tmpl, tmplData)
+	if err != nil {
+		return fmt.Errorf("could not build msg to recipient %s: %w", params.RecipientEmail, err)
+	}
+
+	err = n.attachClassEvent(msgToRecipient, params, ical.MethodCancel, ical.StatusCancelled)
+	if err != nil {
+		return fmt.Errorf("could not attach class event: %w", err)
+	}
+
+	if err = n.sender.Send(msgToRecipient); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+func (n *notifier) NotifyBookingReminder(
+	locale i18n.Locale, params models.NotifierParams, cancellationLink string,
+) error {
+	classStartTimeDetails, err := getClassStartTimeDetails(locale, params.StartTime, params.Location)
+	if err != nil {
+		return fmt.Errorf("could not get class start time details: %w", err)
+	}
+
+	tmplData := notifierModels.BookingReminderTmplData{
+		BaseTmplData:     n.getBaseTmplData(params, classStartTimeDetails),
+		CancellationLink: cancellationLink,
+		PassSlotsView:    n.getPassSlotsView(params.PassSlots),
+	}
+
+	tmpl, err := n.parseTemplate(locale, n.classReminderTmplPath, n.passTmplPath, n.classTmplPath)
+	if err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
+
+	subject := i18n.T(locale, "email.booking_reminder.subject", classStartTimeDetails.startDate)
+
+	msgToRecipient, err := n.buildMsgToRecipient(params.RecipientEmail, subject, tmpl, tmplData)
+	if err != nil {
+		return fmt.Errorf("could not build msg to recipient %s: %w", params.RecipientEmail, err)
+	}
+
+	if err = n.sender.Send(msgToRecipient); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+func (n *notifier) NotifyWaitlistSpotAvailable(
+	locale i18n.Locale, params models.NotifierParams, confirmationLink string,
+) error {
+	email := params.RecipientEmail
+
+	classStartTimeDetails, err := getClassStartTimeDetails(locale, params.StartTime, params.Location)
+	if err != nil {
+		return fmt.Errorf("could not get class start time details: %w", err)
+	}
+
+	tmplData := notifierModels.WaitlistSpotAvailableTmplData{
+		RecipientFirstName: params.RecipientFirstName,
+		ConfirmationLink:   confirmationLink,
+		WeekDay:            classStartTimeDetails.weekDay,
+		Date:               classStartTimeDetails.startDate,
+		Hour:               classStartTimeDetails.startHour,
+		OfferValidMinutes:  int(models.WaitlistOfferTTL.Minutes()),
+		Signature:          n.signatureOf(params.Instructor),
+	}
+
+	tmpl, err := n.parseTemplate(locale, n.waitlistSpotAvailableTmplPath)
+	if err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
+
+	subject := i18n.T(locale, "email.waitlist_spot_available.subject", classStartTimeDetails.startDate)
+
+	msgToRecipient, err := n.buildMsgToRecipient(email, subject, tmpl, tmplData)
+	if err != nil {
+		return fmt.Errorf("could not build msg to recipient %s: %w", email, err)
+	}
+
+	if err = n.sender.Send(msgToRecipient); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+func (n *notifier) NotifyStudentLoginLink(locale i18n.Locale, email, loginLink string) error {
+	tmplData := notifierModels.StudentLoginLinkTmplData{
+		LoginLink:        loginLink,
+		LinkValidMinutes: int(models.StudentLoginLinkTTL.Minutes()),
+		Signature:        n.signature,
+	}
+
+	tmpl, err := n.parseTemplate(locale, n.studentLoginLinkTmplPath)
+	if err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
+
+	subject := i18n.T(locale, "email.student_login_link.subject")
+
+	msgToRecipient, err := n.buildMsgToRecipient(email, subject, tmpl, tmplData)
+	if err != nil {
+		return fmt.Errorf("could not build msg to recipient %s: %w", email, err)
+	}
+
+	if err = n.sender.Send(msgToRecipient); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+func (n *notifier) buildMsgToRecipient(
+	email,
+	subject string,
+	tmpl *template.Template,
+	tmplData any,
+) (*gomail.Message, error) {
+	var body strings.Builder
+
+	err := tmpl.Execute(&body, tmplData)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute template: %w", err)
+	}
+
+	msg := gomail.NewMessage()
+	msg.SetHeader("From", n.from)
+	msg.SetHeader("To", email)
+	msg.SetHeader("Subject", subject)
+	msg.SetBody("text/html", body.String())
+
+	return msg, nil
+}
+
+// attachClassEvent adds an .ics invitation, or its cancellation, for the class in params.
+func (n *notifier) attachClassEvent(
+	msg *gomail.Message,
+	params models.NotifierParams,
+	method ical.Method,
+	status ical.EventStatus,
+) error {
+	// messages queued before calendar support do not know their class
+	if params.ClassID == uuid.Nil {
+		return nil
+	}
+
+	event := services.ClassEvent(params.Class(), status)
+	event.Organizer = n.from
+	event.Attendee = params.RecipientEmail
+
+	var calendar bytes.Buffer
+
+	err := ical.Encode(&calendar, ical.Calendar{
+		ProdID: services.CalendarProdID,
+		Method: method,
+		Events: []ical.Event{event},
+	})
+	if err != nil {
+		return fmt.Errorf("could not encode calendar: %w", err)
+	}
+
+	msg.Attach(
+		calendarAttachmentName,
+		gomail.SetCopyFunc(func(w io.Writer) error {
+			if _, err := w.Write(calendar.Bytes()); err != nil {
+				return fmt.Errorf("could not write calendar attachment: %w", err)
+			}
+
+			return nil
+		}),
+		gomail.SetHeader(map[string][]string{
+			"Content-Type": {fmt.Sprintf("text/calendar; charset=utf-8; method=%s", method)},
+		}),
+	)
+
+	return nil
+}
+
+func (n *notifier) buildMsgToOwner(
+	status models.OperationStatus,
+	recipientFirstName, recipientLastName string,
+	passSlotsView []notifierModels.PassSlotView,
+	classTimeDetails timeDetails,
+) *gomail.Message {
+	subject := fmt.Sprintf("%s %s %s",
+		recipientFirstName,
+		recipientLastName,
+		status,
+	)
+
+	if !isAllPassSlotsBlank(passSlotsView) {
+		assignedSlots := 0
+
+		for _, slot := range passSlotsView {
+			if slot.Status != models.Blank {
+				assignedSlots++
+			}
+		}
+
+		subject += fmt.Sprintf(
+			" %s: %d/%d", PassLabel, assignedSlots, len(passSlotsView),
+		)
+	}
+
+	// the owner reads about every booking in the default language
+	msg := fmt.Sprintf("%s (%s) - %s",
+		i18n.WeekDay(i18n.Default, classTimeDetails.start.Weekday()),
+		classTimeDetails.startDate,
+		classTimeDetails.startHour,
+	)
+
+	msgToOwner := gomail.NewMessage()
+	msgToOwner.SetHeader("From", n.from)
+	msgToOwner.SetHeader("To", n.from)
+	msgToOwner.SetHeader("Subject", subject)
+	msgToOwner.SetBody("text/html", msg)
+
+	return msgToOwner
+}
+
+func isAllPassSlotsBlank(passSlotsView []notifierModels.PassSlotView) bool {
+	for _, passSlotView := range passSlotsView {
+		if passSlotView.Status != models.Blank {
+			return false
+		}
+	}
+
+	return true
+}
+
+type timeDetails struct {
+	start     time.Time
+	startHour string
+	startDate string
+	weekDay   string
+}
+
+// getClassStartTimeDetails shows t in the time zone of the class location.
+func getClassStartTimeDetails(
+	locale i18n.Locale, t time.Time, location models.Location,
+) (timeDetails, error) {
+	localTime, err := converter.ConvertToLocationTime(t, location.TimeZone)
+	if err != nil {
+		return timeDetails{}, fmt.Errorf("could not convert to local time: %w", err)
+	}
+
+	startDate := localTime.Format(converter.DateLayout)
+	startHour := localTime.Format(converter.HourLayout)
+
+	return timeDetails{
+		start:     localTime,
+		startHour: startHour,
+		startDate: startDate,
+		weekDay:   i18n.WeekDay(locale, localTime.Weekday()),
+	}, nil
+}
+
+// parseTemplate parses the files with the i18n helpers bound to locale, the first file
+// is the one executed.
+func (n *notifier) parseTemplate(locale i18n.Locale, files ...string) (*template.Template, error) {
+	tmpl, err := template.New(filepath.Base(files[0])).Funcs(i18n.FuncMap(locale)).ParseFiles(files...)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %v: %w", files, err)
+	}
+
+	return tmpl, nil
+}
+
+func (n *notifier) getBaseTmplData(
+	params models.NotifierParams, classStartTimeDetails timeDetails,
+) notifierModels.BaseTmplData {
+	return notifierModels.BaseTmplData{
+		RecipientFirstName: params.RecipientFirstName,
+		ClassName:          params.ClassName,
+		ClassLevel:         params.ClassLevel,
+		Hour:               classStartTimeDetails.startHour,
+		WeekDay:            classStartTimeDetails.weekDay,
+		Date:               classStartTimeDetails.startDate,
+		Location:           params.Location.Name,
+		Address:            params.Location.Address,
+		Directions:         params.Location.Directions,
+		MapURL:             params.Location.MapURL,
+		AccessNotes:        params.Location.AccessNotes,
+		Instructor:         params.Instructor.Name,
+		Signature:          n.signatureOf(params.Instructor),
+	}
+}
+
+// signatureOf closes the emails about a class with the signature of its instructor,
+// classes without one keep the signature from the configuration.
+func (n *notifier) signatureOf(instructor models.Instructor) string {
+	if instructor.Signature != "" {
+		return instructor.Signature
+	}
+
+	if instructor.Name != "" {
+		return instructor.Name
+	}
+
+	return n.signature
+}
+
+func (n *notifier) getPassSlotsView(passSlots []models.PassSlot) []notifierModels.PassSlotView {
+	passSlotsView := make([]notifierModels.PassSlotView, 0, len(passSlots))
+
+	for _, slot := range passSlots {
+		passSlotView := notifierModels.PassSlotView{
+			Status: slot.Status,
+		}
+
+		if slot.ClassStartTime != nil {
+			passSlotView.ClassStartDate = slot.ClassStartTime.Format("02.01")
+		}
+
+		passSlotsView = append(passSlotsView, passSlotView)
+	}
+
+	return passSlotsView
+}
@@ -0,0 +1,64 @@
+package notifier
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	domainNotifier "main/internal/domain/notifier"
+	"main/internal/infrastructure/configuration"
+	"main/internal/infrastructure/notifier/email"
+	"main/internal/infrastructure/notifier/senders"
+)
+
+type senderFactory func(cfg configuration.Notifier) (email.ISender, error)
+
+var registry = map[string]senderFactory{
+	configuration.NotifierBackendSMTP: func(cfg configuration.Notifier) (email.ISender, error) {
+		return senders.NewSMTPSender(cfg.Host, cfg.Port, cfg.Login, cfg.Password), nil
+	},
+	configuration.NotifierBackendFile: func(cfg configuration.Notifier) (email.ISender, error) {
+		return senders.NewFileSender(cfg.Dir)
+	},
+	configuration.NotifierBackendMemory: func(configuration.Notifier) (email.ISender, error) {
+		return senders.NewMemorySender(), nil
+	},
+	configuration.NotifierBackendLog: func(configuration.Notifier) (email.ISender, error) {
+		return senders.NewLogSender(), nil
+	},
+}
+
+// NewNotifier builds the email notifier delivering through the backend selected in cfg.
+func NewNotifier(cfg configuration.Notifier, baseTmplPath string) (domainNotifier.INotifier, error) {
+	sender, err := NewSender(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return email.NewNotifier(sender, cfg.Sender(), cfg.Signature, baseTmplPath), nil
+}
+
+func NewSender(cfg configuration.Notifier) (email.ISender, error) {
+	factory, ok := registry[cfg.Backend]
+	if !ok {
+		return nil, fmt.Errorf("unknown notifier backend %q, available: %s", cfg.Backend, backends())
+	}
+
+	sender, err := factory(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("could not create %s notifier backend: %w", cfg.Backend, err)
+	}
+
+	return sender, nil
+}
+
+func backends() string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,213 @@
+package notifier
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/infrastructure/configuration"
+	"main/internal/infrastructure/notifier/email"
+	"main/internal/infrastructure/notifier/senders"
+	"main/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+const baseTmplPath = "templates/"
+
+func TestNewSender(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+
+	tests := []struct {
+		name    string
+		cfg     configuration.Notifier
+		wantErr bool
+		check   func(t *testing.T, sender email.ISender)
+	}{
+		{
+			name: "memory backend captures messages",
+			cfg:  configuration.Notifier{Backend: configuration.NotifierBackendMemory},
+			check: func(t *testing.T, sender email.ISender) {
+				memory, ok := sender.(interface{ Messages() []senders.Message })
+				if !ok {
+					t.Fatalf("got %T, want memory sender", sender)
+				}
+
+				messages := memory.Messages()
+				if len(messages) != 1 || messages[0].To[0] != "student@example.com" {
+					t.Fatalf("got %+v, want one message to student@example.com", messages)
+				}
+
+				if !strings.Contains(messages[0].Raw, "https://yoga.test/login") {
+					t.Errorf("got %q, want it to contain the login link", messages[0].Raw)
+				}
+			},
+		},
+		{
+			name: "file backend writes eml files",
+			cfg:  configuration.Notifier{Backend: configuration.NotifierBackendFile, Dir: dir},
+			check: func(t *testing.T, _ email.ISender) {
+				files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+				if err != nil || len(files) != 1 {
+					t.Fatalf("got %v (%v), want one .eml file", files, err)
+				}
+
+				content, err := os.ReadFile(files[0])
+				if err != nil {
+					t.Fatalf("could not read %s: %v", files[0], err)
+				}
+
+				if !strings.Contains(string(content), "To: student@example.com") {
+					t.Errorf("got %q, want a message to student@example.com", content)
+				}
+			},
+		},
+		{
+			name:  "log backend",
+			cfg:   configuration.Notifier{Backend: configuration.NotifierBackendLog},
+			check: func(*testing.T, email.ISender) {},
+		},
+		{
+			name:    "unknown backend",
+			cfg:     configuration.Notifier{Backend: "pigeon"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender, err := NewSender(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got %v, want error %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			n := email.NewNotifier(sender, tt.cfg.Sender(), "Igor", baseTmplPath)
+			if err = n.NotifyStudentLoginLink(i18n.Default, "student@example.com", "https://yoga.test/login"); err != nil {
+				t.Fatalf("could not notify: %v", err)
+			}
+
+			tt.check(t, sender)
+		})
+	}
+}
+
+func TestNotifyBookingMoved(t *testing.T) {
+	sender := senders.NewMemorySender()
+	n := email.NewNotifier(sender, "studio@example.com", "Igor", baseTmplPath)
+
+	previous := models.NotifierParams{
+		RecipientEmail:     "student@example.com",
+		RecipientFirstName: "Anna",
+		ClassID:            uuid.New(),
+		ClassSequence:      3,
+		ClassName:          "hatha",
+		StartTime:          time.Now().Add(48 * time.Hour),
+		Duration:           time.Hour,
+	}
+
+	params := previous
+	params.ClassID = uuid.New()
+	params.ClassSequence = 0
+	params.StartTime = previous.StartTime.Add(7 * 24 * time.Hour)
+
+	err := n.NotifyBookingMoved(i18n.Default, params, previous, "https://yoga.test/cancel")
+	if err != nil {
+		t.Fatalf("could not notify: %v", err)
+	}
+
+	// an iTIP message has one method, the previous class is cancelled in its own message
+	messages := sender.Messages()
+	if len(messages) != 3 {
+		t.Fatalf("got %d messages, want the previous class, the new class and the owner", len(messages))
+	}
+
+	for i, want := range []models.NotifierParams{previous, params} {
+		method := "CANCEL"
+		if i == 1 {
+			method = "REQUEST"
+		}
+
+		calendars := calendarParts(t, messages[i].Raw)
+		if len(calendars) != 1 {
+			t.Fatalf("message %d has %d calendars, want 1", i, len(calendars))
+		}
+
+		for _, line := range []string{
+			"METHOD:" + method,
+			"UID:" + services.ClassEventUID(want.ClassID),
+			fmt.Sprintf("SEQUENCE:%d", want.ClassSequence),
+		} {
+			if !strings.Contains(calendars[0], line+"\r\n") {
+				t.Errorf("message %d calendar %q, want %s", i, calendars[0], line)
+			}
+		}
+	}
+}
+
+// calendarParts decodes the text/calendar parts of a raw message.
+func calendarParts(t *testing.T, raw string) []string {
+	t.Helper()
+
+	msg, err := mail.ReadMessage(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not read message: %v", err)
+	}
+
+	return readCalendarParts(t, msg.Header.Get("Content-Type"), msg.Body)
+}
+
+func readCalendarParts(t *testing.T, contentType string, body io.Reader) []string {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatalf("could not parse content type %q: %v", contentType, err)
+	}
+
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return nil
+	}
+
+	var calendars []string
+
+	reader := multipart.NewReader(body, params["boundary"])
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return calendars
+		}
+
+		if err != nil {
+			t.Fatalf("could not read part: %v", err)
+		}
+
+		partType := part.Header.Get("Content-Type")
+		if !strings.HasPrefix(partType, "text/calendar") {
+			calendars = append(calendars, readCalendarParts(t, partType, part)...)
+
+			continue
+		}
+
+		content, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, part))
+		if err != nil {
+			t.Fatalf("could not decode calendar: %v", err)
+		}
+
+		calendars = append(calendars, string(content))
+	}
+}
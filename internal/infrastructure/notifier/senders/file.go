@@ -0,0 +1,66 @@
+package senders
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/gomail.v2"
+)
+
+type fileSender struct {
+	dir     string
+	counter atomic.Uint64
+}
+
+// NewFileSender writes every message as an .eml file into dir, which can be opened
+// in any mail client. Files are written to a temporary name first and renamed, so a
+// watcher never sees a partial message, like in a maildir.
+func NewFileSender(dir string) (*fileSender, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("could not create mail directory %s: %w", dir, err)
+	}
+
+	return &fileSender{
+		dir: dir,
+	}, nil
+}
+
+func (s *fileSender) Send(msgs ...*gomail.Message) error {
+	for _, msg := range msgs {
+		if err := s.write(msg); err != nil {
+			return fmt.Errorf("could not write message: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *fileSender) write(msg *gomail.Message) error {
+	name := fmt.Sprintf("%s-%d-%d.eml", time.Now().UTC().Format("20060102T150405.000000000"), os.Getpid(), s.counter.Add(1))
+
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("could not create temporary file: %w", err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err = msg.WriteTo(tmp); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("could not write %s: %w", tmp.Name(), err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("could not close %s: %w", tmp.Name(), err)
+	}
+
+	if err = os.Rename(tmp.Name(), filepath.Join(s.dir, name)); err != nil {
+		return fmt.Errorf("could not rename %s: %w", tmp.Name(), err)
+	}
+
+	return nil
+}
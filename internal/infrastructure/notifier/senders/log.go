@@ -0,0 +1,27 @@
+package senders
+
+import (
+	"log/slog"
+	"strings"
+
+	"gopkg.in/gomail.v2"
+)
+
+type logSender struct{}
+
+// NewLogSender only logs the envelope of every message, nothing is delivered.
+func NewLogSender() *logSender {
+	return &logSender{}
+}
+
+func (s *logSender) Send(msgs ...*gomail.Message) error {
+	for _, msg := range msgs {
+		slog.Info("EmailNotSent",
+			slog.String("from", strings.Join(msg.GetHeader("From"), ", ")),
+			slog.String("to", strings.Join(msg.GetHeader("To"), ", ")),
+			slog.String("subject", strings.Join(msg.GetHeader("Subject"), ", ")),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package senders
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	"gopkg.in/gomail.v2"
+)
+
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Raw     string
+}
+
+type memorySender struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewMemorySender keeps sent messages in memory so tests can assert on them.
+func NewMemorySender() *memorySender {
+	return &memorySender{}
+}
+
+func (s *memorySender) Send(msgs ...*gomail.Message) error {
+	captured := make([]Message, 0, len(msgs))
+
+	for _, msg := range msgs {
+		var buf bytes.Buffer
+
+		if _, err := msg.WriteTo(&buf); err != nil {
+			return fmt.Errorf("could not render message: %w", err)
+		}
+
+		captured = append(captured, Message{
+			From:    strings.Join(msg.GetHeader("From"), ", "),
+			To:      msg.GetHeader("To"),
+			Subject: strings.Join(msg.GetHeader("Subject"), ", "),
+			Raw:     buf.String(),
+		})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, captured...)
+
+	return nil
+}
+
+// Messages returns a copy of everything sent so far.
+func (s *memorySender) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Message(nil), s.messages...)
+}
+
+func (s *memorySender) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = nil
+}
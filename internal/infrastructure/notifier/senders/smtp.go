@@ -0,0 +1,33 @@
+package senders
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"gopkg.in/gomail.v2"
+)
+
+type smtpSender struct {
+	dialer *gomail.Dialer
+}
+
+func NewSMTPSender(host string, port int, login, password string) *smtpSender {
+	dialer := gomail.NewDialer(host, port, login, password)
+	dialer.TLSConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         host,
+		InsecureSkipVerify: false,
+	}
+
+	return &smtpSender{
+		dialer: dialer,
+	}
+}
+
+func (s *smtpSender) Send(msgs ...*gomail.Message) error {
+	if err := s.dialer.DialAndSend(msgs...); err != nil {
+		return fmt.Errorf("could not send over smtp: %w", err)
+	}
+
+	return nil
+}
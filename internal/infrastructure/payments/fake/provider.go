@@ -0,0 +1,89 @@
+package fake
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"main/internal/domain/models"
+	"main/internal/domain/payments"
+)
+
+const SignatureHeader = "X-Signature"
+
+// Webhook is the payload the fake provider sends, Status is "paid" or "failed".
+type Webhook struct {
+	EventID   string `json:"event_id"`
+	Reference string `json:"reference"`
+	Status    string `json:"status"`
+}
+
+// provider takes no money, the checkout returns straight to the shop and the payment
+// is settled by a webhook signed with the shared secret, e.g. from tests or curl.
+type provider struct {
+	secret []byte
+}
+
+func NewProvider(secret string) *provider {
+	return &provider{
+		secret: []byte(secret),
+	}
+}
+
+func (p *provider) Name() string {
+	return "fake"
+}
+
+func (p *provider) CreateCheckout(
+	_ context.Context, payment models.Payment, returnURL string,
+) (models.Checkout, error) {
+	return models.Checkout{
+		PaymentID:   payment.ID,
+		ProviderRef: "fake_" + payment.ID.String(),
+		RedirectURL: returnURL,
+	}, nil
+}
+
+func (p *provider) ParseWebhook(payload []byte, header http.Header) (models.PaymentEvent, error) {
+	signature, err := hex.DecodeString(header.Get(SignatureHeader))
+	if err != nil {
+		return models.PaymentEvent{}, fmt.Errorf("could not decode signature: %w", payments.ErrInvalidWebhook)
+	}
+
+	if !hmac.Equal(signature, p.sign(payload)) {
+		return models.PaymentEvent{}, fmt.Errorf("signature mismatch: %w", payments.ErrInvalidWebhook)
+	}
+
+	var webhook Webhook
+
+	if err := json.Unmarshal(payload, &webhook); err != nil {
+		return models.PaymentEvent{}, fmt.Errorf("could not decode payload: %w", payments.ErrInvalidWebhook)
+	}
+
+	status := models.PaymentStatus(webhook.Status)
+	if webhook.Reference == "" || (status != models.PaymentPaid && status != models.PaymentFailed) {
+		return models.PaymentEvent{}, fmt.Errorf("unexpected event %+v: %w", webhook, payments.ErrInvalidWebhook)
+	}
+
+	return models.PaymentEvent{
+		EventID:     webhook.EventID,
+		ProviderRef: webhook.Reference,
+		Status:      status,
+	}, nil
+}
+
+// Sign returns the signature header value the fake provider expects for payload.
+func (p *provider) Sign(payload []byte) string {
+	return hex.EncodeToString(p.sign(payload))
+}
+
+func (p *provider) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,64 @@
+package fake
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"main/internal/domain/models"
+	"main/internal/domain/payments"
+)
+
+func TestParseWebhook(t *testing.T) {
+	provider := NewProvider("secret")
+	paid := []byte(`{"event_id":"ev_1","reference":"fake_1","status":"paid"}`)
+
+	tests := []struct {
+		name      string
+		payload   []byte
+		signature string
+		want      models.PaymentEvent
+		wantErr   error
+	}{
+		{
+			name:      "signed event is parsed",
+			payload:   paid,
+			signature: provider.Sign(paid),
+			want:      models.PaymentEvent{EventID: "ev_1", ProviderRef: "fake_1", Status: models.PaymentPaid},
+		},
+		{
+			name:      "signature of another secret is rejected",
+			payload:   paid,
+			signature: NewProvider("other").Sign(paid),
+			wantErr:   payments.ErrInvalidWebhook,
+		},
+		{
+			name:      "missing signature is rejected",
+			payload:   paid,
+			signature: "",
+			wantErr:   payments.ErrInvalidWebhook,
+		},
+		{
+			name:      "unknown status is rejected",
+			payload:   []byte(`{"event_id":"ev_2","reference":"fake_1","status":"refunded"}`),
+			signature: provider.Sign([]byte(`{"event_id":"ev_2","reference":"fake_1","status":"refunded"}`)),
+			wantErr:   payments.ErrInvalidWebhook,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(SignatureHeader, tt.signature)
+
+			got, err := provider.ParseWebhook(tt.payload, header)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package payments
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	domainPayments "main/internal/domain/payments"
+	"main/internal/infrastructure/configuration"
+	"main/internal/infrastructure/payments/fake"
+)
+
+type providerFactory func(cfg configuration.Payments) (domainPayments.IPaymentProvider, error)
+
+var registry = map[string]providerFactory{
+	configuration.PaymentsProviderFake: func(cfg configuration.Payments) (domainPayments.IPaymentProvider, error) {
+		return fake.NewProvider(cfg.WebhookSecret), nil
+	},
+}
+
+// NewProvider builds the payment provider selected in cfg.
+func NewProvider(cfg configuration.Payments) (domainPayments.IPaymentProvider, error) {
+	factory, ok := registry[cfg.Provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown payment provider %q, available: %s", cfg.Provider, providers())
+	}
+
+	provider, err := factory(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("could not create %s payment provider: %w", cfg.Provider, err)
+	}
+
+	return provider, nil
+}
+
+func providers() string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
@@ -1,9 +1,10 @@
-package sqlite
+package repository
 
 import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"main/internal/domain/models"
 	"main/internal/infrastructure/errs"
@@ -18,6 +19,9 @@ type bookingsRepo struct {
 	db *gorm.DB
 }
 
+// cancelledStatuses are left out of everything but pass slot counting.
+var cancelledStatuses = []models.BookingStatus{models.BookingCancelled, models.BookingLateCancelled}
+
 func NewBookingsRepo(db *gorm.DB) *bookingsRepo {
 	return &bookingsRepo{
 		db: db,
@@ -29,7 +33,7 @@ func (r *bookingsRepo) GetByID(
 ) (models.Booking, error) {
 	var SQLBooking db.SQLBooking
 
-	result := r.db.WithContext(ctx).Where("id = ?", bookingID).Preload("Class").Preload("Pass").First(&SQLBooking)
+	result := r.db.WithContext(ctx).Where("id = ? AND status NOT IN ?", bookingID, cancelledStatuses).Preload("Class.Location").Preload("Class.Instructor").Preload("Pass").First(&SQLBooking)
 
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
@@ -51,7 +55,7 @@ func (r *bookingsRepo) GetByEmailAndClassID(
 	var SQLBooking db.SQLBooking
 
 	result := r.db.WithContext(ctx).
-		Where("class_id = ? AND email = ?", classID, email).
+		Where("class_id = ? AND email = ? AND status NOT IN ?", classID, email, cancelledStatuses).
 		First(&SQLBooking)
 
 	if result.Error != nil {
@@ -76,10 +80,10 @@ func (r *bookingsRepo) ListWithoutPassByEmail(
 	}
 
 	if err := r.db.WithContext(ctx).
-		Where("email = ? AND pass_id IS NULL", email).
+		Where("email = ? AND pass_id IS NULL AND payment_id IS NULL AND status <> ?", email, models.BookingCancelled).
 		Order("created_at DESC").
 		Limit(limit).
-		Preload("Class").
+		Preload("Class.Location").Preload("Class.Instructor").
 		Preload("Pass").
 		Find(&SQLBookings).Error; err != nil {
 		return nil, fmt.Errorf("could not get bookings for %s without pass_id: %w", email, err)
@@ -97,7 +101,11 @@ func (r *bookingsRepo) ListWithoutPassByEmail(
 func (r *bookingsRepo) List(ctx context.Context) ([]models.Booking, error) {
 	var SQLBookings []db.SQLBooking
 
-	if err := r.db.WithContext(ctx).Preload("Class").Preload("Pass").Find(&SQLBookings).Error; err != nil {
+	if err := r.db.WithContext(ctx).
+		Where("status NOT IN ?", cancelledStatuses).
+		Preload("Class.Location").Preload("Class.Instructor").
+		Preload("Pass").
+		Find(&SQLBookings).Error; err != nil {
 		return nil, fmt.Errorf("could not list bookings: %w", err)
 	}
 
@@ -117,7 +125,7 @@ func (r *bookingsRepo) CountForClassID(ctx context.Context, classID uuid.UUID) (
 
 	if err := r.db.WithContext(ctx).
 		Model(&SQLBooking).
-		Where("class_id = ?", classID).
+		Where("class_id = ? AND status NOT IN ?", classID, cancelledStatuses).
 		Count(&count).Error; err != nil {
 		return 0, fmt.Errorf("could count bookings for classID %s: %w", classID, err)
 	}
@@ -125,6 +133,7 @@ func (r *bookingsRepo) CountForClassID(ctx context.Context, classID uuid.UUID) (
 	return int(count), nil
 }
 
+// CountForPassID includes late cancellations and no-shows, they still consume their pass slot.
 func (r *bookingsRepo) CountForPassID(ctx context.Context, passID int) (int, error) {
 	var count int64
 
@@ -132,7 +141,7 @@ func (r *bookingsRepo) CountForPassID(ctx context.Context, passID int) (int, err
 
 	if err := r.db.WithContext(ctx).
 		Model(&SQLBooking).
-		Where("pass_id = ?", passID).
+		Where("pass_id = ? AND status <> ?", passID, models.BookingCancelled).
 		Count(&count).Error; err != nil {
 		return 0, fmt.Errorf("could count bookings for passID %d: %w", passID, err)
 	}
@@ -147,8 +156,8 @@ func (r *bookingsRepo) ListByClassID(
 	var SQLBookings []db.SQLBooking
 
 	if err := r.db.WithContext(ctx).
-		Where("class_id = ?", classID).
-		Preload("Class").
+		Where("class_id = ? AND status NOT IN ?", classID, cancelledStatuses).
+		Preload("Class.Location").Preload("Class.Instructor").
 		Preload("Pass").
 		Find(&SQLBookings).Error; err != nil {
 		return nil, fmt.Errorf("could not get bookings for classID %s: %w", classID, err)
@@ -163,6 +172,30 @@ func (r *bookingsRepo) ListByClassID(
 	return result, nil
 }
 
+func (r *bookingsRepo) ListByEmail(
+	ctx context.Context,
+	email string,
+) ([]models.Booking, error) {
+	var SQLBookings []db.SQLBooking
+
+	if err := r.db.WithContext(ctx).
+		Where("email = ? AND status NOT IN ?", email, cancelledStatuses).
+		Preload("Class.Location").Preload("Class.Instructor").
+		Preload("Pass").
+		Find(&SQLBookings).Error; err != nil {
+		return nil, fmt.Errorf("could not get bookings for email %s: %w", email, err)
+	}
+
+	result := make([]models.Booking, len(SQLBookings))
+
+	for i, SQLBooking := range SQLBookings {
+		result[i] = SQLBooking.ToDomain()
+	}
+
+	return result, nil
+}
+
+// ListByPassID includes late cancellations and no-shows, they still consume their pass slot.
 func (r *bookingsRepo) ListByPassID(
 	ctx context.Context,
 	passID int,
@@ -170,8 +203,8 @@ func (r *bookingsRepo) ListByPassID(
 	var SQLBookings []db.SQLBooking
 
 	if err := r.db.WithContext(ctx).
-		Preload("Class").Preload("Pass").
-		Where("pass_id = ?", passID).
+		Preload("Class.Location").Preload("Class.Instructor").Preload("Pass").
+		Where("pass_id = ? AND status <> ?", passID, models.BookingCancelled).
 		Order("created_at ASC").
 		Find(&SQLBookings).Error; err != nil {
 		return nil, fmt.Errorf("could not list bookings: %w", err)
@@ -186,6 +219,47 @@ func (r *bookingsRepo) ListByPassID(
 	return result, nil
 }
 
+// ListStatisticsByEmails counts bookings of every status, emails without any booking are left out.
+func (r *bookingsRepo) ListStatisticsByEmails(
+	ctx context.Context,
+	emails []string,
+) ([]models.ContactStatistics, error) {
+	var SQLStatistics []db.SQLContactStatistics
+
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&db.SQLBooking{}).
+		Select(`email,
+			SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS confirmed,
+			SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS attended,
+			SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS no_shows,
+			SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS late_cancellations,
+			SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS cancellations,
+			SUM(CASE WHEN walk_in THEN 1 ELSE 0 END) AS walk_ins`,
+			models.BookingConfirmed,
+			models.BookingAttended,
+			models.BookingNoShow,
+			models.BookingLateCancelled,
+			models.BookingCancelled,
+		).
+		Where("email IN ?", emails).
+		Group("email").
+		Scan(&SQLStatistics).Error; err != nil {
+		return nil, fmt.Errorf("could not count bookings for emails %v: %w", emails, err)
+	}
+
+	result := make([]models.ContactStatistics, len(SQLStatistics))
+
+	for i, SQLStatistic := range SQLStatistics {
+		result[i] = SQLStatistic.ToDomain()
+	}
+
+	return result, nil
+}
+
 func (r *bookingsRepo) Insert(
 	ctx context.Context,
 	booking models.Booking,
@@ -199,14 +273,21 @@ func (r *bookingsRepo) Insert(
 	return booking.ID, nil
 }
 
-func (r *bookingsRepo) Delete(ctx context.Context, id uuid.UUID) error {
+// UpdateStatus keeps the booking for its history, cancelled ones get the cancellation time.
+func (r *bookingsRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status models.BookingStatus) error {
 	var SQLBooking db.SQLBooking
 
+	update := map[string]any{"status": string(status)}
+	if !status.HoldsSpot() {
+		update["cancelled_at"] = time.Now().UTC()
+	}
+
 	result := r.db.WithContext(ctx).
-		Where("id = ?", id).
-		Delete(&SQLBooking)
+		Model(&SQLBooking).
+		Where("id = ? AND status NOT IN ?", id, cancelledStatuses).
+		Updates(update)
 	if result.Error != nil {
-		return fmt.Errorf("could not delete booking: %w", result.Error)
+		return fmt.Errorf("could not update booking status: %w", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
@@ -216,6 +297,20 @@ func (r *bookingsRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// DeleteByClassID removes bookings of a deleted class, cancellations included.
+func (r *bookingsRepo) DeleteByClassID(ctx context.Context, classID uuid.UUID) error {
+	var SQLBooking db.SQLBooking
+
+	err := r.db.WithContext(ctx).
+		Where("class_id = ?", classID).
+		Delete(&SQLBooking).Error
+	if err != nil {
+		return fmt.Errorf("could not delete bookings for class %s: %w", classID, err)
+	}
+
+	return nil
+}
+
 func (r *bookingsRepo) Update(
 	ctx context.Context,
 	bookingID uuid.UUID,
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"main/internal/domain/models"
+	"main/internal/infrastructure/errs"
+	"main/internal/infrastructure/models/db"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type calendarFeedsRepo struct {
+	db *gorm.DB
+}
+
+func NewCalendarFeedsRepo(db *gorm.DB) *calendarFeedsRepo {
+	return &calendarFeedsRepo{
+		db: db,
+	}
+}
+
+func (r *calendarFeedsRepo) GetByEmail(ctx context.Context, email string) (models.CalendarFeed, error) {
+	return r.getBy(ctx, "email = ?", email)
+}
+
+func (r *calendarFeedsRepo) GetByToken(ctx context.Context, token string) (models.CalendarFeed, error) {
+	return r.getBy(ctx, "token = ?", token)
+}
+
+func (r *calendarFeedsRepo) getBy(ctx context.Context, query string, arg string) (models.CalendarFeed, error) {
+	var sqlCalendarFeed db.SQLCalendarFeed
+
+	if err := r.db.WithContext(ctx).
+		Where(query, arg).
+		First(&sqlCalendarFeed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.CalendarFeed{}, errs.ErrNotFound
+		}
+
+		return models.CalendarFeed{}, fmt.Errorf("could not get calendar feed: %w", err)
+	}
+
+	return sqlCalendarFeed.ToDomain(), nil
+}
+
+// Insert keeps the existing feed when the email already has one.
+func (r *calendarFeedsRepo) Insert(ctx context.Context, feed models.CalendarFeed) error {
+	sqlCalendarFeed := db.SQLCalendarFeedFromDomain(feed)
+
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&sqlCalendarFeed).Error; err != nil {
+		return fmt.Errorf("could not insert calendar feed: %w", err)
+	}
+
+	return nil
+}
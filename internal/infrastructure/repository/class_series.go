@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"main/internal/domain/models"
+	"main/internal/infrastructure/errs"
+	"main/internal/infrastructure/models/db"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type classSeriesRepo struct {
+	db *gorm.DB
+}
+
+func NewClassSeriesRepo(db *gorm.DB) *classSeriesRepo {
+	return &classSeriesRepo{
+		db: db,
+	}
+}
+
+func (r *classSeriesRepo) Get(ctx context.Context, id uuid.UUID) (models.ClassSeries, error) {
+	var sqlSeries db.SQLClassSeries
+
+	if err := r.db.WithContext(ctx).Preload("Location").First(&sqlSeries, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ClassSeries{}, errs.ErrNotFound
+		}
+
+		return models.ClassSeries{}, fmt.Errorf("could not get class series: %w", err)
+	}
+
+	return sqlSeries.ToDomain(), nil
+}
+
+func (r *classSeriesRepo) List(ctx context.Context) ([]models.ClassSeries, error) {
+	var sqlSeries []db.SQLClassSeries
+
+	if err := r.db.WithContext(ctx).Preload("Location").Order("start_time ASC").Find(&sqlSeries).Error; err != nil {
+		return nil, fmt.Errorf("could not list class series: %w", err)
+	}
+
+	series := make([]models.ClassSeries, len(sqlSeries))
+
+	for i, s := range sqlSeries {
+		series[i] = s.ToDomain()
+	}
+
+	return series, nil
+}
+
+func (r *classSeriesRepo) Insert(ctx context.Context, series models.ClassSeries) error {
+	sqlSeries := db.SQLClassSeriesFromDomain(series)
+
+	if err := r.db.WithContext(ctx).Create(&sqlSeries).Error; err != nil {
+		return fmt.Errorf("could not insert class series: %w", err)
+	}
+
+	return nil
+}
+
+func (r *classSeriesRepo) Update(ctx context.Context, series models.ClassSeries) error {
+	sqlSeries := db.SQLClassSeriesFromDomain(series)
+
+	result := r.db.WithContext(ctx).
+		Model(&sqlSeries).
+		Select("*").
+		Updates(&sqlSeries)
+	if result.Error != nil {
+		return fmt.Errorf("could not update class series %v: %w", series.ID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (r *classSeriesRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	var sqlSeries db.SQLClassSeries
+
+	result := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&sqlSeries)
+	if result.Error != nil {
+		return fmt.Errorf("could not delete class series: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
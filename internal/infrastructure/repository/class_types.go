@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"main/internal/domain/models"
+	"main/internal/infrastructure/errs"
+	"main/internal/infrastructure/models/db"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type classTypesRepo struct {
+	db      *gorm.DB
+	dialect Dialect
+}
+
+func NewClassTypesRepo(db *gorm.DB, dialect Dialect) *classTypesRepo {
+	return &classTypesRepo{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+func (r *classTypesRepo) Get(ctx context.Context, id uuid.UUID) (models.ClassType, error) {
+	var sqlClassType db.SQLClassType
+
+	if err := r.db.WithContext(ctx).First(&sqlClassType, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ClassType{}, errs.ErrNotFound
+		}
+
+		return models.ClassType{}, fmt.Errorf("could not get class type %s: %w", id, err)
+	}
+
+	return sqlClassType.ToDomain(), nil
+}
+
+func (r *classTypesRepo) List(ctx context.Context) ([]models.ClassType, error) {
+	var sqlClassTypes []db.SQLClassType
+
+	if err := r.db.WithContext(ctx).Order("name ASC, level ASC").Find(&sqlClassTypes).Error; err != nil {
+		return nil, fmt.Errorf("could not list class types: %w", err)
+	}
+
+	classTypes := make([]models.ClassType, len(sqlClassTypes))
+
+	for i, sqlClassType := range sqlClassTypes {
+		classTypes[i] = sqlClassType.ToDomain()
+	}
+
+	return classTypes, nil
+}
+
+func (r *classTypesRepo) Insert(ctx context.Context, classType models.ClassType) error {
+	sqlClassType := db.SQLClassTypeFromDomain(classType)
+
+	if err := r.db.WithContext(ctx).Create(&sqlClassType).Error; err != nil {
+		if r.dialect.IsUniqueViolation(err) {
+			return errs.ErrAlreadyExist
+		}
+
+		return fmt.Errorf("could not insert class type: %w", err)
+	}
+
+	return nil
+}
+
+func (r *classTypesRepo) Update(ctx context.Context, classType models.ClassType) error {
+	sqlClassType := db.SQLClassTypeFromDomain(classType)
+
+	result := r.db.WithContext(ctx).
+		Model(&sqlClassType).
+		Select("*").
+		Omit("created_at").
+		Updates(&sqlClassType)
+	if result.Error != nil {
+		if r.dialect.IsUniqueViolation(result.Error) {
+			return errs.ErrAlreadyExist
+		}
+
+		return fmt.Errorf("could not update class type %s: %w", classType.ID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (r *classTypesRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&db.SQLClassType{})
+	if result.Error != nil {
+		return fmt.Errorf("could not delete class type %s: %w", id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (r *classTypesRepo) IsInUse(ctx context.Context, id uuid.UUID) (bool, error) {
+	var count int64
+
+	if err := r.db.WithContext(ctx).Model(&db.SQLClass{}).Where("class_type_id = ?", id).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("could not count usages of class type %s: %w", id, err)
+	}
+
+	return count > 0, nil
+}
@@ -1,4 +1,4 @@
-package sqlite
+package repository
 
 import (
 	"context"
@@ -8,18 +8,20 @@ import (
 	"main/internal/domain/models"
 	"main/internal/infrastructure/errs"
 	"main/internal/infrastructure/models/db"
+	"main/pkg/i18n"
 
-	"github.com/mattn/go-sqlite3"
 	"gorm.io/gorm"
 )
 
 type contactsRepo struct {
-	db *gorm.DB
+	db      *gorm.DB
+	dialect Dialect
 }
 
-func NewContactsRepo(db *gorm.DB) *contactsRepo {
+func NewContactsRepo(db *gorm.DB, dialect Dialect) *contactsRepo {
 	return &contactsRepo{
-		db: db,
+		db:      db,
+		dialect: dialect,
 	}
 }
 
@@ -33,13 +35,9 @@ func (r *contactsRepo) Insert(
 		LastName:  lastName,
 	}
 
-	var sqliteErr sqlite3.Error
-
 	if err := r.db.WithContext(ctx).
 		Create(&contact).Error; err != nil {
-		if errors.As(err, &sqliteErr) &&
-			sqliteErr.Code == sqlite3.ErrConstraint &&
-			sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		if r.dialect.IsUniqueViolation(err) {
 			return models.Contact{}, errs.ErrAlreadyExist
 		}
 
@@ -49,6 +47,22 @@ func (r *contactsRepo) Insert(
 	return contact.ToDomain(), nil
 }
 
+func (r *contactsRepo) GetByEmail(ctx context.Context, email string) (models.Contact, error) {
+	var SQLContact db.SQLContact
+
+	if err := r.db.WithContext(ctx).
+		Where("email = ?", email).
+		First(&SQLContact).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Contact{}, errs.ErrNotFound
+		}
+
+		return models.Contact{}, fmt.Errorf("could not get contact %s: %w", email, err)
+	}
+
+	return SQLContact.ToDomain(), nil
+}
+
 func (r *contactsRepo) List(ctx context.Context) ([]models.Contact, error) {
 	var SQLContacts []db.SQLContact
 
@@ -66,3 +80,19 @@ func (r *contactsRepo) List(ctx context.Context) ([]models.Contact, error) {
 
 	return result, nil
 }
+
+func (r *contactsRepo) UpdateLanguage(ctx context.Context, email string, language i18n.Locale) error {
+	result := r.db.WithContext(ctx).
+		Model(&db.SQLContact{}).
+		Where("email = ?", email).
+		Update("language", string(language))
+	if result.Error != nil {
+		return fmt.Errorf("could not update language of contact %s: %w", email, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
@@ -0,0 +1,1121 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"main/internal/domain/models"
+	"main/internal/domain/repositories"
+	"main/internal/infrastructure/errs"
+	"main/internal/infrastructure/migrations"
+	"main/internal/infrastructure/repository"
+	postgresRepo "main/internal/infrastructure/repository/postgres"
+	sqliteRepo "main/internal/infrastructure/repository/sqlite"
+	"main/pkg/i18n"
+	"main/pkg/optional"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type backend struct {
+	repos      repositories.Repositories
+	unitOfWork repositories.IUnitOfWork
+}
+
+type backendFactory struct {
+	name string
+	open func(t *testing.T) backend
+}
+
+func backends() []backendFactory {
+	return []backendFactory{
+		{
+			name: "sqlite",
+			open: openSQLite,
+		},
+		{
+			name: "postgres",
+			open: openPostgres,
+		},
+	}
+}
+
+func openSQLite(t *testing.T) backend {
+	t.Helper()
+
+	dialect := sqliteRepo.NewDialect()
+
+	database, err := gorm.Open(dialect.Open(filepath.Join(t.TempDir(), "yoga.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("could not open sqlite: %v", err)
+	}
+
+	migrate(t, database, "sqlite")
+
+	return backend{
+		repos:      repository.NewRepositories(database, dialect),
+		unitOfWork: repository.NewUnitOfWork(database, dialect),
+	}
+}
+
+// openPostgres gives every test its own schema on the test server, dropped afterwards.
+func openPostgres(t *testing.T) backend {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("postgres is not started in short mode")
+	}
+
+	if postgresServer.dsn == "" && postgresServer.err == nil {
+		t.Skip("postgres runs only with " + postgresDSNEnv + " set or the postgres build tag")
+	}
+
+	if postgresServer.err != nil {
+		t.Fatalf("postgres test server is not running: %v", postgresServer.err)
+	}
+
+	dialect := postgresRepo.NewDialect()
+
+	admin, err := gorm.Open(dialect.Open(postgresServer.dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("could not open postgres: %v", err)
+	}
+
+	schema := "contract_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	if err = admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatalf("could not create schema %s: %v", schema, err)
+	}
+
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+
+		if sqlDB, err := admin.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	database, err := gorm.Open(dialect.Open(postgresServer.dsn+" search_path="+schema), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("could not open postgres schema %s: %v", schema, err)
+	}
+
+	t.Cleanup(func() {
+		if sqlDB, err := database.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	migrate(t, database, "postgres")
+
+	return backend{
+		repos:      repository.NewRepositories(database, dialect),
+		unitOfWork: repository.NewUnitOfWork(database, dialect),
+	}
+}
+
+func migrate(t *testing.T, database *gorm.DB, driver string) {
+	t.Helper()
+
+	migrator, err := migrations.NewMigrator(database, driver)
+	if err != nil {
+		t.Fatalf("could not load migrations: %v", err)
+	}
+
+	if _, err = migrator.Up(context.Background()); err != nil {
+		t.Fatalf("could not migrate: %v", err)
+	}
+}
+
+func TestRepositoryContract(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, ctx context.Context, b backend)
+	}{
+		{
+			name: "classes insert, get and update",
+			run:  testClasses,
+		},
+		{
+			name: "bookings are counted and listed by class, email and pass",
+			run:  testBookings,
+		},
+		{
+			name: "pending bookings are deleted once expired",
+			run:  testPendingBookings,
+		},
+		{
+			name: "passes keep freezes",
+			run:  testPasses,
+		},
+		{
+			name: "duplicate contact is reported",
+			run:  testContacts,
+		},
+		{
+			name: "waitlist counts offers",
+			run:  testWaitlist,
+		},
+		{
+			name: "class series round trip exception dates",
+			run:  testClassSeries,
+		},
+		{
+			name: "job runs are upserted",
+			run:  testJobRuns,
+		},
+		{
+			name: "outbox lists due messages",
+			run:  testOutbox,
+		},
+		{
+			name: "student sessions expire",
+			run:  testStudentSessions,
+		},
+		{
+			name: "calendar feed keeps the first token",
+			run:  testCalendarFeeds,
+		},
+		{
+			name: "payment status changes once",
+			run:  testPayments,
+		},
+		{
+			name: "products are listed active and passes keep them",
+			run:  testProducts,
+		},
+		{
+			name: "locations are unique and kept while in use",
+			run:  testLocations,
+		},
+		{
+			name: "instructors are loaded with their classes",
+			run:  testInstructors,
+		},
+		{
+			name: "class types are unique and default classes to an hour",
+			run:  testClassTypes,
+		},
+		{
+			name: "unit of work rolls back on error",
+			run:  testUnitOfWorkRollback,
+		},
+	}
+
+	for _, factory := range backends() {
+		t.Run(factory.name, func(t *testing.T) {
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					tt.run(t, context.Background(), factory.open(t))
+				})
+			}
+		})
+	}
+}
+
+func insertClass(t *testing.T, ctx context.Context, repos repositories.Repositories, startTime time.Time) models.Class {
+	t.Helper()
+
+	location := insertLocation(t, ctx, repos, "studio "+uuid.NewString())
+
+	classes, err := repos.Classes.Insert(ctx, []models.Class{{
+		ID:          uuid.New(),
+		StartTime:   startTime,
+		ClassLevel:  "beginner",
+		ClassName:   "hatha",
+		MaxCapacity: 10,
+		LocationID:  location.ID,
+	}})
+	if err != nil {
+		t.Fatalf("could not insert class: %v", err)
+	}
+
+	return classes[0]
+}
+
+func insertLocation(t *testing.T, ctx context.Context, repos repositories.Repositories, name string) models.Location {
+	t.Helper()
+
+	now := time.Now().UTC()
+	location := models.Location{
+		ID:        uuid.New(),
+		Name:      name,
+		Address:   "Długa 1, Kraków",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err := repos.Locations.Insert(ctx, location)
+	if err != nil {
+		t.Fatalf("could not insert location: %v", err)
+	}
+
+	return location
+}
+
+func insertBooking(
+	t *testing.T, ctx context.Context, repos repositories.Repositories, classID uuid.UUID, email string, pass *models.Pass,
+) uuid.UUID {
+	t.Helper()
+
+	booking := models.Booking{
+		ID:                uuid.New(),
+		ClassID:           classID,
+		FirstName:         "Anna",
+		LastName:          "Kowalska",
+		Email:             email,
+		CreatedAt:         time.Now().UTC(),
+		ConfirmationToken: uuid.NewString(),
+	}
+
+	if pass != nil {
+		booking.PassID = optional.Of(pass.ID)
+		booking.Pass = optional.Of(*pass)
+	}
+
+	id, err := repos.Bookings.Insert(ctx, booking)
+	if err != nil {
+		t.Fatalf("could not insert booking: %v", err)
+	}
+
+	return id
+}
+
+func testClasses(t *testing.T, ctx context.Context, b backend) {
+	class := insertClass(t, ctx, b.repos, time.Now().Add(24*time.Hour).UTC())
+
+	_, err := b.repos.Classes.Update(ctx, class.ID, map[string]any{"max_capacity": 3})
+	if err != nil {
+		t.Fatalf("could not update class: %v", err)
+	}
+
+	got, err := b.repos.Classes.Get(ctx, class.ID)
+	if err != nil {
+		t.Fatalf("could not get class: %v", err)
+	}
+
+	if got.MaxCapacity != 3 || got.ClassName != class.ClassName {
+		t.Errorf("got %+v, want max capacity 3 and name %s", got, class.ClassName)
+	}
+
+	_, err = b.repos.Classes.Get(ctx, uuid.New())
+	if !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("got %v, want %v", err, errs.ErrNotFound)
+	}
+
+	err = b.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		return repos.Classes.Lock(ctx, class.ID)
+	})
+	if err != nil {
+		t.Errorf("could not lock class: %v", err)
+	}
+
+	err = b.repos.Classes.Lock(ctx, uuid.New())
+	if !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("lock: got %v, want %v", err, errs.ErrNotFound)
+	}
+
+	nextWeek := insertClass(t, ctx, b.repos, class.StartTime.Add(7*24*time.Hour))
+
+	for _, tt := range []struct {
+		name     string
+		from, to time.Time
+		want     []uuid.UUID
+	}{
+		{
+			name: "both",
+			from: class.StartTime,
+			to:   nextWeek.StartTime.Add(time.Minute),
+			want: []uuid.UUID{class.ID, nextWeek.ID},
+		},
+		{name: "to is excluded", from: class.StartTime, to: nextWeek.StartTime, want: []uuid.UUID{class.ID}},
+		{name: "none", from: class.StartTime.Add(time.Minute), to: nextWeek.StartTime, want: []uuid.UUID{}},
+	} {
+		classes, err := b.repos.Classes.ListBetween(ctx, tt.from, tt.to)
+		if err != nil {
+			t.Fatalf("could not list classes: %v", err)
+		}
+
+		got := make([]uuid.UUID, len(classes))
+		for i, class := range classes {
+			got[i] = class.ID
+		}
+
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func testBookings(t *testing.T, ctx context.Context, b backend) {
+	class := insertClass(t, ctx, b.repos, time.Now().Add(24*time.Hour).UTC())
+
+	pass, err := b.repos.Passes.Insert(ctx, models.Pass{
+		Email:      "anna@example.com",
+		TotalSlots: 4,
+		ValidFrom:  time.Now().UTC(),
+		CreatedAt:  time.Now().UTC(),
+		UpdatedAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		t.Fatalf("could not insert pass: %v", err)
+	}
+
+	bookingID := insertBooking(t, ctx, b.repos, class.ID, "anna@example.com", &pass)
+	attendedID := insertBooking(t, ctx, b.repos, class.ID, "ola@example.com", nil)
+
+	count, err := b.repos.Bookings.CountForClassID(ctx, class.ID)
+	if err != nil || count != 2 {
+		t.Errorf("got count %d (%v), want 2", count, err)
+	}
+
+	passCount, err := b.repos.Bookings.CountForPassID(ctx, pass.ID)
+	if err != nil || passCount != 1 {
+		t.Errorf("got pass count %d (%v), want 1", passCount, err)
+	}
+
+	_, err = b.repos.Bookings.GetByEmailAndClassID(ctx, class.ID, "anna@example.com")
+	if err != nil {
+		t.Fatalf("could not get booking by email: %v", err)
+	}
+
+	booking, err := b.repos.Bookings.GetByID(ctx, bookingID)
+	if err != nil {
+		t.Fatalf("could not get booking: %v", err)
+	}
+
+	if booking.ID != bookingID || booking.Class.ID != class.ID || !booking.Pass.Exists() {
+		t.Errorf("got %+v, want booking %v with class and pass", booking, bookingID)
+	}
+
+	err = b.repos.Bookings.UpdateStatus(ctx, bookingID, models.BookingCancelled)
+	if err != nil {
+		t.Fatalf("could not cancel booking: %v", err)
+	}
+
+	err = b.repos.Bookings.UpdateStatus(ctx, bookingID, models.BookingCancelled)
+	if !errors.Is(err, errs.ErrNoRowsAffected) {
+		t.Errorf("got %v, want %v", err, errs.ErrNoRowsAffected)
+	}
+
+	lateCancelledID := insertBooking(t, ctx, b.repos, class.ID, "anna@example.com", &pass)
+
+	err = b.repos.Bookings.UpdateStatus(ctx, lateCancelledID, models.BookingLateCancelled)
+	if err != nil {
+		t.Fatalf("could not cancel booking: %v", err)
+	}
+
+	count, err = b.repos.Bookings.CountForClassID(ctx, class.ID)
+	if err != nil || count != 1 {
+		t.Errorf("got count %d (%v) with late cancellation, want 1", count, err)
+	}
+
+	passCount, err = b.repos.Bookings.CountForPassID(ctx, pass.ID)
+	if err != nil || passCount != 1 {
+		t.Errorf("got pass count %d (%v) with late cancellation, want 1", passCount, err)
+	}
+
+	_, err = b.repos.Bookings.GetByID(ctx, lateCancelledID)
+	if !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("got %v for late cancelled booking, want %v", err, errs.ErrNotFound)
+	}
+
+	passBookings, err := b.repos.Bookings.ListByPassID(ctx, pass.ID)
+	if err != nil || len(passBookings) != 1 || passBookings[0].Status != models.BookingLateCancelled {
+		t.Errorf("got pass bookings %+v (%v), want the late cancelled one", passBookings, err)
+	}
+
+	err = b.repos.Bookings.UpdateStatus(ctx, attendedID, models.BookingAttended)
+	if err != nil {
+		t.Fatalf("could not mark booking as attended: %v", err)
+	}
+
+	statistics, err := b.repos.Bookings.ListStatisticsByEmails(
+		ctx, []string{"anna@example.com", "ola@example.com", "nobody@example.com"},
+	)
+	if err != nil {
+		t.Fatalf("could not get statistics: %v", err)
+	}
+
+	wantStatistics := map[string]models.ContactStatistics{
+		"anna@example.com": {Email: "anna@example.com", Cancellations: 1, LateCancellations: 1},
+		"ola@example.com":  {Email: "ola@example.com", Attended: 1},
+	}
+
+	if len(statistics) != len(wantStatistics) {
+		t.Errorf("got %d statistics, want %d", len(statistics), len(wantStatistics))
+	}
+
+	for _, statistic := range statistics {
+		if statistic != wantStatistics[statistic.Email] {
+			t.Errorf("got statistics %+v, want %+v", statistic, wantStatistics[statistic.Email])
+		}
+	}
+
+	err = b.repos.Bookings.DeleteByClassID(ctx, class.ID)
+	if err != nil {
+		t.Fatalf("could not delete bookings of class: %v", err)
+	}
+
+	passCount, err = b.repos.Bookings.CountForPassID(ctx, pass.ID)
+	if err != nil || passCount != 0 {
+		t.Errorf("got pass count %d (%v) after class deletion, want 0", passCount, err)
+	}
+}
+
+func testPendingBookings(t *testing.T, ctx context.Context, b backend) {
+	class := insertClass(t, ctx, b.repos, time.Now().Add(24*time.Hour).UTC())
+	now := time.Now().UTC()
+
+	for i, createdAt := range []time.Time{now.Add(-2 * time.Hour), now} {
+		err := b.repos.PendingBookings.Insert(ctx, models.PendingBooking{
+			ID:                uuid.New(),
+			ClassID:           class.ID,
+			Email:             fmt.Sprintf("student%d@example.com", i),
+			FirstName:         "Anna",
+			LastName:          "Kowalska",
+			ConfirmationToken: uuid.NewString(),
+			CreatedAt:         createdAt,
+		})
+		if err != nil {
+			t.Fatalf("could not insert pending booking: %v", err)
+		}
+	}
+
+	deleted, err := b.repos.PendingBookings.DeleteCreatedBefore(ctx, now.Add(-time.Hour))
+	if err != nil || deleted != 1 {
+		t.Errorf("got deleted %d (%v), want 1", deleted, err)
+	}
+
+	pendingBookings, err := b.repos.PendingBookings.List(ctx)
+	if err != nil || len(pendingBookings) != 1 {
+		t.Fatalf("got %d pending bookings (%v), want 1", len(pendingBookings), err)
+	}
+
+	err = b.repos.PendingBookings.Delete(ctx, pendingBookings[0].ID)
+	if err != nil {
+		t.Fatalf("could not delete pending booking: %v", err)
+	}
+
+	err = b.repos.PendingBookings.Delete(ctx, pendingBookings[0].ID)
+	if !errors.Is(err, errs.ErrNoRowsAffected) {
+		t.Errorf("got %v, want %v", err, errs.ErrNoRowsAffected)
+	}
+}
+
+func testPasses(t *testing.T, ctx context.Context, b backend) {
+	now := time.Now().UTC()
+
+	pass, err := b.repos.Passes.Insert(ctx, models.Pass{
+		Email:      "anna@example.com",
+		TotalSlots: 8,
+		ValidFrom:  now,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	if err != nil {
+		t.Fatalf("could not insert pass: %v", err)
+	}
+
+	_, err = b.repos.Passes.InsertFreeze(ctx, models.PassFreeze{
+		PassID:    pass.ID,
+		StartsAt:  now,
+		EndsAt:    now.Add(7 * 24 * time.Hour),
+		Reason:    "holiday",
+		CreatedAt: now,
+	})
+	if err != nil {
+		t.Fatalf("could not insert freeze: %v", err)
+	}
+
+	err = b.repos.Passes.Update(ctx, pass.ID, map[string]any{"total_slots": 10})
+	if err != nil {
+		t.Fatalf("could not update pass: %v", err)
+	}
+
+	passes, err := b.repos.Passes.ListByEmail(ctx, "anna@example.com", 3)
+	if err != nil {
+		t.Fatalf("could not list passes: %v", err)
+	}
+
+	if len(passes) != 1 || passes[0].TotalSlots != 10 || len(passes[0].Freezes) != 1 {
+		t.Errorf("got %+v, want one pass with 10 slots and one freeze", passes)
+	}
+
+	_, err = b.repos.Passes.Get(ctx, pass.ID+100)
+	if !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("got %v, want %v", err, errs.ErrNotFound)
+	}
+}
+
+func testContacts(t *testing.T, ctx context.Context, b backend) {
+	_, err := b.repos.Contacts.Insert(ctx, "anna@example.com", "Anna", "Kowalska")
+	if err != nil {
+		t.Fatalf("could not insert contact: %v", err)
+	}
+
+	_, err = b.repos.Contacts.Insert(ctx, "anna@example.com", "Anna", "Kowalska")
+	if !errors.Is(err, errs.ErrAlreadyExist) {
+		t.Errorf("got %v, want %v", err, errs.ErrAlreadyExist)
+	}
+
+	err = b.repos.Contacts.UpdateLanguage(ctx, "anna@example.com", i18n.English)
+	if err != nil {
+		t.Fatalf("could not update language: %v", err)
+	}
+
+	contact, err := b.repos.Contacts.GetByEmail(ctx, "anna@example.com")
+	if err != nil || contact.Language != i18n.English {
+		t.Errorf("got language %q (%v), want %q", contact.Language, err, i18n.English)
+	}
+
+	_, err = b.repos.Contacts.GetByEmail(ctx, "missing@example.com")
+	if !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("got %v, want %v", err, errs.ErrNotFound)
+	}
+}
+
+func testWaitlist(t *testing.T, ctx context.Context, b backend) {
+	class := insertClass(t, ctx, b.repos, time.Now().Add(24*time.Hour).UTC())
+	now := time.Now().UTC()
+
+	for i, offeredAt := range []*time.Time{&now, nil} {
+		err := b.repos.Waitlist.Insert(ctx, models.WaitlistEntry{
+			ID:        uuid.New(),
+			ClassID:   class.ID,
+			Email:     fmt.Sprintf("student%d@example.com", i),
+			FirstName: "Anna",
+			LastName:  "Kowalska",
+			CreatedAt: now,
+			OfferedAt: offeredAt,
+		})
+		if err != nil {
+			t.Fatalf("could not insert waitlist entry: %v", err)
+		}
+	}
+
+	offered, err := b.repos.Waitlist.CountOfferedSince(ctx, class.ID, now.Add(-time.Minute))
+	if err != nil || offered != 1 {
+		t.Errorf("got offered %d (%v), want 1", offered, err)
+	}
+
+	err = b.repos.Waitlist.DeleteByClassID(ctx, class.ID)
+	if err != nil {
+		t.Fatalf("could not delete waitlist: %v", err)
+	}
+
+	entries, err := b.repos.Waitlist.ListByClassID(ctx, class.ID)
+	if err != nil || len(entries) != 0 {
+		t.Errorf("got %d entries (%v), want 0", len(entries), err)
+	}
+}
+
+func testClassSeries(t *testing.T, ctx context.Context, b backend) {
+	exceptionDate := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	series := models.ClassSeries{
+		ID:             uuid.New(),
+		Frequency:      models.ClassSeriesWeekly,
+		StartTime:      time.Date(2026, 4, 3, 18, 0, 0, 0, time.UTC),
+		ExceptionDates: []time.Time{exceptionDate},
+		ClassLevel:     "beginner",
+		ClassName:      "hatha",
+		MaxCapacity:    10,
+		LocationID:     insertLocation(t, ctx, b.repos, "studio").ID,
+	}
+
+	err := b.repos.ClassSeries.Insert(ctx, series)
+	if err != nil {
+		t.Fatalf("could not insert class series: %v", err)
+	}
+
+	got, err := b.repos.ClassSeries.Get(ctx, series.ID)
+	if err != nil {
+		t.Fatalf("could not get class series: %v", err)
+	}
+
+	if len(got.ExceptionDates) != 1 || !got.ExceptionDates[0].Equal(exceptionDate) {
+		t.Errorf("got exception dates %v, want [%v]", got.ExceptionDates, exceptionDate)
+	}
+}
+
+func testJobRuns(t *testing.T, ctx context.Context, b backend) {
+	nextRunAt := time.Date(2026, 4, 3, 18, 0, 0, 0, time.UTC)
+
+	for _, lastError := range []*string{optionalString("boom"), nil} {
+		err := b.repos.JobRuns.Upsert(ctx, models.JobRun{
+			Name:      "remind_bookings",
+			NextRunAt: nextRunAt,
+			LastError: lastError,
+		})
+		if err != nil {
+			t.Fatalf("could not upsert job run: %v", err)
+		}
+	}
+
+	jobRuns, err := b.repos.JobRuns.List(ctx)
+	if err != nil {
+		t.Fatalf("could not list job runs: %v", err)
+	}
+
+	if len(jobRuns) != 1 || jobRuns[0].LastError != nil || !jobRuns[0].NextRunAt.Equal(nextRunAt) {
+		t.Errorf("got %+v, want one job run without error", jobRuns)
+	}
+}
+
+func testOutbox(t *testing.T, ctx context.Context, b backend) {
+	err := b.repos.Outbox.Enqueue(ctx, models.Notification{
+		Kind:   models.NotificationStudentLoginLink,
+		Params: models.NotifierParams{RecipientEmail: "anna@example.com"},
+		Link:   "https://example.com/my_bookings/auth?token=abc",
+	})
+	if err != nil {
+		t.Fatalf("could not enqueue: %v", err)
+	}
+
+	due, err := b.repos.Outbox.ListDue(ctx, time.Now().UTC().Add(time.Second), 10)
+	if err != nil {
+		t.Fatalf("could not list due messages: %v", err)
+	}
+
+	if len(due) != 1 || due[0].Notification.Params.RecipientEmail != "anna@example.com" {
+		t.Fatalf("got %+v, want one message for anna@example.com", due)
+	}
+
+	err = b.repos.Outbox.Update(ctx, due[0].ID, map[string]any{"status": models.OutboxStatusSent})
+	if err != nil {
+		t.Fatalf("could not update message: %v", err)
+	}
+
+	sent, err := b.repos.Outbox.ListByStatus(ctx, models.OutboxStatusSent)
+	if err != nil || len(sent) != 1 {
+		t.Errorf("got %d sent messages (%v), want 1", len(sent), err)
+	}
+}
+
+func testStudentSessions(t *testing.T, ctx context.Context, b backend) {
+	now := time.Now().UTC()
+
+	for _, expiresAt := range []time.Time{now.Add(-time.Minute), now.Add(time.Hour)} {
+		err := b.repos.StudentSessions.Insert(ctx, models.StudentSession{
+			ID:        uuid.New(),
+			Email:     "anna@example.com",
+			Token:     uuid.NewString(),
+			ExpiresAt: expiresAt,
+			CreatedAt: now,
+		})
+		if err != nil {
+			t.Fatalf("could not insert student session: %v", err)
+		}
+	}
+
+	deleted, err := b.repos.StudentSessions.DeleteExpiredBefore(ctx, now)
+	if err != nil || deleted != 1 {
+		t.Errorf("got deleted %d (%v), want 1", deleted, err)
+	}
+
+	_, err = b.repos.StudentSessions.GetByToken(ctx, "missing")
+	if !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("got %v, want %v", err, errs.ErrNotFound)
+	}
+}
+
+func testCalendarFeeds(t *testing.T, ctx context.Context, b backend) {
+	for _, token := range []string{"first", "second"} {
+		err := b.repos.CalendarFeeds.Insert(ctx, models.CalendarFeed{
+			Email:     "anna@example.com",
+			Token:     token,
+			CreatedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			t.Fatalf("could not insert calendar feed: %v", err)
+		}
+	}
+
+	feed, err := b.repos.CalendarFeeds.GetByToken(ctx, "first")
+	if err != nil || feed.Email != "anna@example.com" {
+		t.Errorf("got %+v (%v), want feed of anna@example.com", feed, err)
+	}
+
+	_, err = b.repos.CalendarFeeds.GetByToken(ctx, "second")
+	if !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("got %v, want %v", err, errs.ErrNotFound)
+	}
+}
+
+func testPayments(t *testing.T, ctx context.Context, b backend) {
+	payment := models.Payment{
+		ID:         uuid.New(),
+		Kind:       models.PaymentKindPass,
+		Status:     models.PaymentPending,
+		Provider:   "fake",
+		Email:      "anna@example.com",
+		TotalSlots: 4,
+		Amount:     14000,
+		Currency:   "PLN",
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	// payments waiting for the checkout have no provider ref yet, which must not collide
+	for _, id := range []uuid.UUID{payment.ID, uuid.New()} {
+		payment.ID = id
+
+		err := b.repos.Payments.Insert(ctx, payment)
+		if err != nil {
+			t.Fatalf("could not insert payment: %v", err)
+		}
+	}
+
+	err := b.repos.Payments.Update(ctx, payment.ID, map[string]any{"provider_ref": "ref_1"})
+	if err != nil {
+		t.Fatalf("could not update payment: %v", err)
+	}
+
+	err = b.repos.Payments.UpdateStatus(ctx, payment.ID, models.PaymentPending, models.PaymentPaid)
+	if err != nil {
+		t.Fatalf("could not mark payment as paid: %v", err)
+	}
+
+	err = b.repos.Payments.UpdateStatus(ctx, payment.ID, models.PaymentPending, models.PaymentPaid)
+	if !errors.Is(err, errs.ErrNoRowsAffected) {
+		t.Errorf("got %v, want %v", err, errs.ErrNoRowsAffected)
+	}
+
+	got, err := b.repos.Payments.GetByProviderRef(ctx, "ref_1")
+	if err != nil || got.ID != payment.ID || got.Status != models.PaymentPaid || got.PaidAt == nil {
+		t.Errorf("got %+v (%v), want paid payment %s", got, err, payment.ID)
+	}
+
+	class := insertClass(t, ctx, b.repos, time.Now().Add(48*time.Hour))
+
+	// only pending drop-ins of the class started since, of other students, hold a spot
+	for _, dropIn := range []struct {
+		email     string
+		status    models.PaymentStatus
+		createdAt time.Time
+	}{
+		{email: "bob@example.com", status: models.PaymentPending, createdAt: time.Now()},
+		{email: "anna@example.com", status: models.PaymentPending, createdAt: time.Now()},
+		{email: "ewa@example.com", status: models.PaymentPaid, createdAt: time.Now()},
+		{email: "ola@example.com", status: models.PaymentPending, createdAt: time.Now().Add(-time.Hour)},
+	} {
+		err = b.repos.Payments.Insert(ctx, models.Payment{
+			ID:        uuid.New(),
+			Kind:      models.PaymentKindDropIn,
+			Status:    dropIn.status,
+			Provider:  "fake",
+			Email:     dropIn.email,
+			ClassID:   optional.Of(class.ID),
+			Amount:    4000,
+			Currency:  "PLN",
+			CreatedAt: dropIn.createdAt.UTC(),
+		})
+		if err != nil {
+			t.Fatalf("could not insert drop-in payment: %v", err)
+		}
+	}
+
+	count, err := b.repos.Payments.CountPendingDropIns(
+		ctx, class.ID, "anna@example.com", time.Now().Add(-models.DropInCheckoutTTL),
+	)
+	if err != nil || count != 1 {
+		t.Errorf("got %d (%v), want 1 pending drop-in", count, err)
+	}
+}
+
+func testProducts(t *testing.T, ctx context.Context, b backend) {
+	now := time.Now().UTC()
+
+	products := []models.Product{
+		{ID: uuid.New(), Name: "8 classes", Kind: models.ProductPass, TotalSlots: 8, ValidityDays: 60, Price: 26000},
+		{ID: uuid.New(), Name: "4 classes", Kind: models.ProductPass, TotalSlots: 4, ValidityDays: 30, Price: 14000},
+		{ID: uuid.New(), Name: "drop-in", Kind: models.ProductDropIn, Price: 4000},
+	}
+
+	for _, product := range products {
+		product.Currency = "PLN"
+		product.Active = true
+		product.CreatedAt = now
+		product.UpdatedAt = now
+
+		err := b.repos.Products.Insert(ctx, product)
+		if err != nil {
+			t.Fatalf("could not insert product: %v", err)
+		}
+	}
+
+	retired, err := b.repos.Products.Get(ctx, products[0].ID)
+	if err != nil {
+		t.Fatalf("could not get product: %v", err)
+	}
+
+	retired.Active = false
+
+	err = b.repos.Products.Update(ctx, retired)
+	if err != nil {
+		t.Fatalf("could not update product: %v", err)
+	}
+
+	active, err := b.repos.Products.ListActive(ctx)
+	if err != nil {
+		t.Fatalf("could not list active products: %v", err)
+	}
+
+	if len(active) != 2 || active[0].ID != products[2].ID || active[1].ID != products[1].ID {
+		t.Errorf("got %+v, want the drop-in and the 4 classes pass", active)
+	}
+
+	pass, err := b.repos.Passes.Insert(ctx, models.Pass{
+		Email:      "anna@example.com",
+		TotalSlots: 4,
+		ProductID:  optional.Of(products[1].ID),
+		ValidFrom:  now,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	if err != nil {
+		t.Fatalf("could not insert pass: %v", err)
+	}
+
+	got, err := b.repos.Passes.Get(ctx, pass.ID)
+	if err != nil || !got.ProductID.Exists() || got.ProductID.Get() != products[1].ID {
+		t.Errorf("got %+v (%v), want pass of product %s", got, err, products[1].ID)
+	}
+}
+
+func testLocations(t *testing.T, ctx context.Context, b backend) {
+	class := insertClass(t, ctx, b.repos, time.Now().Add(24*time.Hour).UTC())
+
+	got, err := b.repos.Classes.Get(ctx, class.ID)
+	if err != nil {
+		t.Fatalf("could not get class: %v", err)
+	}
+
+	if got.Location.ID != class.LocationID || got.Location.Address == "" {
+		t.Errorf("got location %+v, want location %s with address", got.Location, class.LocationID)
+	}
+
+	err = b.repos.Locations.Insert(ctx, models.Location{ID: uuid.New(), Name: got.Location.Name})
+	if !errors.Is(err, errs.ErrAlreadyExist) {
+		t.Errorf("got %v, want %v", err, errs.ErrAlreadyExist)
+	}
+
+	inUse, err := b.repos.Locations.IsInUse(ctx, class.LocationID)
+	if err != nil || !inUse {
+		t.Errorf("got in use %t (%v), want true", inUse, err)
+	}
+
+	unused := insertLocation(t, ctx, b.repos, "park")
+
+	inUse, err = b.repos.Locations.IsInUse(ctx, unused.ID)
+	if err != nil || inUse {
+		t.Errorf("got in use %t (%v), want false", inUse, err)
+	}
+
+	err = b.repos.Locations.Delete(ctx, unused.ID)
+	if err != nil {
+		t.Fatalf("could not delete location: %v", err)
+	}
+
+	_, err = b.repos.Locations.Get(ctx, unused.ID)
+	if !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("got %v, want %v", err, errs.ErrNotFound)
+	}
+
+	// the time zone belongs to the location, renaming it keeps the times of its classes
+	london := models.Location{ID: uuid.New(), Name: "London studio", TimeZone: "Europe/London"}
+	if err = b.repos.Locations.Insert(ctx, london); err != nil {
+		t.Fatalf("could not insert location: %v", err)
+	}
+
+	london.Name = "Soho studio"
+	if err = b.repos.Locations.Update(ctx, london); err != nil {
+		t.Fatalf("could not update location: %v", err)
+	}
+
+	renamed, err := b.repos.Locations.Get(ctx, london.ID)
+	if err != nil || renamed.TimeZone != london.TimeZone {
+		t.Errorf("got time zone %q (%v), want %q", renamed.TimeZone, err, london.TimeZone)
+	}
+}
+
+func testInstructors(t *testing.T, ctx context.Context, b backend) {
+	now := time.Now().UTC()
+	instructors := []models.Instructor{
+		{ID: uuid.New(), Name: "Igor", Email: "igor@example.com", Signature: "Igor"},
+		{ID: uuid.New(), Name: "Ola", Email: "ola@example.com", Bio: "Ashtanga since 2010"},
+	}
+
+	for _, instructor := range instructors {
+		instructor.CreatedAt = now
+		instructor.UpdatedAt = now
+
+		err := b.repos.Instructors.Insert(ctx, instructor)
+		if err != nil {
+			t.Fatalf("could not insert instructor: %v", err)
+		}
+	}
+
+	err := b.repos.Instructors.Insert(ctx, models.Instructor{ID: uuid.New(), Name: "Igor", Email: "igor@example.com"})
+	if !errors.Is(err, errs.ErrAlreadyExist) {
+		t.Errorf("got %v, want %v", err, errs.ErrAlreadyExist)
+	}
+
+	class := insertClass(t, ctx, b.repos, now.Add(24*time.Hour))
+
+	updated, err := b.repos.Classes.Update(ctx, class.ID, map[string]any{"instructor_id": instructors[1].ID})
+	if err != nil {
+		t.Fatalf("could not update class: %v", err)
+	}
+
+	if updated.Instructor.Bio != instructors[1].Bio || updated.Location.ID != class.LocationID {
+		t.Errorf("got %+v, want class at %s taught by %s", updated, class.LocationID, instructors[1].Name)
+	}
+
+	got, err := b.repos.Classes.Get(ctx, class.ID)
+	if err != nil {
+		t.Fatalf("could not get class: %v", err)
+	}
+
+	if !got.InstructorID.Exists() || got.Instructor.Name != instructors[1].Name {
+		t.Errorf("got instructor %+v, want %s", got.Instructor, instructors[1].Name)
+	}
+
+	for _, tt := range []struct {
+		instructor models.Instructor
+		inUse      bool
+	}{
+		{instructor: instructors[0], inUse: false},
+		{instructor: instructors[1], inUse: true},
+	} {
+		inUse, err := b.repos.Instructors.IsInUse(ctx, tt.instructor.ID)
+		if err != nil || inUse != tt.inUse {
+			t.Errorf("got %s in use %t (%v), want %t", tt.instructor.Name, inUse, err, tt.inUse)
+		}
+	}
+}
+
+func testClassTypes(t *testing.T, ctx context.Context, b backend) {
+	now := time.Now().UTC()
+	location := insertLocation(t, ctx, b.repos, "shala")
+	classType := models.ClassType{
+		ID:                uuid.New(),
+		Name:              "vinyasa",
+		Level:             "beginner",
+		DefaultDuration:   90 * time.Minute,
+		DefaultCapacity:   12,
+		DefaultLocationID: optional.Of(location.ID),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	err := b.repos.ClassTypes.Insert(ctx, classType)
+	if err != nil {
+		t.Fatalf("could not insert class type: %v", err)
+	}
+
+	err = b.repos.ClassTypes.Insert(ctx, models.ClassType{ID: uuid.New(), Name: "vinyasa", Level: "beginner"})
+	if !errors.Is(err, errs.ErrAlreadyExist) {
+		t.Errorf("got %v, want %v", err, errs.ErrAlreadyExist)
+	}
+
+	got, err := b.repos.ClassTypes.Get(ctx, classType.ID)
+	if err != nil {
+		t.Fatalf("could not get class type: %v", err)
+	}
+
+	if got.DefaultDuration != classType.DefaultDuration || got.DefaultLocationID.GetOrElse(uuid.Nil) != location.ID {
+		t.Errorf("got %+v, want %+v", got, classType)
+	}
+
+	inUse, err := b.repos.Locations.IsInUse(ctx, location.ID)
+	if err != nil || !inUse {
+		t.Errorf("got location in use %t (%v), want true", inUse, err)
+	}
+
+	classes, err := b.repos.Classes.Insert(ctx, []models.Class{
+		{
+			ID:          uuid.New(),
+			StartTime:   now.Add(24 * time.Hour),
+			ClassLevel:  classType.Level,
+			ClassName:   classType.Name,
+			MaxCapacity: classType.DefaultCapacity,
+			LocationID:  location.ID,
+			ClassTypeID: optional.Of(classType.ID),
+			Duration:    classType.DefaultDuration,
+		},
+		{
+			ID:          uuid.New(),
+			StartTime:   now.Add(48 * time.Hour),
+			ClassLevel:  "beginner",
+			ClassName:   "hatha",
+			MaxCapacity: 10,
+			LocationID:  location.ID,
+		},
+	})
+	if err != nil {
+		t.Fatalf("could not insert classes: %v", err)
+	}
+
+	for _, tt := range []struct {
+		class    models.Class
+		duration time.Duration
+		typed    bool
+	}{
+		{class: classes[0], duration: classType.DefaultDuration, typed: true},
+		{class: classes[1], duration: models.ClassDuration, typed: false},
+	} {
+		got, err := b.repos.Classes.Get(ctx, tt.class.ID)
+		if err != nil {
+			t.Fatalf("could not get class: %v", err)
+		}
+
+		if got.Duration != tt.duration || got.ClassTypeID.Exists() != tt.typed {
+			t.Errorf("got %s lasting %s with type %t, want %s with type %t",
+				got.ClassName, got.Duration, got.ClassTypeID.Exists(), tt.duration, tt.typed)
+		}
+	}
+
+	inUse, err = b.repos.ClassTypes.IsInUse(ctx, classType.ID)
+	if err != nil || !inUse {
+		t.Errorf("got class type in use %t (%v), want true", inUse, err)
+	}
+}
+
+func testUnitOfWorkRollback(t *testing.T, ctx context.Context, b backend) {
+	errRollback := errors.New("rollback")
+
+	err := b.unitOfWork.WithTransaction(ctx, func(repos repositories.Repositories) error {
+		_, err := repos.Contacts.Insert(ctx, "anna@example.com", "Anna", "Kowalska")
+		if err != nil {
+			return err
+		}
+
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("got %v, want %v", err, errRollback)
+	}
+
+	contacts, err := b.repos.Contacts.List(ctx)
+	if err != nil || len(contacts) != 0 {
+		t.Errorf("got %d contacts (%v), want 0", len(contacts), err)
+	}
+}
+
+func optionalString(s string) *string {
+	return &s
+}
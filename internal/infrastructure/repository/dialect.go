@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+)
+
+// Dialect is what differs between the supported databases, the repositories run the
+// same queries on all of them.
+type Dialect interface {
+	Open(dsn string) gorm.Dialector
+	// IsUniqueViolation tells whether the row clashes with a unique index, e.g. a name
+	// another location already has.
+	IsUniqueViolation(err error) bool
+}
@@ -0,0 +1,66 @@
+//go:build postgres
+
+package repository_test
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+)
+
+func init() {
+	startPostgres = startEmbeddedPostgres
+}
+
+// startEmbeddedPostgres runs a throwaway server, the binaries are downloaded on the first
+// run and cached in the home directory.
+func startEmbeddedPostgres() (func(), string, error) {
+	dir, err := os.MkdirTemp("", "yoga-postgres")
+	if err != nil {
+		return func() {}, "", fmt.Errorf("could not create postgres dir: %w", err)
+	}
+
+	port, err := freePort()
+	if err != nil {
+		os.RemoveAll(dir)
+
+		return func() {}, "", err
+	}
+
+	server := embeddedpostgres.NewDatabase(embeddedpostgres.DefaultConfig().
+		Port(port).
+		RuntimePath(filepath.Join(dir, "runtime")).
+		DataPath(filepath.Join(dir, "data")).
+		Logger(io.Discard))
+
+	if err = server.Start(); err != nil {
+		os.RemoveAll(dir)
+
+		return func() {}, "", fmt.Errorf("could not start embedded postgres: %w", err)
+	}
+
+	stop := func() {
+		server.Stop()
+		os.RemoveAll(dir)
+	}
+
+	dsn := fmt.Sprintf(
+		"host=localhost port=%d user=postgres password=postgres dbname=postgres sslmode=disable", port,
+	)
+
+	return stop, dsn, nil
+}
+
+func freePort() (uint32, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, fmt.Errorf("could not find a free port: %w", err)
+	}
+	defer listener.Close()
+
+	return uint32(listener.Addr().(*net.TCPAddr).Port), nil //nolint:gosec
+}
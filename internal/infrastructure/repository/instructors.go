@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"main/internal/domain/models"
+	"main/internal/infrastructure/errs"
+	"main/internal/infrastructure/models/db"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type instructorsRepo struct {
+	db      *gorm.DB
+	dialect Dialect
+}
+
+func NewInstructorsRepo(db *gorm.DB, dialect Dialect) *instructorsRepo {
+	return &instructorsRepo{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+func (r *instructorsRepo) Get(ctx context.Context, id uuid.UUID) (models.Instructor, error) {
+	var sqlInstructor db.SQLInstructor
+
+	if err := r.db.WithContext(ctx).First(&sqlInstructor, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Instructor{}, errs.ErrNotFound
+		}
+
+		return models.Instructor{}, fmt.Errorf("could not get instructor %s: %w", id, err)
+	}
+
+	return sqlInstructor.ToDomain(), nil
+}
+
+func (r *instructorsRepo) List(ctx context.Context) ([]models.Instructor, error) {
+	var sqlInstructors []db.SQLInstructor
+
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&sqlInstructors).Error; err != nil {
+		return nil, fmt.Errorf("could not list instructors: %w", err)
+	}
+
+	instructors := make([]models.Instructor, len(sqlInstructors))
+
+	for i, sqlInstructor := range sqlInstructors {
+		instructors[i] = sqlInstructor.ToDomain()
+	}
+
+	return instructors, nil
+}
+
+func (r *instructorsRepo) Insert(ctx context.Context, instructor models.Instructor) error {
+	sqlInstructor := db.SQLInstructorFromDomain(instructor)
+
+	if err := r.db.WithContext(ctx).Create(&sqlInstructor).Error; err != nil {
+		if r.dialect.IsUniqueViolation(err) {
+			return errs.ErrAlreadyExist
+		}
+
+		return fmt.Errorf("could not insert instructor: %w", err)
+	}
+
+	return nil
+}
+
+func (r *instructorsRepo) Update(ctx context.Context, instructor models.Instructor) error {
+	sqlInstructor := db.SQLInstructorFromDomain(instructor)
+
+	result := r.db.WithContext(ctx).
+		Model(&sqlInstructor).
+		Select("*").
+		Omit("created_at").
+		Updates(&sqlInstructor)
+	if result.Error != nil {
+		if r.dialect.IsUniqueViolation(result.Error) {
+			return errs.ErrAlreadyExist
+		}
+
+		return fmt.Errorf("could not update instructor %s: %w", instructor.ID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (r *instructorsRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&db.SQLInstructor{})
+	if result.Error != nil {
+		return fmt.Errorf("could not delete instructor %s: %w", id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (r *instructorsRepo) IsInUse(ctx context.Context, id uuid.UUID) (bool, error) {
+	for _, model := range []any{&db.SQLClass{}, &db.SQLClassSeries{}} {
+		var count int64
+
+		if err := r.db.WithContext(ctx).Model(model).Where("instructor_id = ?", id).Count(&count).Error; err != nil {
+			return false, fmt.Errorf("could not count usages of instructor %s: %w", id, err)
+		}
+
+		if count > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"main/internal/domain/models"
+	"main/internal/infrastructure/errs"
+	"main/internal/infrastructure/models/db"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type jobRunsRepo struct {
+	db *gorm.DB
+}
+
+func NewJobRunsRepo(db *gorm.DB) *jobRunsRepo {
+	return &jobRunsRepo{
+		db: db,
+	}
+}
+
+func (r *jobRunsRepo) Get(ctx context.Context, name string) (models.JobRun, error) {
+	var sqlJobRun db.SQLJobRun
+
+	if err := r.db.WithContext(ctx).First(&sqlJobRun, "name = ?", name).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.JobRun{}, errs.ErrNotFound
+		}
+
+		return models.JobRun{}, fmt.Errorf("could not get job run %s: %w", name, err)
+	}
+
+	return sqlJobRun.ToDomain(), nil
+}
+
+func (r *jobRunsRepo) List(ctx context.Context) ([]models.JobRun, error) {
+	var sqlJobRuns []db.SQLJobRun
+
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&sqlJobRuns).Error; err != nil {
+		return nil, fmt.Errorf("could not list job runs: %w", err)
+	}
+
+	jobRuns := make([]models.JobRun, len(sqlJobRuns))
+
+	for i, sqlJobRun := range sqlJobRuns {
+		jobRuns[i] = sqlJobRun.ToDomain()
+	}
+
+	return jobRuns, nil
+}
+
+func (r *jobRunsRepo) Upsert(ctx context.Context, jobRun models.JobRun) error {
+	sqlJobRun := db.SQLJobRunFromDomain(jobRun)
+
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(&sqlJobRun).Error; err != nil {
+		return fmt.Errorf("could not upsert job run %s: %w", jobRun.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"main/internal/domain/models"
+	"main/internal/infrastructure/errs"
+	"main/internal/infrastructure/models/db"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type locationsRepo struct {
+	db      *gorm.DB
+	dialect Dialect
+}
+
+func NewLocationsRepo(db *gorm.DB, dialect Dialect) *locationsRepo {
+	return &locationsRepo{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+func (r *locationsRepo) Get(ctx context.Context, id uuid.UUID) (models.Location, error) {
+	var sqlLocation db.SQLLocation
+
+	if err := r.db.WithContext(ctx).First(&sqlLocation, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Location{}, errs.ErrNotFound
+		}
+
+		return models.Location{}, fmt.Errorf("could not get location %s: %w", id, err)
+	}
+
+	return sqlLocation.ToDomain(), nil
+}
+
+func (r *locationsRepo) List(ctx context.Context) ([]models.Location, error) {
+	var sqlLocations []db.SQLLocation
+
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&sqlLocations).Error; err != nil {
+		return nil, fmt.Errorf("could not list locations: %w", err)
+	}
+
+	locations := make([]models.Location, len(sqlLocations))
+
+	for i, sqlLocation := range sqlLocations {
+		locations[i] = sqlLocation.ToDomain()
+	}
+
+	return locations, nil
+}
+
+func (r *locationsRepo) Insert(ctx context.Context, location models.Location) error {
+	sqlLocation := db.SQLLocationFromDomain(location)
+
+	if err := r.db.WithContext(ctx).Create(&sqlLocation).Error; err != nil {
+		if r.dialect.IsUniqueViolation(err) {
+			return errs.ErrAlreadyExist
+		}
+
+		return fmt.Errorf("could not insert location: %w", err)
+	}
+
+	return nil
+}
+
+func (r *locationsRepo) Update(ctx context.Context, location models.Location) error {
+	sqlLocation := db.SQLLocationFromDomain(location)
+
+	result := r.db.WithContext(ctx).
+		Model(&sqlLocation).
+		Select("*").
+		Omit("created_at").
+		Updates(&sqlLocation)
+	if result.Error != nil {
+		if r.dialect.IsUniqueViolation(result.Error) {
+			return errs.ErrAlreadyExist
+		}
+
+		return fmt.Errorf("could not update location %s: %w", location.ID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (r *locationsRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&db.SQLLocation{})
+	if result.Error != nil {
+		return fmt.Errorf("could not delete location %s: %w", id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (r *locationsRepo) IsInUse(ctx context.Context, id uuid.UUID) (bool, error) {
+	for _, model := range []any{&db.SQLClass{}, &db.SQLClassSeries{}} {
+		var count int64
+
+		if err := r.db.WithContext(ctx).Model(model).Where("location_id = ?", id).Count(&count).Error; err != nil {
+			return false, fmt.Errorf("could not count usages of location %s: %w", id, err)
+		}
+
+		if count > 0 {
+			return true, nil
+		}
+	}
+
+	var count int64
+
+	err := r.db.WithContext(ctx).Model(&db.SQLClassType{}).Where("default_location_id = ?", id).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("could not count class types at location %s: %w", id, err)
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,39 @@
+package repository_test
+
+import (
+	"flag"
+	"os"
+	"testing"
+)
+
+// postgresDSNEnv points the suite at a running Postgres server. Without it the suite runs
+// on sqlite only, unless it is built with the postgres tag, which starts an embedded one.
+const postgresDSNEnv = "POSTGRES_TEST_DSN"
+
+// postgresServer is shared by the whole suite, err is set when it could not be started.
+var postgresServer struct {
+	dsn string
+	err error
+}
+
+// startPostgres is set by the postgres build tag.
+var startPostgres func() (func(), string, error)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	stop := func() {}
+
+	switch dsn := os.Getenv(postgresDSNEnv); {
+	case testing.Short():
+	case dsn != "":
+		postgresServer.dsn = dsn
+	case startPostgres != nil:
+		stop, postgresServer.dsn, postgresServer.err = startPostgres()
+	}
+
+	code := m.Run()
+
+	stop()
+	os.Exit(code)
+}
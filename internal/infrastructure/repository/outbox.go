@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"main/internal/domain/models"
+	"main/internal/infrastructure/errs"
+	"main/internal/infrastructure/models/db"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type outboxRepo struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepo(db *gorm.DB) *outboxRepo {
+	return &outboxRepo{
+		db: db,
+	}
+}
+
+func (r *outboxRepo) Enqueue(ctx context.Context, notification models.Notification) error {
+	now := time.Now().UTC()
+
+	sqlMessage := db.SQLOutboxMessageFromDomain(models.OutboxMessage{
+		ID:            uuid.New(),
+		Notification:  notification,
+		Status:        models.OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	})
+
+	if err := r.db.WithContext(ctx).Create(&sqlMessage).Error; err != nil {
+		return fmt.Errorf("could not enqueue %s notification: %w", notification.Kind, err)
+	}
+
+	return nil
+}
+
+func (r *outboxRepo) Get(ctx context.Context, id uuid.UUID) (models.OutboxMessage, error) {
+	var sqlMessage db.SQLOutboxMessage
+
+	if err := r.db.WithContext(ctx).First(&sqlMessage, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.OutboxMessage{}, errs.ErrNotFound
+		}
+
+		return models.OutboxMessage{}, fmt.Errorf("could not get outbox message %v: %w", id, err)
+	}
+
+	return sqlMessage.ToDomain(), nil
+}
+
+func (r *outboxRepo) ListByStatus(
+	ctx context.Context,
+	status models.OutboxStatus,
+) ([]models.OutboxMessage, error) {
+	var sqlMessages []db.SQLOutboxMessage
+
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", status).
+		Order("created_at ASC").
+		Find(&sqlMessages).Error; err != nil {
+		return nil, fmt.Errorf("could not list outbox messages with status %s: %w", status, err)
+	}
+
+	return toDomainOutboxMessages(sqlMessages), nil
+}
+
+func (r *outboxRepo) ListDue(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]models.OutboxMessage, error) {
+	var sqlMessages []db.SQLOutboxMessage
+
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", models.OutboxStatusPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&sqlMessages).Error; err != nil {
+		return nil, fmt.Errorf("could not list due outbox messages: %w", err)
+	}
+
+	return toDomainOutboxMessages(sqlMessages), nil
+}
+
+func (r *outboxRepo) Update(ctx context.Context, id uuid.UUID, update map[string]any) error {
+	var sqlMessage db.SQLOutboxMessage
+
+	result := r.db.WithContext(ctx).
+		Model(&sqlMessage).
+		Where("id = ?", id).
+		Updates(update)
+	if result.Error != nil {
+		return fmt.Errorf("could not update outbox message %v: %w", id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func toDomainOutboxMessages(sqlMessages []db.SQLOutboxMessage) []models.OutboxMessage {
+	messages := make([]models.OutboxMessage, len(sqlMessages))
+
+	for i, sqlMessage := range sqlMessages {
+		messages[i] = sqlMessage.ToDomain()
+	}
+
+	return messages
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"main/internal/domain/models"
+	"main/internal/infrastructure/errs"
+	"main/internal/infrastructure/models/db"
+
+	"gorm.io/gorm"
+)
+
+type passesRepo struct {
+	db *gorm.DB
+}
+
+func NewPassesRepo(db *gorm.DB) *passesRepo {
+	return &passesRepo{
+		db: db,
+	}
+}
+
+func (r *passesRepo) Get(ctx context.Context, id int) (models.Pass, error) {
+	var SQLPass db.SQLPass
+
+	if err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Preload("Freezes").
+		First(&SQLPass).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Pass{}, errs.ErrNotFound
+		}
+
+		return models.Pass{}, fmt.Errorf("could not get pass %d: %w", id, err)
+	}
+
+	return SQLPass.ToDomain(), nil
+}
+
+func (r *passesRepo) List(ctx context.Context, email *string) ([]models.Pass, error) {
+	var SQLPasses []db.SQLPass
+
+	query := r.db.WithContext(ctx).Preload("Freezes").Order("created_at DESC")
+
+	if email != nil {
+		query = query.Where("email = ?", *email)
+	}
+
+	if err := query.Find(&SQLPasses).Error; err != nil {
+		return nil, fmt.Errorf("could not list passes: %w", err)
+	}
+
+	result := make([]models.Pass, len(SQLPasses))
+
+	for i, SQLPass := range SQLPasses {
+		result[i] = SQLPass.ToDomain()
+	}
+
+	return result, nil
+}
+
+func (r *passesRepo) ListByEmail(
+	ctx context.Context, email string, limit int,
+) ([]models.Pass, error) {
+	var SQLPasses []db.SQLPass
+
+	if err := r.db.WithContext(ctx).
+		Where("email = ?", email).
+		Order("created_at DESC").
+		Limit(limit).
+		Preload("Freezes").
+		Find(&SQLPasses).Error; err != nil {
+		return nil, fmt.Errorf("could not get passes for email %s: %w", email, err)
+	}
+
+	result := make([]models.Pass, len(SQLPasses))
+
+	for i, SQLPass := range SQLPasses {
+		result[i] = SQLPass.ToDomain()
+	}
+
+	return result, nil
+}
+
+func (r *passesRepo) Insert(ctx context.Context, pass models.Pass) (models.Pass, error) {
+	SQLPass := db.SQLPassFromDomain(pass)
+
+	if err := r.db.WithContext(ctx).Create(&SQLPass).Error; err != nil {
+		return models.Pass{}, fmt.Errorf("could not insert pass: %w", err)
+	}
+
+	return SQLPass.ToDomain(), nil
+}
+
+func (r *passesRepo) Update(ctx context.Context, id int, update map[string]any) error {
+	var SQLPass db.SQLPass
+
+	result := r.db.WithContext(ctx).
+		Model(&SQLPass).
+		Where("id = ?", id).
+		Updates(update)
+	if result.Error != nil {
+		return fmt.Errorf("could not update pass %d: %w", id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (r *passesRepo) InsertFreeze(
+	ctx context.Context, freeze models.PassFreeze,
+) (models.PassFreeze, error) {
+	SQLPassFreeze := db.SQLPassFreezeFromDomain(freeze)
+
+	if err := r.db.WithContext(ctx).Create(&SQLPassFreeze).Error; err != nil {
+		return models.PassFreeze{}, fmt.Errorf("could not insert freeze for pass %d: %w", freeze.PassID, err)
+	}
+
+	return SQLPassFreeze.ToDomain(), nil
+}
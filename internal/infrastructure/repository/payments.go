@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"main/internal/domain/models"
+	"main/internal/infrastructure/errs"
+	"main/internal/infrastructure/models/db"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type paymentsRepo struct {
+	db *gorm.DB
+}
+
+func NewPaymentsRepo(db *gorm.DB) *paymentsRepo {
+	return &paymentsRepo{
+		db: db,
+	}
+}
+
+func (r *paymentsRepo) Get(ctx context.Context, id uuid.UUID) (models.Payment, error) {
+	var sqlPayment db.SQLPayment
+
+	if err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&sqlPayment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Payment{}, errs.ErrNotFound
+		}
+
+		return models.Payment{}, fmt.Errorf("could not get payment %s: %w", id, err)
+	}
+
+	return sqlPayment.ToDomain(), nil
+}
+
+func (r *paymentsRepo) GetByProviderRef(ctx context.Context, providerRef string) (models.Payment, error) {
+	var sqlPayment db.SQLPayment
+
+	if err := r.db.WithContext(ctx).
+		Where("provider_ref = ?", providerRef).
+		First(&sqlPayment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Payment{}, errs.ErrNotFound
+		}
+
+		return models.Payment{}, fmt.Errorf("could not get payment by provider ref %s: %w", providerRef, err)
+	}
+
+	return sqlPayment.ToDomain(), nil
+}
+
+func (r *paymentsRepo) List(ctx context.Context) ([]models.Payment, error) {
+	var sqlPayments []db.SQLPayment
+
+	if err := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Find(&sqlPayments).Error; err != nil {
+		return nil, fmt.Errorf("could not list payments: %w", err)
+	}
+
+	payments := make([]models.Payment, len(sqlPayments))
+
+	for i, sqlPayment := range sqlPayments {
+		payments[i] = sqlPayment.ToDomain()
+	}
+
+	return payments, nil
+}
+
+func (r *paymentsRepo) Insert(ctx context.Context, payment models.Payment) error {
+	sqlPayment := db.SQLPaymentFromDomain(payment)
+
+	if err := r.db.WithContext(ctx).Create(&sqlPayment).Error; err != nil {
+		return fmt.Errorf("could not insert payment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *paymentsRepo) Update(ctx context.Context, id uuid.UUID, update map[string]any) error {
+	var sqlPayment db.SQLPayment
+
+	result := r.db.WithContext(ctx).
+		Model(&sqlPayment).
+		Where("id = ?", id).
+		Updates(update)
+	if result.Error != nil {
+		return fmt.Errorf("could not update payment %s: %w", id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (r *paymentsRepo) CountPendingDropIns(
+	ctx context.Context,
+	classID uuid.UUID,
+	email string,
+	since time.Time,
+) (int, error) {
+	var count int64
+
+	var sqlPayment db.SQLPayment
+
+	if err := r.db.WithContext(ctx).
+		Model(&sqlPayment).
+		Where("class_id = ? AND kind = ? AND status = ?", classID,
+			string(models.PaymentKindDropIn), string(models.PaymentPending)).
+		Where("email <> ? AND created_at > ?", email, since).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("could not count pending drop-ins for classID %s: %w", classID, err)
+	}
+
+	return int(count), nil
+}
+
+// UpdateStatus moves the payment from one status to another, ErrNoRowsAffected means it
+// is not in status from anymore, so each transition happens once.
+func (r *paymentsRepo) UpdateStatus(ctx context.Context, id uuid.UUID, from, to models.PaymentStatus) error {
+	var sqlPayment db.SQLPayment
+
+	update := map[string]any{"status": string(to)}
+
+	// an unfulfilled payment was paid as well, only the purchase could not be given
+	switch to {
+	case models.PaymentPaid, models.PaymentUnfulfilled:
+		update["paid_at"] = time.Now().UTC()
+	case models.PaymentPending:
+		update["paid_at"] = nil
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&sqlPayment).
+		Where("id = ? AND status = ?", id, string(from)).
+		Updates(update)
+	if result.Error != nil {
+		return fmt.Errorf("could not update payment %s status to %s: %w", id, to, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
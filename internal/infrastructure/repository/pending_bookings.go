@@ -1,14 +1,16 @@
-package sqlite
+package repository
 
 import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"main/internal/domain/models"
 	"main/internal/infrastructure/errs"
 	"main/internal/infrastructure/models/db"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -43,7 +45,7 @@ func (r *pendingBookingsRepo) GetByConfirmationToken(
 
 	if err := r.db.WithContext(ctx).
 		Where("confirmation_token = ?", token).
-		Preload("Class").
+		Preload("Class.Location").Preload("Class.Instructor").
 		First(&sqlPendingBooking).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return models.PendingBooking{}, errs.ErrNotFound
@@ -59,7 +61,7 @@ func (r *pendingBookingsRepo) List(ctx context.Context) ([]models.PendingBooking
 	var SQLPendingBookings []db.SQLPendingBooking
 
 	if err := r.db.WithContext(ctx).
-		Preload("Class").
+		Preload("Class.Location").Preload("Class.Instructor").
 		Find(&SQLPendingBookings).Error; err != nil {
 		return nil, fmt.Errorf("could not list all pending bookings: %w", err)
 	}
@@ -72,3 +74,33 @@ func (r *pendingBookingsRepo) List(ctx context.Context) ([]models.PendingBooking
 
 	return result, nil
 }
+
+func (r *pendingBookingsRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	var sqlPendingBooking db.SQLPendingBooking
+
+	result := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&sqlPendingBooking)
+	if result.Error != nil {
+		return fmt.Errorf("could not delete pending booking %v: %w", id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (r *pendingBookingsRepo) DeleteCreatedBefore(ctx context.Context, before time.Time) (int, error) {
+	var sqlPendingBooking db.SQLPendingBooking
+
+	result := r.db.WithContext(ctx).
+		Where("created_at < ?", before).
+		Delete(&sqlPendingBooking)
+	if result.Error != nil {
+		return 0, fmt.Errorf("could not delete pending bookings created before %v: %w", before, result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// uniqueViolationCode is the postgres SQLSTATE for unique_violation.
+const uniqueViolationCode = "23505"
+
+type dialect struct{}
+
+func NewDialect() *dialect {
+	return &dialect{}
+}
+
+func (d *dialect) Open(dsn string) gorm.Dialector {
+	return postgres.Open(dsn)
+}
+
+func (d *dialect) IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"main/internal/domain/models"
+	"main/internal/infrastructure/errs"
+	"main/internal/infrastructure/models/db"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type productsRepo struct {
+	db *gorm.DB
+}
+
+func NewProductsRepo(db *gorm.DB) *productsRepo {
+	return &productsRepo{
+		db: db,
+	}
+}
+
+func (r *productsRepo) Get(ctx context.Context, id uuid.UUID) (models.Product, error) {
+	var sqlProduct db.SQLProduct
+
+	if err := r.db.WithContext(ctx).First(&sqlProduct, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Product{}, errs.ErrNotFound
+		}
+
+		return models.Product{}, fmt.Errorf("could not get product %s: %w", id, err)
+	}
+
+	return sqlProduct.ToDomain(), nil
+}
+
+func (r *productsRepo) List(ctx context.Context) ([]models.Product, error) {
+	return r.list(r.db.WithContext(ctx))
+}
+
+func (r *productsRepo) ListActive(ctx context.Context) ([]models.Product, error) {
+	return r.list(r.db.WithContext(ctx).Where("active = ?", true))
+}
+
+// list orders the price list the way it is shown, drop-ins first and passes by size.
+func (r *productsRepo) list(query *gorm.DB) ([]models.Product, error) {
+	var sqlProducts []db.SQLProduct
+
+	if err := query.
+		Order("kind ASC, total_slots ASC, price ASC").
+		Find(&sqlProducts).Error; err != nil {
+		return nil, fmt.Errorf("could not list products: %w", err)
+	}
+
+	products := make([]models.Product, len(sqlProducts))
+
+	for i, sqlProduct := range sqlProducts {
+		products[i] = sqlProduct.ToDomain()
+	}
+
+	return products, nil
+}
+
+func (r *productsRepo) Insert(ctx context.Context, product models.Product) error {
+	sqlProduct := db.SQLProductFromDomain(product)
+
+	if err := r.db.WithContext(ctx).Create(&sqlProduct).Error; err != nil {
+		return fmt.Errorf("could not insert product: %w", err)
+	}
+
+	return nil
+}
+
+func (r *productsRepo) Update(ctx context.Context, product models.Product) error {
+	sqlProduct := db.SQLProductFromDomain(product)
+
+	result := r.db.WithContext(ctx).
+		Model(&sqlProduct).
+		Select("*").
+		Omit("created_at").
+		Updates(&sqlProduct)
+	if result.Error != nil {
+		return fmt.Errorf("could not update product %s: %w", product.ID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
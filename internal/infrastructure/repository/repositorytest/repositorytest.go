@@ -0,0 +1,111 @@
+// Package repositorytest gives service tests the real repositories on a migrated
+// SQLite database in the test's temporary directory.
+package repositorytest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"main/internal/domain/models"
+	"main/internal/domain/repositories"
+	"main/internal/infrastructure/migrations"
+	"main/internal/infrastructure/repository"
+	sqliteRepo "main/internal/infrastructure/repository/sqlite"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func OpenSQLite(t testing.TB) (repositories.Repositories, repositories.IUnitOfWork) {
+	t.Helper()
+
+	dialect := sqliteRepo.NewDialect()
+
+	database, err := gorm.Open(dialect.Open(filepath.Join(t.TempDir(), "yoga.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("could not open sqlite: %v", err)
+	}
+
+	migrator, err := migrations.NewMigrator(database, "sqlite")
+	if err != nil {
+		t.Fatalf("could not load migrations: %v", err)
+	}
+
+	if _, err = migrator.Up(context.Background()); err != nil {
+		t.Fatalf("could not migrate: %v", err)
+	}
+
+	return repository.NewRepositories(database, dialect), repository.NewUnitOfWork(database, dialect)
+}
+
+func InsertLocation(t testing.TB, repos repositories.Repositories) models.Location {
+	t.Helper()
+
+	now := time.Now().UTC()
+	location := models.Location{
+		ID:        uuid.New(),
+		Name:      "studio " + uuid.NewString(),
+		Address:   "Długa 1, Kraków",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := repos.Locations.Insert(context.Background(), location); err != nil {
+		t.Fatalf("could not insert location: %v", err)
+	}
+
+	return location
+}
+
+func InsertClass(
+	t testing.TB, repos repositories.Repositories, startTime time.Time, maxCapacity int,
+) models.Class {
+	t.Helper()
+
+	ctx := context.Background()
+	location := InsertLocation(t, repos)
+
+	classes, err := repos.Classes.Insert(ctx, []models.Class{{
+		ID:          uuid.New(),
+		StartTime:   startTime,
+		Duration:    time.Hour,
+		ClassLevel:  "beginner",
+		ClassName:   "hatha",
+		MaxCapacity: maxCapacity,
+		LocationID:  location.ID,
+	}})
+	if err != nil {
+		t.Fatalf("could not insert class: %v", err)
+	}
+
+	class, err := repos.Classes.Get(ctx, classes[0].ID)
+	if err != nil {
+		t.Fatalf("could not get class: %v", err)
+	}
+
+	return class
+}
+
+func InsertBooking(t testing.TB, repos repositories.Repositories, classID uuid.UUID, email string) uuid.UUID {
+	t.Helper()
+
+	id, err := repos.Bookings.Insert(context.Background(), models.Booking{
+		ID:                uuid.New(),
+		ClassID:           classID,
+		FirstName:         "Anna",
+		LastName:          "Kowalska",
+		Email:             email,
+		CreatedAt:         time.Now().UTC(),
+		ConfirmationToken: uuid.NewString(),
+	})
+	if err != nil {
+		t.Fatalf("could not insert booking: %v", err)
+	}
+
+	return id
+}
@@ -0,0 +1,28 @@
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type dialect struct{}
+
+func NewDialect() *dialect {
+	return &dialect{}
+}
+
+// Open takes the path of the database file.
+func (d *dialect) Open(dsn string) gorm.Dialector {
+	return sqlite.Open(dsn)
+}
+
+func (d *dialect) IsUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+
+	return errors.As(err, &sqliteErr) &&
+		sqliteErr.Code == sqlite3.ErrConstraint &&
+		sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
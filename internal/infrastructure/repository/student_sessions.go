@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"main/internal/domain/models"
+	"main/internal/infrastructure/errs"
+	"main/internal/infrastructure/models/db"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type studentSessionsRepo struct {
+	db *gorm.DB
+}
+
+func NewStudentSessionsRepo(db *gorm.DB) *studentSessionsRepo {
+	return &studentSessionsRepo{
+		db: db,
+	}
+}
+
+func (r *studentSessionsRepo) GetByToken(
+	ctx context.Context, token string,
+) (models.StudentSession, error) {
+	var sqlStudentSession db.SQLStudentSession
+
+	if err := r.db.WithContext(ctx).
+		Where("token = ?", token).
+		First(&sqlStudentSession).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.StudentSession{}, errs.ErrNotFound
+		}
+
+		return models.StudentSession{}, fmt.Errorf("could not get student session: %w", err)
+	}
+
+	return sqlStudentSession.ToDomain(), nil
+}
+
+func (r *studentSessionsRepo) Insert(ctx context.Context, session models.StudentSession) error {
+	sqlStudentSession := db.SQLStudentSessionFromDomain(session)
+
+	if err := r.db.WithContext(ctx).Create(&sqlStudentSession).Error; err != nil {
+		return fmt.Errorf("could not insert student session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *studentSessionsRepo) Update(
+	ctx context.Context, id uuid.UUID, update map[string]any,
+) error {
+	var sqlStudentSession db.SQLStudentSession
+
+	result := r.db.WithContext(ctx).
+		Model(&sqlStudentSession).
+		Where("id = ?", id).
+		Updates(update)
+	if result.Error != nil {
+		return fmt.Errorf("could not update student session %s: %w", id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (r *studentSessionsRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	var sqlStudentSession db.SQLStudentSession
+
+	result := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&sqlStudentSession)
+	if result.Error != nil {
+		return fmt.Errorf("could not delete student session %s: %w", id, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (r *studentSessionsRepo) DeleteExpiredBefore(ctx context.Context, before time.Time) (int, error) {
+	var sqlStudentSession db.SQLStudentSession
+
+	result := r.db.WithContext(ctx).
+		Where("expires_at < ?", before).
+		Delete(&sqlStudentSession)
+	if result.Error != nil {
+		return 0, fmt.Errorf("could not delete student sessions expired before %v: %w", before, result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
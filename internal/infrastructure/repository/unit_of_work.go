@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"main/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+type unitOfWork struct {
+	db      *gorm.DB
+	dialect Dialect
+}
+
+func NewUnitOfWork(db *gorm.DB, dialect Dialect) *unitOfWork {
+	return &unitOfWork{db: db, dialect: dialect}
+}
+
+func (u *unitOfWork) WithTransaction(
+	ctx context.Context,
+	fn func(repos repositories.Repositories) error, //nolint
+) error {
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositories(tx, u.dialect))
+	})
+	if err != nil {
+		return fmt.Errorf("transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+// NewRepositories returns every repository bound to db, which may be a transaction.
+func NewRepositories(db *gorm.DB, dialect Dialect) repositories.Repositories {
+	return repositories.Repositories{
+		PendingBookings: NewPendingBookingsRepo(db),
+		Bookings:        NewBookingsRepo(db),
+		Classes:         NewClassesRepo(db),
+		Passes:          NewPassesRepo(db),
+		Contacts:        NewContactsRepo(db, dialect),
+		Waitlist:        NewWaitlistRepo(db),
+		ClassSeries:     NewClassSeriesRepo(db),
+		Outbox:          NewOutboxRepo(db),
+		StudentSessions: NewStudentSessionsRepo(db),
+		JobRuns:         NewJobRunsRepo(db),
+		CalendarFeeds:   NewCalendarFeedsRepo(db),
+		Payments:        NewPaymentsRepo(db),
+		Products:        NewProductsRepo(db),
+		Locations:       NewLocationsRepo(db, dialect),
+		Instructors:     NewInstructorsRepo(db, dialect),
+		ClassTypes:      NewClassTypesRepo(db, dialect),
+	}
+}
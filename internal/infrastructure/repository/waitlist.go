@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"main/internal/domain/models"
+	"main/internal/infrastructure/errs"
+	"main/internal/infrastructure/models/db"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type waitlistRepo struct {
+	db *gorm.DB
+}
+
+func NewWaitlistRepo(db *gorm.DB) *waitlistRepo {
+	return &waitlistRepo{
+		db: db,
+	}
+}
+
+func (r *waitlistRepo) GetByClassIDAndEmail(
+	ctx context.Context,
+	classID uuid.UUID,
+	email string,
+) (models.WaitlistEntry, error) {
+	var sqlEntry db.SQLWaitlistEntry
+
+	if err := r.db.WithContext(ctx).
+		Where("class_id = ? AND email = ?", classID, email).
+		Preload("Class.Location").Preload("Class.Instructor").
+		First(&sqlEntry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.WaitlistEntry{}, errs.ErrNotFound
+		}
+
+		return models.WaitlistEntry{},
+			fmt.Errorf("could not get waitlist entry by email %s, classID %s: %w", email, classID, err)
+	}
+
+	return sqlEntry.ToDomain(), nil
+}
+
+func (r *waitlistRepo) ListByClassID(
+	ctx context.Context,
+	classID uuid.UUID,
+) ([]models.WaitlistEntry, error) {
+	var sqlEntries []db.SQLWaitlistEntry
+
+	if err := r.db.WithContext(ctx).
+		Where("class_id = ?", classID).
+		Preload("Class.Location").Preload("Class.Instructor").
+		Order("created_at ASC").
+		Find(&sqlEntries).Error; err != nil {
+		return nil, fmt.Errorf("could not list waitlist entries for classID %s: %w", classID, err)
+	}
+
+	result := make([]models.WaitlistEntry, len(sqlEntries))
+
+	for i, sqlEntry := range sqlEntries {
+		result[i] = sqlEntry.ToDomain()
+	}
+
+	return result, nil
+}
+
+func (r *waitlistRepo) ListClassIDsWithOffersBefore(
+	ctx context.Context,
+	before time.Time,
+) ([]uuid.UUID, error) {
+	var classIDs []uuid.UUID
+
+	var sqlEntry db.SQLWaitlistEntry
+
+	if err := r.db.WithContext(ctx).
+		Model(&sqlEntry).
+		Distinct("class_id").
+		Where("offered_at < ?", before).
+		Pluck("class_id", &classIDs).Error; err != nil {
+		return nil, fmt.Errorf("could not list classes with waitlist offers before %v: %w", before, err)
+	}
+
+	return classIDs, nil
+}
+
+func (r *waitlistRepo) CountOfferedSince(
+	ctx context.Context,
+	classID uuid.UUID,
+	since time.Time,
+) (int, error) {
+	var count int64
+
+	var sqlEntry db.SQLWaitlistEntry
+
+	if err := r.db.WithContext(ctx).
+		Model(&sqlEntry).
+		Where("class_id = ? AND offered_at > ?", classID, since).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("could not count waitlist offers for classID %s: %w", classID, err)
+	}
+
+	return int(count), nil
+}
+
+func (r *waitlistRepo) Insert(ctx context.Context, entry models.WaitlistEntry) error {
+	sqlEntry := db.SQLWaitlistEntryFromDomain(entry)
+
+	if err := r.db.WithContext(ctx).Create(&sqlEntry).Error; err != nil {
+		return fmt.Errorf("could not insert waitlist entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *waitlistRepo) Update(
+	ctx context.Context,
+	id uuid.UUID,
+	update map[string]any,
+) error {
+	var sqlEntry db.SQLWaitlistEntry
+
+	result := r.db.WithContext(ctx).
+		Model(&sqlEntry).
+		Where("id = ?", id).
+		Updates(update)
+
+	if result.Error != nil {
+		return fmt.Errorf("could not update waitlist entry: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (r *waitlistRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	var sqlEntry db.SQLWaitlistEntry
+
+	result := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&sqlEntry)
+	if result.Error != nil {
+		return fmt.Errorf("could not delete waitlist entry: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (r *waitlistRepo) DeleteByClassIDAndEmail(
+	ctx context.Context,
+	classID uuid.UUID,
+	email string,
+) error {
+	var sqlEntry db.SQLWaitlistEntry
+
+	if err := r.db.WithContext(ctx).
+		Where("class_id = ? AND email = ?", classID, email).
+		Delete(&sqlEntry).Error; err != nil {
+		return fmt.Errorf("could not delete waitlist entry for email %s, classID %s: %w", email, classID, err)
+	}
+
+	return nil
+}
+
+func (r *waitlistRepo) DeleteByClassID(ctx context.Context, classID uuid.UUID) error {
+	var sqlEntry db.SQLWaitlistEntry
+
+	if err := r.db.WithContext(ctx).
+		Where("class_id = ?", classID).
+		Delete(&sqlEntry).Error; err != nil {
+		return fmt.Errorf("could not delete waitlist entries for classID %s: %w", classID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"main/internal/domain/models"
+)
+
+type AttendanceURI struct {
+	ClassID   string `binding:"required,uuid" uri:"class_id"`
+	BookingID string `binding:"required,uuid" uri:"booking_id"`
+}
+
+type MarkAttendanceRequest struct {
+	Status string `binding:"required,oneof=attended no_show" json:"status"`
+}
+
+type WalkInURI struct {
+	ClassID string `binding:"required,uuid" uri:"class_id"`
+}
+
+type CreateWalkInRequest struct {
+	FirstName string `binding:"required,max=30" json:"first_name"`
+	LastName  string `binding:"required,max=30" json:"last_name"`
+	Email     string `binding:"required,email" json:"email"`
+}
+
+type ContactStatisticsURI struct {
+	Email string `binding:"required,email" uri:"email"`
+}
+
+type ContactStatisticsResponse struct {
+	Email             string  `json:"email"`
+	Confirmed         int     `json:"confirmed"`
+	Attended          int     `json:"attended"`
+	NoShows           int     `json:"no_shows"`
+	LateCancellations int     `json:"late_cancellations"`
+	Cancellations     int     `json:"cancellations"`
+	WalkIns           int     `json:"walk_ins"`
+	AttendanceRate    float64 `json:"attendance_rate"`
+}
+
+func ToContactStatisticsResponse(statistics models.ContactStatistics) ContactStatisticsResponse {
+	return ContactStatisticsResponse{
+		Email:             statistics.Email,
+		Confirmed:         statistics.Confirmed,
+		Attended:          statistics.Attended,
+		NoShows:           statistics.NoShows,
+		LateCancellations: statistics.LateCancellations,
+		Cancellations:     statistics.Cancellations,
+		WalkIns:           statistics.WalkIns,
+		AttendanceRate:    statistics.AttendanceRate(),
+	}
+}
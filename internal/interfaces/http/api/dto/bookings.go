@@ -11,6 +11,28 @@ import (
 	"github.com/google/uuid"
 )
 
+type ClassBookingsURI struct {
+	ClassID string `binding:"required,uuid" uri:"class_id"`
+}
+
+type BookingURI struct {
+	BookingID string `binding:"required,uuid" uri:"booking_id"`
+}
+
+// RescheduleBookingRequest moves a booking to another class, keeping its pass slot.
+type RescheduleBookingRequest struct {
+	ClassID string `binding:"required,uuid" json:"class_id"`
+}
+
+// CreateBookingRequest books a class without the email confirmation step, the student
+// still gets the confirmation email with the cancellation link unless it is skipped.
+type CreateBookingRequest struct {
+	FirstName        string `binding:"required,max=30" json:"first_name"`
+	LastName         string `binding:"required,max=30" json:"last_name"`
+	Email            string `binding:"required,email" json:"email"`
+	SkipConfirmation bool   `json:"skip_confirmation"`
+}
+
 type BookingResponse struct {
 	ID        uuid.UUID    `json:"id"`
 	ClassID   uuid.UUID    `json:"class_id"`
@@ -19,14 +41,16 @@ type BookingResponse struct {
 	FirstName string       `json:"first_name"`
 	LastName  string       `json:"last_name"`
 	Email     string       `json:"email"`
+	Status    string       `json:"status"`
+	WalkIn    bool         `json:"walk_in"`
 	CreatedAt time.Time    `json:"created_at"`
 	Class     dto.ClassDTO `json:"class"`
 }
 
 func ToBookingResponse(booking domainModels.Booking) (BookingResponse, error) {
-	createdAtWarsawTime, err := converter.ConvertToWarsawTime(booking.CreatedAt)
+	createdAt, err := converter.ConvertToStudioTime(booking.CreatedAt)
 	if err != nil {
-		return BookingResponse{}, fmt.Errorf("could not convert createdAt to warsaw time: %w", err)
+		return BookingResponse{}, fmt.Errorf("could not convert createdAt to studio time: %w", err)
 	}
 
 	class, err := dto.ToClassDTO(booking.Class)
@@ -40,7 +64,9 @@ func ToBookingResponse(booking domainModels.Booking) (BookingResponse, error) {
 		FirstName: booking.FirstName,
 		LastName:  booking.LastName,
 		Email:     booking.Email,
-		CreatedAt: createdAtWarsawTime,
+		Status:    string(booking.Status),
+		WalkIn:    booking.WalkIn,
+		CreatedAt: createdAt,
 		Class:     class,
 	}
 
@@ -0,0 +1,102 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"main/internal/domain/models"
+	"main/pkg/converter"
+
+	"github.com/google/uuid"
+)
+
+type CreateClassSeriesRequest struct {
+	Frequency      string      `binding:"required,oneof=weekly biweekly" json:"frequency"`
+	StartTime      time.Time   `binding:"required" json:"start_time"`
+	EndDate        *time.Time  `json:"end_date"`
+	ExceptionDates []time.Time `json:"exception_dates"`
+	ClassLevel     string      `binding:"required,min=3,max=40" json:"class_level"`
+	ClassName      string      `binding:"required,min=3,max=60" json:"class_name"`
+	MaxCapacity    int         `binding:"min=0" json:"max_capacity"`
+	LocationID     string      `binding:"required,uuid" json:"location_id"`
+	InstructorID   *string     `binding:"omitempty,uuid" json:"instructor_id"`
+}
+
+type UpdateClassSeriesRequest struct {
+	EndDate        *time.Time   `json:"end_date"`
+	ExceptionDates *[]time.Time `json:"exception_dates"`
+	ClassLevel     *string      `binding:"omitempty,min=3,max=40" json:"class_level"`
+	ClassName      *string      `binding:"omitempty,min=3,max=60" json:"class_name"`
+	MaxCapacity    *int         `binding:"omitempty,gte=1" json:"max_capacity"`
+	LocationID     *string      `binding:"omitempty,uuid" json:"location_id"`
+	InstructorID   *string      `binding:"omitempty,uuid" json:"instructor_id"`
+}
+
+type DeleteClassSeriesRequest struct {
+	Message *string `binding:"omitempty,min=1,max=250" json:"message"`
+}
+
+type ClassSeriesURI struct {
+	SeriesID string `binding:"required,uuid" uri:"series_id"`
+}
+
+type ClassSeriesResponse struct {
+	ID                uuid.UUID   `json:"id"`
+	Frequency         string      `json:"frequency"`
+	StartTime         time.Time   `json:"start_time"`
+	EndDate           *time.Time  `json:"end_date,omitempty"`
+	ExceptionDates    []time.Time `json:"exception_dates"`
+	ClassLevel        string      `json:"class_level"`
+	ClassName         string      `json:"class_name"`
+	MaxCapacity       int         `json:"max_capacity"`
+	LocationID        uuid.UUID   `json:"location_id"`
+	Location          string      `json:"location"`
+	InstructorID      *uuid.UUID  `json:"instructor_id,omitempty"`
+	MaterializedUntil *time.Time  `json:"materialized_until,omitempty"`
+}
+
+func ToClassSeriesResponse(series models.ClassSeries) (ClassSeriesResponse, error) {
+	startTime, err := converter.ConvertToLocationTime(series.StartTime, series.Location.TimeZone)
+	if err != nil {
+		return ClassSeriesResponse{}, fmt.Errorf("could not convert startTime to local time: %w", err)
+	}
+
+	exceptionDates := make([]time.Time, 0, len(series.ExceptionDates))
+	exceptionDates = append(exceptionDates, series.ExceptionDates...)
+
+	response := ClassSeriesResponse{
+		ID:                series.ID,
+		Frequency:         string(series.Frequency),
+		StartTime:         startTime,
+		EndDate:           series.EndDate,
+		ExceptionDates:    exceptionDates,
+		ClassLevel:        series.ClassLevel,
+		ClassName:         series.ClassName,
+		MaxCapacity:       series.MaxCapacity,
+		LocationID:        series.LocationID,
+		Location:          series.Location.Name,
+		MaterializedUntil: series.MaterializedUntil,
+	}
+
+	if series.InstructorID.Exists() {
+		instructorID := series.InstructorID.Get()
+		response.InstructorID = &instructorID
+	}
+
+	return response, nil
+}
+
+func ToClassSeriesListResponse(allSeries []models.ClassSeries) ([]ClassSeriesResponse, error) {
+	response := make([]ClassSeriesResponse, len(allSeries))
+
+	for idx, series := range allSeries {
+		seriesResponse, err := ToClassSeriesResponse(series)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert class series to response: %w", err)
+		}
+
+		response[idx] = seriesResponse
+	}
+
+	return response, nil
+}
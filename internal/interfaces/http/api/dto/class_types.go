@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type CreateClassTypeRequest struct {
+	Name                   string  `binding:"required,min=3,max=60" json:"name"`
+	Level                  string  `binding:"required,min=3,max=40" json:"level"`
+	Description            string  `binding:"max=2000" json:"description"`
+	DefaultDurationMinutes int     `binding:"min=0,max=600" json:"default_duration_minutes"`
+	DefaultCapacity        int     `binding:"min=0" json:"default_capacity"`
+	DefaultLocationID      *string `binding:"omitempty,uuid" json:"default_location_id"`
+}
+
+type UpdateClassTypeRequest struct {
+	Name                   *string `binding:"omitempty,min=3,max=60" json:"name"`
+	Level                  *string `binding:"omitempty,min=3,max=40" json:"level"`
+	Description            *string `binding:"omitempty,max=2000" json:"description"`
+	DefaultDurationMinutes *int    `binding:"omitempty,min=1,max=600" json:"default_duration_minutes"`
+	DefaultCapacity        *int    `binding:"omitempty,min=0" json:"default_capacity"`
+	DefaultLocationID      *string `binding:"omitempty,uuid" json:"default_location_id"`
+}
+
+type ClassTypeURI struct {
+	ClassTypeID string `binding:"required,uuid" uri:"class_type_id"`
+}
+
+type ClassTypeResponse struct {
+	ID                     uuid.UUID  `json:"id"`
+	Name                   string     `json:"name"`
+	Level                  string     `json:"level"`
+	Description            string     `json:"description"`
+	DefaultDurationMinutes int        `json:"default_duration_minutes"`
+	DefaultCapacity        int        `json:"default_capacity"`
+	DefaultLocationID      *uuid.UUID `json:"default_location_id,omitempty"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              time.Time  `json:"updated_at"`
+}
+
+func ToClassTypeResponse(classType models.ClassType) ClassTypeResponse {
+	response := ClassTypeResponse{
+		ID:                     classType.ID,
+		Name:                   classType.Name,
+		Level:                  classType.Level,
+		Description:            classType.Description,
+		DefaultDurationMinutes: int(classType.DefaultDuration / time.Minute),
+		DefaultCapacity:        classType.DefaultCapacity,
+		CreatedAt:              classType.CreatedAt,
+		UpdatedAt:              classType.UpdatedAt,
+	}
+
+	if classType.DefaultLocationID.Exists() {
+		locationID := classType.DefaultLocationID.Get()
+		response.DefaultLocationID = &locationID
+	}
+
+	return response
+}
+
+func ToClassTypesResponse(classTypes []models.ClassType) []ClassTypeResponse {
+	response := make([]ClassTypeResponse, len(classTypes))
+
+	for idx, classType := range classTypes {
+		response[idx] = ToClassTypeResponse(classType)
+	}
+
+	return response
+}
@@ -1,20 +1,34 @@
 package dto
 
 import (
+	"fmt"
 	"time"
+
+	"main/internal/domain/models"
+	"main/pkg/converter"
+
+	"github.com/google/uuid"
 )
 
+// CreateClassRequest with ClassTypeID takes the name and the level of the class type,
+// the fields left out take its defaults. Without MaxCapacity the class gets the default
+// capacity of the class type or else of the location.
 type CreateClassRequest struct {
-	StartTime   time.Time `binding:"required" json:"start_time"  time_format:"2006-01-02T15:04:05Z07:00"` //nolint
-	ClassLevel  string    `binding:"required,min=3,max=40" json:"class_level"`
-	ClassName   string    `binding:"required,min=3,max=60" json:"class_name"`
-	MaxCapacity int       `binding:"gte=1" json:"max_capacity"`
-	Location    string    `binding:"required" json:"location"`
+	StartTime       time.Time `binding:"required" json:"start_time"  time_format:"2006-01-02T15:04:05Z07:00"` //nolint
+	ClassTypeID     *string   `binding:"omitempty,uuid" json:"class_type_id"`
+	ClassLevel      string    `binding:"required_without=ClassTypeID,omitempty,min=3,max=40" json:"class_level"`
+	ClassName       string    `binding:"required_without=ClassTypeID,omitempty,min=3,max=60" json:"class_name"`
+	MaxCapacity     int       `binding:"min=0" json:"max_capacity"`
+	DurationMinutes int       `binding:"min=0,max=600" json:"duration_minutes"`
+	LocationID      *string   `binding:"required_without=ClassTypeID,omitempty,uuid" json:"location_id"`
+	InstructorID    *string   `binding:"omitempty,uuid" json:"instructor_id"`
 }
 
 type GetClassesRequest struct {
-	OnlyUpcomingClasses bool `json:"only_upcoming_classes"`
-	ClassesLimit        *int `json:"classes_limit"`
+	OnlyUpcomingClasses bool    `json:"only_upcoming_classes"`
+	ClassesLimit        *int    `json:"classes_limit"`
+	ClassTypeID         *string `binding:"omitempty,uuid" json:"class_type_id"`
+	ClassLevel          string  `binding:"max=40" json:"class_level"`
 }
 
 type DeleteClassRequest struct {
@@ -26,9 +40,82 @@ type UpdateClassRequest struct {
 	ClassLevel  *string    `json:"class_level"`
 	ClassName   *string    `json:"class_name"`
 	MaxCapacity *int       `json:"max_capacity"`
-	Location    *string    `json:"location"`
+	LocationID  *string    `binding:"omitempty,uuid" json:"location_id"`
+}
+
+type SubstituteInstructorRequest struct {
+	InstructorID string `binding:"required,uuid" json:"instructor_id"`
 }
 
 type UpdateClassURI struct {
 	ClassID string `binding:"required" uri:"class_id"`
 }
+
+// PublicClassesQuery is a range of days in the studio time zone, To included.
+type PublicClassesQuery struct {
+	From        string `binding:"omitempty,datetime=2006-01-02" form:"from"`
+	To          string `binding:"omitempty,datetime=2006-01-02" form:"to"`
+	ClassLevel  string `binding:"max=40" form:"level"`
+	ClassTypeID string `binding:"omitempty,uuid" form:"class_type"`
+	LocationID  string `binding:"omitempty,uuid" form:"location"`
+}
+
+// PublicClassDTO has StartTime in RFC 3339 with the offset of the time zone of its location.
+type PublicClassDTO struct {
+	ID              uuid.UUID  `json:"id"`
+	StartTime       string     `json:"start_time"`
+	DurationMinutes int        `json:"duration_minutes"`
+	ClassLevel      string     `json:"class_level"`
+	ClassName       string     `json:"class_name"`
+	ClassTypeID     *uuid.UUID `json:"class_type_id,omitempty"`
+	CurrentCapacity int        `json:"current_capacity"`
+	MaxCapacity     int        `json:"max_capacity"`
+	LocationID      uuid.UUID  `json:"location_id"`
+	Location        string     `json:"location"`
+	Address         string     `json:"address"`
+	MapURL          string     `json:"map_url"`
+	Instructor      string     `json:"instructor,omitempty"`
+}
+
+type PublicClassesResponse struct {
+	From    string           `json:"from"`
+	To      string           `json:"to"`
+	Classes []PublicClassDTO `json:"classes"`
+}
+
+func ToPublicClassesResponse(schedule models.PublicSchedule) (PublicClassesResponse, error) {
+	resp := PublicClassesResponse{
+		From:    schedule.FirstDay.Format(time.DateOnly),
+		To:      schedule.LastDay.Format(time.DateOnly),
+		Classes: make([]PublicClassDTO, len(schedule.Classes)),
+	}
+
+	for idx, class := range schedule.Classes {
+		startTime, err := converter.ConvertToLocationTime(class.StartTime, class.Location.TimeZone)
+		if err != nil {
+			return PublicClassesResponse{}, fmt.Errorf("could not convert class start time: %w", err)
+		}
+
+		resp.Classes[idx] = PublicClassDTO{
+			ID:              class.ID,
+			StartTime:       startTime.Format(time.RFC3339),
+			DurationMinutes: int(class.Duration / time.Minute),
+			ClassLevel:      class.ClassLevel,
+			ClassName:       class.ClassName,
+			CurrentCapacity: class.CurrentCapacity,
+			MaxCapacity:     class.MaxCapacity,
+			LocationID:      class.Location.ID,
+			Location:        class.Location.Name,
+			Address:         class.Location.Address,
+			MapURL:          class.Location.MapURL,
+			Instructor:      class.Instructor.Name,
+		}
+
+		if class.ClassTypeID.Exists() {
+			classTypeID := class.ClassTypeID.Get()
+			resp.Classes[idx].ClassTypeID = &classTypeID
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type CreateInstructorRequest struct {
+	Name      string `binding:"required,min=2,max=60" json:"name"`
+	Bio       string `binding:"max=1000" json:"bio"`
+	Email     string `binding:"required,email" json:"email"`
+	Signature string `binding:"max=60" json:"signature"`
+}
+
+type UpdateInstructorRequest struct {
+	Name      *string `binding:"omitempty,min=2,max=60" json:"name"`
+	Bio       *string `binding:"omitempty,max=1000" json:"bio"`
+	Email     *string `binding:"omitempty,email" json:"email"`
+	Signature *string `binding:"omitempty,max=60" json:"signature"`
+}
+
+type InstructorURI struct {
+	InstructorID string `binding:"required,uuid" uri:"instructor_id"`
+}
+
+type InstructorResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Bio       string    `json:"bio"`
+	Email     string    `json:"email"`
+	Signature string    `json:"signature"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func ToInstructorResponse(instructor models.Instructor) InstructorResponse {
+	return InstructorResponse{
+		ID:        instructor.ID,
+		Name:      instructor.Name,
+		Bio:       instructor.Bio,
+		Email:     instructor.Email,
+		Signature: instructor.Signature,
+		CreatedAt: instructor.CreatedAt,
+		UpdatedAt: instructor.UpdatedAt,
+	}
+}
+
+func ToInstructorsResponse(instructors []models.Instructor) []InstructorResponse {
+	response := make([]InstructorResponse, len(instructors))
+
+	for idx, instructor := range instructors {
+		response[idx] = ToInstructorResponse(instructor)
+	}
+
+	return response
+}
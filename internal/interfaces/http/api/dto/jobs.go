@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+)
+
+type JobStatusResponse struct {
+	Name      string     `json:"name"`
+	Interval  string     `json:"interval"`
+	LastRunAt *time.Time `json:"last_run_at"`
+	NextRunAt *time.Time `json:"next_run_at"`
+	LastError *string    `json:"last_error"`
+}
+
+func ToJobStatusesResponse(statuses []models.JobStatus) []JobStatusResponse {
+	response := make([]JobStatusResponse, len(statuses))
+
+	for idx, status := range statuses {
+		response[idx] = JobStatusResponse{
+			Name:      status.Name,
+			Interval:  status.Interval.String(),
+			LastRunAt: status.LastRunAt,
+			NextRunAt: status.NextRunAt,
+			LastError: status.LastError,
+		}
+	}
+
+	return response
+}
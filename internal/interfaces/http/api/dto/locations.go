@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type CreateLocationRequest struct {
+	Name            string `binding:"required,min=2,max=60" json:"name"`
+	Address         string `binding:"max=200" json:"address"`
+	Directions      string `binding:"max=500" json:"directions"`
+	MapURL          string `binding:"omitempty,url" json:"map_url"`
+	DefaultCapacity int    `binding:"min=0" json:"default_capacity"`
+	AccessNotes     string `binding:"max=500" json:"access_notes"`
+	TimeZone        string `binding:"omitempty,timezone" json:"time_zone"`
+}
+
+type UpdateLocationRequest struct {
+	Name            *string `binding:"omitempty,min=2,max=60" json:"name"`
+	Address         *string `binding:"omitempty,max=200" json:"address"`
+	Directions      *string `binding:"omitempty,max=500" json:"directions"`
+	MapURL          *string `binding:"omitempty,url" json:"map_url"`
+	DefaultCapacity *int    `binding:"omitempty,min=0" json:"default_capacity"`
+	AccessNotes     *string `binding:"omitempty,max=500" json:"access_notes"`
+	// an empty time zone puts the location back in the studio one
+	TimeZone *string `binding:"omitempty,timezone" json:"time_zone"`
+}
+
+type LocationURI struct {
+	LocationID string `binding:"required,uuid" uri:"location_id"`
+}
+
+type LocationResponse struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	Address         string    `json:"address"`
+	Directions      string    `json:"directions"`
+	MapURL          string    `json:"map_url"`
+	DefaultCapacity int       `json:"default_capacity"`
+	AccessNotes     string    `json:"access_notes"`
+	TimeZone        string    `json:"time_zone"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func ToLocationResponse(location models.Location) LocationResponse {
+	return LocationResponse{
+		ID:              location.ID,
+		Name:            location.Name,
+		Address:         location.Address,
+		Directions:      location.Directions,
+		MapURL:          location.MapURL,
+		DefaultCapacity: location.DefaultCapacity,
+		AccessNotes:     location.AccessNotes,
+		TimeZone:        location.TimeZone,
+		CreatedAt:       location.CreatedAt,
+		UpdatedAt:       location.UpdatedAt,
+	}
+}
+
+func ToLocationsResponse(locations []models.Location) []LocationResponse {
+	response := make([]LocationResponse, len(locations))
+
+	for idx, location := range locations {
+		response[idx] = ToLocationResponse(location)
+	}
+
+	return response
+}
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type ListOutboxRequest struct {
+	Status string `binding:"omitempty,oneof=pending sent dead" form:"status"`
+}
+
+type OutboxMessageResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	Kind           string     `json:"kind"`
+	RecipientEmail string     `json:"recipient_email"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastError      *string    `json:"last_error"`
+	CreatedAt      time.Time  `json:"created_at"`
+	SentAt         *time.Time `json:"sent_at"`
+}
+
+func ToOutboxMessagesResponse(messages []models.OutboxMessage) []OutboxMessageResponse {
+	response := make([]OutboxMessageResponse, len(messages))
+
+	for idx, message := range messages {
+		response[idx] = OutboxMessageResponse{
+			ID:             message.ID,
+			Kind:           string(message.Notification.Kind),
+			RecipientEmail: message.Notification.Params.RecipientEmail,
+			Status:         string(message.Status),
+			Attempts:       message.Attempts,
+			NextAttemptAt:  message.NextAttemptAt,
+			LastError:      message.LastError,
+			CreatedAt:      message.CreatedAt,
+			SentAt:         message.SentAt,
+		}
+	}
+
+	return response
+}
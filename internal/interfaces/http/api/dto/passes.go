@@ -10,10 +10,48 @@ import (
 	"github.com/google/uuid"
 )
 
+// ActivatePassRequest needs TotalSlots or a ProductID, the pass then takes its slots and validity.
 type ActivatePassRequest struct {
-	Email                string `binding:"required,min=3,max=40" json:"email"`
-	InitialAssignedSlots int    `binding:"min=0" json:"initial_assigned_slots"`
-	TotalSlots           int    `binding:"min=1" json:"total_slots"`
+	Email                string     `binding:"required,min=3,max=40" json:"email"`
+	InitialAssignedSlots int        `binding:"min=0" json:"initial_assigned_slots"`
+	TotalSlots           int        `binding:"min=0" json:"total_slots"`
+	ValidFrom            *time.Time `json:"valid_from"`
+	ValidUntil           *time.Time `json:"valid_until"`
+	ProductID            *string    `binding:"omitempty,uuid" json:"product_id"`
+}
+
+var passSlotStatusNames = map[models.PassSlotStatus]string{
+	models.Blank:         "blank",
+	models.Past:          "past",
+	models.Future:        "future",
+	models.LateCancelled: "late_cancelled",
+	models.NoShow:        "no_show",
+}
+
+type PassURI struct {
+	PassID int `binding:"required,min=1" uri:"pass_id"`
+}
+
+type ListPassesRequest struct {
+	Email         *string `binding:"omitempty,email" form:"email"`
+	Active        *bool   `form:"active"`
+	Exhausted     *bool   `form:"exhausted"`
+	WithFreeSlots *bool   `form:"with_free_slots"`
+	ProductID     *string `binding:"omitempty,uuid" form:"product_id"`
+}
+
+type ContactPassesURI struct {
+	Email string `binding:"required,email" uri:"email"`
+}
+
+type ExtendPassRequest struct {
+	Days int `binding:"required,min=1,max=365" json:"days"`
+}
+
+type FreezePassRequest struct {
+	StartsAt time.Time `binding:"required" json:"starts_at"`
+	EndsAt   time.Time `binding:"required" json:"ends_at"`
+	Reason   string    `binding:"max=250" json:"reason"`
 }
 
 type ActivatePassResponse struct {
@@ -22,33 +60,184 @@ type ActivatePassResponse struct {
 }
 
 type PassDTO struct {
-	ID         int       `json:"id"`
-	Email      string    `json:"email"`
-	TotalSlots int       `json:"total_slots"`
-	UpdatedAt  time.Time `json:"updated_at"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID         int             `json:"id"`
+	Email      string          `json:"email"`
+	TotalSlots int             `json:"total_slots"`
+	ValidFrom  time.Time       `json:"valid_from"`
+	ValidUntil *time.Time      `json:"valid_until,omitempty"`
+	ProductID  *uuid.UUID      `json:"product_id,omitempty"`
+	Freezes    []PassFreezeDTO `json:"freezes"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type PassFreezeDTO struct {
+	ID       int       `json:"id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+type PassSlotDTO struct {
+	Status         string     `json:"status"`
+	ClassStartTime *time.Time `json:"class_start_time,omitempty"`
+}
+
+type PassBookingDTO struct {
+	ID        uuid.UUID `json:"id"`
+	ClassID   uuid.UUID `json:"class_id"`
+	ClassName string    `json:"class_name"`
+	StartTime time.Time `json:"start_time"`
+}
+
+type PassResponse struct {
+	PassDTO
+	UsedSlots      int              `json:"used_slots"`
+	RemainingSlots int              `json:"remaining_slots"`
+	Expired        bool             `json:"expired"`
+	Frozen         bool             `json:"frozen"`
+	Slots          []PassSlotDTO    `json:"slots"`
+	Bookings       []PassBookingDTO `json:"bookings"`
 }
 
 func ToPassDTO(pass models.Pass) (PassDTO, error) {
-	cratedAtWarsawTime, err := converter.ConvertToWarsawTime(pass.CreatedAt)
+	createdAt, err := converter.ConvertToStudioTime(pass.CreatedAt)
+	if err != nil {
+		return PassDTO{}, fmt.Errorf("error while converting createdAt to studio time: %w", err)
+	}
+
+	updatedAt, err := converter.ConvertToStudioTime(pass.UpdatedAt)
 	if err != nil {
-		return PassDTO{}, fmt.Errorf("error while converting createdAt to warsaw time: %w", err)
+		return PassDTO{}, fmt.Errorf("error while converting createdAt to studio time: %w", err)
 	}
 
-	updatedAtWarsawTime, err := converter.ConvertToWarsawTime(pass.UpdatedAt)
+	validFrom, err := converter.ConvertToStudioTime(pass.ValidFrom)
 	if err != nil {
-		return PassDTO{}, fmt.Errorf("error while converting createdAt to warsaw time: %w", err)
+		return PassDTO{}, fmt.Errorf("error while converting validFrom to studio time: %w", err)
+	}
+
+	var validUntil *time.Time
+
+	if pass.ValidUntil != nil {
+		studioValidUntil, err := converter.ConvertToStudioTime(*pass.ValidUntil)
+		if err != nil {
+			return PassDTO{}, fmt.Errorf("error while converting validUntil to studio time: %w", err)
+		}
+
+		validUntil = &studioValidUntil
+	}
+
+	freezes := make([]PassFreezeDTO, 0, len(pass.Freezes))
+
+	for _, freeze := range pass.Freezes {
+		startsAt, err := converter.ConvertToStudioTime(freeze.StartsAt)
+		if err != nil {
+			return PassDTO{}, fmt.Errorf("error while converting freeze startsAt to studio time: %w", err)
+		}
+
+		endsAt, err := converter.ConvertToStudioTime(freeze.EndsAt)
+		if err != nil {
+			return PassDTO{}, fmt.Errorf("error while converting freeze endsAt to studio time: %w", err)
+		}
+
+		freezes = append(freezes, PassFreezeDTO{
+			ID:       freeze.ID,
+			StartsAt: startsAt,
+			EndsAt:   endsAt,
+			Reason:   freeze.Reason,
+		})
 	}
 
-	return PassDTO{
+	passDTO := PassDTO{
 		ID:         pass.ID,
 		Email:      pass.Email,
 		TotalSlots: pass.TotalSlots,
-		UpdatedAt:  updatedAtWarsawTime,
-		CreatedAt:  cratedAtWarsawTime,
+		ValidFrom:  validFrom,
+		ValidUntil: validUntil,
+		Freezes:    freezes,
+		UpdatedAt:  updatedAt,
+		CreatedAt:  createdAt,
+	}
+
+	if pass.ProductID.Exists() {
+		productID := pass.ProductID.Get()
+		passDTO.ProductID = &productID
+	}
+
+	return passDTO, nil
+}
+
+func ToPassResponse(pass models.PassWithSlots) (PassResponse, error) {
+	passDTO, err := ToPassDTO(pass.Pass)
+	if err != nil {
+		return PassResponse{}, fmt.Errorf("error PassDTO cration failed: %w", err)
+	}
+
+	slots := make([]PassSlotDTO, 0, len(pass.Slots))
+
+	for _, slot := range pass.Slots {
+		slotDTO := PassSlotDTO{
+			Status: passSlotStatusNames[slot.Status],
+		}
+
+		if slot.ClassStartTime != nil {
+			classStartTime, err := converter.ConvertToStudioTime(*slot.ClassStartTime)
+			if err != nil {
+				return PassResponse{}, fmt.Errorf("error while converting slot time to studio time: %w", err)
+			}
+
+			slotDTO.ClassStartTime = &classStartTime
+		}
+
+		slots = append(slots, slotDTO)
+	}
+
+	bookings := make([]PassBookingDTO, 0, len(pass.Bookings))
+
+	for _, booking := range pass.Bookings {
+		startTime, err := converter.ConvertToLocationTime(
+			booking.Class.StartTime, booking.Class.Location.TimeZone,
+		)
+		if err != nil {
+			return PassResponse{}, fmt.Errorf("error while converting class start time to local time: %w", err)
+		}
+
+		bookings = append(bookings, PassBookingDTO{
+			ID:        booking.ID,
+			ClassID:   booking.ClassID,
+			ClassName: booking.Class.ClassName,
+			StartTime: startTime,
+		})
+	}
+
+	now := time.Now()
+
+	return PassResponse{
+		PassDTO:        passDTO,
+		UsedSlots:      pass.UsedSlots(),
+		RemainingSlots: pass.RemainingSlots(),
+		Expired:        pass.Pass.IsExpiredAt(now),
+		Frozen:         pass.Pass.IsFrozenAt(now),
+		Slots:          slots,
+		Bookings:       bookings,
 	}, nil
 }
 
+func ToPassListResponse(passes []models.PassWithSlots) ([]PassResponse, error) {
+	response := make([]PassResponse, len(passes))
+
+	for idx, pass := range passes {
+		passResponse, err := ToPassResponse(pass)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert pass %d: %w", pass.Pass.ID, err)
+		}
+
+		response[idx] = passResponse
+	}
+
+	return response, nil
+}
+
 func ToPassActivationResp(passActivation models.PassActivation) (ActivatePassResponse, error) {
 	passDTO, err := ToPassDTO(passActivation.Pass)
 	if err != nil {
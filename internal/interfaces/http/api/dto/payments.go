@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type PaymentResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Kind          string     `json:"kind"`
+	Status        string     `json:"status"`
+	Provider      string     `json:"provider"`
+	ProviderRef   string     `json:"provider_ref"`
+	Email         string     `json:"email"`
+	ClassID       *uuid.UUID `json:"class_id"`
+	TotalSlots    int        `json:"total_slots,omitempty"`
+	ProductID     *uuid.UUID `json:"product_id"`
+	Amount        int        `json:"amount"`
+	Currency      string     `json:"currency"`
+	PassID        *int       `json:"pass_id"`
+	BookingID     *uuid.UUID `json:"booking_id"`
+	FailureReason *string    `json:"failure_reason"`
+	CreatedAt     time.Time  `json:"created_at"`
+	PaidAt        *time.Time `json:"paid_at"`
+}
+
+func ToPaymentsResponse(payments []models.Payment) []PaymentResponse {
+	response := make([]PaymentResponse, len(payments))
+
+	for idx, payment := range payments {
+		response[idx] = PaymentResponse{
+			ID:            payment.ID,
+			Kind:          string(payment.Kind),
+			Status:        string(payment.Status),
+			Provider:      payment.Provider,
+			ProviderRef:   payment.ProviderRef,
+			Email:         payment.Email,
+			TotalSlots:    payment.TotalSlots,
+			Amount:        payment.Amount,
+			Currency:      payment.Currency,
+			FailureReason: payment.FailureReason,
+			CreatedAt:     payment.CreatedAt,
+			PaidAt:        payment.PaidAt,
+		}
+
+		if payment.ClassID.Exists() {
+			classID := payment.ClassID.Get()
+			response[idx].ClassID = &classID
+		}
+
+		if payment.ProductID.Exists() {
+			productID := payment.ProductID.Get()
+			response[idx].ProductID = &productID
+		}
+
+		if payment.PassID.Exists() {
+			passID := payment.PassID.Get()
+			response[idx].PassID = &passID
+		}
+
+		if payment.BookingID.Exists() {
+			bookingID := payment.BookingID.Get()
+			response[idx].BookingID = &bookingID
+		}
+	}
+
+	return response
+}
@@ -23,9 +23,9 @@ type PendingBookingResponse struct {
 func ToPendingBookingResponse(
 	pendingBooking models.PendingBooking,
 ) (PendingBookingResponse, error) {
-	createdAtWarsawTime, err := converter.ConvertToWarsawTime(pendingBooking.CreatedAt)
+	createdAt, err := converter.ConvertToStudioTime(pendingBooking.CreatedAt)
 	if err != nil {
-		return PendingBookingResponse{}, fmt.Errorf("could not convert createdAt to warsaw time: %w", err)
+		return PendingBookingResponse{}, fmt.Errorf("could not convert createdAt to studio time: %w", err)
 	}
 
 	return PendingBookingResponse{
@@ -35,7 +35,7 @@ func ToPendingBookingResponse(
 		FirstName: pendingBooking.FirstName,
 		LastName:  pendingBooking.LastName,
 		Email:     pendingBooking.Email,
-		CreatedAt: createdAtWarsawTime,
+		CreatedAt: createdAt,
 	}, nil
 }
 
@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"time"
+
+	"main/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// CreateProductRequest prices are in the smallest unit of Currency, e.g. 4000 PLN is 40.00 PLN.
+type CreateProductRequest struct {
+	Name         string `binding:"required,min=3,max=60" json:"name"`
+	Kind         string `binding:"required,oneof=drop_in pass" json:"kind"`
+	TotalSlots   int    `binding:"min=0" json:"total_slots"`
+	ValidityDays int    `binding:"min=0" json:"validity_days"`
+	Price        int    `binding:"min=0" json:"price"`
+	Currency     string `binding:"required,len=3" json:"currency"`
+}
+
+type UpdateProductRequest struct {
+	Name         *string `binding:"omitempty,min=3,max=60" json:"name"`
+	ValidityDays *int    `binding:"omitempty,min=0" json:"validity_days"`
+	Price        *int    `binding:"omitempty,min=0" json:"price"`
+	Currency     *string `binding:"omitempty,len=3" json:"currency"`
+	Active       *bool   `json:"active"`
+}
+
+// ListProductsRequest lists the products no longer sold as well, unless Active is set.
+type ListProductsRequest struct {
+	Active bool `form:"active"`
+}
+
+type ProductURI struct {
+	ProductID string `binding:"required,uuid" uri:"product_id"`
+}
+
+type ProductResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Kind         string    `json:"kind"`
+	TotalSlots   int       `json:"total_slots"`
+	ValidityDays int       `json:"validity_days"`
+	Price        int       `json:"price"`
+	Currency     string    `json:"currency"`
+	Active       bool      `json:"active"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func ToProductResponse(product models.Product) ProductResponse {
+	return ProductResponse{
+		ID:           product.ID,
+		Name:         product.Name,
+		Kind:         string(product.Kind),
+		TotalSlots:   product.TotalSlots,
+		ValidityDays: product.ValidityDays,
+		Price:        product.Price,
+		Currency:     product.Currency,
+		Active:       product.Active,
+		CreatedAt:    product.CreatedAt,
+		UpdatedAt:    product.UpdatedAt,
+	}
+}
+
+func ToProductsResponse(products []models.Product) []ProductResponse {
+	response := make([]ProductResponse, len(products))
+
+	for idx, product := range products {
+		response[idx] = ToProductResponse(product)
+	}
+
+	return response
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"main/internal/domain/models"
+	"main/pkg/converter"
+
+	"github.com/google/uuid"
+)
+
+type WaitlistEntryResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	ClassID   uuid.UUID  `json:"class_id"`
+	FirstName string     `json:"first_name"`
+	LastName  string     `json:"last_name"`
+	Email     string     `json:"email"`
+	CreatedAt time.Time  `json:"created_at"`
+	OfferedAt *time.Time `json:"offered_at,omitempty"`
+}
+
+func ToWaitlistEntryResponse(entry models.WaitlistEntry) (WaitlistEntryResponse, error) {
+	createdAt, err := converter.ConvertToStudioTime(entry.CreatedAt)
+	if err != nil {
+		return WaitlistEntryResponse{}, fmt.Errorf("could not convert createdAt to studio time: %w", err)
+	}
+
+	resp := WaitlistEntryResponse{
+		ID:        entry.ID,
+		ClassID:   entry.ClassID,
+		FirstName: entry.FirstName,
+		LastName:  entry.LastName,
+		Email:     entry.Email,
+		CreatedAt: createdAt,
+	}
+
+	if entry.OfferedAt != nil {
+		offeredAt, err := converter.ConvertToStudioTime(*entry.OfferedAt)
+		if err != nil {
+			return WaitlistEntryResponse{}, fmt.Errorf("could not convert offeredAt to studio time: %w", err)
+		}
+
+		resp.OfferedAt = &offeredAt
+	}
+
+	return resp, nil
+}
+
+func ToWaitlistResponse(entries []models.WaitlistEntry) ([]WaitlistEntryResponse, error) {
+	waitlistResponse := make([]WaitlistEntryResponse, len(entries))
+
+	for idx, entry := range entries {
+		resp, err := ToWaitlistEntryResponse(entry)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert waitlist entry to response: %w", err)
+		}
+
+		waitlistResponse[idx] = resp
+	}
+
+	return waitlistResponse, nil
+}
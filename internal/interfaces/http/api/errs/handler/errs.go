@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	domainErrs "main/internal/domain/errs/api"
+	viewErrs "main/internal/domain/errs/view"
 
 	"github.com/gin-gonic/gin"
 )
@@ -32,5 +33,27 @@ func (e errorHandler) Handle(ctx *gin.Context, err error) {
 		return
 	}
 
+	// services shared with the pages report student facing errors
+	var businessError *viewErrs.BusinessError
+	if errors.As(err, &businessError) {
+		switch businessError.Code {
+		case viewErrs.BookingNotFoundCode,
+			viewErrs.PendingBookingNotFoundCode,
+			viewErrs.CalendarFeedNotFoundCode,
+			viewErrs.ClassNotFoundCode:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case viewErrs.BookingAlreadyExistsCode,
+			viewErrs.ClassExpiredCode,
+			viewErrs.ClassFullyBookedCode,
+			viewErrs.SomeoneBookedClassFasterCode,
+			viewErrs.AlreadyOnWaitlistCode:
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+
+		return
+	}
+
 	ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 }
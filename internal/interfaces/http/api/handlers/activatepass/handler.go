@@ -7,8 +7,10 @@ import (
 	"main/internal/domain/services"
 	"main/internal/interfaces/http/api/dto"
 	apiErrs "main/internal/interfaces/http/api/errs"
+	"main/pkg/optional"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type handler struct {
@@ -40,6 +42,19 @@ func (h *handler) Handle(ginCtx *gin.Context) {
 		Email:                dtoActivatePassRequest.Email,
 		InitialAssignedSlots: dtoActivatePassRequest.InitialAssignedSlots,
 		TotalSlots:           dtoActivatePassRequest.TotalSlots,
+		ValidFrom:            dtoActivatePassRequest.ValidFrom,
+		ValidUntil:           dtoActivatePassRequest.ValidUntil,
+	}
+
+	if dtoActivatePassRequest.ProductID != nil {
+		productID, err := uuid.Parse(*dtoActivatePassRequest.ProductID)
+		if err != nil {
+			ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		params.ProductID = optional.Of(productID)
 	}
 
 	ctx := ginCtx.Request.Context()
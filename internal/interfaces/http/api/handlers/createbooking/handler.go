@@ -0,0 +1,81 @@
+package createbooking
+
+import (
+	"net/http"
+	"strings"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	bookingsService services.IBookingsService
+	apiErrorHandler apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	bookingsService services.IBookingsService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		bookingsService: bookingsService,
+		apiErrorHandler: apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.CreateBookingRequest
+
+	err := ginCtx.ShouldBindJSON(&request)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var uri dto.ClassBookingsURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	classID, err := uuid.Parse(uri.ClassID)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	params := models.ConfirmedBookingParams{
+		ClassID:          classID,
+		FirstName:        request.FirstName,
+		LastName:         request.LastName,
+		Email:            strings.ToLower(request.Email),
+		SendConfirmation: !request.SkipConfirmation,
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	booking, err := h.bookingsService.CreateConfirmedBooking(ctx, params)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	resp, err := dto.ToBookingResponse(booking)
+	if err != nil {
+		ginCtx.JSON(http.StatusInternalServerError, gin.H{"error": "DTOResponse: " + err.Error()})
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusCreated, resp)
+}
@@ -2,12 +2,14 @@ package createclasses
 
 import (
 	"net/http"
+	"time"
 
 	"main/internal/domain/models"
 	"main/internal/domain/services"
 	"main/internal/interfaces/http/api/dto"
 	apiErrs "main/internal/interfaces/http/api/errs"
 	sharedDTO "main/internal/interfaces/http/shared/dto"
+	"main/pkg/optional"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -47,7 +49,40 @@ func (h *handler) Handle(ginCtx *gin.Context) {
 			ClassLevel:  dtoClass.ClassLevel,
 			ClassName:   dtoClass.ClassName,
 			MaxCapacity: dtoClass.MaxCapacity,
-			Location:    dtoClass.Location,
+			Duration:    time.Duration(dtoClass.DurationMinutes) * time.Minute,
+		}
+
+		if dtoClass.LocationID != nil {
+			locationID, err := uuid.Parse(*dtoClass.LocationID)
+			if err != nil {
+				ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+				return
+			}
+
+			class.LocationID = locationID
+		}
+
+		if dtoClass.ClassTypeID != nil {
+			classTypeID, err := uuid.Parse(*dtoClass.ClassTypeID)
+			if err != nil {
+				ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+				return
+			}
+
+			class.ClassTypeID = optional.Of(classTypeID)
+		}
+
+		if dtoClass.InstructorID != nil {
+			instructorID, err := uuid.Parse(*dtoClass.InstructorID)
+			if err != nil {
+				ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+				return
+			}
+
+			class.InstructorID = optional.Of(instructorID)
 		}
 
 		classes = append(classes, class)
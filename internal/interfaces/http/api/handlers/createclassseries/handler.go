@@ -0,0 +1,88 @@
+package createclassseries
+
+import (
+	"net/http"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+	"main/pkg/optional"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	classSeriesService services.IClassSeriesService
+	apiErrorHandler    apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	classSeriesService services.IClassSeriesService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		classSeriesService: classSeriesService,
+		apiErrorHandler:    apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.CreateClassSeriesRequest
+
+	err := ginCtx.ShouldBindJSON(&request)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	locationID, err := uuid.Parse(request.LocationID)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	series := models.ClassSeries{
+		ID:             uuid.New(),
+		Frequency:      models.ClassSeriesFrequency(request.Frequency),
+		StartTime:      request.StartTime.UTC(),
+		EndDate:        request.EndDate,
+		ExceptionDates: request.ExceptionDates,
+		ClassLevel:     request.ClassLevel,
+		ClassName:      request.ClassName,
+		MaxCapacity:    request.MaxCapacity,
+		LocationID:     locationID,
+	}
+
+	if request.InstructorID != nil {
+		instructorID, err := uuid.Parse(*request.InstructorID)
+		if err != nil {
+			ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		series.InstructorID = optional.Of(instructorID)
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	createdSeries, err := h.classSeriesService.CreateClassSeries(ctx, series)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	resp, err := dto.ToClassSeriesResponse(createdSeries)
+	if err != nil {
+		ginCtx.JSON(http.StatusInternalServerError, gin.H{"error": "DTOResponse: " + err.Error()})
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusCreated, resp)
+}
@@ -0,0 +1,70 @@
+package createclasstype
+
+import (
+	"net/http"
+	"time"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+	"main/pkg/optional"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	classTypesService services.IClassTypesService
+	apiErrorHandler   apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	classTypesService services.IClassTypesService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		classTypesService: classTypesService,
+		apiErrorHandler:   apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.CreateClassTypeRequest
+
+	if err := ginCtx.ShouldBindJSON(&request); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	params := models.ClassTypeParams{
+		Name:            request.Name,
+		Level:           request.Level,
+		Description:     request.Description,
+		DefaultDuration: time.Duration(request.DefaultDurationMinutes) * time.Minute,
+		DefaultCapacity: request.DefaultCapacity,
+	}
+
+	if request.DefaultLocationID != nil {
+		locationID, err := uuid.Parse(*request.DefaultLocationID)
+		if err != nil {
+			ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		params.DefaultLocationID = optional.Of(locationID)
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	classType, err := h.classTypesService.CreateClassType(ctx, params)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusCreated, dto.ToClassTypeResponse(classType))
+}
@@ -0,0 +1,55 @@
+package createinstructor
+
+import (
+	"net/http"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	instructorsService services.IInstructorsService
+	apiErrorHandler    apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	instructorsService services.IInstructorsService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		instructorsService: instructorsService,
+		apiErrorHandler:    apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.CreateInstructorRequest
+
+	if err := ginCtx.ShouldBindJSON(&request); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	params := models.InstructorParams{
+		Name:      request.Name,
+		Bio:       request.Bio,
+		Email:     request.Email,
+		Signature: request.Signature,
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	instructor, err := h.instructorsService.CreateInstructor(ctx, params)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusCreated, dto.ToInstructorResponse(instructor))
+}
@@ -0,0 +1,58 @@
+package createlocation
+
+import (
+	"net/http"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	locationsService services.ILocationsService
+	apiErrorHandler  apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	locationsService services.ILocationsService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		locationsService: locationsService,
+		apiErrorHandler:  apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.CreateLocationRequest
+
+	if err := ginCtx.ShouldBindJSON(&request); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	params := models.LocationParams{
+		Name:            request.Name,
+		Address:         request.Address,
+		Directions:      request.Directions,
+		MapURL:          request.MapURL,
+		DefaultCapacity: request.DefaultCapacity,
+		AccessNotes:     request.AccessNotes,
+		TimeZone:        request.TimeZone,
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	location, err := h.locationsService.CreateLocation(ctx, params)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusCreated, dto.ToLocationResponse(location))
+}
@@ -0,0 +1,57 @@
+package createproduct
+
+import (
+	"net/http"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	productsService services.IProductsService
+	apiErrorHandler apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	productsService services.IProductsService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		productsService: productsService,
+		apiErrorHandler: apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.CreateProductRequest
+
+	if err := ginCtx.ShouldBindJSON(&request); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	params := models.ProductParams{
+		Name:         request.Name,
+		Kind:         models.ProductKind(request.Kind),
+		TotalSlots:   request.TotalSlots,
+		ValidityDays: request.ValidityDays,
+		Price:        request.Price,
+		Currency:     request.Currency,
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	product, err := h.productsService.CreateProduct(ctx, params)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusCreated, dto.ToProductResponse(product))
+}
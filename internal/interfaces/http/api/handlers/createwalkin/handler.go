@@ -0,0 +1,79 @@
+package createwalkin
+
+import (
+	"net/http"
+	"strings"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	attendanceService services.IAttendanceService
+	apiErrorHandler   apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	attendanceService services.IAttendanceService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		attendanceService: attendanceService,
+		apiErrorHandler:   apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.CreateWalkInRequest
+
+	err := ginCtx.ShouldBindJSON(&request)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var uri dto.WalkInURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	classID, err := uuid.Parse(uri.ClassID)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	params := models.WalkInParams{
+		FirstName: request.FirstName,
+		LastName:  request.LastName,
+		Email:     strings.ToLower(request.Email),
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	booking, err := h.attendanceService.AddWalkIn(ctx, classID, params)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	resp, err := dto.ToBookingResponse(booking)
+	if err != nil {
+		ginCtx.JSON(http.StatusInternalServerError, gin.H{"error": "DTOResponse: " + err.Error()})
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusCreated, resp)
+}
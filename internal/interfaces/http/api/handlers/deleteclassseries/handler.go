@@ -0,0 +1,56 @@
+package deleteclassseries
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	classSeriesService services.IClassSeriesService
+	apiErrorHandler    apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	classSeriesService services.IClassSeriesService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		classSeriesService: classSeriesService,
+		apiErrorHandler:    apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.DeleteClassSeriesRequest
+
+	err := ginCtx.ShouldBindJSON(&request)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	seriesID, err := uuid.Parse(ginCtx.Param("series_id"))
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	err = h.classSeriesService.DeleteClassSeries(ctx, seriesID, request.Message)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, gin.H{"series_id": seriesID})
+}
@@ -0,0 +1,55 @@
+package deleteclasstype
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	classTypesService services.IClassTypesService
+	apiErrorHandler   apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	classTypesService services.IClassTypesService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		classTypesService: classTypesService,
+		apiErrorHandler:   apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var uri dto.ClassTypeURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	classTypeID, err := uuid.Parse(uri.ClassTypeID)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	err = h.classTypesService.DeleteClassType(ctx, classTypeID)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, gin.H{"class_type_id": classTypeID})
+}
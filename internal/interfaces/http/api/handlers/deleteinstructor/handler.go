@@ -0,0 +1,55 @@
+package deleteinstructor
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	instructorsService services.IInstructorsService
+	apiErrorHandler    apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	instructorsService services.IInstructorsService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		instructorsService: instructorsService,
+		apiErrorHandler:    apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var uri dto.InstructorURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	instructorID, err := uuid.Parse(uri.InstructorID)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	err = h.instructorsService.DeleteInstructor(ctx, instructorID)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, gin.H{"instructor_id": instructorID})
+}
@@ -0,0 +1,55 @@
+package deletelocation
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	locationsService services.ILocationsService
+	apiErrorHandler  apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	locationsService services.ILocationsService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		locationsService: locationsService,
+		apiErrorHandler:  apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var uri dto.LocationURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	locationID, err := uuid.Parse(uri.LocationID)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	err = h.locationsService.DeleteLocation(ctx, locationID)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, gin.H{"location_id": locationID})
+}
@@ -0,0 +1,63 @@
+package extendpass
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	passesService   services.IPassesService
+	apiErrorHandler apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	passesService services.IPassesService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		passesService:   passesService,
+		apiErrorHandler: apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.ExtendPassRequest
+
+	err := ginCtx.ShouldBindJSON(&request)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var uri dto.PassURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	pass, err := h.passesService.ExtendPass(ctx, uri.PassID, request.Days)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	resp, err := dto.ToPassResponse(pass)
+	if err != nil {
+		ginCtx.JSON(http.StatusInternalServerError, gin.H{"error": "DTOResponse: " + err.Error()})
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,70 @@
+package freezepass
+
+import (
+	"net/http"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	passesService   services.IPassesService
+	apiErrorHandler apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	passesService services.IPassesService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		passesService:   passesService,
+		apiErrorHandler: apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.FreezePassRequest
+
+	err := ginCtx.ShouldBindJSON(&request)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var uri dto.PassURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	params := models.PassFreezeParams{
+		StartsAt: request.StartsAt,
+		EndsAt:   request.EndsAt,
+		Reason:   request.Reason,
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	pass, err := h.passesService.FreezePass(ctx, uri.PassID, params)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	resp, err := dto.ToPassResponse(pass)
+	if err != nil {
+		ginCtx.JSON(http.StatusInternalServerError, gin.H{"error": "DTOResponse: " + err.Error()})
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusCreated, resp)
+}
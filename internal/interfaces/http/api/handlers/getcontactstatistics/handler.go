@@ -0,0 +1,48 @@
+package getcontactstatistics
+
+import (
+	"net/http"
+	"strings"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	attendanceService services.IAttendanceService
+	apiErrorHandler   apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	attendanceService services.IAttendanceService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		attendanceService: attendanceService,
+		apiErrorHandler:   apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var uri dto.ContactStatisticsURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	statistics, err := h.attendanceService.GetContactStatistics(ctx, strings.ToLower(uri.Email))
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, dto.ToContactStatisticsResponse(statistics))
+}
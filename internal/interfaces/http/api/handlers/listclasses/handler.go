@@ -3,12 +3,16 @@ package listclasses
 import (
 	"net/http"
 
+	"main/internal/domain/models"
 	"main/internal/domain/services"
 	"main/internal/interfaces/http/api/dto"
 	apiErrs "main/internal/interfaces/http/api/errs"
 	sharedDTO "main/internal/interfaces/http/shared/dto"
+	"main/pkg/i18n"
+	"main/pkg/optional"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type handler struct {
@@ -36,11 +40,25 @@ func (h *handler) Handle(ginCtx *gin.Context) {
 		return
 	}
 
+	filter := models.ClassFilter{ClassLevel: dtoGetClasses.ClassLevel}
+
+	if dtoGetClasses.ClassTypeID != nil {
+		classTypeID, err := uuid.Parse(*dtoGetClasses.ClassTypeID)
+		if err != nil {
+			ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		filter.ClassTypeID = optional.Of(classTypeID)
+	}
+
 	ctx := ginCtx.Request.Context()
 
 	classes, err := h.classesService.ListClasses(
 		ctx,
 		dtoGetClasses.OnlyUpcomingClasses,
+		filter,
 		dtoGetClasses.ClassesLimit,
 	)
 	if err != nil {
@@ -49,7 +67,7 @@ func (h *handler) Handle(ginCtx *gin.Context) {
 		return
 	}
 
-	classesResp, err := sharedDTO.ToClassesWithCurrentCapacityDTO(classes)
+	classesResp, err := sharedDTO.ToClassesWithCurrentCapacityDTO(classes, i18n.Default)
 	if err != nil {
 		ginCtx.JSON(http.StatusInternalServerError, gin.H{"error": "DTOResponse: " + err.Error()})
 
@@ -0,0 +1,46 @@
+package listclassseries
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	classSeriesService services.IClassSeriesService
+	apiErrorHandler    apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	classSeriesService services.IClassSeriesService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		classSeriesService: classSeriesService,
+		apiErrorHandler:    apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	ctx := ginCtx.Request.Context()
+
+	allSeries, err := h.classSeriesService.ListClassSeries(ctx)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	resp, err := dto.ToClassSeriesListResponse(allSeries)
+	if err != nil {
+		ginCtx.JSON(http.StatusInternalServerError, gin.H{"error": "DTOResponse: " + err.Error()})
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,39 @@
+package listclasstypes
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	classTypesService services.IClassTypesService
+	apiErrorHandler   apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	classTypesService services.IClassTypesService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		classTypesService: classTypesService,
+		apiErrorHandler:   apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	ctx := ginCtx.Request.Context()
+
+	classTypes, err := h.classTypesService.ListClassTypes(ctx)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, dto.ToClassTypesResponse(classTypes))
+}
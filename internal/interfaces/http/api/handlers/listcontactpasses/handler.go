@@ -0,0 +1,74 @@
+package listcontactpasses
+
+import (
+	"net/http"
+	"strings"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	passesService   services.IPassesService
+	apiErrorHandler apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	passesService services.IPassesService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		passesService:   passesService,
+		apiErrorHandler: apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.ListPassesRequest
+
+	err := ginCtx.ShouldBindQuery(&request)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var uri dto.ContactPassesURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	email := strings.ToLower(uri.Email)
+
+	ctx := ginCtx.Request.Context()
+
+	filter := models.PassFilter{
+		Email:         &email,
+		Active:        request.Active,
+		Exhausted:     request.Exhausted,
+		WithFreeSlots: request.WithFreeSlots,
+	}
+
+	passes, err := h.passesService.ListPasses(ctx, filter)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	resp, err := dto.ToPassListResponse(passes)
+	if err != nil {
+		ginCtx.JSON(http.StatusInternalServerError, gin.H{"error": "DTOResponse: " + err.Error()})
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,39 @@
+package listinstructors
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	instructorsService services.IInstructorsService
+	apiErrorHandler    apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	instructorsService services.IInstructorsService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		instructorsService: instructorsService,
+		apiErrorHandler:    apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	ctx := ginCtx.Request.Context()
+
+	instructors, err := h.instructorsService.ListInstructors(ctx)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, dto.ToInstructorsResponse(instructors))
+}
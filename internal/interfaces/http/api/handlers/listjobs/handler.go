@@ -0,0 +1,39 @@
+package listjobs
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	jobsService     services.IJobsService
+	apiErrorHandler apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	jobsService services.IJobsService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		jobsService:     jobsService,
+		apiErrorHandler: apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	ctx := ginCtx.Request.Context()
+
+	statuses, err := h.jobsService.ListJobs(ctx)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, dto.ToJobStatusesResponse(statuses))
+}
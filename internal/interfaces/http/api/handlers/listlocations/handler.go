@@ -0,0 +1,39 @@
+package listlocations
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	locationsService services.ILocationsService
+	apiErrorHandler  apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	locationsService services.ILocationsService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		locationsService: locationsService,
+		apiErrorHandler:  apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	ctx := ginCtx.Request.Context()
+
+	locations, err := h.locationsService.ListLocations(ctx)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, dto.ToLocationsResponse(locations))
+}
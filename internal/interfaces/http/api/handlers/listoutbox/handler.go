@@ -0,0 +1,54 @@
+package listoutbox
+
+import (
+	"net/http"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	outboxService   services.IOutboxService
+	apiErrorHandler apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	outboxService services.IOutboxService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		outboxService:   outboxService,
+		apiErrorHandler: apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.ListOutboxRequest
+
+	err := ginCtx.ShouldBindQuery(&request)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	status := models.OutboxStatusDead
+	if request.Status != "" {
+		status = models.OutboxStatus(request.Status)
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	messages, err := h.outboxService.ListMessages(ctx, status)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, dto.ToOutboxMessagesResponse(messages))
+}
@@ -0,0 +1,75 @@
+package listpasses
+
+import (
+	"net/http"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	passesService   services.IPassesService
+	apiErrorHandler apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	passesService services.IPassesService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		passesService:   passesService,
+		apiErrorHandler: apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.ListPassesRequest
+
+	err := ginCtx.ShouldBindQuery(&request)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	filter := models.PassFilter{
+		Email:         request.Email,
+		Active:        request.Active,
+		Exhausted:     request.Exhausted,
+		WithFreeSlots: request.WithFreeSlots,
+	}
+
+	if request.ProductID != nil {
+		productID, err := uuid.Parse(*request.ProductID)
+		if err != nil {
+			ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		filter.ProductID = &productID
+	}
+
+	passes, err := h.passesService.ListPasses(ctx, filter)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	resp, err := dto.ToPassListResponse(passes)
+	if err != nil {
+		ginCtx.JSON(http.StatusInternalServerError, gin.H{"error": "DTOResponse: " + err.Error()})
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, resp)
+}
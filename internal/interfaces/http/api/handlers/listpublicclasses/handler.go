@@ -0,0 +1,132 @@
+package listpublicclasses
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+	"main/pkg/optional"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	classesService  services.IClassesService
+	apiErrorHandler apiErrs.IErrorHandler
+	cacheMaxAge     time.Duration
+}
+
+func NewHandler(
+	classesService services.IClassesService,
+	apiErrorHandler apiErrs.IErrorHandler,
+	cacheMaxAge time.Duration,
+) *handler {
+	return &handler{
+		classesService:  classesService,
+		apiErrorHandler: apiErrorHandler,
+		cacheMaxAge:     cacheMaxAge,
+	}
+}
+
+// Handle lists the upcoming classes with their free spots, from today for two weeks by
+// default. Bookings, deletions and other changes of the listed classes all change the
+// response, so clients revalidate it by the ETag only.
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var query dto.PublicClassesQuery
+
+	if err := ginCtx.ShouldBindQuery(&query); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	filter, err := toClassFilter(query)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	schedule, err := h.classesService.ListPublicSchedule(
+		ginCtx.Request.Context(),
+		models.PublicScheduleParams{From: query.From, To: query.To, Filter: filter},
+	)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	resp, err := dto.ToPublicClassesResponse(schedule)
+	if err != nil {
+		ginCtx.JSON(http.StatusInternalServerError, gin.H{"error": "DTOResponse: " + err.Error()})
+
+		return
+	}
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		ginCtx.JSON(http.StatusInternalServerError, gin.H{"error": "DTOResponse: " + err.Error()})
+
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	ginCtx.Header("ETag", etag)
+	ginCtx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.cacheMaxAge.Seconds())))
+
+	if notModified(ginCtx.Request, etag) {
+		ginCtx.Status(http.StatusNotModified)
+
+		return
+	}
+
+	ginCtx.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+func toClassFilter(query dto.PublicClassesQuery) (models.ClassFilter, error) {
+	filter := models.ClassFilter{ClassLevel: query.ClassLevel}
+
+	if query.ClassTypeID != "" {
+		classTypeID, err := uuid.Parse(query.ClassTypeID)
+		if err != nil {
+			return models.ClassFilter{}, fmt.Errorf("could not parse class_type: %w", err)
+		}
+
+		filter.ClassTypeID = optional.Of(classTypeID)
+	}
+
+	if query.LocationID != "" {
+		locationID, err := uuid.Parse(query.LocationID)
+		if err != nil {
+			return models.ClassFilter{}, fmt.Errorf("could not parse location: %w", err)
+		}
+
+		filter.LocationID = optional.Of(locationID)
+	}
+
+	return filter, nil
+}
+
+// notModified checks If-None-Match, a weak ETag of a client matches as well.
+func notModified(req *http.Request, etag string) bool {
+	for _, candidate := range strings.Split(req.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+
+	return false
+}
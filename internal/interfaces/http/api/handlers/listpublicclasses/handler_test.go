@@ -0,0 +1,181 @@
+package listpublicclasses
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"main/internal/application/classes"
+	"main/internal/application/waitlist"
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/infrastructure/generator/token"
+	"main/internal/infrastructure/repository/repositorytest"
+	"main/internal/interfaces/http/api/dto"
+	apiErrHandler "main/internal/interfaces/http/api/errs/handler"
+	"main/pkg/converter"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHandle(t *testing.T) {
+	studio, err := converter.LoadLocation("")
+	if err != nil {
+		t.Fatalf("could not load studio location: %v", err)
+	}
+
+	today := time.Now().In(studio).Format(time.DateOnly)
+	yesterday := time.Now().In(studio).AddDate(0, 0, -1).Format(time.DateOnly)
+
+	tests := []struct {
+		name string
+		// conditional makes the headers of a request repeating the first response
+		conditional func(first http.Header) http.Header
+		query       string
+		wantStatus  int
+	}{
+		{
+			name:       "Success: upcoming classes",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Success: not modified by the ETag",
+			conditional: func(first http.Header) http.Header {
+				return http.Header{"If-None-Match": {first.Get("ETag")}}
+			},
+			wantStatus: http.StatusNotModified,
+		},
+		{
+			name: "Success: If-Modified-Since is ignored",
+			conditional: func(http.Header) http.Header {
+				return http.Header{"If-Modified-Since": {time.Now().Format(http.TimeFormat)}}
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Success: modified for other ETag",
+			conditional: func(http.Header) http.Header {
+				return http.Header{"If-None-Match": {`"other"`}}
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Error: to before from",
+			query:      "?from=" + today + "&to=" + yesterday,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Error: range longer than 62 days",
+			query:      "?from=2030-01-01&to=2030-03-15",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Error: date in other layout",
+			query:      "?from=01.01.2030",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, class := newTestRouter(t)
+
+			first := serve(router, tt.query, nil)
+
+			if tt.conditional == nil {
+				if first.Code != tt.wantStatus {
+					t.Fatalf("status = %d, want %d: %s", first.Code, tt.wantStatus, first.Body)
+				}
+			} else {
+				resp := serve(router, tt.query, tt.conditional(first.Header()))
+				if resp.Code != tt.wantStatus {
+					t.Fatalf("status = %d, want %d", resp.Code, tt.wantStatus)
+				}
+
+				if resp.Header().Get("ETag") != first.Header().Get("ETag") {
+					t.Errorf("ETag = %s, want %s", resp.Header().Get("ETag"), first.Header().Get("ETag"))
+				}
+
+				return
+			}
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			if first.Header().Get("ETag") == "" {
+				t.Error("response has no ETag")
+			}
+
+			if first.Header().Get("Last-Modified") != "" {
+				t.Error("response has Last-Modified, the ETag alone is kept up to date")
+			}
+
+			var resp dto.PublicClassesResponse
+			if err := json.Unmarshal(first.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("could not decode response: %v", err)
+			}
+
+			if len(resp.Classes) != 1 {
+				t.Fatalf("classes = %d, want 1", len(resp.Classes))
+			}
+
+			got := resp.Classes[0]
+			if got.ID != class.ID {
+				t.Errorf("class id = %s, want %s", got.ID, class.ID)
+			}
+
+			startTime, err := time.Parse(time.RFC3339, got.StartTime)
+			if err != nil || !startTime.Equal(class.StartTime.Truncate(time.Second)) {
+				t.Errorf("start_time = %s, want %s", got.StartTime, class.StartTime)
+			}
+
+			if got.DurationMinutes != 60 {
+				t.Errorf("duration_minutes = %d, want 60", got.DurationMinutes)
+			}
+		})
+	}
+}
+
+// newTestRouter serves the handler with a single class two days from now.
+func newTestRouter(t *testing.T) (*gin.Engine, models.Class) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	repos, unitOfWork := repositorytest.OpenSQLite(t)
+	class := repositorytest.InsertClass(t, repos, time.Now().Add(48*time.Hour), 10)
+
+	classesService := classes.NewService(
+		repos.Classes,
+		repos.Bookings,
+		repos.Locations,
+		repos.Instructors,
+		repos.ClassTypes,
+		repos.Waitlist,
+		unitOfWork,
+		&services.PassManager{},
+		waitlist.NewService(unitOfWork, token.NewGenerator(), ""),
+	)
+
+	router := gin.New()
+	router.GET("/classes", NewHandler(classesService, apiErrHandler.NewErrorHandler(), time.Minute).Handle)
+
+	return router, class
+}
+
+func serve(router *gin.Engine, query string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/classes"+query, nil)
+	req.Header = header.Clone()
+
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	return recorder
+}
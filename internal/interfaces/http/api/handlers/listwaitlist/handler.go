@@ -0,0 +1,54 @@
+package listwaitlist
+
+import (
+	"net/http"
+
+	"main/internal/domain/repositories"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	waitlistRepo    repositories.IWaitlist
+	apiErrorHandler apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	waitlistRepo repositories.IWaitlist,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		waitlistRepo:    waitlistRepo,
+		apiErrorHandler: apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	classID, err := uuid.Parse(ginCtx.Param("class_id"))
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	entries, err := h.waitlistRepo.ListByClassID(ctx, classID)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	response, err := dto.ToWaitlistResponse(entries)
+	if err != nil {
+		ginCtx.JSON(http.StatusInternalServerError, gin.H{"error": "DTOResponse: " + err.Error()})
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, response)
+}
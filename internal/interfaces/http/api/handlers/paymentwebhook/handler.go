@@ -0,0 +1,54 @@
+package paymentwebhook
+
+import (
+	"errors"
+	"net/http"
+
+	"main/internal/domain/payments"
+	"main/internal/domain/services"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	paymentsService services.IPaymentsService
+	apiErrorHandler apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	paymentsService services.IPaymentsService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		paymentsService: paymentsService,
+		apiErrorHandler: apiErrorHandler,
+	}
+}
+
+// Handle is called by the payment provider, which retries until it gets a 2xx response.
+func (h *handler) Handle(ginCtx *gin.Context) {
+	payload, err := ginCtx.GetRawData()
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	err = h.paymentsService.HandleWebhook(ctx, payload, ginCtx.Request.Header)
+	if err != nil {
+		if errors.Is(err, payments.ErrInvalidWebhook) {
+			ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.Status(http.StatusOK)
+}
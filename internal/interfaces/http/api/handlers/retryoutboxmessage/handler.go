@@ -0,0 +1,46 @@
+package retryoutboxmessage
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	outboxService   services.IOutboxService
+	apiErrorHandler apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	outboxService services.IOutboxService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		outboxService:   outboxService,
+		apiErrorHandler: apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	messageID, err := uuid.Parse(ginCtx.Param("message_id"))
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	err = h.outboxService.RetryMessage(ctx, messageID)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, gin.H{"message_id": messageID})
+}
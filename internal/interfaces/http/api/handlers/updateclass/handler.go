@@ -60,7 +60,17 @@ func (h *handler) Handle(ginCtx *gin.Context) {
 		ClassLevel:  dtoUpdateClass.ClassLevel,
 		ClassName:   dtoUpdateClass.ClassName,
 		MaxCapacity: dtoUpdateClass.MaxCapacity,
-		Location:    dtoUpdateClass.Location,
+	}
+
+	if dtoUpdateClass.LocationID != nil {
+		locationID, err := uuid.Parse(*dtoUpdateClass.LocationID)
+		if err != nil {
+			ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		update.LocationID = &locationID
 	}
 
 	updatedClass, err := h.classesService.UpdateClass(ctx, parsedUUID, update)
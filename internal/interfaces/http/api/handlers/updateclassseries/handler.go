@@ -0,0 +1,102 @@
+package updateclassseries
+
+import (
+	"net/http"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	classSeriesService services.IClassSeriesService
+	apiErrorHandler    apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	classSeriesService services.IClassSeriesService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		classSeriesService: classSeriesService,
+		apiErrorHandler:    apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.UpdateClassSeriesRequest
+
+	err := ginCtx.ShouldBindJSON(&request)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var uri dto.ClassSeriesURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	seriesID, err := uuid.Parse(uri.SeriesID)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	update := models.UpdateClassSeries{
+		EndDate:        request.EndDate,
+		ExceptionDates: request.ExceptionDates,
+		ClassLevel:     request.ClassLevel,
+		ClassName:      request.ClassName,
+		MaxCapacity:    request.MaxCapacity,
+	}
+
+	if request.LocationID != nil {
+		locationID, err := uuid.Parse(*request.LocationID)
+		if err != nil {
+			ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		update.LocationID = &locationID
+	}
+
+	if request.InstructorID != nil {
+		instructorID, err := uuid.Parse(*request.InstructorID)
+		if err != nil {
+			ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		update.InstructorID = &instructorID
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	updatedSeries, err := h.classSeriesService.UpdateClassSeries(ctx, seriesID, update)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	resp, err := dto.ToClassSeriesResponse(updatedSeries)
+	if err != nil {
+		ginCtx.JSON(http.StatusInternalServerError, gin.H{"error": "DTOResponse: " + err.Error()})
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, resp)
+}
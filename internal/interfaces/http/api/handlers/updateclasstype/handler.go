@@ -0,0 +1,88 @@
+package updateclasstype
+
+import (
+	"net/http"
+	"time"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	classTypesService services.IClassTypesService
+	apiErrorHandler   apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	classTypesService services.IClassTypesService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		classTypesService: classTypesService,
+		apiErrorHandler:   apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.UpdateClassTypeRequest
+
+	if err := ginCtx.ShouldBindJSON(&request); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var uri dto.ClassTypeURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	classTypeID, err := uuid.Parse(uri.ClassTypeID)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	update := models.UpdateClassType{
+		Name:            request.Name,
+		Level:           request.Level,
+		Description:     request.Description,
+		DefaultCapacity: request.DefaultCapacity,
+	}
+
+	if request.DefaultDurationMinutes != nil {
+		duration := time.Duration(*request.DefaultDurationMinutes) * time.Minute
+		update.DefaultDuration = &duration
+	}
+
+	if request.DefaultLocationID != nil {
+		locationID, err := uuid.Parse(*request.DefaultLocationID)
+		if err != nil {
+			ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		update.DefaultLocationID = &locationID
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	classType, err := h.classTypesService.UpdateClassType(ctx, classTypeID, update)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, dto.ToClassTypeResponse(classType))
+}
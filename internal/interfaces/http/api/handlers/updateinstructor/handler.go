@@ -0,0 +1,71 @@
+package updateinstructor
+
+import (
+	"net/http"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	instructorsService services.IInstructorsService
+	apiErrorHandler    apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	instructorsService services.IInstructorsService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		instructorsService: instructorsService,
+		apiErrorHandler:    apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.UpdateInstructorRequest
+
+	if err := ginCtx.ShouldBindJSON(&request); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var uri dto.InstructorURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	instructorID, err := uuid.Parse(uri.InstructorID)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	update := models.UpdateInstructor{
+		Name:      request.Name,
+		Bio:       request.Bio,
+		Email:     request.Email,
+		Signature: request.Signature,
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	instructor, err := h.instructorsService.UpdateInstructor(ctx, instructorID, update)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, dto.ToInstructorResponse(instructor))
+}
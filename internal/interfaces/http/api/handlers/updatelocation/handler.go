@@ -0,0 +1,74 @@
+package updatelocation
+
+import (
+	"net/http"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	locationsService services.ILocationsService
+	apiErrorHandler  apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	locationsService services.ILocationsService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		locationsService: locationsService,
+		apiErrorHandler:  apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.UpdateLocationRequest
+
+	if err := ginCtx.ShouldBindJSON(&request); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var uri dto.LocationURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	locationID, err := uuid.Parse(uri.LocationID)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	update := models.UpdateLocation{
+		Name:            request.Name,
+		Address:         request.Address,
+		Directions:      request.Directions,
+		MapURL:          request.MapURL,
+		DefaultCapacity: request.DefaultCapacity,
+		AccessNotes:     request.AccessNotes,
+		TimeZone:        request.TimeZone,
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	location, err := h.locationsService.UpdateLocation(ctx, locationID, update)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, dto.ToLocationResponse(location))
+}
@@ -0,0 +1,72 @@
+package updateproduct
+
+import (
+	"net/http"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/api/dto"
+	apiErrs "main/internal/interfaces/http/api/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	productsService services.IProductsService
+	apiErrorHandler apiErrs.IErrorHandler
+}
+
+func NewHandler(
+	productsService services.IProductsService,
+	apiErrorHandler apiErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		productsService: productsService,
+		apiErrorHandler: apiErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var request dto.UpdateProductRequest
+
+	if err := ginCtx.ShouldBindJSON(&request); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var uri dto.ProductURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	productID, err := uuid.Parse(uri.ProductID)
+	if err != nil {
+		ginCtx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	update := models.UpdateProduct{
+		Name:         request.Name,
+		ValidityDays: request.ValidityDays,
+		Price:        request.Price,
+		Currency:     request.Currency,
+		Active:       request.Active,
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	product, err := h.productsService.UpdateProduct(ctx, productID, update)
+	if err != nil {
+		h.apiErrorHandler.Handle(ginCtx, err)
+
+		return
+	}
+
+	ginCtx.JSON(http.StatusOK, dto.ToProductResponse(product))
+}
@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"main/internal/domain/errs/api"
+	"main/internal/domain/models"
+	"main/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+type AttendanceURI struct {
+	ClassID string `uri:"class_id" binding:"required,uuid"`
+}
+
+type MarkAttendanceURI struct {
+	ClassID   string `uri:"class_id" binding:"required,uuid"`
+	BookingID string `uri:"booking_id" binding:"required,uuid"`
+}
+
+type MarkAttendanceForm struct {
+	Status string `form:"status" binding:"required,oneof=attended no_show"`
+}
+
+type WalkInForm struct {
+	Email     string `binding:"required,email" form:"email"`
+	LastName  string `binding:"required,max=30" form:"last_name"`
+	FirstName string `binding:"required,max=30" form:"first_name"`
+}
+
+type RosterEntryView struct {
+	BookingID uuid.UUID
+	FirstName string
+	LastName  string
+	Status    string
+	WalkIn    bool
+	HasPass   bool
+	Attended  int
+	NoShows   int
+}
+
+type RosterView struct {
+	ClassID     uuid.UUID
+	Class       ClassView
+	Started     bool
+	MaxCapacity int
+	Attended    int
+	Entries     []RosterEntryView
+	Error       string
+}
+
+func ToRosterView(roster models.Roster, locale i18n.Locale) (RosterView, error) {
+	classView, err := ToClassView(roster.Class, locale)
+	if err != nil {
+		return RosterView{}, fmt.Errorf("could not convert class %s: %w", roster.Class.ID, err)
+	}
+
+	view := RosterView{
+		ClassID:     roster.Class.ID,
+		Class:       classView,
+		Started:     !roster.Class.StartTime.After(time.Now()),
+		MaxCapacity: roster.Class.MaxCapacity,
+		Entries:     make([]RosterEntryView, 0, len(roster.Entries)),
+	}
+
+	for _, entry := range roster.Entries {
+		if entry.Booking.Status == models.BookingAttended {
+			view.Attended++
+		}
+
+		view.Entries = append(view.Entries, RosterEntryView{
+			BookingID: entry.Booking.ID,
+			FirstName: entry.Booking.FirstName,
+			LastName:  entry.Booking.LastName,
+			Status:    string(entry.Booking.Status),
+			WalkIn:    entry.Booking.WalkIn,
+			HasPass:   entry.Booking.Pass.Exists(),
+			Attended:  entry.Statistics.Attended,
+			NoShows:   entry.Statistics.NoShows,
+		})
+	}
+
+	return view, nil
+}
+
+// RosterError maps an error of the attendance service to the message key and status
+// shown on the roster, ok is false for errors the instructor can not do anything about.
+func RosterError(err error) (key string, code int, ok bool) {
+	var apiError *api.APIError
+	if !errors.As(err, &apiError) {
+		return "", 0, false
+	}
+
+	switch apiError.Code {
+	case api.BadRequestCode:
+		return "attendance.error_invalid", http.StatusBadRequest, true
+	case api.ConflictCode:
+		return "attendance.error_conflict", http.StatusConflict, true
+	case api.NotFoundCode:
+		return "attendance.error_not_found", http.StatusNotFound, true
+	default:
+		return "", 0, false
+	}
+}
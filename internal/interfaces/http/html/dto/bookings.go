@@ -5,7 +5,9 @@ import (
 
 	"main/internal/domain/models"
 	"main/pkg/converter"
-	"main/pkg/translator"
+	"main/pkg/i18n"
+
+	"github.com/google/uuid"
 )
 
 type BookingCancelForm struct {
@@ -16,6 +18,11 @@ type BookingCancelURI struct {
 	BookingID string `uri:"id" binding:"required"`
 }
 
+type BookingRescheduleForm struct {
+	Token   string `form:"token" binding:"required,len=44"`
+	ClassID string `form:"class_id" binding:"required,uuid"`
+}
+
 type BookingCreateForm struct {
 	Token string `form:"token" binding:"required,len=44"`
 }
@@ -28,23 +35,55 @@ type ClassView struct {
 	Location   string
 }
 
-func ToClassView(class models.Class) (ClassView, error) {
-	warsawStartTime, err := converter.ConvertToWarsawTime(class.StartTime)
+func ToClassView(class models.Class, locale i18n.Locale) (ClassView, error) {
+	startTime, err := converter.ConvertToLocationTime(class.StartTime, class.Location.TimeZone)
 	if err != nil {
 		return ClassView{}, fmt.Errorf("could not convert class start time from booking: %w", err)
 	}
 
-	weekDay, err := translator.TranslateToWeekDayToPolish(warsawStartTime.Weekday())
-	if err != nil {
-		return ClassView{}, fmt.Errorf("could not convert weekday from booking: %w", err)
-	}
-
 	return ClassView{
-		WeekDay:    weekDay,
-		StartDate:  warsawStartTime.Format(converter.DateLayout),
-		StartHour:  warsawStartTime.Format(converter.HourLayout),
+		WeekDay:    i18n.WeekDay(locale, startTime.Weekday()),
+		StartDate:  startTime.Format(converter.DateLayout),
+		StartHour:  startTime.Format(converter.HourLayout),
 		ClassLevel: class.ClassLevel,
 		ClassName:  class.ClassName,
-		Location:   class.Location,
+		Location:   class.Location.Name,
 	}, nil
 }
+
+type RescheduleOptionView struct {
+	ID    uuid.UUID
+	Class ClassView
+}
+
+// ToRescheduleOptions lists the classes a booking of classID can be moved to, the ones
+// with free spots.
+func ToRescheduleOptions(
+	classes []models.ClassWithCurrentCapacity, classID uuid.UUID, locale i18n.Locale,
+) ([]RescheduleOptionView, error) {
+	options := make([]RescheduleOptionView, 0, len(classes))
+
+	for _, class := range classes {
+		if class.ID == classID || class.CurrentCapacity <= 0 {
+			continue
+		}
+
+		classView, err := ToClassView(models.Class{
+			ID:         class.ID,
+			StartTime:  class.StartTime,
+			ClassLevel: class.ClassLevel,
+			ClassName:  class.ClassName,
+			Location:   class.Location,
+		}, locale)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert class %s: %w", class.ID, err)
+		}
+
+		options = append(options, RescheduleOptionView{
+			ID:    class.ID,
+			Class: classView,
+		})
+	}
+
+	return options, nil
+}
@@ -0,0 +1,7 @@
+package dto
+
+const CalendarContentType = "text/calendar; charset=utf-8"
+
+type CalendarFeedQuery struct {
+	Token string `form:"token" binding:"required"`
+}
@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"slices"
+	"time"
+
+	"main/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ClassFilterQuery narrows the schedule on the home page, empty fields show every class.
+type ClassFilterQuery struct {
+	ClassTypeID string `form:"class_type" binding:"omitempty,uuid"`
+	Level       string `form:"level" binding:"max=40"`
+}
+
+type ClassTypeURI struct {
+	ClassTypeID string `uri:"id" binding:"required,uuid"`
+}
+
+type ClassTypeOptionView struct {
+	ID       uuid.UUID
+	Label    string
+	Selected bool
+}
+
+type LevelOptionView struct {
+	Level    string
+	Selected bool
+}
+
+type ClassFilterView struct {
+	ClassTypes []ClassTypeOptionView
+	Levels     []LevelOptionView
+	Active     bool
+}
+
+type ClassTypeView struct {
+	ID              uuid.UUID
+	Name            string
+	Level           string
+	Description     string
+	DurationMinutes int
+}
+
+// ToClassFilterView lists the class types and their distinct levels to filter by,
+// marking the ones of the filter as selected.
+func ToClassFilterView(classTypes []models.ClassType, filter models.ClassFilter) ClassFilterView {
+	view := ClassFilterView{
+		ClassTypes: make([]ClassTypeOptionView, 0, len(classTypes)),
+		Active:     filter.ClassTypeID.Exists() || filter.ClassLevel != "",
+	}
+
+	levels := make([]string, 0, len(classTypes))
+
+	for _, classType := range classTypes {
+		view.ClassTypes = append(view.ClassTypes, ClassTypeOptionView{
+			ID:       classType.ID,
+			Label:    classType.Name + " (" + classType.Level + ")",
+			Selected: filter.ClassTypeID.Exists() && filter.ClassTypeID.Get() == classType.ID,
+		})
+
+		if !slices.Contains(levels, classType.Level) {
+			levels = append(levels, classType.Level)
+		}
+	}
+
+	slices.Sort(levels)
+
+	for _, level := range levels {
+		view.Levels = append(view.Levels, LevelOptionView{
+			Level:    level,
+			Selected: level == filter.ClassLevel,
+		})
+	}
+
+	return view
+}
+
+func ToClassTypeView(classType models.ClassType) ClassTypeView {
+	return ClassTypeView{
+		ID:              classType.ID,
+		Name:            classType.Name,
+		Level:           classType.Level,
+		Description:     classType.Description,
+		DurationMinutes: int(classType.DefaultDuration / time.Minute),
+	}
+}
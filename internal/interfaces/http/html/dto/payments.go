@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"fmt"
+
+	"main/internal/domain/models"
+	"main/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+type PassPaymentForm struct {
+	Email     string `binding:"required,email" form:"email"`
+	ProductID string `binding:"required,uuid" form:"product_id"`
+}
+
+type PaymentURI struct {
+	PaymentID string `uri:"id" binding:"required,uuid"`
+}
+
+type PassOfferView struct {
+	ProductID uuid.UUID
+	Label     string
+}
+
+type PaymentView struct {
+	Amount  string
+	Message string
+}
+
+// FormatPrice renders an amount kept in the smallest unit of the currency, e.g. 4000 PLN as 40.00 PLN.
+func FormatPrice(amount int, currency string) string {
+	return fmt.Sprintf("%d.%02d %s", amount/100, amount%100, currency)
+}
+
+func ToPassOffers(products []models.Product, locale i18n.Locale) []PassOfferView {
+	offers := make([]PassOfferView, 0, len(products))
+
+	for _, product := range products {
+		if product.Kind != models.ProductPass {
+			continue
+		}
+
+		offers = append(offers, PassOfferView{
+			ProductID: product.ID,
+			Label:     i18n.T(locale, "buy_pass.option", product.Name, FormatPrice(product.Price, product.Currency)),
+		})
+	}
+
+	return offers
+}
+
+// DropInPrice is the formatted price of the drop-in in products, empty without one.
+func DropInPrice(products []models.Product) string {
+	for _, product := range products {
+		if product.Kind == models.ProductDropIn {
+			return FormatPrice(product.Price, product.Currency)
+		}
+	}
+
+	return ""
+}
+
+func ToPaymentView(payment models.Payment, locale i18n.Locale) PaymentView {
+	var key string
+
+	switch payment.Status {
+	case models.PaymentPaid:
+		key = "payment.paid_pass"
+		if payment.Kind == models.PaymentKindDropIn {
+			key = "payment.paid_drop_in"
+		}
+	case models.PaymentFailed:
+		key = "payment.failed"
+	case models.PaymentUnfulfilled:
+		key = "payment.unfulfilled"
+	default:
+		key = "payment.pending"
+	}
+
+	return PaymentView{
+		Amount:  FormatPrice(payment.Amount, payment.Currency),
+		Message: i18n.T(locale, key),
+	}
+}
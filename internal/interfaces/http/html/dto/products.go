@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"main/internal/domain/models"
+	"main/pkg/i18n"
+)
+
+type ProductView struct {
+	Name    string
+	Price   string
+	Details string
+}
+
+func ToProductViews(products []models.Product, locale i18n.Locale) []ProductView {
+	productViews := make([]ProductView, 0, len(products))
+
+	for _, product := range products {
+		details := i18n.T(locale, "home.price_drop_in")
+		if product.Kind == models.ProductPass {
+			details = i18n.T(locale, "home.price_pass", product.TotalSlots)
+			if product.ValidityDays > 0 {
+				details = i18n.T(locale, "home.price_pass_validity", product.TotalSlots, product.ValidityDays)
+			}
+		}
+
+		productViews = append(productViews, ProductView{
+			Name:    product.Name,
+			Price:   FormatPrice(product.Price, product.Currency),
+			Details: details,
+		})
+	}
+
+	return productViews
+}
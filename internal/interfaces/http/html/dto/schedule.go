@@ -0,0 +1,160 @@
+package dto
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"main/internal/domain/models"
+	sharedDTO "main/internal/interfaces/http/shared/dto"
+	"main/pkg/converter"
+	"main/pkg/i18n"
+)
+
+// WeekLayout is the format of the week query, the date of any day of the week.
+const WeekLayout = "2006-01-02"
+
+// fewSpotsLeft is the number of free spots from which the schedule warns they run out.
+const fewSpotsLeft = 3
+
+type ScheduleQuery struct {
+	Week       string `form:"week" binding:"omitempty,datetime=2006-01-02"`
+	Level      string `form:"level" binding:"max=40"`
+	Name       string `form:"name" binding:"max=60"`
+	LocationID string `form:"location" binding:"omitempty,uuid"`
+}
+
+type OptionView struct {
+	Value    string
+	Label    string
+	Selected bool
+}
+
+type ScheduleFilterView struct {
+	Levels    []OptionView
+	Names     []OptionView
+	Locations []OptionView
+	Active    bool
+}
+
+// ScheduleClassView tells with Availability whether the class is "full", has "few"
+// spots left or is "free".
+type ScheduleClassView struct {
+	sharedDTO.ClassWithCurrentCapacityDTO
+	Availability string
+}
+
+type ScheduleDayView struct {
+	WeekDay string
+	Date    string
+	Classes []ScheduleClassView
+}
+
+// ScheduleView is a week of the schedule, PreviousURL is empty for the current week.
+type ScheduleView struct {
+	Week        string
+	WeekStart   string
+	WeekEnd     string
+	PreviousURL string
+	NextURL     string
+	Days        []ScheduleDayView
+	Filter      ScheduleFilterView
+}
+
+// ToScheduleView groups the classes of the week starting at week by day, the links to
+// the neighbouring weeks keep the filters of the query.
+func ToScheduleView(
+	schedule models.Schedule, query ScheduleQuery, week, currentWeek time.Time, locale i18n.Locale,
+) (ScheduleView, error) {
+	view := ScheduleView{
+		Week:      week.Format(WeekLayout),
+		WeekStart: week.Format(converter.DateLayout),
+		WeekEnd:   week.AddDate(0, 0, 6).Format(converter.DateLayout),
+		NextURL:   scheduleURL(query, week.AddDate(0, 0, 7)),
+		Days:      make([]ScheduleDayView, 0, len(schedule.Classes)),
+		Filter:    toScheduleFilterView(schedule, query),
+	}
+
+	if week.After(currentWeek) {
+		view.PreviousURL = scheduleURL(query, week.AddDate(0, 0, -7))
+	}
+
+	for _, class := range schedule.Classes {
+		classDTO, err := sharedDTO.ToClassWithCurrentCapacityDTO(class, locale)
+		if err != nil {
+			return ScheduleView{}, fmt.Errorf("could not convert class %s: %w", class.ID, err)
+		}
+
+		if len(view.Days) == 0 || view.Days[len(view.Days)-1].Date != classDTO.StartDate {
+			view.Days = append(view.Days, ScheduleDayView{
+				WeekDay: classDTO.WeekDay,
+				Date:    classDTO.StartDate,
+			})
+		}
+
+		day := &view.Days[len(view.Days)-1]
+		day.Classes = append(day.Classes, ScheduleClassView{
+			ClassWithCurrentCapacityDTO: classDTO,
+			Availability:                availability(class.CurrentCapacity),
+		})
+	}
+
+	return view, nil
+}
+
+func toScheduleFilterView(schedule models.Schedule, query ScheduleQuery) ScheduleFilterView {
+	view := ScheduleFilterView{
+		Levels:    make([]OptionView, 0, len(schedule.ClassLevels)),
+		Names:     make([]OptionView, 0, len(schedule.ClassNames)),
+		Locations: make([]OptionView, 0, len(schedule.Locations)),
+		Active:    query.Level != "" || query.Name != "" || query.LocationID != "",
+	}
+
+	for _, level := range schedule.ClassLevels {
+		view.Levels = append(view.Levels, OptionView{Value: level, Label: level, Selected: level == query.Level})
+	}
+
+	for _, name := range schedule.ClassNames {
+		view.Names = append(view.Names, OptionView{Value: name, Label: name, Selected: name == query.Name})
+	}
+
+	for _, location := range schedule.Locations {
+		view.Locations = append(view.Locations, OptionView{
+			Value:    location.ID.String(),
+			Label:    location.Name,
+			Selected: location.ID.String() == query.LocationID,
+		})
+	}
+
+	return view
+}
+
+func scheduleURL(query ScheduleQuery, week time.Time) string {
+	values := url.Values{}
+	values.Set("week", week.Format(WeekLayout))
+
+	if query.Level != "" {
+		values.Set("level", query.Level)
+	}
+
+	if query.Name != "" {
+		values.Set("name", query.Name)
+	}
+
+	if query.LocationID != "" {
+		values.Set("location", query.LocationID)
+	}
+
+	return "/schedule?" + values.Encode()
+}
+
+func availability(currentCapacity int) string {
+	switch {
+	case currentCapacity <= 0:
+		return "full"
+	case currentCapacity <= fewSpotsLeft:
+		return "few"
+	default:
+		return "free"
+	}
+}
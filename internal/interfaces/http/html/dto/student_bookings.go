@@ -0,0 +1,141 @@
+package dto
+
+import (
+	"fmt"
+
+	"main/internal/domain/models"
+	"main/pkg/converter"
+	"main/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+const StudentSessionCookie = "student_session"
+
+type StudentLoginForm struct {
+	Email string `binding:"required,email" form:"email"`
+}
+
+type StudentAuthForm struct {
+	Token string `form:"token" binding:"required,len=44"`
+}
+
+type StudentBookingView struct {
+	BookingID         uuid.UUID
+	ConfirmationToken string
+	Class             ClassView
+}
+
+type PassSlotView struct {
+	Status         string
+	ClassStartDate string
+}
+
+type PassView struct {
+	UsedSlots  int
+	TotalSlots int
+	ValidUntil string
+	Slots      []PassSlotView
+}
+
+type StudentBookingsView struct {
+	Email            string
+	Upcoming         []StudentBookingView
+	Past             []StudentBookingView
+	Passes           []PassView
+	CalendarFeedLink string
+}
+
+func ToStudentBookingsView(
+	studentBookings models.StudentBookings, locale i18n.Locale,
+) (StudentBookingsView, error) {
+	upcoming, err := toStudentBookingViews(studentBookings.Upcoming, locale)
+	if err != nil {
+		return StudentBookingsView{}, fmt.Errorf("could not convert upcoming bookings: %w", err)
+	}
+
+	past, err := toStudentBookingViews(studentBookings.Past, locale)
+	if err != nil {
+		return StudentBookingsView{}, fmt.Errorf("could not convert past bookings: %w", err)
+	}
+
+	passes := make([]PassView, 0, len(studentBookings.Passes))
+
+	for _, pass := range studentBookings.Passes {
+		passView, err := toPassView(pass)
+		if err != nil {
+			return StudentBookingsView{}, fmt.Errorf("could not convert pass %d: %w", pass.Pass.ID, err)
+		}
+
+		passes = append(passes, passView)
+	}
+
+	return StudentBookingsView{
+		Email:            studentBookings.Email,
+		Upcoming:         upcoming,
+		Past:             past,
+		Passes:           passes,
+		CalendarFeedLink: studentBookings.CalendarFeedLink,
+	}, nil
+}
+
+func toStudentBookingViews(bookings []models.Booking, locale i18n.Locale) ([]StudentBookingView, error) {
+	views := make([]StudentBookingView, 0, len(bookings))
+
+	for _, booking := range bookings {
+		classView, err := ToClassView(booking.Class, locale)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert class for booking %s: %w", booking.ID, err)
+		}
+
+		views = append(views, StudentBookingView{
+			BookingID:         booking.ID,
+			ConfirmationToken: booking.ConfirmationToken,
+			Class:             classView,
+		})
+	}
+
+	return views, nil
+}
+
+func toPassView(pass models.PassWithSlots) (PassView, error) {
+	view := PassView{
+		UsedSlots:  pass.UsedSlots(),
+		TotalSlots: pass.Pass.TotalSlots,
+		Slots:      make([]PassSlotView, 0, len(pass.Slots)),
+	}
+
+	if pass.Pass.ValidUntil != nil {
+		validUntil, err := converter.ConvertToStudioTime(*pass.Pass.ValidUntil)
+		if err != nil {
+			return PassView{}, fmt.Errorf("could not convert pass valid until: %w", err)
+		}
+
+		view.ValidUntil = validUntil.Format(converter.DateLayout)
+	}
+
+	for _, slot := range pass.Slots {
+		slotView := PassSlotView{}
+
+		switch slot.Status {
+		case models.Past:
+			slotView.Status = "past"
+		case models.Future:
+			slotView.Status = "future"
+		case models.LateCancelled:
+			slotView.Status = "late-cancelled"
+		case models.NoShow:
+			slotView.Status = "no-show"
+		case models.Blank:
+			slotView.Status = "blank"
+		}
+
+		if slot.ClassStartTime != nil {
+			slotView.ClassStartDate = slot.ClassStartTime.Format("02.01")
+		}
+
+		view.Slots = append(view.Slots, slotView)
+	}
+
+	return view, nil
+}
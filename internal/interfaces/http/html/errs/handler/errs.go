@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	domainErrs "main/internal/domain/errs/view"
+	"main/internal/interfaces/http/html/views"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,36 +23,63 @@ func (e errorHandler) Handle(ctx *gin.Context, tmplName string, err error) {
 		case domainErrs.BookingNotFoundCode,
 			domainErrs.ClassExpiredCode,
 			domainErrs.ClassEmptyCode:
-			ctx.HTML(http.StatusNotFound, tmplName, gin.H{
+			views.HTML(ctx, http.StatusNotFound, tmplName, gin.H{
 				"ID":    businessError.ClassID,
-				"Error": businessError.Message,
+				"Error": businessError.Message(views.Locale(ctx)),
+			})
+
+			return
+		case domainErrs.ClassFullyBookedCode:
+			views.HTML(ctx, http.StatusConflict, tmplName, gin.H{
+				"ID":       businessError.ClassID,
+				"Error":    businessError.Message(views.Locale(ctx)),
+				"Waitlist": true,
 			})
 
 			return
 		case domainErrs.BookingAlreadyExistsCode,
 			domainErrs.TooManyPendingBookingsCode,
-			domainErrs.ClassFullyBookedCode:
-			ctx.HTML(http.StatusConflict, tmplName, gin.H{
+			domainErrs.TooLateToBook,
+			domainErrs.ClassNotFullyBookedCode,
+			domainErrs.AlreadyOnWaitlistCode,
+			domainErrs.BookingNotOpenYetCode,
+			domainErrs.TooManyActiveBookingsCode,
+			domainErrs.TooLateToRescheduleCode,
+			domainErrs.PassNotUsableCode:
+			views.HTML(ctx, http.StatusConflict, tmplName, gin.H{
 				"ID":    businessError.ClassID,
-				"Error": businessError.Message,
+				"Error": businessError.Message(views.Locale(ctx)),
 			})
 
 			return
 		case domainErrs.PendingBookingNotFoundCode,
-			domainErrs.InvalidCancellationLinkCode:
-			ctx.HTML(http.StatusNotFound, tmplName, gin.H{
-				"Error": businessError.Message,
+			domainErrs.InvalidCancellationLinkCode,
+			domainErrs.InvalidLoginLinkCode,
+			domainErrs.CalendarFeedNotFoundCode,
+			domainErrs.PaymentNotFoundCode,
+			domainErrs.ClassTypeNotFoundCode,
+			domainErrs.ClassNotFoundCode:
+			views.HTML(ctx, http.StatusNotFound, tmplName, gin.H{
+				"Error": businessError.Message(views.Locale(ctx)),
+			})
+
+			return
+		case domainErrs.StudentSessionExpiredCode:
+			views.HTML(ctx, http.StatusUnauthorized, tmplName, gin.H{
+				"Error": businessError.Message(views.Locale(ctx)),
 			})
 
 			return
-		case domainErrs.SomeoneBookedClassFasterCode:
-			ctx.HTML(http.StatusConflict, tmplName, gin.H{
-				"Error": businessError.Message,
+		case domainErrs.SomeoneBookedClassFasterCode,
+			domainErrs.PassNotOfferedCode,
+			domainErrs.DropInNotOfferedCode:
+			views.HTML(ctx, http.StatusConflict, tmplName, gin.H{
+				"Error": businessError.Message(views.Locale(ctx)),
 			})
 
 			return
 		default:
-			ctx.HTML(http.StatusInternalServerError, "err.tmpl", gin.H{
+			views.HTML(ctx, http.StatusInternalServerError, "err.tmpl", gin.H{
 				"Error": "error_id: " + ctx.GetString("request_id"),
 			})
 		}
@@ -68,7 +96,7 @@ func (e errorHandler) handleInternalError(ctx *gin.Context) {
 		return
 	}
 
-	ctx.HTML(http.StatusInternalServerError, "err.tmpl", gin.H{
+	views.HTML(ctx, http.StatusInternalServerError, "err.tmpl", gin.H{
 		"Error": "error_id: " + ctx.GetString("request_id"),
 	})
 }
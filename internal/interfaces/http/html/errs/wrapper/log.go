@@ -31,7 +31,7 @@ func (e ErrorHandler) Handle(ctx *gin.Context, tmplName string, err error) {
 	if e.logBusinessErrors && errors.As(err, &viewError) {
 		slog.Info("BookingBusinessError",
 			slog.Int("code", viewError.Code),
-			slog.String("message", viewError.Message),
+			slog.String("message", viewError.MessageKey),
 			slog.String("error", err.Error()),
 			slog.Any("classID", viewError.ClassID),
 			slog.Any("params", ctx.Request.URL.Query()),
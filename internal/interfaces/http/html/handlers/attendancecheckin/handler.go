@@ -0,0 +1,92 @@
+package attendancecheckin
+
+import (
+	"net/http"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/html/dto"
+	viewErrs "main/internal/interfaces/http/html/errs"
+	"main/internal/interfaces/http/html/views"
+	"main/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	attendanceService services.IAttendanceService
+}
+
+func NewHandler(attendanceService services.IAttendanceService) *handler {
+	return &handler{
+		attendanceService: attendanceService,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var uri dto.MarkAttendanceURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	var form dto.MarkAttendanceForm
+
+	if err := ginCtx.ShouldBind(&form); err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	classID, err := uuid.Parse(uri.ClassID)
+	if err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	bookingID, err := uuid.Parse(uri.BookingID)
+	if err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+	code := http.StatusOK
+	errorMessage := ""
+
+	_, err = h.attendanceService.MarkAttendance(ctx, classID, bookingID, models.BookingStatus(form.Status))
+	if err != nil {
+		key, errCode, ok := dto.RosterError(err)
+		if !ok {
+			viewErrs.HandleError(ginCtx, err, http.StatusInternalServerError)
+
+			return
+		}
+
+		code = errCode
+		errorMessage = i18n.T(views.Locale(ginCtx), key)
+	}
+
+	roster, err := h.attendanceService.GetRoster(ctx, classID)
+	if err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	view, err := dto.ToRosterView(roster, views.Locale(ginCtx))
+	if err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	view.Error = errorMessage
+
+	views.HTML(ginCtx, code, "attendance_roster", view)
+}
@@ -0,0 +1,67 @@
+package attendanceroster
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/html/dto"
+	viewErrs "main/internal/interfaces/http/html/errs"
+	"main/internal/interfaces/http/html/views"
+	"main/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	attendanceService services.IAttendanceService
+}
+
+func NewHandler(attendanceService services.IAttendanceService) *handler {
+	return &handler{
+		attendanceService: attendanceService,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var uri dto.AttendanceURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	classID, err := uuid.Parse(uri.ClassID)
+	if err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	roster, err := h.attendanceService.GetRoster(ctx, classID)
+	if err != nil {
+		if key, code, ok := dto.RosterError(err); ok {
+			views.HTML(ginCtx, code, "err.tmpl", gin.H{
+				"Error": i18n.T(views.Locale(ginCtx), key),
+			})
+
+			return
+		}
+
+		viewErrs.HandleError(ginCtx, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	view, err := dto.ToRosterView(roster, views.Locale(ginCtx))
+	if err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	views.HTML(ginCtx, http.StatusOK, "attendance.tmpl", view)
+}
@@ -0,0 +1,42 @@
+package buypassform
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/html/dto"
+	viewErrs "main/internal/interfaces/http/html/errs"
+	"main/internal/interfaces/http/html/views"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	productsService  services.IProductsService
+	viewErrorHandler viewErrs.IErrorHandler
+}
+
+func NewHandler(
+	productsService services.IProductsService,
+	viewErrorHandler viewErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		productsService:  productsService,
+		viewErrorHandler: viewErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	ctx := ginCtx.Request.Context()
+
+	products, err := h.productsService.ListProducts(ctx, true)
+	if err != nil {
+		h.viewErrorHandler.Handle(ginCtx, "err.tmpl", err)
+
+		return
+	}
+
+	views.HTML(ginCtx, http.StatusOK, "buy_pass.tmpl", gin.H{
+		"Offers": dto.ToPassOffers(products, views.Locale(ginCtx)),
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"main/internal/domain/services"
 	"main/internal/interfaces/http/html/dto"
 	viewErrs "main/internal/interfaces/http/html/errs"
+	"main/internal/interfaces/http/html/views"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -59,5 +60,5 @@ func (h *handler) Handle(ginCtx *gin.Context) {
 		return
 	}
 
-	ginCtx.HTML(http.StatusOK, "confirmation_cancel_booking.tmpl", gin.H{})
+	views.HTML(ginCtx, http.StatusOK, "confirmation_cancel_booking.tmpl", gin.H{})
 }
@@ -3,9 +3,11 @@ package cancelbookingform
 import (
 	"net/http"
 
+	"main/internal/domain/models"
 	"main/internal/domain/services"
 	"main/internal/interfaces/http/html/dto"
 	viewErrs "main/internal/interfaces/http/html/errs"
+	"main/internal/interfaces/http/html/views"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -13,15 +15,18 @@ import (
 
 type handler struct {
 	bookingService   services.IBookingsService
+	classesService   services.IClassesService
 	viewErrorHandler viewErrs.IErrorHandler
 }
 
 func NewHandler(
 	bookingService services.IBookingsService,
+	classesService services.IClassesService,
 	viewErrorHandler viewErrs.IErrorHandler,
 ) *handler {
 	return &handler{
 		bookingService:   bookingService,
+		classesService:   classesService,
 		viewErrorHandler: viewErrorHandler,
 	}
 }
@@ -52,21 +57,46 @@ func (h *handler) Handle(ginCtx *gin.Context) {
 
 	ctx := ginCtx.Request.Context()
 
-	booking, err := h.bookingService.GetBookingForCancellation(ctx, bookingID, form.Token)
+	cancellation, err := h.bookingService.GetBookingForCancellation(ctx, bookingID, form.Token)
 	if err != nil {
 		h.viewErrorHandler.Handle(ginCtx, "err.tmpl", err)
 
 		return
 	}
 
-	classView, err := dto.ToClassView(booking.Class)
+	booking := cancellation.Booking
+
+	classView, err := dto.ToClassView(booking.Class, views.Locale(ginCtx))
 	if err != nil {
 		viewErrs.HandleError(ginCtx, err, http.StatusInternalServerError)
 
 		return
 	}
 
-	ginCtx.HTML(http.StatusOK, "cancel_booking_form.tmpl", gin.H{
-		"Class": classView, "BookingID": bookingID, "ConfirmationToken": booking.ConfirmationToken,
+	var rescheduleOptions []dto.RescheduleOptionView
+
+	// past the cancellation cutoff the booking can not be moved either
+	if !cancellation.Late {
+		classes, err := h.classesService.ListClasses(ctx, true, models.ClassFilter{}, nil)
+		if err != nil {
+			h.viewErrorHandler.Handle(ginCtx, "err.tmpl", err)
+
+			return
+		}
+
+		rescheduleOptions, err = dto.ToRescheduleOptions(classes, booking.ClassID, views.Locale(ginCtx))
+		if err != nil {
+			viewErrs.HandleError(ginCtx, err, http.StatusInternalServerError)
+
+			return
+		}
+	}
+
+	views.HTML(ginCtx, http.StatusOK, "cancel_booking_form.tmpl", gin.H{
+		"Class":             classView,
+		"BookingID":         bookingID,
+		"ConfirmationToken": booking.ConfirmationToken,
+		"LateCancellation":  cancellation.Late,
+		"RescheduleOptions": rescheduleOptions,
 	})
 }
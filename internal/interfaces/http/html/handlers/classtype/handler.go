@@ -0,0 +1,70 @@
+package classtype
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/html/dto"
+	viewErrs "main/internal/interfaces/http/html/errs"
+	"main/internal/interfaces/http/html/views"
+	sharedDTO "main/internal/interfaces/http/shared/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	classTypesService services.IClassTypesService
+	viewErrorHandler  viewErrs.IErrorHandler
+}
+
+func NewHandler(
+	classTypesService services.IClassTypesService,
+	viewErrorHandler viewErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		classTypesService: classTypesService,
+		viewErrorHandler:  viewErrorHandler,
+	}
+}
+
+// Handle shows the public description of a class type with its upcoming classes.
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var uri dto.ClassTypeURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	classTypeID, err := uuid.Parse(uri.ClassTypeID)
+	if err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	description, err := h.classTypesService.DescribeClassType(ctx, classTypeID)
+	if err != nil {
+		h.viewErrorHandler.Handle(ginCtx, "err.tmpl", err)
+
+		return
+	}
+
+	classesView, err := sharedDTO.ToClassesWithCurrentCapacityDTO(
+		description.UpcomingClasses, views.Locale(ginCtx),
+	)
+	if err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	views.HTML(ginCtx, http.StatusOK, "class_type.tmpl", gin.H{
+		"ClassType": dto.ToClassTypeView(description.ClassType),
+		"Classes":   classesView,
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"main/internal/domain/services"
 	"main/internal/interfaces/http/html/dto"
 	viewErrs "main/internal/interfaces/http/html/errs"
+	"main/internal/interfaces/http/html/views"
 
 	"github.com/gin-gonic/gin"
 )
@@ -42,12 +43,12 @@ func (h *handler) Handle(ginCtx *gin.Context) {
 		return
 	}
 
-	view, err := dto.ToClassView(class)
+	view, err := dto.ToClassView(class, views.Locale(ginCtx))
 	if err != nil {
 		viewErrs.HandleError(ginCtx, err, http.StatusInternalServerError)
 
 		return
 	}
 
-	ginCtx.HTML(http.StatusOK, "confirmation_create_booking.tmpl", view)
+	views.HTML(ginCtx, http.StatusOK, "confirmation_create_booking.tmpl", view)
 }
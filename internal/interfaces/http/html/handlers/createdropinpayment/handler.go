@@ -0,0 +1,64 @@
+package createdropinpayment
+
+import (
+	"net/http"
+	"strings"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/html/dto"
+	viewErrs "main/internal/interfaces/http/html/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	paymentsService  services.IPaymentsService
+	viewErrorHandler viewErrs.IErrorHandler
+}
+
+func NewHandler(
+	paymentsService services.IPaymentsService,
+	viewErrorHandler viewErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		paymentsService:  paymentsService,
+		viewErrorHandler: viewErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var form dto.PendingBookingForm
+
+	if err := ginCtx.ShouldBind(&form); err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	classID, err := uuid.Parse(form.ClassID)
+	if err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	checkout, err := h.paymentsService.StartDropInCheckout(ctx, models.DropInPurchaseParams{
+		ClassID:   classID,
+		FirstName: form.FirstName,
+		LastName:  form.LastName,
+		Email:     strings.ToLower(form.Email),
+	})
+	if err != nil {
+		h.viewErrorHandler.Handle(ginCtx, "pending_booking_form.tmpl", err)
+
+		return
+	}
+
+	// htmx leaves the page for the checkout of the provider
+	ginCtx.Header("HX-Redirect", checkout.RedirectURL)
+	ginCtx.Status(http.StatusOK)
+}
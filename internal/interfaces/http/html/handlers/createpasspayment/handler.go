@@ -0,0 +1,62 @@
+package createpasspayment
+
+import (
+	"net/http"
+	"strings"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/html/dto"
+	viewErrs "main/internal/interfaces/http/html/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	paymentsService  services.IPaymentsService
+	viewErrorHandler viewErrs.IErrorHandler
+}
+
+func NewHandler(
+	paymentsService services.IPaymentsService,
+	viewErrorHandler viewErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		paymentsService:  paymentsService,
+		viewErrorHandler: viewErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var form dto.PassPaymentForm
+
+	if err := ginCtx.ShouldBind(&form); err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	productID, err := uuid.Parse(form.ProductID)
+	if err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	checkout, err := h.paymentsService.StartPassCheckout(ctx, models.PassPurchaseParams{
+		Email:     strings.ToLower(form.Email),
+		ProductID: productID,
+	})
+	if err != nil {
+		h.viewErrorHandler.Handle(ginCtx, "buy_pass_error", err)
+
+		return
+	}
+
+	// htmx leaves the page for the checkout of the provider
+	ginCtx.Header("HX-Redirect", checkout.RedirectURL)
+	ginCtx.Status(http.StatusOK)
+}
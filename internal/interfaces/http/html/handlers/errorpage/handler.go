@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net/http"
 
+	"main/internal/interfaces/http/html/views"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -15,7 +17,7 @@ func NewHandler() *Handler {
 
 func (h *Handler) Handle(c *gin.Context) {
 	err := fmt.Errorf("error_id: %s", c.GetString("request_id"))
-	c.HTML(http.StatusInternalServerError, "err.tmpl", gin.H{
+	views.HTML(c, http.StatusInternalServerError, "err.tmpl", gin.H{
 		"Error": err.Error(),
 	})
 }
@@ -3,54 +3,106 @@ package home
 import (
 	"net/http"
 
+	"main/internal/domain/models"
 	"main/internal/domain/services"
+	"main/internal/interfaces/http/html/dto"
 	viewErrs "main/internal/interfaces/http/html/errs"
+	"main/internal/interfaces/http/html/views"
 	sharedDTO "main/internal/interfaces/http/shared/dto"
+	"main/pkg/optional"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 const classViewLimit = 4
 
 type handler struct {
-	classesService   services.IClassesService
-	viewErrorHandler viewErrs.IErrorHandler
-	isVacation       bool
+	classesService    services.IClassesService
+	classTypesService services.IClassTypesService
+	productsService   services.IProductsService
+	viewErrorHandler  viewErrs.IErrorHandler
+	isVacation        bool
+	payOnline         bool
 }
 
 func NewHandler(
 	classesService services.IClassesService,
+	classTypesService services.IClassTypesService,
+	productsService services.IProductsService,
 	viewErrorHandler viewErrs.IErrorHandler,
 	isVacation bool,
+	payOnline bool,
 ) *handler {
 	return &handler{
-		classesService:   classesService,
-		viewErrorHandler: viewErrorHandler,
-		isVacation:       isVacation,
+		classesService:    classesService,
+		classTypesService: classTypesService,
+		productsService:   productsService,
+		viewErrorHandler:  viewErrorHandler,
+		isVacation:        isVacation,
+		payOnline:         payOnline,
 	}
 }
 
 func (h *handler) Handle(ginCtx *gin.Context) {
+	var query dto.ClassFilterQuery
+
+	if err := ginCtx.ShouldBindQuery(&query); err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	filter := models.ClassFilter{ClassLevel: query.Level}
+
+	if query.ClassTypeID != "" {
+		classTypeID, err := uuid.Parse(query.ClassTypeID)
+		if err != nil {
+			viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+			return
+		}
+
+		filter.ClassTypeID = optional.Of(classTypeID)
+	}
+
 	ctx := ginCtx.Request.Context()
 
 	limit := classViewLimit
 
-	classes, err := h.classesService.ListClasses(ctx, true, &limit)
+	classes, err := h.classesService.ListClasses(ctx, true, filter, &limit)
 	if err != nil {
 		h.viewErrorHandler.Handle(ginCtx, "err.tmpl", err)
 
 		return
 	}
 
-	classesView, err := sharedDTO.ToClassesWithCurrentCapacityDTO(classes)
+	classesView, err := sharedDTO.ToClassesWithCurrentCapacityDTO(classes, views.Locale(ginCtx))
 	if err != nil {
 		viewErrs.HandleError(ginCtx, err, http.StatusInternalServerError)
 
 		return
 	}
 
-	ginCtx.HTML(http.StatusOK, "index.html", gin.H{
+	classTypes, err := h.classTypesService.ListClassTypes(ctx)
+	if err != nil {
+		h.viewErrorHandler.Handle(ginCtx, "err.tmpl", err)
+
+		return
+	}
+
+	products, err := h.productsService.ListProducts(ctx, true)
+	if err != nil {
+		h.viewErrorHandler.Handle(ginCtx, "err.tmpl", err)
+
+		return
+	}
+
+	views.HTML(ginCtx, http.StatusOK, "index.html", gin.H{
 		"Classes":    classesView,
+		"Filter":     dto.ToClassFilterView(classTypes, filter),
+		"Products":   dto.ToProductViews(products, views.Locale(ginCtx)),
 		"IsVacation": h.isVacation,
+		"PayOnline":  h.payOnline,
 	})
 }
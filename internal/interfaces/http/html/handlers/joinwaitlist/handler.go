@@ -0,0 +1,64 @@
+package joinwaitlist
+
+import (
+	"net/http"
+	"strings"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/html/dto"
+	viewErrs "main/internal/interfaces/http/html/errs"
+	"main/internal/interfaces/http/html/views"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	waitlistService  services.IWaitlistService
+	viewErrorHandler viewErrs.IErrorHandler
+}
+
+func NewHandler(
+	waitlistService services.IWaitlistService,
+	viewErrorHandler viewErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		waitlistService:  waitlistService,
+		viewErrorHandler: viewErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var form dto.WaitlistEntryForm
+	if err := ginCtx.ShouldBind(&form); err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	classID, err := uuid.Parse(form.ClassID)
+	if err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	params := models.WaitlistEntryParams{
+		ClassID:   classID,
+		FirstName: form.FirstName,
+		LastName:  form.LastName,
+		Email:     strings.ToLower(form.Email),
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	err = h.waitlistService.JoinWaitlist(ctx, params)
+	if err != nil {
+		h.viewErrorHandler.Handle(ginCtx, "waitlist_form.tmpl", err)
+
+		return
+	}
+
+	views.HTML(ginCtx, http.StatusOK, "waitlist_joined.tmpl", gin.H{"ClassID": classID})
+}
@@ -0,0 +1,58 @@
+package payment
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/html/dto"
+	viewErrs "main/internal/interfaces/http/html/errs"
+	"main/internal/interfaces/http/html/views"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	paymentsService  services.IPaymentsService
+	viewErrorHandler viewErrs.IErrorHandler
+}
+
+func NewHandler(
+	paymentsService services.IPaymentsService,
+	viewErrorHandler viewErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		paymentsService:  paymentsService,
+		viewErrorHandler: viewErrorHandler,
+	}
+}
+
+// Handle shows the status of the payment, the provider sends the student back here
+// after the checkout, possibly before its webhook arrives.
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var uri dto.PaymentURI
+
+	if err := ginCtx.ShouldBindUri(&uri); err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	paymentID, err := uuid.Parse(uri.PaymentID)
+	if err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	payment, err := h.paymentsService.GetPayment(ctx, paymentID)
+	if err != nil {
+		h.viewErrorHandler.Handle(ginCtx, "err.tmpl", err)
+
+		return
+	}
+
+	views.HTML(ginCtx, http.StatusOK, "payment.tmpl", dto.ToPaymentView(payment, views.Locale(ginCtx)))
+}
@@ -8,6 +8,7 @@ import (
 	"main/internal/domain/services"
 	"main/internal/interfaces/http/html/dto"
 	viewErrs "main/internal/interfaces/http/html/errs"
+	"main/internal/interfaces/http/html/views"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -61,5 +62,5 @@ func (h *handler) Handle(ginCtx *gin.Context) {
 		return
 	}
 
-	ginCtx.HTML(http.StatusOK, "pending_booking.tmpl", gin.H{"ClassID": classID})
+	views.HTML(ginCtx, http.StatusOK, "pending_booking.tmpl", gin.H{"ClassID": classID})
 }
@@ -3,15 +3,50 @@ package pendingbookingform
 import (
 	"net/http"
 
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/html/dto"
+	viewErrs "main/internal/interfaces/http/html/errs"
+	"main/internal/interfaces/http/html/views"
+
 	"github.com/gin-gonic/gin"
 )
 
-type handler struct{}
+type handler struct {
+	productsService  services.IProductsService
+	viewErrorHandler viewErrs.IErrorHandler
+	payOnline        bool
+}
 
-func NewHandler() *handler {
-	return &handler{}
+// NewHandler creates the booking form handler, with payOnline the form also offers
+// paying for the class at the price of the drop-in.
+func NewHandler(
+	productsService services.IProductsService,
+	viewErrorHandler viewErrs.IErrorHandler,
+	payOnline bool,
+) *handler {
+	return &handler{
+		productsService:  productsService,
+		viewErrorHandler: viewErrorHandler,
+		payOnline:        payOnline,
+	}
 }
 
 func (h *handler) Handle(c *gin.Context) {
-	c.HTML(http.StatusOK, "pending_booking_form.tmpl", gin.H{"ID": c.Param("class_id")})
+	dropInPrice := ""
+
+	if h.payOnline {
+		products, err := h.productsService.ListProducts(c.Request.Context(), true)
+		if err != nil {
+			h.viewErrorHandler.Handle(c, "err.tmpl", err)
+
+			return
+		}
+
+		dropInPrice = dto.DropInPrice(products)
+	}
+
+	views.HTML(c, http.StatusOK, "pending_booking_form.tmpl", gin.H{
+		"ID":          c.Param("class_id"),
+		"DropInPrice": dropInPrice,
+	})
 }
@@ -0,0 +1,113 @@
+package schedule
+
+import (
+	"net/http"
+	"time"
+
+	"main/internal/domain/models"
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/html/dto"
+	viewErrs "main/internal/interfaces/http/html/errs"
+	"main/internal/interfaces/http/html/views"
+	"main/pkg/converter"
+	"main/pkg/optional"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handler struct {
+	classesService   services.IClassesService
+	viewErrorHandler viewErrs.IErrorHandler
+}
+
+func NewHandler(
+	classesService services.IClassesService,
+	viewErrorHandler viewErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		classesService:   classesService,
+		viewErrorHandler: viewErrorHandler,
+	}
+}
+
+// Handle shows a week of the schedule, the current one by default. Past weeks show the
+// current one, the classes which already started are left out. htmx requests get only
+// the week, without the page around it.
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var query dto.ScheduleQuery
+
+	if err := ginCtx.ShouldBindQuery(&query); err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	studio, err := converter.LoadLocation("")
+	if err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	now := time.Now().In(studio)
+	currentWeek := converter.StartOfWeek(now)
+	week := currentWeek
+
+	if query.Week != "" {
+		day, err := time.ParseInLocation(dto.WeekLayout, query.Week, studio)
+		if err != nil {
+			viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+			return
+		}
+
+		if requested := converter.StartOfWeek(day); requested.After(currentWeek) {
+			week = requested
+		}
+	}
+
+	filter := models.ClassFilter{
+		ClassLevel: query.Level,
+		ClassName:  query.Name,
+	}
+
+	if query.LocationID != "" {
+		locationID, err := uuid.Parse(query.LocationID)
+		if err != nil {
+			viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+			return
+		}
+
+		filter.LocationID = optional.Of(locationID)
+	}
+
+	from := week
+	if from.Before(now) {
+		from = now
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	schedule, err := h.classesService.ListSchedule(ctx, from, week.AddDate(0, 0, 7), filter)
+	if err != nil {
+		h.viewErrorHandler.Handle(ginCtx, "err.tmpl", err)
+
+		return
+	}
+
+	view, err := dto.ToScheduleView(schedule, query, week, currentWeek, views.Locale(ginCtx))
+	if err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	name := "schedule.tmpl"
+	if ginCtx.GetHeader("HX-Request") == "true" {
+		name = "schedule_week"
+	}
+
+	views.HTML(ginCtx, http.StatusOK, name, view)
+}
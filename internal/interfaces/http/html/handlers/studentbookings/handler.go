@@ -0,0 +1,54 @@
+package studentbookings
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/html/dto"
+	viewErrs "main/internal/interfaces/http/html/errs"
+	"main/internal/interfaces/http/html/views"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	studentBookingsService services.IStudentBookingsService
+	viewErrorHandler       viewErrs.IErrorHandler
+}
+
+func NewHandler(
+	studentBookingsService services.IStudentBookingsService,
+	viewErrorHandler viewErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		studentBookingsService: studentBookingsService,
+		viewErrorHandler:       viewErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	sessionToken, err := ginCtx.Cookie(dto.StudentSessionCookie)
+	if err != nil || sessionToken == "" {
+		views.HTML(ginCtx, http.StatusOK, "student_login.tmpl", gin.H{})
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	studentBookings, err := h.studentBookingsService.GetStudentBookings(ctx, sessionToken)
+	if err != nil {
+		h.viewErrorHandler.Handle(ginCtx, "student_login.tmpl", err)
+
+		return
+	}
+
+	view, err := dto.ToStudentBookingsView(studentBookings, views.Locale(ginCtx))
+	if err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	views.HTML(ginCtx, http.StatusOK, "student_bookings.tmpl", view)
+}
@@ -0,0 +1,54 @@
+package studentcalendarfeed
+
+import (
+	"bytes"
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/html/dto"
+	viewErrs "main/internal/interfaces/http/html/errs"
+	"main/pkg/ical"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	calendarService  services.ICalendarService
+	viewErrorHandler viewErrs.IErrorHandler
+}
+
+func NewHandler(
+	calendarService services.ICalendarService,
+	viewErrorHandler viewErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		calendarService:  calendarService,
+		viewErrorHandler: viewErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var query dto.CalendarFeedQuery
+	if err := ginCtx.ShouldBindQuery(&query); err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	calendar, err := h.calendarService.GetStudentFeed(ginCtx.Request.Context(), query.Token)
+	if err != nil {
+		h.viewErrorHandler.Handle(ginCtx, "err.tmpl", err)
+
+		return
+	}
+
+	var body bytes.Buffer
+
+	if err = ical.Encode(&body, calendar); err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	ginCtx.Data(http.StatusOK, dto.CalendarContentType, body.Bytes())
+}
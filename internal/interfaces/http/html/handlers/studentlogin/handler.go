@@ -0,0 +1,61 @@
+package studentlogin
+
+import (
+	"net/http"
+	"time"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/html/dto"
+	viewErrs "main/internal/interfaces/http/html/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	studentBookingsService services.IStudentBookingsService
+	viewErrorHandler       viewErrs.IErrorHandler
+	secureCookie           bool
+}
+
+func NewHandler(
+	studentBookingsService services.IStudentBookingsService,
+	viewErrorHandler viewErrs.IErrorHandler,
+	secureCookie bool,
+) *handler {
+	return &handler{
+		studentBookingsService: studentBookingsService,
+		viewErrorHandler:       viewErrorHandler,
+		secureCookie:           secureCookie,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var form dto.StudentAuthForm
+	if err := ginCtx.ShouldBindQuery(&form); err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	session, err := h.studentBookingsService.LogIn(ctx, form.Token)
+	if err != nil {
+		h.viewErrorHandler.Handle(ginCtx, "err.tmpl", err)
+
+		return
+	}
+
+	ginCtx.SetSameSite(http.SameSiteLaxMode)
+	ginCtx.SetCookie(
+		dto.StudentSessionCookie,
+		session.Token,
+		int(time.Until(session.ExpiresAt).Seconds()),
+		"/my_bookings",
+		"",
+		h.secureCookie,
+		true,
+	)
+
+	ginCtx.Redirect(http.StatusSeeOther, "/my_bookings")
+}
@@ -0,0 +1,48 @@
+package studentloginlink
+
+import (
+	"net/http"
+	"strings"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/html/dto"
+	viewErrs "main/internal/interfaces/http/html/errs"
+	"main/internal/interfaces/http/html/views"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	studentBookingsService services.IStudentBookingsService
+	viewErrorHandler       viewErrs.IErrorHandler
+}
+
+func NewHandler(
+	studentBookingsService services.IStudentBookingsService,
+	viewErrorHandler viewErrs.IErrorHandler,
+) *handler {
+	return &handler{
+		studentBookingsService: studentBookingsService,
+		viewErrorHandler:       viewErrorHandler,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	var form dto.StudentLoginForm
+	if err := ginCtx.ShouldBind(&form); err != nil {
+		viewErrs.HandleError(ginCtx, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := ginCtx.Request.Context()
+
+	err := h.studentBookingsService.RequestLoginLink(ctx, strings.ToLower(form.Email))
+	if err != nil {
+		h.viewErrorHandler.Handle(ginCtx, "student_login.tmpl", err)
+
+		return
+	}
+
+	views.HTML(ginCtx, http.StatusOK, "student_login_link_sent.tmpl", gin.H{"Email": form.Email})
+}
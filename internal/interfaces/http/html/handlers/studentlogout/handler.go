@@ -0,0 +1,46 @@
+package studentlogout
+
+import (
+	"net/http"
+
+	"main/internal/domain/services"
+	"main/internal/interfaces/http/html/dto"
+	viewErrs "main/internal/interfaces/http/html/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	studentBookingsService services.IStudentBookingsService
+	viewErrorHandler       viewErrs.IErrorHandler
+	secureCookie           bool
+}
+
+func NewHandler(
+	studentBookingsService services.IStudentBookingsService,
+	viewErrorHandler viewErrs.IErrorHandler,
+	secureCookie bool,
+) *handler {
+	return &handler{
+		studentBookingsService: studentBookingsService,
+		viewErrorHandler:       viewErrorHandler,
+		secureCookie:           secureCookie,
+	}
+}
+
+func (h *handler) Handle(ginCtx *gin.Context) {
+	sessionToken, err := ginCtx.Cookie(dto.StudentSessionCookie)
+	if err == nil && sessionToken != "" {
+		err = h.studentBookingsService.LogOut(ginCtx.Request.Context(), sessionToken)
+		if err != nil {
+			h.viewErrorHandler.Handle(ginCtx, "err.tmpl", err)
+
+			return
+		}
+	}
+
+	ginCtx.SetSameSite(http.SameSiteLaxMode)
+	ginCtx.SetCookie(dto.StudentSessionCookie, "", -1, "/my_bookings", "", h.secureCookie, true)
+
+	ginCtx.Redirect(http.StatusSeeOther, "/my_bookings")
+}
@@ -0,0 +1,19 @@
+package waitlistform
+
+import (
+	"net/http"
+
+	"main/internal/interfaces/http/html/views"
+
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct{}
+
+func NewHandler() *handler {
+	return &handler{}
+}
+
+func (h *handler) Handle(c *gin.Context) {
+	views.HTML(c, http.StatusOK, "waitlist_form.tmpl", gin.H{"ID": c.Param("class_id")})
+}
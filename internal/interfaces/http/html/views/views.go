@@ -0,0 +1,71 @@
+package views
+
+import (
+	"fmt"
+	"html/template"
+
+	"main/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+)
+
+type renderer struct {
+	templates map[i18n.Locale]*template.Template
+}
+
+// NewRenderer parses the page templates once per supported locale, each set with the
+// i18n helpers bound to its locale.
+func NewRenderer(pattern string) (*renderer, error) {
+	templates := make(map[i18n.Locale]*template.Template)
+
+	for _, locale := range i18n.Supported() {
+		tmpl, err := template.New("").Funcs(i18n.FuncMap(locale)).ParseGlob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s templates: %w", locale, err)
+		}
+
+		templates[locale] = tmpl
+	}
+
+	return &renderer{
+		templates: templates,
+	}, nil
+}
+
+type localized struct {
+	locale i18n.Locale
+	data   any
+}
+
+// Instance renders data passed through HTML in its locale, anything else in i18n.Default.
+func (r *renderer) Instance(name string, data any) render.Render {
+	locale := i18n.Default
+
+	if view, ok := data.(localized); ok {
+		locale = view.locale
+		data = view.data
+	}
+
+	return render.HTML{
+		Template: r.templates[locale],
+		Name:     name,
+		Data:     data,
+	}
+}
+
+// HTML renders the template in the language negotiated for the request.
+func HTML(ginCtx *gin.Context, code int, name string, data any) {
+	ginCtx.HTML(code, name, localized{
+		locale: Locale(ginCtx),
+		data:   data,
+	})
+}
+
+func Locale(ginCtx *gin.Context) i18n.Locale {
+	if locale, ok := i18n.FromContext(ginCtx.Request.Context()); ok {
+		return locale
+	}
+
+	return i18n.Default
+}
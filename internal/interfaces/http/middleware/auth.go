@@ -20,3 +20,20 @@ func Auth(secret string) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// BasicAuth protects staff pages opened in a browser, where a bearer token can not be
+// sent. Any user name is accepted together with the auth secret as password.
+func BasicAuth(secret string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		_, password, ok := ctx.Request.BasicAuth()
+
+		if !ok || password != secret {
+			ctx.Header("WWW-Authenticate", `Basic realm="studio", charset="UTF-8"`)
+			ctx.AbortWithStatus(http.StatusUnauthorized)
+
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const anyOrigin = "*"
+
+// CORS lets browsers on the allowed origins read the response, the cache validators
+// included. Preflight requests are answered here and do not reach the handler.
+func CORS(allowedOrigins []string) gin.HandlerFunc {
+	origins := make([]string, 0, len(allowedOrigins))
+
+	for _, origin := range allowedOrigins {
+		if origin = strings.TrimSuffix(strings.TrimSpace(origin), "/"); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+
+	allowAny := slices.Contains(origins, anyOrigin)
+
+	return func(ctx *gin.Context) {
+		origin := ctx.GetHeader("Origin")
+		allowed := origin != "" && (allowAny || slices.Contains(origins, origin))
+
+		ctx.Writer.Header().Add("Vary", "Origin")
+
+		if allowed {
+			ctx.Header("Access-Control-Allow-Origin", origin)
+			ctx.Header("Access-Control-Expose-Headers", "ETag")
+		}
+
+		if ctx.Request.Method != http.MethodOptions {
+			ctx.Next()
+
+			return
+		}
+
+		if !allowed {
+			ctx.AbortWithStatus(http.StatusForbidden)
+
+			return
+		}
+
+		ctx.Header("Access-Control-Allow-Methods", "GET, OPTIONS")
+		ctx.Header("Access-Control-Allow-Headers", "If-None-Match")
+		ctx.Header("Access-Control-Max-Age", "86400")
+		ctx.AbortWithStatus(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCORS(t *testing.T) {
+	tests := []struct {
+		name                string
+		allowedOrigins      []string
+		method              string
+		origin              string
+		expectedStatus      int
+		expectedAllowOrigin string
+	}{
+		{
+			name:                "Success: allowed origin",
+			allowedOrigins:      []string{"https://partner.example"},
+			method:              http.MethodGet,
+			origin:              "https://partner.example",
+			expectedStatus:      http.StatusOK,
+			expectedAllowOrigin: "https://partner.example",
+		},
+		{
+			name:           "Success: other origin gets no CORS headers",
+			allowedOrigins: []string{"https://partner.example"},
+			method:         http.MethodGet,
+			origin:         "https://other.example",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Success: no origin",
+			allowedOrigins: []string{"https://partner.example"},
+			method:         http.MethodGet,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:                "Success: any origin",
+			allowedOrigins:      []string{"*"},
+			method:              http.MethodGet,
+			origin:              "https://other.example",
+			expectedStatus:      http.StatusOK,
+			expectedAllowOrigin: "https://other.example",
+		},
+		{
+			name:                "Success: preflight of allowed origin",
+			allowedOrigins:      []string{" https://partner.example/ "},
+			method:              http.MethodOptions,
+			origin:              "https://partner.example",
+			expectedStatus:      http.StatusNoContent,
+			expectedAllowOrigin: "https://partner.example",
+		},
+		{
+			name:           "Failure: preflight of other origin",
+			allowedOrigins: []string{"https://partner.example"},
+			method:         http.MethodOptions,
+			origin:         "https://other.example",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			router := gin.New()
+			router.Use(CORS(tt.allowedOrigins))
+			router.Handle(tt.method, "/test", func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"message": "ok"})
+			})
+
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/test", nil)
+
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, recorder.Code)
+			}
+
+			if got := recorder.Header().Get("Access-Control-Allow-Origin"); got != tt.expectedAllowOrigin {
+				t.Errorf("Expected allowed origin %q, got %q", tt.expectedAllowOrigin, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+
+	"main/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	localeCookie    = "lang"
+	localeQuery     = "lang"
+	localeCookieAge = 365 * 24 * 60 * 60
+)
+
+// Locale negotiates the page language and stores it in the request context. An explicit
+// ?lang= choice is remembered in a cookie, then the cookie wins over Accept-Language.
+func Locale(secureCookie bool) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		locale := negotiateLocale(ctx, secureCookie)
+
+		ctx.Request = ctx.Request.WithContext(i18n.WithLocale(ctx.Request.Context(), locale))
+		ctx.Header("Content-Language", string(locale))
+
+		ctx.Next()
+	}
+}
+
+func negotiateLocale(ctx *gin.Context, secureCookie bool) i18n.Locale {
+	if locale, ok := i18n.Parse(ctx.Query(localeQuery)); ok {
+		ctx.SetSameSite(http.SameSiteLaxMode)
+		ctx.SetCookie(localeCookie, string(locale), localeCookieAge, "/", "", secureCookie, true)
+
+		return locale
+	}
+
+	if cookie, err := ctx.Cookie(localeCookie); err == nil {
+		if locale, ok := i18n.Parse(cookie); ok {
+			return locale
+		}
+	}
+
+	return i18n.Negotiate(ctx.GetHeader("Accept-Language"))
+}
@@ -2,61 +2,76 @@ package dto
 
 import (
 	"fmt"
+	"time"
 
 	"main/internal/domain/models"
 	"main/pkg/converter"
-	"main/pkg/translator"
+	"main/pkg/i18n"
 
 	"github.com/google/uuid"
 )
 
 type ClassWithCurrentCapacityDTO struct {
-	ID              uuid.UUID `json:"id"`
-	WeekDay         string    `json:"week_day"`
-	StartDate       string    `json:"start_date"`
-	StartHour       string    `json:"start_hour"`
-	ClassLevel      string    `json:"class_level"`
-	ClassName       string    `json:"class_name"`
-	CurrentCapacity int       `json:"current_capacity"`
-	MaxCapacity     int       `json:"max_capacity"`
-	Location        string    `json:"location"`
+	ID              uuid.UUID  `json:"id"`
+	WeekDay         string     `json:"week_day"`
+	StartDate       string     `json:"start_date"`
+	StartHour       string     `json:"start_hour"`
+	ClassLevel      string     `json:"class_level"`
+	ClassName       string     `json:"class_name"`
+	CurrentCapacity int        `json:"current_capacity"`
+	MaxCapacity     int        `json:"max_capacity"`
+	LocationID      uuid.UUID  `json:"location_id"`
+	Location        string     `json:"location"`
+	Instructor      string     `json:"instructor,omitempty"`
+	ClassTypeID     *uuid.UUID `json:"class_type_id,omitempty"`
+	DurationMinutes int        `json:"duration_minutes"`
+	Address         string     `json:"address"`
+	Directions      string     `json:"directions"`
+	MapURL          string     `json:"map_url"`
 }
 
 func ToClassWithCurrentCapacityDTO(
-	class models.ClassWithCurrentCapacity,
+	class models.ClassWithCurrentCapacity, locale i18n.Locale,
 ) (ClassWithCurrentCapacityDTO, error) {
-	warsawTime, err := converter.ConvertToWarsawTime(class.StartTime)
+	startTime, err := converter.ConvertToLocationTime(class.StartTime, class.Location.TimeZone)
 	if err != nil {
 		return ClassWithCurrentCapacityDTO{},
-			fmt.Errorf("error while converting time to warsaw time: %w", err)
+			fmt.Errorf("error while converting time to local time: %w", err)
 	}
 
-	weekday, err := translator.TranslateToWeekDayToPolish(warsawTime.Weekday())
-	if err != nil {
-		return ClassWithCurrentCapacityDTO{},
-			fmt.Errorf("error while translating week day to polish: %w", err)
-	}
-
-	return ClassWithCurrentCapacityDTO{
+	classDTO := ClassWithCurrentCapacityDTO{
 		ID:              class.ID,
-		WeekDay:         weekday,
-		StartDate:       warsawTime.Format(converter.DateLayout),
-		StartHour:       warsawTime.Format(converter.HourLayout),
+		WeekDay:         i18n.WeekDay(locale, startTime.Weekday()),
+		StartDate:       startTime.Format(converter.DateLayout),
+		StartHour:       startTime.Format(converter.HourLayout),
 		ClassLevel:      class.ClassLevel,
 		ClassName:       class.ClassName,
 		CurrentCapacity: class.CurrentCapacity,
 		MaxCapacity:     class.MaxCapacity,
-		Location:        class.Location,
-	}, nil
+		LocationID:      class.Location.ID,
+		Location:        class.Location.Name,
+		Instructor:      class.Instructor.Name,
+		DurationMinutes: int(class.Duration / time.Minute),
+		Address:         class.Location.Address,
+		Directions:      class.Location.Directions,
+		MapURL:          class.Location.MapURL,
+	}
+
+	if class.ClassTypeID.Exists() {
+		classTypeID := class.ClassTypeID.Get()
+		classDTO.ClassTypeID = &classTypeID
+	}
+
+	return classDTO, nil
 }
 
 func ToClassesWithCurrentCapacityDTO(
-	classes []models.ClassWithCurrentCapacity,
+	classes []models.ClassWithCurrentCapacity, locale i18n.Locale,
 ) ([]ClassWithCurrentCapacityDTO, error) {
 	classesResponse := make([]ClassWithCurrentCapacityDTO, len(classes))
 
 	for idx, class := range classes {
-		classResponse, err := ToClassWithCurrentCapacityDTO(class)
+		classResponse, err := ToClassWithCurrentCapacityDTO(class, locale)
 		if err != nil {
 			return nil, fmt.Errorf("could not convert class to classResponse: %w", err)
 		}
@@ -68,37 +83,58 @@ func ToClassesWithCurrentCapacityDTO(
 }
 
 type ClassDTO struct {
-	ID          uuid.UUID `json:"id"`
-	WeekDay     string    `json:"week_day"`
-	StartDate   string    `json:"start_date"`
-	StartHour   string    `json:"start_hour"`
-	ClassLevel  string    `json:"class_level"`
-	ClassName   string    `json:"class_name"`
-	MaxCapacity int       `json:"max_capacity"`
-	Location    string    `json:"location"`
+	ID              uuid.UUID  `json:"id"`
+	WeekDay         string     `json:"week_day"`
+	StartDate       string     `json:"start_date"`
+	StartHour       string     `json:"start_hour"`
+	ClassLevel      string     `json:"class_level"`
+	ClassName       string     `json:"class_name"`
+	MaxCapacity     int        `json:"max_capacity"`
+	LocationID      uuid.UUID  `json:"location_id"`
+	Location        string     `json:"location"`
+	InstructorID    *uuid.UUID `json:"instructor_id,omitempty"`
+	Instructor      string     `json:"instructor,omitempty"`
+	SeriesID        *uuid.UUID `json:"series_id,omitempty"`
+	ClassTypeID     *uuid.UUID `json:"class_type_id,omitempty"`
+	DurationMinutes int        `json:"duration_minutes"`
 }
 
 func ToClassDTO(class models.Class) (ClassDTO, error) {
-	warsawTime, err := converter.ConvertToWarsawTime(class.StartTime)
+	startTime, err := converter.ConvertToLocationTime(class.StartTime, class.Location.TimeZone)
 	if err != nil {
-		return ClassDTO{}, fmt.Errorf("error while converting time to warsaw time: %w", err)
+		return ClassDTO{}, fmt.Errorf("error while converting time to local time: %w", err)
 	}
 
-	weekday, err := translator.TranslateToWeekDayToPolish(warsawTime.Weekday())
-	if err != nil {
-		return ClassDTO{}, fmt.Errorf("error while translating week day to polish: %w", err)
+	classDTO := ClassDTO{
+		ID:              class.ID,
+		WeekDay:         i18n.WeekDay(i18n.Default, startTime.Weekday()),
+		StartDate:       startTime.Format(converter.DateLayout),
+		StartHour:       startTime.Format(converter.HourLayout),
+		ClassLevel:      class.ClassLevel,
+		ClassName:       class.ClassName,
+		MaxCapacity:     class.MaxCapacity,
+		LocationID:      class.LocationID,
+		Location:        class.Location.Name,
+		Instructor:      class.Instructor.Name,
+		DurationMinutes: int(class.EndTime().Sub(class.StartTime) / time.Minute),
+	}
+
+	if class.InstructorID.Exists() {
+		instructorID := class.InstructorID.Get()
+		classDTO.InstructorID = &instructorID
+	}
+
+	if class.SeriesID.Exists() {
+		seriesID := class.SeriesID.Get()
+		classDTO.SeriesID = &seriesID
+	}
+
+	if class.ClassTypeID.Exists() {
+		classTypeID := class.ClassTypeID.Get()
+		classDTO.ClassTypeID = &classTypeID
 	}
 
-	return ClassDTO{
-		ID:          class.ID,
-		WeekDay:     weekday,
-		StartDate:   warsawTime.Format(converter.DateLayout),
-		StartHour:   warsawTime.Format(converter.HourLayout),
-		ClassLevel:  class.ClassLevel,
-		ClassName:   class.ClassName,
-		MaxCapacity: class.MaxCapacity,
-		Location:    class.Location,
-	}, nil
+	return classDTO, nil
 }
 
 func ToClassesDTO(classes []models.Class) ([]ClassDTO, error) {
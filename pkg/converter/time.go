@@ -2,6 +2,7 @@ package converter
 
 import (
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -9,13 +10,68 @@ const (
 	// DateLayout should not be changed, it can cause an error.
 	DateLayout = "02-01-2006"
 	HourLayout = "15:04"
+
+	DefaultTimeZone = "Europe/Warsaw"
 )
 
-func ConvertToWarsawTime(t time.Time) (time.Time, error) { //nolint
-	loc, err := time.LoadLocation("Europe/Warsaw")
+var studioTimeZone = struct {
+	mu   sync.RWMutex
+	name string
+}{
+	name: DefaultTimeZone,
+}
+
+// SetTimeZone sets the studio time zone, the one of the locations without their own.
+func SetTimeZone(studio string) error {
+	if studio == "" {
+		studio = DefaultTimeZone
+	}
+
+	if _, err := time.LoadLocation(studio); err != nil {
+		return fmt.Errorf("could not load studio time zone %q: %w", studio, err)
+	}
+
+	studioTimeZone.mu.Lock()
+	defer studioTimeZone.mu.Unlock()
+
+	studioTimeZone.name = studio
+
+	return nil
+}
+
+// LoadLocation returns the IANA time zone of a class location, the studio one when the
+// location has none.
+func LoadLocation(timeZone string) (*time.Location, error) {
+	if timeZone == "" {
+		studioTimeZone.mu.RLock()
+		timeZone = studioTimeZone.name
+		studioTimeZone.mu.RUnlock()
+	}
+
+	loc, err := time.LoadLocation(timeZone)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("error while loading location: %w", err)
+		return nil, fmt.Errorf("error while loading location: %w", err)
+	}
+
+	return loc, nil
+}
+
+func ConvertToStudioTime(t time.Time) (time.Time, error) {
+	return ConvertToLocationTime(t, "")
+}
+
+func ConvertToLocationTime(t time.Time, timeZone string) (time.Time, error) {
+	loc, err := LoadLocation(timeZone)
+	if err != nil {
+		return time.Time{}, err
 	}
 
 	return t.In(loc), nil
 }
+
+// StartOfWeek returns the midnight of the Monday of the week of t, in the time zone of t.
+func StartOfWeek(t time.Time) time.Time {
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,125 @@
+package converter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConvertToLocationTime(t *testing.T) {
+	err := SetTimeZone("Europe/Warsaw")
+	if err != nil {
+		t.Fatalf("could not set time zones: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = SetTimeZone(DefaultTimeZone)
+	})
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		timeZone string
+		want     string
+	}{
+		{
+			name:     "studio zone before spring forward",
+			t:        time.Date(2026, 3, 28, 17, 0, 0, 0, time.UTC),
+			timeZone: "",
+			want:     "28-03-2026 18:00",
+		},
+		{
+			name:     "studio zone after spring forward",
+			t:        time.Date(2026, 3, 29, 17, 0, 0, 0, time.UTC),
+			timeZone: "",
+			want:     "29-03-2026 19:00",
+		},
+		{
+			name:     "studio zone after fall back",
+			t:        time.Date(2026, 10, 25, 17, 0, 0, 0, time.UTC),
+			timeZone: "",
+			want:     "25-10-2026 18:00",
+		},
+		{
+			name:     "location time zone",
+			t:        time.Date(2026, 3, 29, 17, 0, 0, 0, time.UTC),
+			timeZone: "Europe/London",
+			want:     "29-03-2026 18:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertToLocationTime(tt.t, tt.timeZone)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if formatted := got.Format(DateLayout + " " + HourLayout); formatted != tt.want {
+				t.Errorf("got %s, want %s", formatted, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetTimeZone(t *testing.T) {
+	t.Cleanup(func() {
+		_ = SetTimeZone(DefaultTimeZone)
+	})
+
+	tests := []struct {
+		name    string
+		studio  string
+		wantErr bool
+	}{
+		{
+			name:   "empty studio zone falls back to default",
+			studio: "",
+		},
+		{
+			name:    "unknown studio zone",
+			studio:  "Europe/Atlantis",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetTimeZone(tt.studio)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Fatalf("could not load location: %v", err)
+	}
+
+	monday := time.Date(2026, 10, 26, 0, 0, 0, 0, warsaw)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{name: "monday midnight", t: monday, want: monday},
+		{name: "wednesday evening", t: time.Date(2026, 10, 28, 19, 30, 0, 0, warsaw), want: monday},
+		{name: "sunday night", t: time.Date(2026, 11, 1, 23, 59, 0, 0, warsaw), want: monday},
+		{
+			name: "week of the change to winter time",
+			t:    time.Date(2026, 10, 25, 12, 0, 0, 0, warsaw),
+			want: time.Date(2026, 10, 19, 0, 0, 0, 0, warsaw),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StartOfWeek(tt.t); !got.Equal(tt.want) {
+				t.Errorf("StartOfWeek(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
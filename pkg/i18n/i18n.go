@@ -0,0 +1,162 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Locale string
+
+const (
+	Polish  Locale = "pl"
+	English Locale = "en"
+	Default        = Polish
+)
+
+//go:embed locales/*.json
+var files embed.FS
+
+// catalogs maps every supported locale to its messages, keyed like "page.level".
+var catalogs = mustLoad()
+
+func mustLoad() map[Locale]map[string]string {
+	loaded := make(map[Locale]map[string]string)
+
+	for _, locale := range []Locale{Polish, English} {
+		content, err := files.ReadFile(path.Join("locales", string(locale)+".json"))
+		if err != nil {
+			panic(fmt.Sprintf("could not read %s catalog: %v", locale, err))
+		}
+
+		messages := make(map[string]string)
+		if err = json.Unmarshal(content, &messages); err != nil {
+			panic(fmt.Sprintf("could not parse %s catalog: %v", locale, err))
+		}
+
+		loaded[locale] = messages
+	}
+
+	return loaded
+}
+
+func Supported() []Locale {
+	return []Locale{Polish, English}
+}
+
+// Parse accepts a language tag such as "en", "en-GB" or "PL" and returns the matching
+// supported locale.
+func Parse(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if base, _, found := strings.Cut(tag, "-"); found {
+		tag = base
+	}
+
+	if _, ok := catalogs[Locale(tag)]; !ok {
+		return "", false
+	}
+
+	return Locale(tag), true
+}
+
+// Negotiate picks the supported locale with the highest quality from an
+// Accept-Language header, Default when none of them is supported.
+func Negotiate(acceptLanguage string) Locale {
+	type candidate struct {
+		locale  Locale
+		quality float64
+	}
+
+	var candidates []candidate
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+
+		locale, ok := Parse(tag)
+		if !ok {
+			continue
+		}
+
+		quality := 1.0
+
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+
+			quality = parsed
+		}
+
+		if quality > 0 {
+			candidates = append(candidates, candidate{locale: locale, quality: quality})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return Default
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	return candidates[0].locale
+}
+
+// T returns the message for key, formatted with args. Keys missing in locale fall
+// back to Default and finally to the key itself, so a gap never breaks a page.
+func T(locale Locale, key string, args ...any) string {
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = catalogs[Default][key]
+	}
+
+	if !ok {
+		return key
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+
+	return fmt.Sprintf(message, args...)
+}
+
+func WeekDay(locale Locale, weekDay time.Weekday) string {
+	return T(locale, "weekday."+strings.ToLower(weekDay.String()))
+}
+
+// FuncMap binds the template helpers to locale, it fits both html and text templates.
+func FuncMap(locale Locale) map[string]any {
+	return map[string]any{
+		"t": func(key string, args ...any) string {
+			return T(locale, key, args...)
+		},
+		"weekday": func(weekDay time.Weekday) string {
+			return WeekDay(locale, weekDay)
+		},
+		"locale": func() string {
+			return string(locale)
+		},
+	}
+}
+
+type contextKey struct{}
+
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, contextKey{}, locale)
+}
+
+// FromContext returns the locale negotiated for the request that ctx belongs to.
+func FromContext(ctx context.Context) (Locale, bool) {
+	locale, ok := ctx.Value(contextKey{}).(Locale)
+
+	return locale, ok
+}
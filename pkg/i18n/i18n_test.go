@@ -0,0 +1,117 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCatalogsMatch(t *testing.T) {
+	for _, locale := range Supported() {
+		t.Run(string(locale), func(t *testing.T) {
+			for key, message := range catalogs[Default] {
+				translated, ok := catalogs[locale][key]
+				if !ok {
+					t.Errorf("key %s is missing", key)
+
+					continue
+				}
+
+				if strings.Count(translated, "%") != strings.Count(message, "%") {
+					t.Errorf("key %s has different placeholders: %q and %q", key, translated, message)
+				}
+			}
+
+			if len(catalogs[locale]) != len(catalogs[Default]) {
+				t.Errorf("got %d keys, want %d like %s", len(catalogs[locale]), len(catalogs[Default]), Default)
+			}
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           Locale
+	}{
+		{
+			name:           "empty header falls back to default",
+			acceptLanguage: "",
+			want:           Default,
+		},
+		{
+			name:           "region is ignored",
+			acceptLanguage: "en-GB,en;q=0.9",
+			want:           English,
+		},
+		{
+			name:           "highest quality wins",
+			acceptLanguage: "en;q=0.4, pl;q=0.8",
+			want:           Polish,
+		},
+		{
+			name:           "unsupported languages are skipped",
+			acceptLanguage: "de-DE, fr;q=0.9, en;q=0.5",
+			want:           English,
+		},
+		{
+			name:           "zero quality is refused",
+			acceptLanguage: "en;q=0",
+			want:           Default,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Negotiate(tt.acceptLanguage)
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale Locale
+		key    string
+		args   []any
+		want   string
+	}{
+		{
+			name:   "formats arguments",
+			locale: English,
+			key:    "email.hello",
+			args:   []any{"Anna"},
+			want:   "Hi Anna!",
+		},
+		{
+			name:   "unsupported locale falls back to default",
+			locale: "de",
+			key:    "email.hello",
+			args:   []any{"Anna"},
+			want:   "Hej Anna!",
+		},
+		{
+			name:   "missing key is returned as is",
+			locale: English,
+			key:    "missing.key",
+			want:   "missing.key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := T(tt.locale, tt.key, tt.args...)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := WeekDay(English, time.Wednesday); got != "Wednesday" {
+		t.Errorf("got %q, want Wednesday", got)
+	}
+}
@@ -0,0 +1,175 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type Method string
+
+const (
+	MethodPublish Method = "PUBLISH"
+	MethodRequest Method = "REQUEST"
+	MethodCancel  Method = "CANCEL"
+)
+
+type EventStatus string
+
+const (
+	StatusConfirmed EventStatus = "CONFIRMED"
+	StatusCancelled EventStatus = "CANCELLED"
+)
+
+const (
+	timeLayout = "20060102T150405Z"
+	// RFC 5545 limits content lines to 75 octets, longer ones are folded.
+	maxLineLength = 75
+)
+
+type Calendar struct {
+	ProdID string
+	Name   string
+	Method Method
+	Events []Event
+}
+
+type Event struct {
+	UID         string
+	Sequence    int
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	Status      EventStatus
+	Organizer   string
+	Attendee    string
+}
+
+// Encode writes the calendar as an RFC 5545 VCALENDAR with CRLF line endings.
+func Encode(w io.Writer, calendar Calendar) error {
+	bw := bufio.NewWriter(w)
+	e := &encoder{w: bw}
+
+	e.line("BEGIN", "VCALENDAR")
+	e.line("VERSION", "2.0")
+	e.line("PRODID", calendar.ProdID)
+	e.line("CALSCALE", "GREGORIAN")
+
+	if calendar.Method != "" {
+		e.line("METHOD", string(calendar.Method))
+	}
+
+	if calendar.Name != "" {
+		e.line("X-WR-CALNAME", escape(calendar.Name))
+	}
+
+	for _, event := range calendar.Events {
+		e.event(event)
+	}
+
+	e.line("END", "VCALENDAR")
+
+	if e.err != nil {
+		return fmt.Errorf("could not write calendar: %w", e.err)
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("could not flush calendar: %w", err)
+	}
+
+	return nil
+}
+
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (e *encoder) event(event Event) {
+	e.line("BEGIN", "VEVENT")
+	e.line("UID", event.UID)
+	e.line("SEQUENCE", fmt.Sprint(event.Sequence))
+	e.line("DTSTAMP", formatTime(event.Stamp))
+	e.line("DTSTART", formatTime(event.Start))
+	e.line("DTEND", formatTime(event.End))
+	e.line("SUMMARY", escape(event.Summary))
+
+	if event.Description != "" {
+		e.line("DESCRIPTION", escape(event.Description))
+	}
+
+	if event.Location != "" {
+		e.line("LOCATION", escape(event.Location))
+	}
+
+	if event.Status != "" {
+		e.line("STATUS", string(event.Status))
+	}
+
+	if event.Organizer != "" {
+		e.line("ORGANIZER", "mailto:"+event.Organizer)
+	}
+
+	if event.Attendee != "" {
+		e.line("ATTENDEE;ROLE=REQ-PARTICIPANT", "mailto:"+event.Attendee)
+	}
+
+	e.line("END", "VEVENT")
+}
+
+func (e *encoder) line(name, value string) {
+	if e.err != nil {
+		return
+	}
+
+	_, e.err = e.w.WriteString(fold(name + ":" + value))
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+func escape(text string) string {
+	return escaper.Replace(text)
+}
+
+// fold splits a content line into chunks of at most 75 octets without breaking
+// multi-byte characters, continuation lines start with a single space.
+func fold(line string) string {
+	var sb strings.Builder
+
+	limit := maxLineLength
+
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		sb.WriteString(line[:cut])
+		sb.WriteString("\r\n ")
+
+		line = line[cut:]
+		// the leading space of a continuation line counts towards its length
+		limit = maxLineLength - 1
+	}
+
+	sb.WriteString(line)
+	sb.WriteString("\r\n")
+
+	return sb.String()
+}
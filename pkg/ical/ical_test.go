@@ -0,0 +1,107 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncode(t *testing.T) {
+	start := time.Date(2026, 3, 10, 17, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		calendar Calendar
+		want     []string
+	}{
+		{
+			name: "request carries uid, sequence and method",
+			calendar: Calendar{
+				ProdID: "-//yoga//bookings//PL",
+				Method: MethodRequest,
+				Events: []Event{{
+					UID:      "class-1@yoga",
+					Sequence: 2,
+					Stamp:    start,
+					Start:    start,
+					End:      start.Add(time.Hour),
+					Summary:  "Hatha, beginner",
+					Location: "Studio; room 2",
+					Status:   StatusConfirmed,
+				}},
+			},
+			want: []string{
+				"METHOD:REQUEST\r\n",
+				"UID:class-1@yoga\r\n",
+				"SEQUENCE:2\r\n",
+				"DTSTART:20260310T170000Z\r\n",
+				"DTEND:20260310T180000Z\r\n",
+				"SUMMARY:Hatha\\, beginner\r\n",
+				"LOCATION:Studio\\; room 2\r\n",
+				"STATUS:CONFIRMED\r\n",
+			},
+		},
+		{
+			name: "cancel marks the event cancelled",
+			calendar: Calendar{
+				ProdID: "-//yoga//bookings//PL",
+				Method: MethodCancel,
+				Events: []Event{{
+					UID:      "class-1@yoga",
+					Sequence: 3,
+					Start:    start,
+					End:      start.Add(time.Hour),
+					Status:   StatusCancelled,
+				}},
+			},
+			want: []string{
+				"METHOD:CANCEL\r\n",
+				"SEQUENCE:3\r\n",
+				"STATUS:CANCELLED\r\n",
+			},
+		},
+		{
+			name: "long lines are folded",
+			calendar: Calendar{
+				ProdID: "-//yoga//bookings//PL",
+				Events: []Event{{
+					UID:         "class-1@yoga",
+					Description: strings.Repeat("ż", 50),
+				}},
+			},
+			want: []string{
+				"DESCRIPTION:" + strings.Repeat("ż", 31) + "\r\n " + strings.Repeat("ż", 19) + "\r\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := Encode(&buf, tt.calendar)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := buf.String()
+
+			if !strings.HasPrefix(got, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(got, "END:VCALENDAR\r\n") {
+				t.Errorf("got %q, want a VCALENDAR", got)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("got %q, want it to contain %q", got, want)
+				}
+			}
+
+			for _, line := range strings.Split(got, "\r\n") {
+				if len(line) > maxLineLength {
+					t.Errorf("line %q is longer than %d octets", line, maxLineLength)
+				}
+			}
+		})
+	}
+}